@@ -10,6 +10,7 @@ import (
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/jobs"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
 	"github.com/SalmanDMA/inventory-app/backend/src/migrations"
 	"github.com/SalmanDMA/inventory-app/backend/src/routes"
 	"github.com/SalmanDMA/inventory-app/backend/src/websockets"
@@ -72,7 +73,7 @@ func main() {
 
 	// Routes & websockets
 	routes.RouteInit(app)
-	websockets.Register(app)
+	websockets.Register(app, middlewares.WebSocketAuth)
 
 	// Port
 	port := os.Getenv("PORT")
@@ -0,0 +1,312 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newAccountsPayableService() *services.AccountsPayableService {
+	apRepo := repositories.NewAccountsPayableRepository(configs.DB)
+	paymentRunRepo := repositories.NewPaymentRunRepository(configs.DB)
+	return services.NewAccountsPayableService(apRepo, paymentRunRepo)
+}
+
+func newPaymentRunService() *services.PaymentRunService {
+	paymentRunRepo := repositories.NewPaymentRunRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	uploadRepo := repositories.NewUploadRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewPaymentRunService(paymentRunRepo, paymentRepo, poRepo, soRepo, uploadRepo, numberSequenceRepo)
+}
+
+// GetAPAging
+// @Summary Get accounts payable aging
+// @Description Outstanding purchase order balances per supplier bucketed 0-30/31-60/61-90/90+ days past due date. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param as_of query string false "Aging reference date (YYYY-MM-DD), default today"
+// @Param supplier_id query string false "Filter by Supplier ID (UUID)"
+// @Param search query string false "Search supplier name / code or PO number"
+// @Success 200 {object} models.ResponseAPAging "AP aging retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /api/v1/accounts-payable/aging [get]
+func GetAPAging(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := &models.APAgingRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	aging, err := newAccountsPayableService().GetAPAging(req)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "AP aging retrieved successfully", aging)
+}
+
+// GetAPCashOutForecast
+// @Summary Get accounts payable cash-out forecast
+// @Description Purchase order payables due per day for the coming days (default this week), plus payables already overdue. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param start_date query string false "First forecast day (YYYY-MM-DD), default today"
+// @Param days query int false "Number of days to forecast (default: 7, max: 90)"
+// @Param supplier_id query string false "Filter by Supplier ID (UUID)"
+// @Success 200 {object} models.ResponseAPForecast "Cash-out forecast retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/accounts-payable/forecast [get]
+func GetAPCashOutForecast(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := &models.APForecastRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	forecast, err := newAccountsPayableService().GetCashOutForecast(req)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Cash-out forecast retrieved successfully", forecast)
+}
+
+// ProposePaymentRun
+// @Summary Propose a payment run
+// @Description Suggest purchase orders due up to a date for a batched payment, grouped per supplier. POs already in a proposed run are skipped. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param due_until query string false "Include POs due up to this date (YYYY-MM-DD), default 7 days from today"
+// @Param supplier_id query string false "Filter by Supplier ID (UUID)"
+// @Success 200 {object} models.ResponsePaymentRunProposal "Payment run proposal retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/accounts-payable/payment-run/proposal [get]
+func ProposePaymentRun(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := &models.PaymentRunProposalRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	proposal, err := newAccountsPayableService().ProposePaymentRun(req)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment run proposal retrieved successfully", proposal)
+}
+
+// GetAllPaymentRunsPaginated
+// @Summary List payment runs (paginated)
+// @Description Retrieve payment runs with pagination. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (run number, notes)"
+// @Param status query string false "Filter by status: active, deleted, all (default: active)"
+// @Param run_status query string false "Filter by run status: Proposed, Executed, Cancelled"
+// @Param supplier_id query string false "Supplier ID"
+// @Success 200 {object} models.PaymentRunPaginatedResponse "Payment runs fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch payment runs"
+// @Router /api/v1/accounts-payable/payment-run [get]
+func GetAllPaymentRunsPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newPaymentRunService().GetAllPaymentRunsPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch payment runs", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment runs fetched successfully", result)
+}
+
+// GetPaymentRunByID
+// @Summary Get payment run by ID
+// @Description Retrieve a payment run with its purchase orders and created payments. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment Run ID"
+// @Success 200 {object} models.PaymentRun "Payment run fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Payment run not found"
+// @Router /api/v1/accounts-payable/payment-run/{id} [get]
+func GetPaymentRunByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	run, err := newPaymentRunService().GetPaymentRunByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Payment run not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment run fetched successfully", run)
+}
+
+// CreatePaymentRun
+// @Summary Create payment run
+// @Description Save a batched payment proposal for several purchase orders. An item amount of 0 pays the full remaining balance. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PaymentRunCreateRequest true "Payment run create request body"
+// @Success 201 {object} models.PaymentRun "Payment run created successfully"
+// @Failure 400 {string} string "Failed to create payment run"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/accounts-payable/payment-run [post]
+func CreatePaymentRun(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	runRequest := new(models.PaymentRunCreateRequest)
+	if err := ctx.BodyParser(runRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(runRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	run, err := newPaymentRunService().CreatePaymentRun(runRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create payment run", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Payment run created successfully", run)
+}
+
+// ExecutePaymentRun
+// @Summary Execute payment run
+// @Description Create one payment per purchase order in a proposed run and update their balances in a single transaction. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment Run ID"
+// @Param request body models.PaymentRunExecuteRequest false "Payment run execute request body"
+// @Success 200 {object} models.PaymentRun "Payment run executed successfully"
+// @Failure 400 {string} string "Failed to execute payment run"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/accounts-payable/payment-run/{id}/execute [put]
+func ExecutePaymentRun(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	executeRequest := new(models.PaymentRunExecuteRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(executeRequest); err != nil {
+			return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		}
+	}
+
+	run, err := newPaymentRunService().ExecutePaymentRun(ctx.Params("id"), executeRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to execute payment run", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment run executed successfully", run)
+}
+
+// CancelPaymentRun
+// @Summary Cancel payment run
+// @Description Cancel a proposed payment run so its purchase orders can be scheduled again. Requires authentication.
+// @Tags AccountsPayable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment Run ID"
+// @Success 200 {object} models.PaymentRun "Payment run cancelled successfully"
+// @Failure 400 {string} string "Failed to cancel payment run"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/accounts-payable/payment-run/{id}/cancel [put]
+func CancelPaymentRun(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	run, err := newPaymentRunService().CancelPaymentRun(ctx.Params("id"), userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to cancel payment run", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment run cancelled successfully", run)
+}
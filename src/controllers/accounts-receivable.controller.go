@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newAccountsReceivableService() *services.AccountsReceivableService {
+	arRepo := repositories.NewAccountsReceivableRepository(configs.DB)
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	return services.NewAccountsReceivableService(arRepo, customerRepo)
+}
+
+// GetARAging
+// @Summary Get accounts receivable aging
+// @Description Outstanding sales order balances per customer bucketed 0-30/31-60/61-90/90+ days past due date. Requires authentication.
+// @Tags AccountsReceivable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param as_of query string false "Aging reference date (YYYY-MM-DD), default today"
+// @Param area_id query string false "Filter by Area ID (UUID)"
+// @Param sales_person_id query string false "Filter by Sales Person ID (UUID)"
+// @Param customer_id query string false "Filter by Customer ID (UUID)"
+// @Param search query string false "Search customer name / number"
+// @Success 200 {object} models.ResponseARAging "AR aging retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /api/v1/accounts-receivable/aging [get]
+func GetARAging(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := &models.ARAgingRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	aging, err := newAccountsReceivableService().GetARAging(req)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "AR aging retrieved successfully", aging)
+}
+
+// ExportARAgingExcel
+// @Summary Export accounts receivable aging to Excel
+// @Description Exports the AR aging summary and per-invoice detail into an Excel file. Requires authentication.
+// @Tags AccountsReceivable
+// @Accept json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param as_of query string false "Aging reference date (YYYY-MM-DD), default today"
+// @Param area_id query string false "Filter by Area ID (UUID)"
+// @Param sales_person_id query string false "Filter by Sales Person ID (UUID)"
+// @Param customer_id query string false "Filter by Customer ID (UUID)"
+// @Param search query string false "Search customer name / number"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /api/v1/accounts-receivable/aging/excel [get]
+func ExportARAgingExcel(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := &models.ARAgingRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	filename, fileExcel, err := newAccountsReceivableService().GenerateARAgingExcel(req)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	ctx.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, werr := fileExcel.WriteTo(pw)
+		_ = fileExcel.Close()
+		_ = pw.CloseWithError(werr)
+	}()
+
+	return ctx.SendStream(pr, -1)
+}
+
+// GetCustomerStatement
+// @Summary Get customer statement of account
+// @Description Invoices, credit notes, payments and running balance of a customer for a date range. Requires authentication.
+// @Tags AccountsReceivable
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Customer ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), default first day of this month"
+// @Param end_date query string false "End date (YYYY-MM-DD), default today"
+// @Success 200 {object} models.ResponseCustomerStatement "Customer statement retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Customer not found"
+// @Router /api/v1/accounts-receivable/customer/{id}/statement [get]
+func GetCustomerStatement(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	customerID := ctx.Params("id")
+	req := &models.CustomerStatementRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	statement, err := newAccountsReceivableService().GetCustomerStatement(customerID, req)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return helpers.Response(ctx, fiber.StatusNotFound, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Customer statement retrieved successfully", statement)
+}
+
+// ExportCustomerStatementPDF
+// @Summary Generate customer statement of account PDF
+// @Description Stream the customer statement of account PDF for a date range. Requires authentication.
+// @Tags AccountsReceivable
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Customer ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), default first day of this month"
+// @Param end_date query string false "End date (YYYY-MM-DD), default today"
+// @Success 200 {file} file "PDF stream"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 404 {string} string "Customer not found"
+// @Failure 500 {string} string "Failed to generate pdf document"
+// @Router /api/v1/accounts-receivable/customer/{id}/statement/pdf [get]
+func ExportCustomerStatementPDF(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	customerID := ctx.Params("id")
+	req := &models.CustomerStatementRequest{}
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	filename, pdfBytes, err := newAccountsReceivableService().GenerateCustomerStatementPDF(customerID, req)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return helpers.Response(ctx, fiber.StatusNotFound, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate pdf document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
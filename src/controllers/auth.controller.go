@@ -35,18 +35,140 @@ func LoginController(ctx *fiber.Ctx) error {
 	}
 
 	userRepo := repositories.NewUserRepository(configs.DB)
-	authService := services.NewAuthService(userRepo)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
 
-	user , token, err := authService.Login(loginRequest, ctx)
+	result, err := authService.Login(loginRequest, ctx)
 	if err != nil {
 		return helpers.Response(ctx, fiber.StatusUnauthorized, "Invalid email or password", nil)
 	}
 
+	return helpers.Response(ctx, fiber.StatusOK, "Success login", result)
+}
+
+// RefreshTokenController adalah handler untuk rotasi refresh token.
+// @Summary Refresh access token
+// @Description Menukar refresh token dengan access token baru. Refresh token lama langsung tidak berlaku (rotasi); pemakaian ulang token lama mencabut session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.UserRefreshTokenRequest true "Refresh token request body"
+// @Success 200 {object} models.AuthTokenResponse "Success refresh token"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Invalid or expired refresh token"
+// @Router /api/v1/auth/refresh [post]
+func RefreshTokenController(ctx *fiber.Ctx) error {
+	req := new(models.UserRefreshTokenRequest)
+
+	if err := ctx.BodyParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	userRepo := repositories.NewUserRepository(configs.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
+
+	result, err := authService.RefreshToken(req, ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrRefreshTokenInvalid) || errors.Is(err, services.ErrSessionRevoked) {
+			return helpers.Response(ctx, fiber.StatusUnauthorized, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
 
-	return helpers.Response(ctx, fiber.StatusOK, "Success login", map[string]interface{}{
-		"user": user,
-		"token": token,
-	})
+	return helpers.Response(ctx, fiber.StatusOK, "Success refresh token", result)
+}
+
+// LogoutController adalah handler untuk logout dari device saat ini.
+// @Summary Logout
+// @Description Mencabut session yang sedang dipakai; access & refresh token session ini tidak berlaku lagi.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {string} string "Success logout"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /api/v1/auth/logout [post]
+func LogoutController(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	userRepo := repositories.NewUserRepository(configs.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
+
+	if err := authService.Logout(userInfo.ID, ctx); err != nil {
+		if errors.Is(err, services.ErrSessionRevoked) || errors.Is(err, repositories.ErrUserSessionNotFound) {
+			return helpers.Response(ctx, fiber.StatusUnauthorized, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Success logout", nil)
+}
+
+// LogoutAllController adalah handler untuk logout dari semua device.
+// @Summary Logout all devices
+// @Description Mencabut semua session milik user yang sedang login, termasuk session saat ini.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {string} string "Success logout from all devices"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /api/v1/auth/logout-all [post]
+func LogoutAllController(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	userRepo := repositories.NewUserRepository(configs.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
+
+	if err := authService.LogoutAll(userInfo.ID, ctx); err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Success logout from all devices", nil)
+}
+
+// GetSessionsController adalah handler untuk daftar session aktif user.
+// @Summary Get active sessions
+// @Description Menampilkan device/session yang masih aktif untuk user yang sedang login.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ResponseGetUserSession "Success get active sessions"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /api/v1/auth/sessions [get]
+func GetSessionsController(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	userRepo := repositories.NewUserRepository(configs.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
+
+	sessions, err := authService.GetActiveSessions(userInfo.ID, ctx)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Success get active sessions", sessions)
 }
 
 
@@ -80,7 +202,9 @@ func ResetPasswordController(ctx *fiber.Ctx) error {
 	}
 
 	userRepo := repositories.NewUserRepository(configs.DB)
-	authService := services.NewAuthService(userRepo)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
 	
 	err := authService.ResetPassword(userInfo.ID.String(), userRequest.CurrentPassword, userRequest.NewPassword, ctx)
 	if err != nil {
@@ -117,7 +241,9 @@ func CheckIdentifierController(ctx *fiber.Ctx) error {
 	}
 
 	userRepo := repositories.NewUserRepository(configs.DB)
-	authService := services.NewAuthService(userRepo)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
 
 	user, err := authService.CheckIdentifier(req, ctx)
 	if err != nil {
@@ -131,42 +257,79 @@ func CheckIdentifierController(ctx *fiber.Ctx) error {
 	return helpers.Response(ctx, fiber.StatusOK, "Success check identifier", user)
 }
 
-// ForgotPasswordController adalah handler untuk lupa password.
-// @Summary Forgot password
-// @Description Menangani permintaan lupa password oleh pengguna.
+// ForgotPasswordController adalah handler untuk request link reset password.
+// @Summary Request password reset
+// @Description Mengirim link reset password (token sekali pakai, berlaku terbatas) ke email pemilik akun. Respons selalu sukses untuk identifier yang tidak dikenal.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param request body models.UserForgotPasswordRequest true "Forgot password request body"
-// @Success 200 {string} string "Success reset your password"
+// @Success 200 {string} string "If the account exists, a reset link has been sent"
 // @Failure 400 {string} string "Bad request"
-// @Failure 401 {string} string "Unauthorized"
-// @Failure 404 {string} string "User not found"
+// @Failure 429 {string} string "Too many password reset requests"
 // @Failure 500 {string} string "Internal server error"
 // @Router /api/v1/auth/forgot-password [post]
 func ForgotPasswordController(ctx *fiber.Ctx) error {
 	req := new(models.UserForgotPasswordRequest)
 
 	if err := ctx.BodyParser(req); err != nil {
-					return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
 	}
 
 	userRepo := repositories.NewUserRepository(configs.DB)
-	authService := services.NewAuthService(userRepo)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
 
-	user, err := authService.ForgotPassword(req, ctx)
-	if err != nil {
-					if err.Error() == "User not found" {
-									return helpers.Response(ctx, fiber.StatusNotFound, err.Error(), nil)
-					}
-					if err.Error() == "Password reset is not allowed, please verify your OTP" {
-									return helpers.Response(ctx, fiber.StatusUnauthorized, err.Error(), nil)
-					}
-					return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	if err := authService.RequestPasswordReset(req, ctx); err != nil {
+		if errors.Is(err, services.ErrPasswordResetRateLimited) {
+			return helpers.Response(ctx, fiber.StatusTooManyRequests, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
 	}
 
-	return helpers.Response(ctx, fiber.StatusOK, "Success reset your password", user)
+	return helpers.Response(ctx, fiber.StatusOK, "If the account exists, a reset link has been sent", nil)
 }
 
+// ForgotPasswordConfirmController adalah handler untuk redeem token reset password.
+// @Summary Confirm password reset
+// @Description Menukar token reset password yang masih berlaku dengan password baru. Token hanya bisa dipakai sekali.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.UserForgotPasswordConfirmRequest true "Confirm password reset request body"
+// @Success 200 {string} string "Success reset your password"
+// @Failure 400 {string} string "Invalid or expired reset token"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/auth/forgot-password/confirm [post]
+func ForgotPasswordConfirmController(ctx *fiber.Ctx) error {
+	req := new(models.UserForgotPasswordConfirmRequest)
 
+	if err := ctx.BodyParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
 
+	userRepo := repositories.NewUserRepository(configs.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(configs.DB)
+	userSessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	authService := services.NewAuthService(userRepo, passwordResetRepo, userSessionRepo, helpers.GetMailSender())
+
+	if err := authService.ConfirmPasswordReset(req, ctx); err != nil {
+		if errors.Is(err, services.ErrPasswordResetTokenInvalid) {
+			return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Success reset your password", nil)
+}
@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/bankstatement"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newBankReconciliationService() *services.BankReconciliationService {
+	bankStatementRepo := repositories.NewBankStatementRepository(configs.DB)
+	arRepo := repositories.NewAccountsReceivableRepository(configs.DB)
+	apRepo := repositories.NewAccountsPayableRepository(configs.DB)
+	paymentReceiptRepo := repositories.NewPaymentReceiptRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	uploadRepo := repositories.NewUploadRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewBankReconciliationService(bankStatementRepo, arRepo, apRepo, paymentReceiptRepo, paymentRepo, poRepo, soRepo, uploadRepo, numberSequenceRepo)
+}
+
+// GetAllBankStatementsPaginated
+// @Summary List imported bank statements (paginated)
+// @Description Retrieve imported bank statement files with pagination. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (filename, account number, bank)"
+// @Param status query string false "Filter by status: active, deleted, all (default: active)"
+// @Success 200 {object} models.BankStatementPaginatedResponse "Bank statements fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch bank statements"
+// @Router /api/v1/bank-statement [get]
+func GetAllBankStatementsPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newBankReconciliationService().GetAllBankStatementsPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch bank statements", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statements fetched successfully", result)
+}
+
+// GetBankStatementParsers
+// @Summary List bank statement layouts
+// @Description Names of the registered statement parsers that can be passed as "bank" when importing. Requires authentication.
+// @Tags BankStatement
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} string "Bank statement layouts retrieved successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/bank-statement/parsers [get]
+func GetBankStatementParsers(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statement layouts retrieved successfully", bankstatement.Parsers())
+}
+
+// GetBankReconciliationQueue
+// @Summary Bank reconciliation queue
+// @Description Bank statement lines waiting to be reconciled, with their suggested sales / purchase orders. Without line_status only unmatched, suggested and auto-matched lines are returned. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (description, reference)"
+// @Param bank_statement_id query string false "Bank Statement ID"
+// @Param line_status query string false "Filter by line status: Unmatched, Suggested, AutoMatched, Confirmed, Ignored, all"
+// @Success 200 {object} models.BankStatementLinePaginatedResponse "Reconciliation queue fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch reconciliation queue"
+// @Router /api/v1/bank-statement/queue [get]
+func GetBankReconciliationQueue(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newBankReconciliationService().GetReconciliationQueue(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch reconciliation queue", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Reconciliation queue fetched successfully", result)
+}
+
+// GetBankStatementByID
+// @Summary Get bank statement by ID
+// @Description Retrieve an imported bank statement with all its lines and match suggestions. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Bank Statement ID"
+// @Success 200 {object} models.BankStatement "Bank statement fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Bank statement not found"
+// @Router /api/v1/bank-statement/{id} [get]
+func GetBankStatementByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statement, err := newBankReconciliationService().GetBankStatementByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Bank statement not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statement fetched successfully", statement)
+}
+
+// ImportBankStatement
+// @Summary Import bank statement
+// @Description Upload a bank statement export (CSV, form field "file"). Supported layouts: generic, bca, mandiri, bni, bri; leave "bank" empty to auto-detect. Lines already imported are skipped, the rest are matched against open sales orders (credit) and purchase orders (debit). Requires authentication.
+// @Tags BankStatement
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param file formData file true "Bank statement (.csv)"
+// @Param bank formData string false "Statement layout (see /bank-statement/parsers)"
+// @Param account_number formData string false "Account number (defaults to the one in the file)"
+// @Success 201 {object} models.BankStatement "Bank statement imported successfully"
+// @Failure 400 {string} string "Failed to import bank statement"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/bank-statement/import [post]
+func ImportBankStatement(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statement, err := newBankReconciliationService().ImportBankStatement(ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to import bank statement", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Bank statement imported successfully", statement)
+}
+
+// RematchBankStatement
+// @Summary Re-run matching for a bank statement
+// @Description Recompute order suggestions for the lines of a statement that are not yet confirmed or ignored, e.g. after new orders were created. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Bank Statement ID"
+// @Success 200 {object} models.BankStatement "Bank statement rematched successfully"
+// @Failure 400 {string} string "Failed to rematch bank statement"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/bank-statement/{id}/rematch [put]
+func RematchBankStatement(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statement, err := newBankReconciliationService().RematchBankStatement(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to rematch bank statement", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statement rematched successfully", statement)
+}
+
+// ConfirmBankStatementLine
+// @Summary Confirm bank statement line
+// @Description Record the payment for a statement line through a payment receipt. Without allocations the top suggestion is used; an allocation amount of 0 pays the remaining balance and any remainder is kept as deposit. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Bank Statement Line ID"
+// @Param request body models.BankStatementConfirmRequest true "Bank statement confirm request body"
+// @Success 200 {object} models.BankStatementLine "Bank statement line confirmed successfully"
+// @Failure 400 {string} string "Failed to confirm bank statement line"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/bank-statement/line/{id}/confirm [put]
+func ConfirmBankStatementLine(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	confirmRequest := new(models.BankStatementConfirmRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(confirmRequest); err != nil {
+			return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		}
+	}
+
+	if err := helpers.ValidateStruct(confirmRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	line, err := newBankReconciliationService().ConfirmBankStatementLine(ctx.Params("id"), confirmRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to confirm bank statement line", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statement line confirmed successfully", line)
+}
+
+// IgnoreBankStatementLine
+// @Summary Ignore bank statement line
+// @Description Remove a line that is not an order payment (bank fee, interest, tax) from the reconciliation queue. Requires authentication.
+// @Tags BankStatement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Bank Statement Line ID"
+// @Param request body models.BankStatementIgnoreRequest true "Bank statement ignore request body"
+// @Success 200 {object} models.BankStatementLine "Bank statement line ignored successfully"
+// @Failure 400 {string} string "Failed to ignore bank statement line"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/bank-statement/line/{id}/ignore [put]
+func IgnoreBankStatementLine(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	ignoreRequest := new(models.BankStatementIgnoreRequest)
+	if err := ctx.BodyParser(ignoreRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(ignoreRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	line, err := newBankReconciliationService().IgnoreBankStatementLine(ctx.Params("id"), ignoreRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to ignore bank statement line", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Bank statement line ignored successfully", line)
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newCustomerCreditService() *services.CustomerCreditService {
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	return services.NewCustomerCreditService(customerRepo, soRepo, creditOverrideRepo)
+}
+
+// GetCustomerCredit
+// @Summary Get customer credit position
+// @Description Show the effective credit limit, open receivables (total minus paid across open sales orders), overdue age and whether the customer is currently on credit hold. Requires authentication.
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Customer ID"
+// @Success 200 {object} models.ResponseGetCustomerCredit "Customer credit fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Customer not found"
+// @Router /api/v1/customer/{id}/credit [get]
+func GetCustomerCredit(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	credit, err := newCustomerCreditService().GetCustomerCredit(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Customer not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Customer credit fetched successfully", credit)
+}
+
+// GetAllCreditOverridesPaginated
+// @Summary List credit hold overrides (paginated)
+// @Description Audit trail of sales orders that were created or confirmed while the customer was on credit hold, including who approved and why. Requires authentication.
+// @Tags CreditOverride
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (override reason, hold reason)"
+// @Param customer_id query string false "Customer ID"
+// @Param sales_order_id query string false "Sales order ID"
+// @Success 200 {object} models.CreditOverridePaginatedResponse "Credit overrides fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch credit overrides"
+// @Router /api/v1/credit-override [get]
+func GetAllCreditOverridesPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newCustomerCreditService().GetAllCreditOverridesPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch credit overrides", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Credit overrides fetched successfully", result)
+}
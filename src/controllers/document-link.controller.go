@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newDocumentLinkService() *services.DocumentLinkService {
+	documentLinkRepo := repositories.NewDocumentLinkRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	roleModuleRepo := repositories.NewRoleModuleRepository(configs.DB)
+	return services.NewDocumentLinkService(documentLinkRepo, soRepo, poRepo, roleModuleRepo)
+}
+
+// CreateDocumentLink
+// @Summary Create signed document link
+// @Description Membuat URL publik bertanda tangan (HMAC) dengan masa berlaku untuk invoice, receipt, surat jalan, dokumen PO, atau laporan penjualan. Opsional one_time: link hanya bisa dibuka sekali. Requires authentication.
+// @Tags DocumentLink
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.DocumentLinkCreateRequest true "Document link payload"
+// @Success 201 {object} models.ResponseGetDocumentLink "Document link created successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "No permission for the document's module"
+// @Failure 404 {string} string "Document not found"
+// @Failure 409 {string} string "Invoice has not been issued for this sales order"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/document-link [post]
+func CreateDocumentLink(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	req := new(models.DocumentLinkCreateRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid request body", nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newDocumentLinkService().CreateDocumentLink(req, userInfo)
+	if err != nil {
+		if errors.Is(err, services.ErrDocumentLinkForbidden) {
+			return helpers.Response(ctx, fiber.StatusForbidden, err.Error(), nil)
+		}
+		if errors.Is(err, repositories.ErrSalesOrderNotFound) || errors.Is(err, repositories.ErrPurchaseOrderNotFound) {
+			return helpers.Response(ctx, fiber.StatusNotFound, err.Error(), nil)
+		}
+		if errors.Is(err, services.ErrInvoiceNotIssued) {
+			return helpers.Response(ctx, fiber.StatusConflict, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Document link created successfully", result)
+}
+
+// RevokeDocumentLink
+// @Summary Revoke document link
+// @Description Mencabut link dokumen publik sebelum masa berlakunya habis. Requires authentication.
+// @Tags DocumentLink
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Document Link ID"
+// @Success 200 {object} models.ResponseGetDocumentLink "Document link revoked successfully"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "No permission for the document's module"
+// @Failure 404 {string} string "Document link not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/v1/document-link/{id}/revoke [put]
+func RevokeDocumentLink(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	result, err := newDocumentLinkService().RevokeDocumentLink(ctx.Params("id"), userInfo)
+	if err != nil {
+		if errors.Is(err, services.ErrDocumentLinkForbidden) {
+			return helpers.Response(ctx, fiber.StatusForbidden, err.Error(), nil)
+		}
+		if errors.Is(err, repositories.ErrDocumentLinkNotFound) {
+			return helpers.Response(ctx, fiber.StatusNotFound, err.Error(), nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Document link revoked successfully", result)
+}
+
+// GetSharedDocument
+// @Summary Open shared document
+// @Description Membuka dokumen lewat link bertanda tangan tanpa login. Hanya membaca dokumen (tidak mengubah status SO / menerbitkan nomor invoice). Link ditolak bila signature tidak cocok, sudah kedaluwarsa, dicabut, atau one-time dan sudah pernah dibuka.
+// @Tags DocumentLink
+// @Produce application/pdf
+// @Param id path string true "Document Link ID"
+// @Param expires query int true "Unix timestamp kedaluwarsa"
+// @Param signature query string true "HMAC signature"
+// @Success 200 {file} file "Document stream"
+// @Failure 403 {string} string "Invalid document link"
+// @Failure 409 {string} string "Invoice has not been issued for this sales order"
+// @Failure 410 {string} string "Document link has expired or is no longer valid"
+// @Failure 500 {string} string "Failed to generate document"
+// @Router /api/v1/shared-document/{id} [get]
+func GetSharedDocument(ctx *fiber.Ctx) error {
+	req := new(models.DocumentLinkAccessRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusForbidden, services.ErrDocumentLinkInvalid.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(req); err != nil {
+		return helpers.Response(ctx, fiber.StatusForbidden, services.ErrDocumentLinkInvalid.Error(), nil)
+	}
+
+	linkService := newDocumentLinkService()
+	link, err := linkService.ResolveDocumentLink(ctx.Params("id"), req)
+	if err != nil {
+		return sharedDocumentError(ctx, err)
+	}
+
+	resourceID := ""
+	if link.ResourceID != nil {
+		resourceID = link.ResourceID.String()
+	}
+
+	var (
+		filename string
+		pdfBytes []byte
+	)
+	switch link.DocumentType {
+	case models.DocumentTypeDeliveryOrder:
+		filename, pdfBytes, err = newSalesOrderDocumentService().GenerateDocumentDeliveryOrder(resourceID)
+	case models.DocumentTypeInvoice:
+		filename, pdfBytes, err = newSalesOrderDocumentService().RenderInvoice(resourceID)
+	case models.DocumentTypeReceipt:
+		filename, pdfBytes, err = newSalesOrderDocumentService().GenerateReceipt(resourceID)
+	case models.DocumentTypePurchaseOrder:
+		filename, pdfBytes, err = newPurchaseOrderDocumentService().GenerateDocumentPurchaseOrder(resourceID)
+	case models.DocumentTypeSalesReportPDF:
+		filename, pdfBytes, err = newSalesReportDocumentService().GenerateSalesReportPDF(resourceID)
+	case models.DocumentTypeSalesReportExcel:
+		// filter laporan disimpan sebagai query string; dipasang ulang supaya QueryParser bisa dipakai
+		ctx.Request().URI().SetQueryString(link.Query)
+		filters := &models.PaginationRequest{}
+		if err := ctx.QueryParser(filters); err != nil {
+			return helpers.Response(ctx, fiber.StatusInternalServerError, "Invalid stored report filters", nil)
+		}
+		excelName, fileExcel, err := newSalesReportDocumentService().GenerateSalesReportExcel(filters)
+		if err != nil {
+			return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate document", err.Error())
+		}
+		defer fileExcel.Close()
+		buf, err := fileExcel.WriteToBuffer()
+		if err != nil {
+			return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate document", err.Error())
+		}
+		if err := linkService.RecordDocumentLinkAccess(link.ID.String(), ctx.IP()); err != nil {
+			return sharedDocumentError(ctx, err)
+		}
+		ctx.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		ctx.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, excelName))
+		return ctx.SendStream(bytes.NewReader(buf.Bytes()))
+	default:
+		return helpers.Response(ctx, fiber.StatusForbidden, services.ErrDocumentLinkInvalid.Error(), nil)
+	}
+	if errors.Is(err, services.ErrInvoiceNotIssued) {
+		return helpers.Response(ctx, fiber.StatusConflict, err.Error(), nil)
+	}
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate document", err.Error())
+	}
+	// link baru ditandai terpakai setelah dokumen berhasil dibuat
+	if err := linkService.RecordDocumentLinkAccess(link.ID.String(), ctx.IP()); err != nil {
+		return sharedDocumentError(ctx, err)
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
+
+func sharedDocumentError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrDocumentLinkInvalid):
+		return helpers.Response(ctx, fiber.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, services.ErrDocumentLinkExpired):
+		return helpers.Response(ctx, fiber.StatusGone, err.Error(), nil)
+	}
+	return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
+}
+
+func newSalesOrderDocumentService() *services.SalesOrderService {
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	spRepo := repositories.NewSalesPersonRepository(configs.DB)
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	return services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
+}
+
+func newPurchaseOrderDocumentService() *services.PurchaseOrderService {
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	supplierRepo := repositories.NewSupplierRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	return services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
+}
+
+func newSalesReportDocumentService() *services.SalesReportService {
+	salesReportRepo := repositories.NewSalesReportRepository(configs.DB)
+	salesPersonRepo := repositories.NewSalesPersonRepository(configs.DB)
+	salesOrderRepo := repositories.NewSalesOrderRepository(configs.DB)
+	return services.NewSalesReportService(salesReportRepo, salesPersonRepo, salesOrderRepo)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newDunningService() *services.DunningService {
+	dunningReminderRepo := repositories.NewDunningReminderRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	userRepo := repositories.NewUserRepository(configs.DB)
+	return services.NewDunningService(dunningReminderRepo, soRepo, userRepo, helpers.GetMailSender())
+}
+
+// GetAllDunningRemindersPaginated
+// @Summary List dunning reminders (paginated)
+// @Description Retrieve reminders sent for overdue sales orders with pagination. Requires authentication.
+// @Tags DunningReminder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (SO number, email)"
+// @Param sales_order_id query string false "Sales Order ID"
+// @Param customer_id query string false "Customer ID"
+// @Param sales_person_id query string false "Sales Person ID"
+// @Success 200 {object} models.DunningReminderPaginatedResponse "Dunning reminders fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch dunning reminders"
+// @Router /api/v1/dunning-reminder [get]
+func GetAllDunningRemindersPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newDunningService().GetAllDunningRemindersPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch dunning reminders", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Dunning reminders fetched successfully", result)
+}
+
+// RunDunningReminders
+// @Summary Run dunning reminders now
+// @Description Send due reminders for overdue sales orders immediately instead of waiting for the daily job. Stages already sent are skipped. Requires authentication.
+// @Tags DunningReminder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} models.DunningReminder "Dunning reminders sent successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to run dunning reminders"
+// @Router /api/v1/dunning-reminder/run [post]
+func RunDunningReminders(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	sent, err := newDunningService().RunDunning(time.Now(), helpers.DunningStages())
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to run dunning reminders", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Dunning reminders sent successfully", sent)
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newGoodsReceiptService() *services.GoodsReceiptService {
+	goodsReceiptRepo := repositories.NewGoodsReceiptRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewGoodsReceiptService(goodsReceiptRepo, poRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo)
+}
+
+// GetAllGoodsReceiptsPaginated
+// @Summary List goods receipts (paginated)
+// @Description Retrieve goods receipt notes with pagination, filterable by purchase order, supplier and warehouse. Requires authentication.
+// @Tags GoodsReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (GRN number, delivery note, receiver)"
+// @Param purchase_order_id query string false "Purchase order ID"
+// @Param supplier_id query string false "Supplier ID"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Success 200 {object} models.GoodsReceiptPaginatedResponse "Goods receipts fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch goods receipts"
+// @Router /api/v1/goods-receipt [get]
+func GetAllGoodsReceiptsPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newGoodsReceiptService().GetAllGoodsReceiptsPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch goods receipts", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Goods receipts fetched successfully", result)
+}
+
+// GetGoodsReceiptByID
+// @Summary Get goods receipt by ID
+// @Description Retrieve a single goods receipt note with its lines. Requires authentication.
+// @Tags GoodsReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Goods Receipt ID"
+// @Success 200 {object} models.ResponseGetGoodsReceipt "Goods receipt fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Goods receipt not found"
+// @Router /api/v1/goods-receipt/{id} [get]
+func GetGoodsReceiptByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	receipt, err := newGoodsReceiptService().GetGoodsReceiptByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Goods receipt not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Goods receipt fetched successfully", receipt)
+}
+
+// GenerateGoodsReceiptNote
+// @Summary Generate goods receipt note (PDF)
+// @Description Stream the printable GRN PDF of a goods receipt. Requires authentication.
+// @Tags GoodsReceipt
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path string true "Goods Receipt ID"
+// @Success 200 {file} file "PDF stream"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to generate goods receipt document"
+// @Router /api/v1/goods-receipt/{id}/document [get]
+func GenerateGoodsReceiptNote(ctx *fiber.Ctx) error {
+	filename, pdfBytes, err := newGoodsReceiptService().GenerateGoodsReceiptNote(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate goods receipt document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
@@ -48,8 +48,11 @@ func ItemHistoryControllerGetAll(ctx *fiber.Ctx) error {
 	}
 
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
-	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo)
+	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	itemHistoriesResponse, err := itemHistoryService.GetAllItemHistoriesPaginated(paginationReq, userInfo)
 	if err != nil {
@@ -94,8 +97,11 @@ func ItemHistoryControllerGetAll(ctx *fiber.Ctx) error {
 	}
 
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
-	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo)
+	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	_, err := itemHistoryService.CreateItemHistory(itemHistoryRequest, ctx, userInfo)
 	if err != nil {
@@ -154,8 +160,11 @@ func ItemHistoryControllerDelete(ctx *fiber.Ctx) error {
 	}
 
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
-	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo)
+	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	if err := itemHistoryService.DeleteItemHistories(itemHistoryRequest, ctx, userInfo); err != nil {
 		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
@@ -207,8 +216,11 @@ func ItemHistoryControllerRestore(ctx *fiber.Ctx) error {
 	}
 
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
-	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo)
+	itemHistoryService := services.NewItemHistoryService(itemHistoryRepo, itemRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	restoredItemHistories, err := itemHistoryService.RestoreItemHistories(itemHistoryRequest, ctx, userInfo)
 	if err != nil {
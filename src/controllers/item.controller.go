@@ -1,12 +1,16 @@
 package controllers
 
 import (
+	"errors"
+	"time"
+
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
 	"github.com/SalmanDMA/inventory-app/backend/src/services"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // @Summary Get all items
@@ -44,7 +48,10 @@ func ItemControllerGetAll(ctx *fiber.Ctx) error {
 	uploadRepo := repositories.NewUploadRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	itemsResponse, err := itemService.GetAllItemsPaginated(paginationReq, userInfo)
 	if err != nil {
@@ -80,7 +87,10 @@ func ItemControllerGetByID(c *fiber.Ctx) error {
 	uploadRepo := repositories.NewUploadRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 	itemResponse, err := itemService.GetItemByID(id)
 	
 	if err != nil {
@@ -123,7 +133,10 @@ func ItemControllerGetByID(c *fiber.Ctx) error {
 		uploadRepo := repositories.NewUploadRepository(configs.DB)
 		itemRepo := repositories.NewItemRepository(configs.DB)
 		itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-		itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+		itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+		itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+		warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+		itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 		if _, err := itemService.CreateItem(itemRequest, ctx, userInfo); err != nil {
 			return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
@@ -169,7 +182,10 @@ func ItemControllerGetByID(c *fiber.Ctx) error {
 	uploadRepo := repositories.NewUploadRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 		if _, err := itemService.UpdateItem(itemRequest, itemID, ctx, userInfo); err != nil {
 			if err == repositories.ErrItemNotFound {
@@ -215,7 +231,10 @@ func ItemControllerDelete(ctx *fiber.Ctx) error {
 	uploadRepo := repositories.NewUploadRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	if err := itemService.DeleteItems(itemRequest, ctx, userInfo); err != nil {
 		return helpers.Response(ctx, fiber.StatusInternalServerError, err.Error(), nil)
@@ -269,7 +288,10 @@ func ItemControllerRestore(ctx *fiber.Ctx) error {
 	uploadRepo := repositories.NewUploadRepository(configs.DB)
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemService := services.NewItemService(uploadRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo)
 
 	restoredItems, err := itemService.RestoreItems(itemRequest, ctx, userInfo)
 	if err != nil {
@@ -277,4 +299,128 @@ func ItemControllerRestore(ctx *fiber.Ctx) error {
 	}
 
 	return helpers.Response(ctx, fiber.StatusOK, "Items restored successfully", restoredItems)
-}
\ No newline at end of file
+}
+// @Summary Get item lots
+// @Description Get stock lots of an item ordered by expiry (FEFO). Empty lots are hidden unless include_empty=true.
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Item ID"
+// @Param include_empty query bool false "Include lots with zero quantity"
+// @Success 200 {array} models.ResponseGetItemLot
+// @Failure 404 {string} string "Item not found"
+// @Failure 500 {string} string "Error getting item lots"
+// @Router /api/v1/item/{id}/lots [get]
+func ItemControllerGetLots(c *fiber.Ctx) error {
+	_, ok := c.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(c, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	id := c.Params("id")
+	includeEmpty := c.QueryBool("include_empty", false)
+
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemLotService := services.NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo)
+
+	lots, err := itemLotService.GetLotsByItem(id, includeEmpty)
+	if err != nil {
+		if errors.Is(err, repositories.ErrItemNotFound) {
+			return helpers.Response(c, fiber.StatusNotFound, "Item not found", nil)
+		}
+		return helpers.Response(c, fiber.StatusInternalServerError, "Error getting item lots", nil)
+	}
+
+	return helpers.Response(c, fiber.StatusOK, "Item lots fetched successfully", lots)
+}
+
+// @Summary Get item stock per warehouse
+// @Description Get stock balance of an item in every warehouse.
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Item ID"
+// @Success 200 {array} models.ResponseGetItemStock
+// @Failure 404 {string} string "Item not found"
+// @Failure 500 {string} string "Error getting item stocks"
+// @Router /api/v1/item/{id}/stocks [get]
+func ItemControllerGetStocks(c *fiber.Ctx) error {
+	_, ok := c.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(c, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	id := c.Params("id")
+
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemLotService := services.NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo)
+
+	stocks, err := itemLotService.GetStocksByItem(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrItemNotFound) {
+			return helpers.Response(c, fiber.StatusNotFound, "Item not found", nil)
+		}
+		return helpers.Response(c, fiber.StatusInternalServerError, "Error getting item stocks", nil)
+	}
+
+	return helpers.Response(c, fiber.StatusOK, "Item stocks fetched successfully", stocks)
+}
+
+// @Summary Get near-expiry stock
+// @Description List lots expiring within the given number of days with their value at cost. Defaults to the longest expiry alert horizon (EXPIRY_ALERT_DAYS).
+// @Tags Item
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param days query int false "Horizon in days (default: longest alert horizon)"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param include_expired query bool false "Include lots that are already expired (default: true)"
+// @Success 200 {object} models.NearExpiryReport
+// @Failure 400 {string} string "Invalid warehouse_id"
+// @Failure 500 {string} string "Error getting near-expiry stock"
+// @Router /api/v1/item/near-expiry [get]
+func ItemControllerGetNearExpiry(c *fiber.Ctx) error {
+	_, ok := c.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(c, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	days := c.QueryInt("days", 0)
+	includeExpired := c.QueryBool("include_expired", true)
+
+	var warehouseID *uuid.UUID
+	if raw := c.Query("warehouse_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			return helpers.Response(c, fiber.StatusBadRequest, "Invalid warehouse_id", nil)
+		}
+		warehouseID = &parsed
+	}
+
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemLotService := services.NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo)
+
+	report, err := itemLotService.GetNearExpiryStock(days, warehouseID, includeExpired, time.Now())
+	if err != nil {
+		return helpers.Response(c, fiber.StatusInternalServerError, "Error getting near-expiry stock", nil)
+	}
+
+	return helpers.Response(c, fiber.StatusOK, "Near-expiry stock fetched successfully", report)
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newNumberSequenceService() *services.NumberSequenceService {
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewNumberSequenceService(numberSequenceRepo)
+}
+
+// GetAllNumberSequences
+// @Summary List document number sequences
+// @Description Retrieve the numbering format of every document type with its current counter and a preview of the next number. Requires authentication.
+// @Tags NumberSequence
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} models.ResponseGetNumberSequence "Number sequences fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch number sequences"
+// @Router /api/v1/number-sequence [get]
+func GetAllNumberSequences(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals("userInfo").(*models.User); !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	result, err := newNumberSequenceService().GetAllNumberSequences()
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch number sequences", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Number sequences fetched successfully", result)
+}
+
+// UpdateNumberSequence
+// @Summary Update document number sequence
+// @Description Update prefix, format, padding and reset period of a document number sequence. Format tokens: {PREFIX}, {YYYY}, {YY}, {MM}, {SEQ}. Requires authentication.
+// @Tags NumberSequence
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Number Sequence ID"
+// @Param request body models.NumberSequenceUpdateRequest true "Number sequence update request body"
+// @Success 200 {object} models.ResponseGetNumberSequence "Number sequence updated successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to update number sequence"
+// @Router /api/v1/number-sequence/{id} [put]
+func UpdateNumberSequence(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	sequenceRequest := new(models.NumberSequenceUpdateRequest)
+	if err := ctx.BodyParser(sequenceRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(sequenceRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	sequence, err := newNumberSequenceService().UpdateNumberSequence(ctx.Params("id"), sequenceRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update number sequence", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Number sequence updated successfully", sequence)
+}
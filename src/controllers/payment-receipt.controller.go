@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newPaymentReceiptService() *services.PaymentReceiptService {
+	paymentReceiptRepo := repositories.NewPaymentReceiptRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	uploadRepo := repositories.NewUploadRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewPaymentReceiptService(paymentReceiptRepo, paymentRepo, poRepo, soRepo, uploadRepo, numberSequenceRepo)
+}
+
+// GetAllPaymentReceiptsPaginated
+// @Summary List payment receipts (paginated)
+// @Description Retrieve payment receipts (customer transfers in / supplier transfers out) with pagination. Requires authentication.
+// @Tags PaymentReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (receipt number, reference number, notes)"
+// @Param status query string false "Filter by status: active, deleted, all (default: active)"
+// @Param order_type query string false "Filter by order type: SO, PO"
+// @Param customer_id query string false "Customer ID"
+// @Param supplier_id query string false "Supplier ID"
+// @Success 200 {object} models.PaymentReceiptPaginatedResponse "Payment receipts fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch payment receipts"
+// @Router /api/v1/payment-receipt [get]
+func GetAllPaymentReceiptsPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newPaymentReceiptService().GetAllPaymentReceiptsPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch payment receipts", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment receipts fetched successfully", result)
+}
+
+// GetPaymentDeposits
+// @Summary Get deposit balances
+// @Description Unallocated receipt balances (customer deposits / supplier credit) grouped per customer or supplier. Requires authentication.
+// @Tags PaymentReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param order_type query string false "Filter by order type: SO, PO"
+// @Param customer_id query string false "Customer ID"
+// @Param supplier_id query string false "Supplier ID"
+// @Success 200 {array} models.PaymentDepositBalance "Deposit balances retrieved successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/payment-receipt/deposits [get]
+func GetPaymentDeposits(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	depositReq := new(models.PaymentDepositRequest)
+	if err := ctx.QueryParser(depositReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(depositReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	deposits, err := newPaymentReceiptService().GetDeposits(depositReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch deposit balances", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Deposit balances retrieved successfully", deposits)
+}
+
+// GetPaymentReceiptByID
+// @Summary Get payment receipt by ID
+// @Description Retrieve a payment receipt with its allocations. Requires authentication.
+// @Tags PaymentReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment Receipt ID"
+// @Success 200 {object} models.PaymentReceipt "Payment receipt fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Payment receipt not found"
+// @Router /api/v1/payment-receipt/{id} [get]
+func GetPaymentReceiptByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	receipt, err := newPaymentReceiptService().GetPaymentReceiptByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Payment receipt not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment receipt fetched successfully", receipt)
+}
+
+// CreatePaymentReceipt
+// @Summary Create payment receipt
+// @Description Record one transfer and allocate it across several sales orders (or purchase orders). An allocation amount of 0 pays the remaining balance; any unallocated remainder is kept as deposit. Requires authentication.
+// @Tags PaymentReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PaymentReceiptCreateRequest true "Payment receipt create request body"
+// @Success 201 {object} models.PaymentReceipt "Payment receipt created successfully"
+// @Failure 400 {string} string "Failed to create payment receipt"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/payment-receipt [post]
+func CreatePaymentReceipt(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	receiptRequest := new(models.PaymentReceiptCreateRequest)
+	if err := ctx.BodyParser(receiptRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(receiptRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	receipt, err := newPaymentReceiptService().CreatePaymentReceipt(receiptRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create payment receipt", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Payment receipt created successfully", receipt)
+}
+
+// AllocatePaymentReceipt
+// @Summary Allocate payment receipt balance
+// @Description Apply the unallocated (deposit) balance of a receipt to one or more orders of the same customer or supplier. Requires authentication.
+// @Tags PaymentReceipt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment Receipt ID"
+// @Param request body models.PaymentReceiptAllocateRequest true "Payment receipt allocate request body"
+// @Success 200 {object} models.PaymentReceipt "Payment receipt allocated successfully"
+// @Failure 400 {string} string "Failed to allocate payment receipt"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/payment-receipt/{id}/allocate [put]
+func AllocatePaymentReceipt(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	allocateRequest := new(models.PaymentReceiptAllocateRequest)
+	if err := ctx.BodyParser(allocateRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(allocateRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	receipt, err := newPaymentReceiptService().AllocatePaymentReceipt(ctx.Params("id"), allocateRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to allocate payment receipt", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment receipt allocated successfully", receipt)
+}
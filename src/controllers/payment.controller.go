@@ -119,3 +119,47 @@ func GetPaymentsBySalesOrder(ctx *fiber.Ctx) error {
 
 	return helpers.Response(ctx, fiber.StatusOK, "Payments fetched successfully", payments)
 }
+
+// VoidPayment membatalkan pembayaran dengan entri reversal
+// @Summary Void payment
+// @Description Void a payment: the original record is kept and flagged, a negative reversal entry is recorded with the reason and actor, the attached invoice is archived, and the order's paid amount and payment status are recomputed from its payment ledger. Requires authentication.
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Payment ID"
+// @Param request body models.PaymentVoidRequest true "Payment void request body"
+// @Success 200 {object} models.Payment "Payment voided successfully"
+// @Failure 400 {string} string "Failed to void payment"
+// @Failure 401 {string} string "Unauthorized: Unable to retrieve user information"
+// @Router /api/v1/payment/{id}/void [put]
+func VoidPayment(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: Unable to retrieve user information", nil)
+	}
+
+	voidRequest := new(models.PaymentVoidRequest)
+	if err := ctx.BodyParser(voidRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(voidRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	uploadRepo := repositories.NewUploadRepository(configs.DB)
+	paymentService := services.NewPaymentService(paymentRepo, poRepo, soRepo, uploadRepo)
+
+	reversal, err := paymentService.VoidPayment(ctx.Params("id"), voidRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to void payment", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Payment voided successfully", reversal)
+}
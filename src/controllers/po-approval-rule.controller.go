@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newPurchaseOrderApprovalService() *services.PurchaseOrderApprovalService {
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	return services.NewPurchaseOrderApprovalService(approvalRepo, roleRepo, categoryRepo)
+}
+
+// GetAllPurchaseOrderApprovalRules
+// @Summary List purchase order approval rules
+// @Description Retrieve every approval rule (amount threshold / category, approver role and level). Requires authentication.
+// @Tags PurchaseOrderApprovalRule
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} models.PurchaseOrderApprovalRule "Approval rules fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch approval rules"
+// @Router /api/v1/po-approval-rule [get]
+func GetAllPurchaseOrderApprovalRules(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals("userInfo").(*models.User); !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	rules, err := newPurchaseOrderApprovalService().GetAllApprovalRules()
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch approval rules", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Approval rules fetched successfully", rules)
+}
+
+// CreatePurchaseOrderApprovalRule
+// @Summary Create purchase order approval rule
+// @Description Create an approval rule. A PO matches when its total is at least min_amount (0 = any) and, if category_id is set, it contains an item of that category. Requires authentication.
+// @Tags PurchaseOrderApprovalRule
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PurchaseOrderApprovalRuleRequest true "Approval rule request body"
+// @Success 201 {object} models.PurchaseOrderApprovalRule "Approval rule created successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to create approval rule"
+// @Router /api/v1/po-approval-rule [post]
+func CreatePurchaseOrderApprovalRule(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals("userInfo").(*models.User); !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	ruleRequest := new(models.PurchaseOrderApprovalRuleRequest)
+	if err := ctx.BodyParser(ruleRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(ruleRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	rule, err := newPurchaseOrderApprovalService().CreateApprovalRule(ruleRequest)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create approval rule", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Approval rule created successfully", rule)
+}
+
+// UpdatePurchaseOrderApprovalRule
+// @Summary Update purchase order approval rule
+// @Description Update an approval rule by ID. Chains already submitted are not changed. Requires authentication.
+// @Tags PurchaseOrderApprovalRule
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Approval Rule ID"
+// @Param request body models.PurchaseOrderApprovalRuleRequest true "Approval rule request body"
+// @Success 200 {object} models.PurchaseOrderApprovalRule "Approval rule updated successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to update approval rule"
+// @Router /api/v1/po-approval-rule/{id} [put]
+func UpdatePurchaseOrderApprovalRule(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals("userInfo").(*models.User); !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	ruleRequest := new(models.PurchaseOrderApprovalRuleRequest)
+	if err := ctx.BodyParser(ruleRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(ruleRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	rule, err := newPurchaseOrderApprovalService().UpdateApprovalRule(ctx.Params("id"), ruleRequest)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update approval rule", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Approval rule updated successfully", rule)
+}
+
+// DeletePurchaseOrderApprovalRule
+// @Summary Delete purchase order approval rule
+// @Description Permanently delete an approval rule by ID. Requires authentication.
+// @Tags PurchaseOrderApprovalRule
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Approval Rule ID"
+// @Success 200 {string} string "Approval rule deleted successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to delete approval rule"
+// @Router /api/v1/po-approval-rule/{id} [delete]
+func DeletePurchaseOrderApprovalRule(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals("userInfo").(*models.User); !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	if err := newPurchaseOrderApprovalService().DeleteApprovalRule(ctx.Params("id")); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to delete approval rule", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Approval rule deleted successfully", nil)
+}
@@ -35,7 +35,14 @@ func GetAllPurchaseOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	pos, err := poService.GetAllPurchaseOrders()
 	if err != nil {
@@ -84,7 +91,14 @@ func GetAllPurchaseOrdersPaginated(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	result, err := poService.GetAllPurchaseOrdersPaginated(paginationReq, userInfo)
 	if err != nil {
@@ -119,7 +133,14 @@ func GetPurchaseOrderByID(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	po, err := poService.GetPurchaseOrderByID(poId)
 	if err != nil {
@@ -151,7 +172,14 @@ func GenerateDocumentPurchaseOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	filename, pdfBytes, err := poService.GenerateDocumentPurchaseOrder(poId)
 	if err != nil {
@@ -198,7 +226,14 @@ func CreatePurchaseOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	po, err := poService.CreatePurchaseOrder(poRequest, userInfo)
 	if err != nil {
@@ -245,7 +280,14 @@ func UpdatePurchaseOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	po, err := poService.UpdatePurchaseOrder(poId, poRequest, userInfo)
 	if err != nil {
@@ -292,7 +334,14 @@ func UpdatePurchaseOrderStatus(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	err := poService.UpdatePurchaseOrderStatus(poId, statusRequest, userInfo)
 	if err != nil {
@@ -304,7 +353,7 @@ func UpdatePurchaseOrderStatus(ctx *fiber.Ctx) error {
 
 // ReceiveItems
 // @Summary Receive items for a purchase order (GRN)
-// @Description Post receiving (GRN) for a purchase order: update received/accepted/rejected and stock moves for accepted qty. Requires authentication.
+// @Description Post a goods receipt note (GRN) for one delivery against a purchase order: records delivery note, receiver and lines, puts every received quantity into stock immediately, and records rejected quantities. Requires authentication.
 // @Tags PurchaseOrder
 // @Accept json
 // @Produce json
@@ -312,11 +361,11 @@ func UpdatePurchaseOrderStatus(ctx *fiber.Ctx) error {
 // @Param Authorization header string true "Authorization"
 // @Param id path string true "Purchase Order ID"
 // @Param request body models.ReceiveItemsRequest true "Receive items request body"
-// @Success 200 {string} string "Items received successfully"
+// @Success 201 {object} models.GoodsReceipt "Items received successfully"
 // @Failure 400 {string} string "Invalid request body"
 // @Failure 401 {string} string "Unauthorized: Item info not found"
 // @Failure 400 {string} string "Failed to receive items"
-// @Router /api/v1/purchase-order/{id}/receive [post]
+// @Router /api/v1/purchase-order/{id}/receive [put]
 func ReceiveItems(ctx *fiber.Ctx) error {
 	userInfo, ok := ctx.Locals("userInfo").(*models.User)
 	if !ok {
@@ -333,20 +382,137 @@ func ReceiveItems(ctx *fiber.Ctx) error {
 		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
 	}
 
-	poId := ctx.Params("id")
-	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
-	supplierRepo := repositories.NewSupplierRepository(configs.DB)
-	itemRepo := repositories.NewItemRepository(configs.DB)
-	paymentRepo := repositories.NewPaymentRepository(configs.DB)
-	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
-
-	err := poService.ReceiveItems(poId, receiveRequest, userInfo)
+	receipt, err := newGoodsReceiptService().CreateGoodsReceipt(ctx.Params("id"), receiveRequest, userInfo)
 	if err != nil {
 		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to receive items", err.Error())
 	}
 
-	return helpers.Response(ctx, fiber.StatusOK, "Items received successfully", nil)
+	return helpers.Response(ctx, fiber.StatusCreated, "Items received successfully", receipt)
+}
+
+// GetPurchaseOrderReceipts
+// @Summary Get receipt history of a purchase order
+// @Description Retrieve every goods receipt (GRN) posted against a purchase order, oldest first. Requires authentication.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {array} models.ResponseGetGoodsReceipt "Purchase order receipts fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Purchase order not found"
+// @Router /api/v1/purchase-order/{id}/receipts [get]
+func GetPurchaseOrderReceipts(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	receipts, err := newGoodsReceiptService().GetReceiptsByPurchaseOrder(ctx.Params("id"))
+	if err != nil {
+		if err == repositories.ErrPurchaseOrderNotFound {
+			return helpers.Response(ctx, fiber.StatusNotFound, "Purchase order not found", nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch purchase order receipts", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase order receipts fetched successfully", receipts)
+}
+
+// GetMyPendingPurchaseOrderApprovals
+// @Summary List purchase orders waiting for my approval
+// @Description Retrieve purchase orders whose current approval step is assigned to the role of the logged-in user. Requires authentication.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} models.ResponseGetPurchaseOrder "Pending approvals fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch pending approvals"
+// @Router /api/v1/purchase-order/approvals/pending [get]
+func GetMyPendingPurchaseOrderApprovals(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	result, err := newPurchaseOrderService().GetPendingApprovals(userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch pending approvals", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Pending approvals fetched successfully", result)
+}
+
+// GetPurchaseOrderApprovals
+// @Summary Get approval history of a purchase order
+// @Description Retrieve every approval step (all submission rounds) of a purchase order with approver comments. Requires authentication.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {array} models.PurchaseOrderApproval "Purchase order approvals fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Purchase order not found"
+// @Router /api/v1/purchase-order/{id}/approvals [get]
+func GetPurchaseOrderApprovals(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	approvals, err := newPurchaseOrderService().GetPurchaseOrderApprovals(ctx.Params("id"))
+	if err != nil {
+		if err == repositories.ErrPurchaseOrderNotFound {
+			return helpers.Response(ctx, fiber.StatusNotFound, "Purchase order not found", nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch purchase order approvals", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase order approvals fetched successfully", approvals)
+}
+
+// DecidePurchaseOrderApproval
+// @Summary Approve or reject a purchase order
+// @Description Record the decision of the current approver. The last approval moves the PO to Approved, a rejection (comment required) moves it to Rejected. Requires authentication.
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Purchase Order ID"
+// @Param request body models.PurchaseOrderApprovalDecisionRequest true "Approval decision request body"
+// @Success 200 {object} models.PurchaseOrder "Purchase order approval saved successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to save purchase order approval"
+// @Router /api/v1/purchase-order/{id}/approval [put]
+func DecidePurchaseOrderApproval(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	decisionRequest := new(models.PurchaseOrderApprovalDecisionRequest)
+	if err := ctx.BodyParser(decisionRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(decisionRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	po, err := newPurchaseOrderService().DecidePurchaseOrderApproval(ctx.Params("id"), decisionRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to save purchase order approval", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase order approval saved successfully", po)
 }
 
 // DeletePurchaseOrders
@@ -384,7 +550,14 @@ func DeletePurchaseOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	err := poService.DeletePurchaseOrders(deleteRequest, userInfo)
 	if err != nil {
@@ -434,7 +607,14 @@ func RestorePurchaseOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	poService := services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo,	itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
 
 	pos, err := poService.RestorePurchaseOrders(restoreRequest, userInfo)
 	if err != nil {
@@ -443,3 +623,19 @@ func RestorePurchaseOrders(ctx *fiber.Ctx) error {
 
 	return helpers.Response(ctx, fiber.StatusOK, "Purchase orders restored successfully", pos)
 }
+
+func newPurchaseOrderService() *services.PurchaseOrderService {
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	supplierRepo := repositories.NewSupplierRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	approvalRepo := repositories.NewPurchaseOrderApprovalRepository(configs.DB)
+	roleRepo := repositories.NewRoleRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	return services.NewPurchaseOrderService(poRepo, supplierRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, approvalRepo, roleRepo, categoryRepo)
+}
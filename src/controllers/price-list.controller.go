@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newPriceListService() *services.PriceListService {
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	customerTypeRepo := repositories.NewCustomerTypeRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	return services.NewPriceListService(priceListRepo, customerRepo, customerTypeRepo, itemRepo, itemHistoryRepo)
+}
+
+// GetAllPriceListsPaginated
+// @Summary List price lists (paginated)
+// @Description Retrieve price lists with pagination, filterable by customer, customer type and item. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (name, description)"
+// @Param status query string false "Filter by status: active, deleted, all (default: active)"
+// @Param customer_id query string false "Customer ID"
+// @Param customer_type_id query string false "Customer type ID"
+// @Param item_id query string false "Item ID"
+// @Success 200 {object} models.PriceListPaginatedResponse "Price lists fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch price lists"
+// @Router /api/v1/price-list [get]
+func GetAllPriceListsPaginated(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newPriceListService().GetAllPriceListsPaginated(paginationReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch price lists", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price lists fetched successfully", result)
+}
+
+// GetPriceListByID
+// @Summary Get price list by ID
+// @Description Retrieve a price list with its item prices and quantity breaks. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Price List ID"
+// @Success 200 {object} models.PriceList "Price list fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Price list not found"
+// @Router /api/v1/price-list/{id} [get]
+func GetPriceListByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	priceList, err := newPriceListService().GetPriceListByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Price list not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price list fetched successfully", priceList)
+}
+
+// ResolvePrice
+// @Summary Resolve selling price
+// @Description Preview the unit price a sales order would use for a customer, item and quantity: customer price list first, then customer type price list, then the item's base price. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param customer_id query string true "Customer ID"
+// @Param item_id query string true "Item ID"
+// @Param quantity query int false "Order quantity (default: 1)"
+// @Param date query string false "Price date YYYY-MM-DD (default: today)"
+// @Success 200 {object} models.ResolvedPrice "Price resolved successfully"
+// @Failure 400 {string} string "Failed to resolve price"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/price-list/resolve [get]
+func ResolvePrice(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	resolveReq := &models.PriceResolveRequest{}
+	if err := ctx.QueryParser(resolveReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(resolveReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	resolved, err := newPriceListService().ResolvePrice(resolveReq)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to resolve price", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price resolved successfully", resolved)
+}
+
+// CreatePriceList
+// @Summary Create price list
+// @Description Create a price list for a customer type or a single customer, with per-item prices, optional quantity breaks and a validity period. Every price is recorded in item history. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PriceListCreateRequest true "Price list body"
+// @Success 201 {object} models.PriceList "Price list created successfully"
+// @Failure 400 {string} string "Failed to create price list"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/price-list [post]
+func CreatePriceList(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	priceListRequest := new(models.PriceListCreateRequest)
+	if err := ctx.BodyParser(priceListRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(priceListRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	priceList, err := newPriceListService().CreatePriceList(priceListRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create price list", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Price list created successfully", priceList)
+}
+
+// UpdatePriceList
+// @Summary Update price list
+// @Description Replace a price list header and all of its item prices. Added, changed and removed prices are recorded in item history. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Price List ID"
+// @Param request body models.PriceListCreateRequest true "Price list body"
+// @Success 200 {object} models.PriceList "Price list updated successfully"
+// @Failure 400 {string} string "Failed to update price list"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/price-list/{id} [put]
+func UpdatePriceList(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	priceListRequest := new(models.PriceListCreateRequest)
+	if err := ctx.BodyParser(priceListRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(priceListRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	priceList, err := newPriceListService().UpdatePriceList(ctx.Params("id"), priceListRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update price list", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price list updated successfully", priceList)
+}
+
+// DeletePriceLists
+// @Summary Delete price lists (soft/hard)
+// @Description Delete one or multiple price lists. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PriceListIsHardDeleteRequest true "Delete price lists request body"
+// @Success 200 {string} string "Price lists deleted successfully"
+// @Failure 400 {string} string "Failed to delete price lists"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/price-list/delete [delete]
+func DeletePriceLists(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	deleteRequest := new(models.PriceListIsHardDeleteRequest)
+	if err := ctx.BodyParser(deleteRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(deleteRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	if err := newPriceListService().DeletePriceLists(deleteRequest, userInfo); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to delete price lists", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price lists deleted successfully", nil)
+}
+
+// RestorePriceLists
+// @Summary Restore price lists
+// @Description Restore soft-deleted price lists. Requires authentication.
+// @Tags PriceList
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PriceListRestoreRequest true "Restore price lists request body"
+// @Success 200 {array} models.PriceList "Price lists restored successfully"
+// @Failure 400 {string} string "Failed to restore price lists"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/price-list/restore [put]
+func RestorePriceLists(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	restoreRequest := new(models.PriceListRestoreRequest)
+	if err := ctx.BodyParser(restoreRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(restoreRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	restored, err := newPriceListService().RestorePriceLists(restoreRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to restore price lists", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Price lists restored successfully", restored)
+}
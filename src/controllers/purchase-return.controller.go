@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newPurchaseReturnService() *services.PurchaseReturnService {
+	purchaseReturnRepo := repositories.NewPurchaseReturnRepository(configs.DB)
+	poRepo := repositories.NewPurchaseOrderRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewPurchaseReturnService(purchaseReturnRepo, poRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo)
+}
+
+// GetAllPurchaseReturnsPaginated
+// @Summary List purchase returns (paginated)
+// @Description Retrieve purchase returns with pagination, filterable by purchase order, supplier and return status. Requires authentication.
+// @Tags PurchaseReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param purchase_order_id query string false "Purchase order ID"
+// @Param supplier_id query string false "Supplier ID"
+// @Param return_status query string false "Draft|Completed|Cancelled"
+// @Success 200 {object} models.PurchaseReturnPaginatedResponse "Purchase returns fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch purchase returns"
+// @Router /api/v1/purchase-return [get]
+func GetAllPurchaseReturnsPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newPurchaseReturnService().GetAllPurchaseReturnsPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch purchase returns", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase returns fetched successfully", result)
+}
+
+// GetPurchaseReturnByID
+// @Summary Get purchase return by ID
+// @Description Retrieve a single purchase return with its items. Requires authentication.
+// @Tags PurchaseReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Purchase Return ID"
+// @Success 200 {object} models.ResponseGetPurchaseReturn "Purchase return fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Purchase return not found"
+// @Router /api/v1/purchase-return/{id} [get]
+func GetPurchaseReturnByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	purchaseReturn, err := newPurchaseReturnService().GetPurchaseReturnByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Purchase return not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase return fetched successfully", purchaseReturn)
+}
+
+// CreatePurchaseReturn
+// @Summary Create purchase return
+// @Description Create a draft return to the supplier against received purchase order lines. Quantities are capped at what was received minus earlier returns. Requires authentication.
+// @Tags PurchaseReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.PurchaseReturnCreateRequest true "Purchase return create request body"
+// @Success 201 {object} models.PurchaseReturn "Purchase return created successfully"
+// @Failure 400 {string} string "Failed to create purchase return"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/purchase-return [post]
+func CreatePurchaseReturn(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	returnRequest := new(models.PurchaseReturnCreateRequest)
+	if err := ctx.BodyParser(returnRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(returnRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	purchaseReturn, err := newPurchaseReturnService().CreatePurchaseReturn(returnRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create purchase return", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Purchase return created successfully", purchaseReturn)
+}
+
+// UpdatePurchaseReturnStatus
+// @Summary Update purchase return status
+// @Description Complete a draft return (take the goods out of their lot, issue the debit note and optional supplier refund) or cancel it. Requires authentication.
+// @Tags PurchaseReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Purchase Return ID"
+// @Param request body models.PurchaseReturnStatusUpdateRequest true "Purchase return status update request body"
+// @Success 200 {object} models.PurchaseReturn "Purchase return status updated successfully"
+// @Failure 400 {string} string "Failed to update purchase return status"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/purchase-return/{id}/status [put]
+func UpdatePurchaseReturnStatus(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statusRequest := new(models.PurchaseReturnStatusUpdateRequest)
+	if err := ctx.BodyParser(statusRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(statusRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	purchaseReturn, err := newPurchaseReturnService().UpdatePurchaseReturnStatus(ctx.Params("id"), statusRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update purchase return status", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Purchase return status updated successfully", purchaseReturn)
+}
+
+// GenerateDebitNote
+// @Summary Generate debit note (PDF)
+// @Description Stream the debit note PDF of a completed purchase return. Requires authentication.
+// @Tags PurchaseReturn
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path string true "Purchase Return ID"
+// @Success 200 {file} file "PDF stream"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to generate debit note document"
+// @Router /api/v1/purchase-return/{id}/debit-note [get]
+func GenerateDebitNote(ctx *fiber.Ctx) error {
+	filename, pdfBytes, err := newPurchaseReturnService().GenerateDebitNote(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate debit note document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
@@ -0,0 +1,342 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newSalesQuotationService() *services.SalesQuotationService {
+	quotationRepo := repositories.NewSalesQuotationRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	spRepo := repositories.NewSalesPersonRepository(configs.DB)
+	customerRepo := repositories.NewCustomerRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	return services.NewSalesQuotationService(quotationRepo, soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
+}
+
+// GetAllSalesQuotationsPaginated
+// @Summary List sales quotations (paginated)
+// @Description Retrieve sales quotations with pagination, filterable by customer, sales person and quotation status. Superseded revisions are hidden unless quotation_status is set. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param customer_id query string false "Customer ID"
+// @Param sales_person_id query string false "Sales person ID"
+// @Param quotation_status query string false "Draft|Sent|Accepted|Rejected|Expired|Superseded|Converted"
+// @Success 200 {object} models.SalesQuotationPaginatedResponse "Sales quotations fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch sales quotations"
+// @Router /api/v1/sales-quotation [get]
+func GetAllSalesQuotationsPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newSalesQuotationService().GetAllSalesQuotationsPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch sales quotations", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales quotations fetched successfully", result)
+}
+
+// GetSalesQuotationByID
+// @Summary Get sales quotation by ID
+// @Description Retrieve a single sales quotation revision with its items. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Success 200 {object} models.ResponseGetSalesQuotation "Sales quotation fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Sales quotation not found"
+// @Router /api/v1/sales-quotation/{id} [get]
+func GetSalesQuotationByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	quotation, err := newSalesQuotationService().GetSalesQuotationByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Sales quotation not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales quotation fetched successfully", quotation)
+}
+
+// GetSalesQuotationRevisions
+// @Summary List quotation revisions
+// @Description Retrieve every revision sharing the quotation number of the given quotation, oldest first. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Success 200 {array} models.ResponseGetSalesQuotation "Quotation revisions fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Sales quotation not found"
+// @Router /api/v1/sales-quotation/{id}/revisions [get]
+func GetSalesQuotationRevisions(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	revisions, err := newSalesQuotationService().GetSalesQuotationRevisions(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Sales quotation not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Quotation revisions fetched successfully", revisions)
+}
+
+// CreateSalesQuotation
+// @Summary Create sales quotation
+// @Description Create a draft quotation. A unit price of 0 falls back to the item's current price. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.SalesQuotationCreateRequest true "Sales quotation create request body"
+// @Success 201 {object} models.SalesQuotation "Sales quotation created successfully"
+// @Failure 400 {string} string "Failed to create sales quotation"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-quotation [post]
+func CreateSalesQuotation(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	quotationRequest := new(models.SalesQuotationCreateRequest)
+	if err := ctx.BodyParser(quotationRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(quotationRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	quotation, err := newSalesQuotationService().CreateSalesQuotation(quotationRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create sales quotation", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Sales quotation created successfully", quotation)
+}
+
+// UpdateSalesQuotation
+// @Summary Update draft sales quotation
+// @Description Replace the header and items of a Draft quotation. Sent quotations must be revised instead. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Param request body models.SalesQuotationCreateRequest true "Sales quotation update request body"
+// @Success 200 {object} models.SalesQuotation "Sales quotation updated successfully"
+// @Failure 400 {string} string "Failed to update sales quotation"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-quotation/{id} [put]
+func UpdateSalesQuotation(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	quotationRequest := new(models.SalesQuotationCreateRequest)
+	if err := ctx.BodyParser(quotationRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(quotationRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	quotation, err := newSalesQuotationService().UpdateSalesQuotation(ctx.Params("id"), quotationRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update sales quotation", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales quotation updated successfully", quotation)
+}
+
+// ReviseSalesQuotation
+// @Summary Revise sales quotation
+// @Description Create a new Draft revision (same number, revision + 1) from a Sent, Rejected or Expired quotation. The previous revision becomes Superseded. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Param request body models.SalesQuotationCreateRequest true "Revised quotation body"
+// @Success 201 {object} models.SalesQuotation "Sales quotation revised successfully"
+// @Failure 400 {string} string "Failed to revise sales quotation"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-quotation/{id}/revise [post]
+func ReviseSalesQuotation(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	quotationRequest := new(models.SalesQuotationCreateRequest)
+	if err := ctx.BodyParser(quotationRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(quotationRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	quotation, err := newSalesQuotationService().ReviseSalesQuotation(ctx.Params("id"), quotationRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to revise sales quotation", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Sales quotation revised successfully", quotation)
+}
+
+// UpdateSalesQuotationStatus
+// @Summary Update sales quotation status
+// @Description Mark a Draft quotation as Sent, or record the customer's decision (Accepted / Rejected with reason). Quotations past their validity date are marked Expired. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Param request body models.SalesQuotationStatusUpdateRequest true "Status update request body"
+// @Success 200 {object} models.SalesQuotation "Sales quotation status updated successfully"
+// @Failure 400 {string} string "Failed to update sales quotation status"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-quotation/{id}/status [put]
+func UpdateSalesQuotationStatus(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statusRequest := new(models.SalesQuotationStatusUpdateRequest)
+	if err := ctx.BodyParser(statusRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(statusRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	quotation, err := newSalesQuotationService().UpdateSalesQuotationStatus(ctx.Params("id"), statusRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update sales quotation status", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales quotation status updated successfully", quotation)
+}
+
+// ConvertSalesQuotation
+// @Summary Convert quotation to sales order
+// @Description Create a sales order from an Accepted quotation using the quoted unit prices. Order discount and PPN come from the request and the order is re-priced, so its grand total can differ from the quotation total. Quoted prices below an enforced price list minimum are rejected. The quotation is marked Converted and linked to the new order. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Quotation ID"
+// @Param request body models.SalesQuotationConvertRequest true "Conversion options"
+// @Success 201 {object} models.SalesOrder "Sales quotation converted successfully"
+// @Failure 400 {string} string "Failed to convert sales quotation"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-quotation/{id}/convert [post]
+func ConvertSalesQuotation(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	convertRequest := new(models.SalesQuotationConvertRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(convertRequest); err != nil {
+			return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		}
+	}
+
+	if err := helpers.ValidateStruct(convertRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	so, err := newSalesQuotationService().ConvertToSalesOrder(ctx.Params("id"), convertRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to convert sales quotation", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Sales quotation converted successfully", so)
+}
+
+// GenerateQuotationDocument
+// @Summary Generate quotation (PDF)
+// @Description Stream the quotation PDF for the given revision. Requires authentication.
+// @Tags SalesQuotation
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path string true "Sales Quotation ID"
+// @Success 200 {file} file "PDF stream"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to generate quotation document"
+// @Router /api/v1/sales-quotation/{id}/document [get]
+func GenerateQuotationDocument(ctx *fiber.Ctx) error {
+	filename, pdfBytes, err := newSalesQuotationService().GenerateQuotationDocument(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate quotation document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
@@ -258,6 +258,7 @@ func GetSalesReportInsights(ctx *fiber.Ctx) error {
 // @Tags SalesReport
 // @Accept json
 // @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
 // @Param start_date query string false "RFC3339 date-time (local) start"
 // @Param end_date query string false "RFC3339 date-time (local) end"
 // @Param period query string false "day|week|month|year|custom"
@@ -306,10 +307,11 @@ func ExportSalesReportExcel(ctx *fiber.Ctx) error {
 
 // Generate PDF Sales Report 
 // @Summary Generate pdf
-// @Description Stream sales report PDF directly.
+// @Description Stream sales report PDF directly. Requires authentication.
 // @Tags SalesReport
 // @Accept json
 // @Produce application/pdf
+// @Security ApiKeyAuth
 // @Param id path string true "Sales Report ID"
 // @Success 200 {file} file "PDF stream"
 // @Failure 404 {string} string "Sales report not found"
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newSalesReturnService() *services.SalesReturnService {
+	salesReturnRepo := repositories.NewSalesReturnRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	paymentRepo := repositories.NewPaymentRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewSalesReturnService(salesReturnRepo, soRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo)
+}
+
+// GetAllSalesReturnsPaginated
+// @Summary List sales returns (paginated)
+// @Description Retrieve sales returns with pagination, filterable by sales order, customer and return status. Requires authentication.
+// @Tags SalesReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param sales_order_id query string false "Sales order ID"
+// @Param customer_id query string false "Customer ID"
+// @Param return_status query string false "Draft|Completed|Cancelled"
+// @Success 200 {object} models.SalesReturnPaginatedResponse "Sales returns fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch sales returns"
+// @Router /api/v1/sales-return [get]
+func GetAllSalesReturnsPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newSalesReturnService().GetAllSalesReturnsPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch sales returns", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales returns fetched successfully", result)
+}
+
+// GetSalesReturnByID
+// @Summary Get sales return by ID
+// @Description Retrieve a single sales return with its items. Requires authentication.
+// @Tags SalesReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Return ID"
+// @Success 200 {object} models.ResponseGetSalesReturn "Sales return fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Sales return not found"
+// @Router /api/v1/sales-return/{id} [get]
+func GetSalesReturnByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	salesReturn, err := newSalesReturnService().GetSalesReturnByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Sales return not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales return fetched successfully", salesReturn)
+}
+
+// CreateSalesReturn
+// @Summary Create sales return
+// @Description Create a draft return against a delivered sales order. Quantities are capped at what was delivered minus earlier returns. Requires authentication.
+// @Tags SalesReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.SalesReturnCreateRequest true "Sales return create request body"
+// @Success 201 {object} models.SalesReturn "Sales return created successfully"
+// @Failure 400 {string} string "Failed to create sales return"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-return [post]
+func CreateSalesReturn(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	returnRequest := new(models.SalesReturnCreateRequest)
+	if err := ctx.BodyParser(returnRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(returnRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	salesReturn, err := newSalesReturnService().CreateSalesReturn(returnRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create sales return", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Sales return created successfully", salesReturn)
+}
+
+// UpdateSalesReturnStatus
+// @Summary Update sales return status
+// @Description Complete a draft return (restock resellable lines, issue the credit note and optional refund) or cancel it. Requires authentication.
+// @Tags SalesReturn
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Return ID"
+// @Param request body models.SalesReturnStatusUpdateRequest true "Sales return status update request body"
+// @Success 200 {object} models.SalesReturn "Sales return status updated successfully"
+// @Failure 400 {string} string "Failed to update sales return status"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-return/{id}/status [put]
+func UpdateSalesReturnStatus(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statusRequest := new(models.SalesReturnStatusUpdateRequest)
+	if err := ctx.BodyParser(statusRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(statusRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	salesReturn, err := newSalesReturnService().UpdateSalesReturnStatus(ctx.Params("id"), statusRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update sales return status", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales return status updated successfully", salesReturn)
+}
+
+// GenerateCreditNote
+// @Summary Generate credit note (PDF)
+// @Description Stream the credit note PDF of a completed sales return. Requires authentication.
+// @Tags SalesReturn
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path string true "Sales Return ID"
+// @Success 200 {file} file "PDF stream"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to generate credit note document"
+// @Router /api/v1/sales-return/{id}/credit-note [get]
+func GenerateCreditNote(ctx *fiber.Ctx) error {
+	filename, pdfBytes, err := newSalesReturnService().GenerateCreditNote(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate credit note document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
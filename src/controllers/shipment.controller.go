@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newShipmentService() *services.ShipmentService {
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	soRepo := repositories.NewSalesOrderRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewShipmentService(shipmentRepo, soRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo)
+}
+
+// GetAllShipmentsPaginated
+// @Summary List shipments (paginated)
+// @Description Retrieve sales order shipments (delivery orders) with pagination, filterable by sales order, customer, warehouse and shipment status. Requires authentication.
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword (DO number, courier, tracking number)"
+// @Param sales_order_id query string false "Sales order ID"
+// @Param customer_id query string false "Customer ID"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param shipment_status query string false "Shipped|Delivered"
+// @Success 200 {object} models.ShipmentPaginatedResponse "Shipments fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch shipments"
+// @Router /api/v1/shipment [get]
+func GetAllShipmentsPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newShipmentService().GetAllShipmentsPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch shipments", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Shipments fetched successfully", result)
+}
+
+// GetShipmentByID
+// @Summary Get shipment by ID
+// @Description Retrieve a single shipment with its lines. Requires authentication.
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} models.ResponseGetShipment "Shipment fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Shipment not found"
+// @Router /api/v1/shipment/{id} [get]
+func GetShipmentByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	shipment, err := newShipmentService().GetShipmentByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Shipment not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Shipment fetched successfully", shipment)
+}
+
+// MarkShipmentDelivered
+// @Summary Confirm shipment delivery
+// @Description Mark a shipment as received by the customer. The sales order becomes Delivered once every line is fully shipped and all its shipments are delivered. Requires authentication.
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Shipment ID"
+// @Param request body models.ShipmentDeliveredRequest true "Delivery confirmation body"
+// @Success 200 {object} models.Shipment "Shipment marked as delivered"
+// @Failure 400 {string} string "Failed to confirm shipment delivery"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/shipment/{id}/delivered [put]
+func MarkShipmentDelivered(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	deliveredRequest := new(models.ShipmentDeliveredRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(deliveredRequest); err != nil {
+			return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		}
+	}
+
+	shipment, err := newShipmentService().MarkShipmentDelivered(ctx.Params("id"), deliveredRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to confirm shipment delivery", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Shipment marked as delivered", shipment)
+}
+
+// GenerateShipmentDeliveryOrder
+// @Summary Generate delivery order for a shipment (PDF)
+// @Description Stream the delivery order PDF of one shipment, including courier, tracking number and backorder per line. Requires authentication.
+// @Tags Shipment
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param id path string true "Shipment ID"
+// @Success 200 {file} file "PDF stream"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to generate delivery order document"
+// @Router /api/v1/shipment/{id}/document [get]
+func GenerateShipmentDeliveryOrder(ctx *fiber.Ctx) error {
+	filename, pdfBytes, err := newShipmentService().GenerateShipmentDeliveryOrder(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate delivery order document", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/pdf")
+	ctx.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return ctx.SendStream(bytes.NewReader(pdfBytes))
+}
@@ -35,7 +35,14 @@ func GetAllSalesOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	orders, err := soService.GetAllSalesOrders()
 	if err != nil {
@@ -84,7 +91,14 @@ func GetAllSalesOrdersPaginated(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	result, err := soService.GetAllSalesOrdersPaginated(paginationReq, userInfo)
 	if err != nil {
@@ -119,7 +133,14 @@ func GetSalesOrderByID(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	order, err := soService.GetSalesOrderByID(soId)
 	if err != nil {
@@ -151,7 +172,14 @@ func GenerateDocumentDeliveryOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	filename, pdfBytes, err := soService.GenerateDocumentDeliveryOrder(soId)
 	if err != nil {
@@ -185,7 +213,14 @@ func GenerateInvoice(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	filename, pdfBytes, err := soService.GenerateInvoice(soId)
 	if err != nil {
@@ -219,7 +254,14 @@ func GenerateReceipt(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	filename, pdfBytes, err := soService.GenerateReceipt(soId)
 	if err != nil {
@@ -266,7 +308,14 @@ func CreateSalesOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	order, err := soService.CreateSalesOrder(soRequest, userInfo)
 	if err != nil {
@@ -313,7 +362,14 @@ func UpdateSalesOrder(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 	order, err := soService.UpdateSalesOrder(soId, soRequest, userInfo)
 	if err != nil {
 		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update sales order", err.Error())
@@ -359,7 +415,14 @@ func UpdateSalesOrderStatus(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	err := soService.UpdateSalesOrderStatus(soId, statusRequest, userInfo)
 	if err != nil {
@@ -405,7 +468,14 @@ func DeleteSalesOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	err := soService.DeleteSalesOrders(deleteRequest, userInfo)
 	if err != nil {
@@ -451,7 +521,14 @@ func RestoreSalesOrders(ctx *fiber.Ctx) error {
 	itemRepo := repositories.NewItemRepository(configs.DB)
 	paymentRepo := repositories.NewPaymentRepository(configs.DB)
 	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
-	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	shipmentRepo := repositories.NewShipmentRepository(configs.DB)
+	creditOverrideRepo := repositories.NewCreditOverrideRepository(configs.DB)
+	priceListRepo := repositories.NewPriceListRepository(configs.DB)
+	soService := services.NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo)
 
 	err := soService.RestoreSalesOrders(restoreRequest, userInfo)
 	if err != nil {
@@ -460,3 +537,71 @@ func RestoreSalesOrders(ctx *fiber.Ctx) error {
 
 	return helpers.Response(ctx, fiber.StatusOK, "Sales order restored successfully", nil)
 }
+
+// CreateSalesOrderShipment
+// @Summary Ship items for a sales order
+// @Description Record one shipment (delivery order) against a sales order with per-line quantities, courier and tracking number. Stock is deducted per shipment; remaining quantities stay on backorder and the order becomes PartiallyShipped until fully shipped. Requires authentication.
+// @Tags SalesOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Order ID"
+// @Param request body models.ShipmentCreateRequest true "Shipment request body"
+// @Success 201 {object} models.Shipment "Shipment created successfully"
+// @Failure 400 {string} string "Failed to create shipment"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/sales-order/{id}/shipments [post]
+func CreateSalesOrderShipment(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	shipmentRequest := new(models.ShipmentCreateRequest)
+	if err := ctx.BodyParser(shipmentRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(shipmentRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	shipment, err := newShipmentService().CreateShipment(ctx.Params("id"), shipmentRequest, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create shipment", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Shipment created successfully", shipment)
+}
+
+// GetSalesOrderShipments
+// @Summary Get shipment history of a sales order
+// @Description Retrieve every shipment posted against a sales order, oldest first. Requires authentication.
+// @Tags SalesOrder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Sales Order ID"
+// @Success 200 {array} models.ResponseGetShipment "Sales order shipments fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Sales order not found"
+// @Router /api/v1/sales-order/{id}/shipments [get]
+func GetSalesOrderShipments(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	shipments, err := newShipmentService().GetShipmentsBySalesOrder(ctx.Params("id"))
+	if err != nil {
+		if err == repositories.ErrSalesOrderNotFound {
+			return helpers.Response(ctx, fiber.StatusNotFound, "Sales order not found", nil)
+		}
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch sales order shipments", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Sales order shipments fetched successfully", shipments)
+}
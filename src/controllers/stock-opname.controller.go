@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newStockOpnameService() *services.StockOpnameService {
+	stockOpnameRepo := repositories.NewStockOpnameRepository(configs.DB)
+	categoryRepo := repositories.NewCategoryRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewStockOpnameService(stockOpnameRepo, categoryRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo)
+}
+
+// GetAllStockOpnamesPaginated
+// @Summary List stock opname sessions (paginated)
+// @Description Retrieve stock opname sessions with pagination, filterable by warehouse, category and status. Requires authentication.
+// @Tags StockOpname
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param category_id query string false "Category ID"
+// @Param opname_status query string false "Draft|Submitted|Approved|Rejected|Cancelled"
+// @Success 200 {object} models.StockOpnamePaginatedResponse "Stock opnames fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch stock opnames"
+// @Router /api/v1/stock-opname [get]
+func GetAllStockOpnamesPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newStockOpnameService().GetAllStockOpnamesPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch stock opnames", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock opnames fetched successfully", result)
+}
+
+// GetStockOpnameByID
+// @Summary Get stock opname by ID
+// @Description Retrieve a stock opname session with its snapshot and counted lines. Requires authentication.
+// @Tags StockOpname
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Opname ID"
+// @Success 200 {object} models.ResponseGetStockOpname "Stock opname fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Stock opname not found"
+// @Router /api/v1/stock-opname/{id} [get]
+func GetStockOpnameByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	opname, err := newStockOpnameService().GetStockOpnameByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Stock opname not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock opname fetched successfully", opname)
+}
+
+// CreateStockOpname
+// @Summary Create stock opname
+// @Description Open a counting session for a warehouse (optionally one category) and snapshot system quantities per lot. Requires authentication.
+// @Tags StockOpname
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.StockOpnameCreateRequest true "Stock opname create request body"
+// @Success 201 {object} models.StockOpname "Stock opname created successfully"
+// @Failure 400 {string} string "Failed to create stock opname"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-opname [post]
+func CreateStockOpname(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	opnameRequest := new(models.StockOpnameCreateRequest)
+	if err := ctx.BodyParser(opnameRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(opnameRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	opname, err := newStockOpnameService().CreateStockOpname(opnameRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create stock opname", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Stock opname created successfully", opname)
+}
+
+// UpdateStockOpnameCounts
+// @Summary Enter counted quantities
+// @Description Record counted quantities per line (by line ID, or item ID + lot number for lots not in the snapshot). Allowed while Draft or Rejected. Requires authentication.
+// @Tags StockOpname
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Opname ID"
+// @Param request body models.StockOpnameCountsUpdateRequest true "Stock opname counts request body"
+// @Success 200 {object} models.StockOpname "Stock opname counts updated successfully"
+// @Failure 400 {string} string "Failed to update stock opname counts"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-opname/{id}/counts [put]
+func UpdateStockOpnameCounts(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	countsRequest := new(models.StockOpnameCountsUpdateRequest)
+	if err := ctx.BodyParser(countsRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(countsRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	opname, err := newStockOpnameService().UpdateCounts(ctx.Params("id"), countsRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update stock opname counts", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock opname counts updated successfully", opname)
+}
+
+// UploadStockOpnameCounts
+// @Summary Upload count sheet
+// @Description Upload a filled-in count sheet (Excel, form field "file"). Rows with an empty Counted Qty are skipped. Requires authentication.
+// @Tags StockOpname
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Opname ID"
+// @Param file formData file true "Count sheet (.xlsx)"
+// @Success 200 {object} models.StockOpname "Stock opname counts uploaded successfully"
+// @Failure 400 {string} string "Failed to upload stock opname counts"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-opname/{id}/upload [post]
+func UploadStockOpnameCounts(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	opname, err := newStockOpnameService().UploadCounts(ctx.Params("id"), ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to upload stock opname counts", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock opname counts uploaded successfully", opname)
+}
+
+// ExportStockOpnameCountSheet
+// @Summary Download count sheet (Excel)
+// @Description Download the count sheet of a stock opname for counting offline. Requires authentication.
+// @Tags StockOpname
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Param id path string true "Stock Opname ID"
+// @Success 200 {file} file "Excel file"
+// @Failure 500 {string} string "Failed to generate count sheet"
+// @Router /api/v1/stock-opname/{id}/count-sheet [get]
+func ExportStockOpnameCountSheet(ctx *fiber.Ctx) error {
+	filename, fileExcel, err := newStockOpnameService().GenerateCountSheet(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to generate count sheet", err.Error())
+	}
+
+	ctx.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, werr := fileExcel.WriteTo(pw)
+		_ = fileExcel.Close()
+		_ = pw.CloseWithError(werr)
+	}()
+
+	return ctx.SendStream(pr, -1)
+}
+
+// UpdateStockOpnameStatus
+// @Summary Update stock opname status
+// @Description Submit counts for review, approve (posts variances to stock as stock_opname adjustments), reject back for recount, or cancel. The reviewer must differ from the submitter. Requires authentication.
+// @Tags StockOpname
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Opname ID"
+// @Param request body models.StockOpnameStatusUpdateRequest true "Stock opname status update request body"
+// @Success 200 {object} models.StockOpname "Stock opname status updated successfully"
+// @Failure 400 {string} string "Failed to update stock opname status"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-opname/{id}/status [put]
+func UpdateStockOpnameStatus(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statusRequest := new(models.StockOpnameStatusUpdateRequest)
+	if err := ctx.BodyParser(statusRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(statusRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	opname, err := newStockOpnameService().UpdateStockOpnameStatus(ctx.Params("id"), statusRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update stock opname status", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock opname status updated successfully", opname)
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newStockTransferService() *services.StockTransferService {
+	stockTransferRepo := repositories.NewStockTransferRepository(configs.DB)
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemRepo := repositories.NewItemRepository(configs.DB)
+	itemHistoryRepo := repositories.NewItemHistoryRepository(configs.DB)
+	itemLotRepo := repositories.NewItemLotRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	numberSequenceRepo := repositories.NewNumberSequenceRepository(configs.DB)
+	return services.NewStockTransferService(stockTransferRepo, warehouseRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, numberSequenceRepo)
+}
+
+// GetAllStockTransfersPaginated
+// @Summary List stock transfers (paginated)
+// @Description Retrieve stock transfers with pagination, filterable by warehouse and transfer status. Requires authentication.
+// @Tags StockTransfer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param warehouse_id query string false "Source or destination warehouse ID"
+// @Param transfer_status query string false "Draft|InTransit|Received|Cancelled"
+// @Success 200 {object} models.StockTransferPaginatedResponse "Stock transfers fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 500 {string} string "Failed to fetch stock transfers"
+// @Router /api/v1/stock-transfer [get]
+func GetAllStockTransfersPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	result, err := newStockTransferService().GetAllStockTransfersPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch stock transfers", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock transfers fetched successfully", result)
+}
+
+// GetStockTransferByID
+// @Summary Get stock transfer by ID
+// @Description Retrieve a single stock transfer with its items and shipped lots. Requires authentication.
+// @Tags StockTransfer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Transfer ID"
+// @Success 200 {object} models.ResponseGetStockTransfer "Stock transfer fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Stock transfer not found"
+// @Router /api/v1/stock-transfer/{id} [get]
+func GetStockTransferByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	transfer, err := newStockTransferService().GetStockTransferByID(ctx.Params("id"))
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Stock transfer not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock transfer fetched successfully", transfer)
+}
+
+// CreateStockTransfer
+// @Summary Create stock transfer
+// @Description Create a draft stock transfer between two warehouses. Requires authentication.
+// @Tags StockTransfer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.StockTransferCreateRequest true "Stock transfer create request body"
+// @Success 201 {object} models.StockTransfer "Stock transfer created successfully"
+// @Failure 400 {string} string "Failed to create stock transfer"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-transfer [post]
+func CreateStockTransfer(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	transferRequest := new(models.StockTransferCreateRequest)
+	if err := ctx.BodyParser(transferRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(transferRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	transfer, err := newStockTransferService().CreateStockTransfer(transferRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create stock transfer", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Stock transfer created successfully", transfer)
+}
+
+// UpdateStockTransfer
+// @Summary Update stock transfer
+// @Description Update a draft stock transfer. Requires authentication.
+// @Tags StockTransfer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Transfer ID"
+// @Param request body models.StockTransferUpdateRequest true "Stock transfer update request body"
+// @Success 200 {object} models.StockTransfer "Stock transfer updated successfully"
+// @Failure 400 {string} string "Failed to update stock transfer"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-transfer/{id} [put]
+func UpdateStockTransfer(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	transferRequest := new(models.StockTransferUpdateRequest)
+	if err := ctx.BodyParser(transferRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(transferRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	transfer, err := newStockTransferService().UpdateStockTransfer(ctx.Params("id"), transferRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update stock transfer", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock transfer updated successfully", transfer)
+}
+
+// UpdateStockTransferStatus
+// @Summary Update stock transfer status
+// @Description Move a stock transfer through Draft → InTransit (stock leaves source) → Received (stock enters destination), or cancel a draft. Requires authentication.
+// @Tags StockTransfer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Stock Transfer ID"
+// @Param request body models.StockTransferStatusUpdateRequest true "Stock transfer status update request body"
+// @Success 200 {object} models.StockTransfer "Stock transfer status updated successfully"
+// @Failure 400 {string} string "Failed to update stock transfer status"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Router /api/v1/stock-transfer/{id}/status [put]
+func UpdateStockTransferStatus(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	statusRequest := new(models.StockTransferStatusUpdateRequest)
+	if err := ctx.BodyParser(statusRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(statusRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	transfer, err := newStockTransferService().UpdateStockTransferStatus(ctx.Params("id"), statusRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update stock transfer status", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Stock transfer status updated successfully", transfer)
+}
@@ -0,0 +1,325 @@
+package controllers
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAllWarehouses
+// @Summary List all warehouses
+// @Description Retrieve all warehouses (non-paginated). Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Success 200 {array} models.Warehouse "Warehouses fetched successfully"
+// @Failure 401 {string} string "Unauthorized: Unable to retrieve user information"
+// @Failure 500 {string} string "Failed to fetch warehouses"
+// @Router /api/v1/warehouse/all [get]
+func GetAllWarehouses(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: Unable to retrieve user information", nil)
+	}
+
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	warehouses, err := warehouseService.GetAllWarehouses()
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch warehouses", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouses fetched successfully", warehouses)
+}
+
+// GetAllWarehousesPaginated
+// @Summary List warehouses (paginated)
+// @Description Retrieve warehouses with pagination and optional query filters. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param page  query int    false "Page number (default: 1)"
+// @Param limit query int    false "Items per page (default: 10)"
+// @Param search query string false "Search keyword"
+// @Param sort  query string false "Sort field"
+// @Param order query string false "Sort order (asc|desc)"
+// @Success 200 {object} models.WarehousePaginatedResponse "Warehouses fetched successfully"
+// @Failure 400 {string} string "Invalid query parameters"
+// @Failure 401 {string} string "Unauthorized: Unable to retrieve user information"
+// @Failure 500 {string} string "Failed to fetch warehouses"
+// @Router /api/v1/warehouse [get]
+func GetAllWarehousesPaginated(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: Unable to retrieve user information", nil)
+	}
+
+	paginationReq := &models.PaginationRequest{}
+	if err := ctx.QueryParser(paginationReq); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Invalid query parameters", nil)
+	}
+
+	if err := helpers.ValidateStruct(paginationReq); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	result, err := warehouseService.GetAllWarehousesPaginated(paginationReq, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Failed to fetch warehouses", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouses fetched successfully", result)
+}
+
+// GetWarehouseByID
+// @Summary Get warehouse by ID
+// @Description Retrieve a single warehouse by its ID. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Warehouse ID"
+// @Success 200 {object} models.Warehouse "Warehouse fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Warehouse not found"
+// @Router /api/v1/warehouse/{id} [get]
+func GetWarehouseByID(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	warehouseId := ctx.Params("id")
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	warehouse, err := warehouseService.GetWarehouseByID(warehouseId)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Warehouse not found", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouse fetched successfully", warehouse)
+}
+
+// GetWarehouseStocks
+// @Summary Get stock balances of a warehouse
+// @Description Retrieve per-item stock balances held in a warehouse. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Warehouse ID"
+// @Success 200 {array} models.ResponseGetItemStock "Warehouse stocks fetched successfully"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 404 {string} string "Failed to fetch warehouse stocks"
+// @Router /api/v1/warehouse/{id}/stocks [get]
+func GetWarehouseStocks(ctx *fiber.Ctx) error {
+	_, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	warehouseId := ctx.Params("id")
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	stocks, err := warehouseService.GetWarehouseStocks(warehouseId)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusNotFound, "Failed to fetch warehouse stocks", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouse stocks fetched successfully", stocks)
+}
+
+// CreateWarehouse
+// @Summary Create warehouse
+// @Description Create a new warehouse. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.WarehouseCreateRequest true "Warehouse create request body"
+// @Success 201 {object} models.Warehouse "Warehouse created successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to create warehouse"
+// @Router /api/v1/warehouse [post]
+func CreateWarehouse(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	warehouseRequest := new(models.WarehouseCreateRequest)
+	if err := ctx.BodyParser(warehouseRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(warehouseRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	warehouse, err := warehouseService.CreateWarehouse(warehouseRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to create warehouse", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusCreated, "Warehouse created successfully", warehouse)
+}
+
+// UpdateWarehouse
+// @Summary Update warehouse
+// @Description Update an existing warehouse by ID. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param id path string true "Warehouse ID"
+// @Param request body models.WarehouseUpdateRequest true "Warehouse update request body"
+// @Success 200 {object} models.Warehouse "Warehouse updated successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to update warehouse"
+// @Router /api/v1/warehouse/{id} [put]
+func UpdateWarehouse(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	warehouseRequest := new(models.WarehouseUpdateRequest)
+	if err := ctx.BodyParser(warehouseRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(warehouseRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	warehouseId := ctx.Params("id")
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	warehouse, err := warehouseService.UpdateWarehouse(warehouseId, warehouseRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to update warehouse", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouse updated successfully", warehouse)
+}
+
+// DeleteWarehouses
+// @Summary Delete warehouses (soft/hard)
+// @Description Delete one or multiple warehouses. Use is_hard_delete to control hard/soft delete. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.WarehouseIsHardDeleteRequest true "Delete warehouses request body"
+// @Success 200 {string} string "Warehouses soft deleted successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to delete warehouses"
+// @Router /api/v1/warehouse/delete [delete]
+func DeleteWarehouses(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	deleteRequest := new(models.WarehouseIsHardDeleteRequest)
+	if err := ctx.BodyParser(deleteRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(deleteRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	err := warehouseService.DeleteWarehouses(deleteRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to delete warehouses", err.Error())
+	}
+
+	message := "Warehouses soft deleted successfully"
+	if deleteRequest.IsHardDelete == "hardDelete" {
+		message = "Warehouses permanently deleted successfully"
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, message, nil)
+}
+
+// RestoreWarehouses
+// @Summary Restore warehouses
+// @Description Restore soft-deleted warehouses. Requires authentication.
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Authorization"
+// @Param request body models.WarehouseRestoreRequest true "Restore warehouses request body"
+// @Success 200 {string} string "Warehouses restored successfully"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 401 {string} string "Unauthorized: User info not found"
+// @Failure 400 {string} string "Failed to restore warehouses"
+// @Router /api/v1/warehouse/restore [post]
+func RestoreWarehouses(ctx *fiber.Ctx) error {
+	userInfo, ok := ctx.Locals("userInfo").(*models.User)
+	if !ok {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Unauthorized: User info not found", nil)
+	}
+
+	restoreRequest := new(models.WarehouseRestoreRequest)
+	if err := ctx.BodyParser(restoreRequest); err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err := helpers.ValidateStruct(restoreRequest); err != nil {
+		errorMessage := helpers.ExtractErrorMessages(err)
+		return helpers.Response(ctx, fiber.StatusBadRequest, errorMessage, nil)
+	}
+
+	warehouseRepo := repositories.NewWarehouseRepository(configs.DB)
+	itemStockRepo := repositories.NewItemStockRepository(configs.DB)
+	warehouseService := services.NewWarehouseService(warehouseRepo, itemStockRepo)
+
+	restoredWarehouses, err := warehouseService.RestoreWarehouses(restoreRequest, ctx, userInfo)
+	if err != nil {
+		return helpers.Response(ctx, fiber.StatusBadRequest, "Failed to restore warehouses", err.Error())
+	}
+
+	return helpers.Response(ctx, fiber.StatusOK, "Warehouses restored successfully", restoredWarehouses)
+}
@@ -0,0 +1,430 @@
+package bankstatement
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(genericLayout)
+	Register(bcaLayout)
+	Register(mandiriLayout)
+	Register(bniLayout)
+	Register(briLayout)
+}
+
+// columnLayout adalah parser berbasis nama kolom header. Hampir semua ekspor CSV bank Indonesia
+// bisa dipetakan dengan cara ini; layout baru cukup didaftarkan lewat Register.
+type columnLayout struct {
+	name        string
+	signature   []string // header yang wajib ada untuk auto-detect; kosong = cukup kolom wajib
+	date        []string
+	description []string
+	reference   []string
+	amount      []string // satu kolom bertanda (+/-, CR/DB) bila tidak ada debit/credit terpisah
+	debit       []string
+	credit      []string
+	direction   []string // kolom indikator D/K, DB/CR
+	balance     []string
+	account     []string // kolom nomor rekening per baris (Mandiri MCM)
+	dateLayouts []string
+}
+
+var defaultDateLayouts = []string{
+	"02/01/2006", "02/01/06", "2006-01-02", "02-01-2006", "02-01-06", "2006/01/02",
+	"02 Jan 2006", "02-Jan-2006", "02-Jan-06", "02 Jan 06",
+	"02/01/2006 15:04:05", "02/01/2006 15:04", "2006-01-02 15:04:05", "02-01-2006 15:04:05",
+}
+
+var genericLayout = &columnLayout{
+	name:        "generic",
+	date:        []string{"date", "tanggal", "tgl", "transaction date", "tanggal transaksi", "posting date", "post date"},
+	description: []string{"description", "keterangan", "deskripsi", "remark", "remarks", "uraian", "narrative"},
+	reference:   []string{"reference", "reference no.", "reference no", "ref", "ref no", "no. referensi", "no referensi", "referensi"},
+	amount:      []string{"amount", "jumlah", "nominal", "mutasi"},
+	debit:       []string{"debit", "debet", "mutasi debet", "mutasi debit"},
+	credit:      []string{"credit", "kredit", "mutasi kredit"},
+	direction:   []string{"type", "d/k", "db/cr", "dk", "jenis"},
+	balance:     []string{"balance", "saldo", "running balance", "saldo akhir"},
+	dateLayouts: defaultDateLayouts,
+}
+
+// KlikBCA: "Tanggal Transaksi,Keterangan,Cabang,Jumlah,,Saldo" dengan tanggal dd/mm (tahun dari baris Periode).
+var bcaLayout = &columnLayout{
+	name:        "bca",
+	signature:   []string{"tanggal transaksi", "keterangan", "cabang", "jumlah"},
+	date:        []string{"tanggal transaksi"},
+	description: []string{"keterangan"},
+	amount:      []string{"jumlah"},
+	balance:     []string{"saldo"},
+	dateLayouts: append([]string{"02/01"}, defaultDateLayouts...),
+}
+
+// Mandiri (MCM / Livin'): "Account No,Date,Val. Date,Transaction Code,Description,Description,Reference No.,Debit,Credit".
+var mandiriLayout = &columnLayout{
+	name:        "mandiri",
+	signature:   []string{"account no", "val. date", "transaction code"},
+	date:        []string{"date"},
+	description: []string{"description", "description1", "description2", "description 1", "description 2"},
+	reference:   []string{"reference no.", "reference no"},
+	debit:       []string{"debit"},
+	credit:      []string{"credit"},
+	balance:     []string{"balance"},
+	account:     []string{"account no"},
+	dateLayouts: defaultDateLayouts,
+}
+
+// BNI Direct: "Post Date,Value Date,Branch,Journal No.,Description,Debit,Credit".
+var bniLayout = &columnLayout{
+	name:        "bni",
+	signature:   []string{"post date", "journal no.", "description"},
+	date:        []string{"post date"},
+	description: []string{"description"},
+	reference:   []string{"journal no."},
+	debit:       []string{"debit"},
+	credit:      []string{"credit"},
+	balance:     []string{"balance"},
+	dateLayouts: defaultDateLayouts,
+}
+
+// BRI CMS / Qlola: "TGL_TRAN,DESK_TRAN,MUTASI_DEBET,MUTASI_KREDIT,SALDO_AKHIR_MUTASI".
+var briLayout = &columnLayout{
+	name:        "bri",
+	signature:   []string{"tgl_tran", "desk_tran"},
+	date:        []string{"tgl_tran"},
+	description: []string{"desk_tran"},
+	reference:   []string{"no_ref", "ref_no", "nomor_referensi"},
+	debit:       []string{"mutasi_debet"},
+	credit:      []string{"mutasi_kredit"},
+	balance:     []string{"saldo_akhir_mutasi", "saldo_akhir"},
+	dateLayouts: defaultDateLayouts,
+}
+
+func (l *columnLayout) Name() string { return l.name }
+
+func (l *columnLayout) Detect(rows [][]string) bool {
+	_, ok := l.findHeader(rows)
+	return ok
+}
+
+type columnIndex struct {
+	date, reference, amount, debit, credit, direction, balance, account int
+	description                                                         []int
+}
+
+func (l *columnLayout) findHeader(rows [][]string) (int, bool) {
+	for i, row := range rows {
+		cells := make(map[string]bool, len(row))
+		for _, c := range row {
+			cells[normalizeHeader(c)] = true
+		}
+
+		matched := true
+		for _, s := range l.signature {
+			if !cells[s] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		idx := l.mapColumns(row)
+		if idx.date >= 0 && (idx.amount >= 0 || (idx.debit >= 0 && idx.credit >= 0)) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (l *columnLayout) mapColumns(header []string) columnIndex {
+	find := func(aliases []string) int {
+		for _, a := range aliases {
+			for i, c := range header {
+				if normalizeHeader(c) == a {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+
+	idx := columnIndex{
+		date:      find(l.date),
+		reference: find(l.reference),
+		amount:    find(l.amount),
+		debit:     find(l.debit),
+		credit:    find(l.credit),
+		direction: find(l.direction),
+		balance:   find(l.balance),
+		account:   find(l.account),
+	}
+	for i, c := range header {
+		h := normalizeHeader(c)
+		for _, a := range l.description {
+			if h == a {
+				idx.description = append(idx.description, i)
+				break
+			}
+		}
+	}
+	return idx
+}
+
+func (l *columnLayout) Parse(rows [][]string) (*Statement, error) {
+	headerRow, ok := l.findHeader(rows)
+	if !ok {
+		return nil, errors.New("statement header row not found for layout " + l.name)
+	}
+
+	st := &Statement{Bank: l.name}
+	parseMeta(rows[:headerRow], st)
+
+	idx := l.mapColumns(rows[headerRow])
+	cell := func(row []string, i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	for n, row := range rows[headerRow+1:] {
+		date, ok := l.parseDate(cell(row, idx.date), st)
+		if !ok {
+			continue // baris kosong, saldo awal/akhir, transaksi PEND, dll.
+		}
+
+		line := Line{
+			LineNo:          headerRow + n + 2,
+			TransactionDate: date,
+			Reference:       cell(row, idx.reference),
+		}
+
+		desc := make([]string, 0, len(idx.description))
+		for _, i := range idx.description {
+			if v := cell(row, i); v != "" {
+				desc = append(desc, v)
+			}
+		}
+		line.Description = strings.Join(desc, " ")
+
+		if idx.debit >= 0 || idx.credit >= 0 {
+			debit, _, _ := parseAmount(cell(row, idx.debit))
+			credit, _, _ := parseAmount(cell(row, idx.credit))
+			switch {
+			case credit > 0:
+				line.Amount, line.Direction = credit, "Credit"
+			case debit > 0:
+				line.Amount, line.Direction = debit, "Debit"
+			}
+		} else {
+			amount, indicator, _ := parseAmount(cell(row, idx.amount))
+			if indicator == "" {
+				indicator = directionIndicator(cell(row, idx.direction))
+			}
+			if indicator == "" {
+				// KlikBCA menaruh CR/DB di kolom tanpa judul tepat setelah Jumlah
+				indicator = directionIndicator(cell(row, idx.amount+1))
+			}
+			switch {
+			case indicator == "Debit" || amount < 0:
+				line.Direction = "Debit"
+			default:
+				line.Direction = "Credit"
+			}
+			if amount < 0 {
+				amount = -amount
+			}
+			line.Amount = amount
+		}
+		if line.Amount == 0 {
+			continue
+		}
+
+		if idx.balance >= 0 {
+			if b, _, ok := parseAmount(cell(row, idx.balance)); ok {
+				line.Balance = &b
+			}
+		}
+
+		if st.AccountNumber == "" {
+			st.AccountNumber = cell(row, idx.account)
+		}
+
+		st.Lines = append(st.Lines, line)
+	}
+
+	if len(st.Lines) == 0 {
+		return nil, errors.New("no transactions found in statement file")
+	}
+
+	if st.PeriodStart == nil || st.PeriodEnd == nil {
+		first, last := st.Lines[0].TransactionDate, st.Lines[0].TransactionDate
+		for _, ln := range st.Lines {
+			if ln.TransactionDate.Before(first) {
+				first = ln.TransactionDate
+			}
+			if ln.TransactionDate.After(last) {
+				last = ln.TransactionDate
+			}
+		}
+		st.PeriodStart, st.PeriodEnd = &first, &last
+	}
+
+	return st, nil
+}
+
+func (l *columnLayout) parseDate(value string, st *Statement) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range l.dateLayouts {
+		t, err := time.ParseInLocation(layout, value, wib)
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			// format tanpa tahun: ambil dari periode mutasi (mendukung periode lintas tahun)
+			year := time.Now().In(wib).Year()
+			if st.PeriodEnd != nil {
+				year = st.PeriodEnd.Year()
+				if st.PeriodStart != nil && t.Month() >= st.PeriodStart.Month() && st.PeriodStart.Year() != st.PeriodEnd.Year() {
+					year = st.PeriodStart.Year()
+				}
+			}
+			t = time.Date(year, t.Month(), t.Day(), 0, 0, 0, 0, wib)
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+var (
+	wib           = loadWIB()
+	metaDateRegex = regexp.MustCompile(`\d{1,2}[/-]\d{1,2}[/-]\d{2,4}`)
+	digitsRegex   = regexp.MustCompile(`\d{6,}`)
+)
+
+func loadWIB() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// parseMeta membaca nomor rekening & periode dari baris-baris sebelum header.
+func parseMeta(rows [][]string, st *Statement) {
+	for _, row := range rows {
+		text := strings.Join(row, " ")
+		lower := strings.ToLower(text)
+
+		if st.AccountNumber == "" && (strings.Contains(lower, "rekening") || strings.Contains(lower, "account")) {
+			if m := digitsRegex.FindString(text); m != "" {
+				st.AccountNumber = m
+			}
+		}
+
+		if st.PeriodStart == nil && (strings.Contains(lower, "periode") || strings.Contains(lower, "period")) {
+			dates := metaDateRegex.FindAllString(text, 2)
+			if len(dates) == 2 {
+				start, okStart := parseMetaDate(dates[0])
+				end, okEnd := parseMetaDate(dates[1])
+				if okStart && okEnd {
+					st.PeriodStart, st.PeriodEnd = &start, &end
+				}
+			}
+		}
+	}
+}
+
+func parseMetaDate(s string) (time.Time, bool) {
+	s = strings.ReplaceAll(s, "-", "/")
+	for _, layout := range []string{"02/01/2006", "2/1/2006", "02/01/06"} {
+		if t, err := time.ParseInLocation(layout, s, wib); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func normalizeHeader(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func directionIndicator(s string) string {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "CR", "C", "K", "KR", "CREDIT", "KREDIT":
+		return "Credit"
+	case "DB", "D", "DR", "DEBIT", "DEBET":
+		return "Debit"
+	}
+	return ""
+}
+
+// parseAmount membaca nominal format Indonesia (1.500.000,00) maupun internasional (1,500,000.00),
+// termasuk akhiran CR/DB, tanda minus dan kurung. Nilai dibulatkan ke rupiah.
+func parseAmount(s string) (int, string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, "", false
+	}
+
+	indicator := ""
+	for _, suffix := range []string{"CR", "DB", "DR", "K", "D"} {
+		if strings.HasSuffix(s, suffix) {
+			indicator = directionIndicator(suffix)
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+			break
+		}
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.Trim(s, "()")
+	}
+	s = strings.TrimSpace(strings.TrimPrefix(s, "RP"))
+	s = strings.ReplaceAll(s, " ", "")
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+
+	lastDot, lastComma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastComma > lastDot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case lastComma >= 0:
+		if strings.Count(s, ",") == 1 && len(s)-lastComma-1 != 3 {
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case lastDot >= 0:
+		if strings.Count(s, ".") > 1 || len(s)-lastDot-1 == 3 {
+			s = strings.ReplaceAll(s, ".", "")
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, indicator, false
+	}
+	amount := int(math.Round(v))
+	if negative {
+		amount = -amount
+	}
+	return amount, indicator, true
+}
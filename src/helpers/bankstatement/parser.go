@@ -0,0 +1,143 @@
+package bankstatement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Line adalah satu mutasi rekening hasil parsing. Amount selalu positif; Direction menentukan arah.
+type Line struct {
+	LineNo          int
+	TransactionDate time.Time
+	Description     string
+	Reference       string
+	Amount          int
+	Direction       string // Credit (uang masuk), Debit (uang keluar)
+	Balance         *int
+}
+
+// Statement adalah hasil parsing satu file mutasi.
+type Statement struct {
+	Bank          string
+	AccountNumber string
+	PeriodStart   *time.Time
+	PeriodEnd     *time.Time
+	Lines         []Line
+}
+
+// Parser membaca satu layout file mutasi bank. Detect dipanggil dengan baris-baris CSV mentah
+// untuk auto-detect layout bila user tidak memilih bank.
+type Parser interface {
+	Name() string
+	Detect(rows [][]string) bool
+	Parse(rows [][]string) (*Statement, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Parser{}
+)
+
+// Register menambahkan parser (mis. layout bank lain) ke registry; nama yang sama akan ditimpa.
+func Register(p Parser) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(p.Name())] = p
+}
+
+// Parsers mengembalikan nama parser yang terdaftar, urut abjad.
+func Parsers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse membaca file CSV mutasi. bank kosong = auto-detect; parser "generic" dicoba paling akhir.
+func Parse(data []byte, bank string) (*Statement, error) {
+	rows, err := readRows(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("statement file is empty")
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if bank = strings.ToLower(strings.TrimSpace(bank)); bank != "" {
+		p, ok := registry[bank]
+		if !ok {
+			return nil, fmt.Errorf("unknown bank statement layout %q", bank)
+		}
+		return p.Parse(rows)
+	}
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		if name != "generic" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append(names, "generic")
+
+	for _, name := range names {
+		p, ok := registry[name]
+		if ok && p.Detect(rows) {
+			return p.Parse(rows)
+		}
+	}
+	return nil, errors.New("unable to detect bank statement layout, please choose the bank")
+}
+
+// readRows membaca CSV dengan pemisah koma atau titik koma (ekspor Excel berlocale Indonesia).
+func readRows(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	firstLines := string(data)
+	if len(firstLines) > 4096 {
+		firstLines = firstLines[:4096]
+	}
+	delimiter := ','
+	if strings.Count(firstLines, ";") > strings.Count(firstLines, ",") {
+		delimiter = ';'
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+
+	for i := range rows {
+		for j := range rows[i] {
+			rows[i][j] = cleanCell(rows[i][j])
+		}
+	}
+	return rows, nil
+}
+
+// cleanCell membuang apostrof pembuka (trik ekspor agar Excel memperlakukan sel sebagai teks).
+func cleanCell(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "'")
+	return strings.TrimSpace(s)
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -53,4 +54,13 @@ func ConvertToInt(value interface{}) (int, error) {
 	default:
 		return 0, fmt.Errorf("unsupported type for conversion: %T", v)
 	}
-}
\ No newline at end of file
+}
+
+// EnvInt membaca env sebagai int positif, fallback ke def bila kosong/tidak valid.
+func EnvInt(key string, def int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
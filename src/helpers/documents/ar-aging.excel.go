@@ -0,0 +1,126 @@
+package documents
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// GenerateARAgingExcel membuat laporan umur piutang dua sheet:
+// Summary  : Customer | Nomor | Area | 0-30 | 31-60 | 61-90 | 90+ | Total
+// Invoices : Customer | SO Number | Sales Person | SO Date | Due Date | Days Overdue | Bucket | Total | Paid | Outstanding
+func GenerateARAgingExcel(aging *models.ResponseARAging) (*excelize.File, string, error) {
+	f := excelize.NewFile()
+	const summarySheet = "Summary"
+	const detailSheet = "Invoices"
+	f.SetSheetName("Sheet1", summarySheet)
+	if _, err := f.NewSheet(detailSheet); err != nil {
+		return nil, "", err
+	}
+
+	border := []excelize.Border{
+		{Type: "left", Color: "DDDDDD", Style: 1},
+		{Type: "right", Color: "DDDDDD", Style: 1},
+		{Type: "top", Color: "DDDDDD", Style: 1},
+		{Type: "bottom", Color: "DDDDDD", Style: 1},
+	}
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "FFFFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#2980B9"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    border,
+	})
+	rowStyle, _ := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{Vertical: "center"},
+		Border:    border,
+	})
+	amountStyle, _ := f.NewStyle(&excelize.Style{
+		NumFmt:    3, // #,##0
+		Alignment: &excelize.Alignment{Horizontal: "right", Vertical: "center"},
+		Border:    border,
+	})
+	totalStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		NumFmt:    3,
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#F0F0F0"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "right", Vertical: "center"},
+		Border:    border,
+	})
+
+	writeRow := func(sheet string, row int, values []interface{}) {
+		for c, v := range values {
+			cell, _ := excelize.CoordinatesToCellName(c+1, row)
+			_ = f.SetCellValue(sheet, cell, v)
+		}
+	}
+	styleRange := func(sheet string, row, fromCol, toCol, style int) {
+		left, _ := excelize.CoordinatesToCellName(fromCol, row)
+		right, _ := excelize.CoordinatesToCellName(toCol, row)
+		_ = f.SetCellStyle(sheet, left, right, style)
+	}
+
+	// === Summary ===
+	writeRow(summarySheet, 1, []interface{}{
+		"Customer", "Nomor", "Area", "0-30", "31-60", "61-90", "90+", "Total",
+	})
+	styleRange(summarySheet, 1, 1, 8, headerStyle)
+
+	row := 2
+	for _, c := range aging.Customers {
+		writeRow(summarySheet, row, []interface{}{
+			c.CustomerName, c.CustomerNomor, c.AreaName,
+			c.Buckets.Days0To30, c.Buckets.Days31To60, c.Buckets.Days61To90, c.Buckets.Over90, c.Buckets.Total,
+		})
+		styleRange(summarySheet, row, 1, 3, rowStyle)
+		styleRange(summarySheet, row, 4, 8, amountStyle)
+		row++
+	}
+	writeRow(summarySheet, row, []interface{}{
+		"TOTAL", "", "",
+		aging.Totals.Days0To30, aging.Totals.Days31To60, aging.Totals.Days61To90, aging.Totals.Over90, aging.Totals.Total,
+	})
+	styleRange(summarySheet, row, 1, 8, totalStyle)
+	writeRow(summarySheet, row+2, []interface{}{"As of " + aging.AsOf.Format("02 Jan 2006")})
+
+	_ = f.SetColWidth(summarySheet, "A", "A", 30)
+	_ = f.SetColWidth(summarySheet, "B", "B", 14)
+	_ = f.SetColWidth(summarySheet, "C", "C", 18)
+	_ = f.SetColWidth(summarySheet, "D", "H", 16)
+
+	// === Invoices ===
+	writeRow(detailSheet, 1, []interface{}{
+		"Customer", "SO Number", "Sales Person", "SO Date", "Due Date",
+		"Days Overdue", "Bucket", "Total", "Paid", "Outstanding",
+	})
+	styleRange(detailSheet, 1, 1, 10, headerStyle)
+
+	row = 2
+	for _, c := range aging.Customers {
+		for _, inv := range c.Invoices {
+			dueDate := "-"
+			if inv.DueDate != nil {
+				dueDate = inv.DueDate.Format("02 Jan 2006")
+			}
+			writeRow(detailSheet, row, []interface{}{
+				c.CustomerName, inv.SONumber, inv.SalesPersonName,
+				inv.SODate.Format("02 Jan 2006"), dueDate,
+				inv.DaysOverdue, inv.Bucket,
+				inv.TotalAmount, inv.PaidAmount, inv.Outstanding,
+			})
+			styleRange(detailSheet, row, 1, 7, rowStyle)
+			styleRange(detailSheet, row, 8, 10, amountStyle)
+			row++
+		}
+	}
+
+	_ = f.SetColWidth(detailSheet, "A", "A", 30)
+	_ = f.SetColWidth(detailSheet, "B", "C", 18)
+	_ = f.SetColWidth(detailSheet, "D", "E", 13)
+	_ = f.SetColWidth(detailSheet, "F", "G", 12)
+	_ = f.SetColWidth(detailSheet, "H", "J", 15)
+
+	filename := fmt.Sprintf("ar_aging_%s.xlsx", time.Now().Format("20060102_150405"))
+	return f, filename, nil
+}
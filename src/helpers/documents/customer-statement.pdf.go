@@ -0,0 +1,127 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+func GenerateCustomerStatementPDF(st *models.ResponseCustomerStatement) (string, []byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// === Header ===
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "STATEMENT OF ACCOUNT")
+	pdf.Ln(12)
+
+	// helper row
+	row := func(label, val string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, label)
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(0, 7, val)
+		pdf.Ln(7)
+	}
+
+	// === Meta Info ===
+	row("Period:", fmt.Sprintf("%s - %s", st.StartDate.Format("02 January 2006"), st.EndDate.Format("02 January 2006")))
+	row("Issued Date:", time.Now().Format("02 January 2006"))
+	pdf.Ln(4)
+
+	// === Customer ===
+	pdf.SetFont("Arial", "B", 13)
+	pdf.Cell(0, 8, "Customer")
+	pdf.Ln(9)
+
+	row("Name:", st.Customer.Name)
+	row("Customer No:", st.Customer.Nomor)
+	if v := st.Customer.Phone; v != nil {
+		row("Phone:", *v)
+	}
+	if v := st.Customer.Address; v != nil {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, "Address:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(0, 6, *v, "", "", false)
+	}
+	pdf.Ln(4)
+
+	// === Ledger (Date | Type | Reference | SO | Debit | Credit | Balance) ===
+	colDate := 22.0
+	colType := 22.0
+	colRef := 35.0
+	colSO := 30.0
+	colDebit := 25.0
+	colCredit := 25.0
+	colBalance := 26.0
+	labelW := colDate + colType + colRef + colSO
+
+	pdf.SetFont("Arial", "B", 9)
+	pdf.SetFillColor(240, 240, 240)
+	pdf.CellFormat(colDate, 8, "Date", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colType, 8, "Type", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colRef, 8, "Reference", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colSO, 8, "SO Number", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colDebit, 8, "Debit", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colCredit, 8, "Credit", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colBalance, 8, "Balance", "1", 0, "C", true, 0, "")
+	pdf.Ln(8)
+
+	amount := func(v int) string {
+		if v == 0 {
+			return "-"
+		}
+		return "Rp " + formatIDR(v)
+	}
+
+	pdf.SetFont("Arial", "B", 9)
+	pdf.CellFormat(labelW+colDebit+colCredit, 8, "Opening Balance", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(colBalance, 8, "Rp "+formatIDR(st.OpeningBalance), "1", 0, "R", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Arial", "", 8)
+	for _, l := range st.Lines {
+		pdf.CellFormat(colDate, 8, l.Date.Format("02/01/2006"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colType, 8, l.Type, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colRef, 8, l.Reference, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colSO, 8, l.SONumber, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colDebit, 8, amount(l.Debit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colCredit, 8, amount(l.Credit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colBalance, 8, "Rp "+formatIDR(l.Balance), "1", 0, "R", false, 0, "")
+		pdf.Ln(8)
+	}
+
+	pdf.SetFont("Arial", "B", 9)
+	pdf.CellFormat(labelW, 8, "Total Movement", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(colDebit, 8, "Rp "+formatIDR(st.TotalDebit), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(colCredit, 8, "Rp "+formatIDR(st.TotalCredit), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(colBalance, 8, "", "1", 0, "R", true, 0, "")
+	pdf.Ln(8)
+	pdf.CellFormat(labelW+colDebit+colCredit, 8, "Closing Balance", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(colBalance, 8, "Rp "+formatIDR(st.ClosingBalance), "1", 0, "R", true, 0, "")
+	pdf.Ln(12)
+
+	if len(st.Lines) == 0 {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.Cell(0, 6, "No transactions in this period.")
+		pdf.Ln(8)
+	}
+
+	// Footer
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.Cell(0, 5, fmt.Sprintf("Generated at %s", time.Now().Format("02 January 2006 15:04:05")))
+
+	// Output
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate statement PDF: %w", err)
+	}
+	filename := fmt.Sprintf("SOA_%s_%s_%s.pdf", st.Customer.Nomor, st.EndDate.Format("200601"), time.Now().Format("20060102150405"))
+	return filename, buf.Bytes(), nil
+}
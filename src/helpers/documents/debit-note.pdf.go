@@ -0,0 +1,166 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+func GenerateDebitNotePDF(pr *models.PurchaseReturn) (string, []byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// === Header ===
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "DEBIT NOTE")
+	pdf.Ln(12)
+
+	// helper row
+	row := func(label, val string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, label)
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(0, 7, val)
+		pdf.Ln(7)
+	}
+
+	debitNoteNo := "-"
+	if pr.DebitNoteNumber != nil {
+		debitNoteNo = *pr.DebitNoteNumber
+	}
+
+	// === Meta Info ===
+	row("Debit Note No:", debitNoteNo)
+	if pr.CompletedAt != nil {
+		row("Issued Date:", pr.CompletedAt.Format("02 January 2006"))
+	}
+	row("Return Number:", pr.ReturnNumber)
+	row("Return Date:", pr.ReturnDate.Format("02 January 2006"))
+	row("PO Reference:", pr.PurchaseOrder.PONumber)
+	row("Settlement:", pr.SettlementType)
+	pdf.Ln(4)
+
+	// === Supplier ===
+	if pr.Supplier.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Supplier")
+		pdf.Ln(9)
+
+		row("Name:", pr.Supplier.Name)
+		if v := pr.Supplier.Code; v != "" {
+			row("Code:", v)
+		}
+		if v := pstr(pr.Supplier.Phone); v != "" {
+			row("Phone:", v)
+		}
+		if v := pstr(pr.Supplier.Address); v != "" {
+			pdf.SetFont("Arial", "B", 11)
+			pdf.Cell(45, 7, "Address:")
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(0, 6, v, "", "", false)
+		}
+		pdf.Ln(2)
+	}
+
+	// === Items (No | Item Name | Lot | Reason | Qty | Unit Price | Subtotal) ===
+	if len(pr.PurchaseReturnItems) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Returned Items")
+		pdf.Ln(10)
+
+		colNo := 10.0
+		colName := 45.0
+		colLot := 25.0
+		colReason := 35.0
+		colQty := 12.0
+		colPrice := 28.0
+		colSubtotal := 35.0
+
+		// header
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.CellFormat(colNo, 8, "No", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colName, 8, "Item Name", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colLot, 8, "Lot", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colReason, 8, "Reason", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colQty, 8, "Qty", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colPrice, 8, "Unit Price", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colSubtotal, 8, "Subtotal", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+
+		// rows
+		pdf.SetFont("Arial", "", 9)
+		for i, it := range pr.PurchaseReturnItems {
+			name := it.Item.Name
+			if name == "" {
+				name = "Unknown Item"
+			}
+
+			pdf.CellFormat(colNo, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colName, 8, name, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colLot, 8, it.LotNumber, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colReason, 8, it.Reason, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colQty, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colPrice, 8, "Rp "+formatIDR(it.UnitPrice), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(it.TotalPrice), "1", 0, "R", false, 0, "")
+			pdf.Ln(8)
+		}
+
+		// summary
+		labelW := colNo + colName + colLot + colReason + colQty + colPrice
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(labelW, 8, "Total Debit", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(pr.DebitAmount), "1", 0, "R", true, 0, "")
+		pdf.Ln(8)
+
+		if pr.SettlementType == "Refund" && pr.RefundAmount > 0 {
+			pdf.SetFont("Arial", "", 10)
+			method := pr.RefundMethod
+			if method == "" {
+				method = "-"
+			}
+			pdf.CellFormat(labelW, 8, fmt.Sprintf("Refund Due from Supplier (%s)", method), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(pr.RefundAmount), "1", 0, "R", false, 0, "")
+			pdf.Ln(8)
+		}
+		pdf.Ln(4)
+	}
+
+	// === Notes ===
+	if pr.Notes != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 7, "Notes")
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, pr.Notes, "", "", false)
+	}
+
+	// === Signature ===
+	pdf.Ln(14)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(80, 7, "Issued By")
+	pdf.Cell(80, 7, "Supplier")
+	pdf.Ln(20)
+	pdf.Cell(80, 7, "(..................)")
+	pdf.Cell(80, 7, "(..................)")
+	pdf.Ln(10)
+
+	// Footer
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.Cell(0, 5, fmt.Sprintf("Generated at %s", time.Now().Format("02 January 2006 15:04:05")))
+
+	// Output
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate Debit Note PDF: %w", err)
+	}
+	filename := fmt.Sprintf("DN_%s_%s.pdf", debitNoteNo, time.Now().Format("20060102150405"))
+	return filename, buf.Bytes(), nil
+}
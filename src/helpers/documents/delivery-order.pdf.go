@@ -168,3 +168,143 @@ func GenerateDeliveryOrderPDF(so *models.SalesOrder) (string, []byte, error) {
 	filename := fmt.Sprintf("DO_%s_%s.pdf", so.SONumber, time.Now().Format("20060102150405"))
 	return filename, buf.Bytes(), nil
 }
+
+// GenerateShipmentDeliveryOrderPDF mencetak surat jalan untuk satu shipment (pengiriman parsial),
+// termasuk kurir, nomor resi, dan sisa backorder per baris.
+func GenerateShipmentDeliveryOrderPDF(sh *models.Shipment) (string, []byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// === Header ===
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "DELIVERY ORDER")
+	pdf.Ln(12)
+
+	// Helper row writer
+	row := func(label, val string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, label)
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(0, 7, val)
+		pdf.Ln(7)
+	}
+
+	// === Meta Info ===
+	row("DO Number:", sh.ShipmentNumber)
+	row("DO Date:", sh.ShipmentDate.Format("02 January 2006"))
+	row("Sales Order Ref:", sh.SalesOrder.SONumber)
+	row("Warehouse:", sh.Warehouse.Name)
+	if sh.Courier != "" {
+		row("Courier:", sh.Courier)
+	}
+	if sh.TrackingNumber != "" {
+		row("Tracking Number:", sh.TrackingNumber)
+	}
+	row("Status:", sh.Status)
+	pdf.Ln(4)
+
+	// === Customer Info ===
+	if sh.Customer.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Customer")
+		pdf.Ln(9)
+
+		row("Name:", sh.Customer.Name)
+		if v := sh.Customer.Phone; v != nil {
+			row("Phone:", *v)
+		}
+		if v := sh.Customer.Address; v != nil {
+			pdf.SetFont("Arial", "B", 11)
+			pdf.Cell(45, 7, "Address:")
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(0, 6, *v, "", "", false)
+		}
+		pdf.Ln(2)
+	}
+
+	// === Items (No | Item Name | Lot | Ordered | Shipped | Backorder) ===
+	if len(sh.ShipmentItems) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Items Shipped")
+		pdf.Ln(10)
+
+		colNo := 10.0
+		colName := 65.0
+		colLot := 40.0
+		colOrdered := 25.0
+		colShipped := 25.0
+		colBackorder := 25.0
+
+		// Header
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.CellFormat(colNo, 8, "No", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colName, 8, "Item Name", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colLot, 8, "Lot", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colOrdered, 8, "Ordered", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colShipped, 8, "Shipped", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colBackorder, 8, "Backorder", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+
+		// Rows
+		pdf.SetFont("Arial", "", 9)
+		for i, it := range sh.ShipmentItems {
+			name := it.Item.Name
+			if name == "" {
+				name = "Unknown Item"
+			}
+
+			pdf.CellFormat(colNo, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colName, 8, name, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colLot, 8, it.LotNumbers, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colOrdered, 8, fmt.Sprintf("%d", it.OrderedQuantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colShipped, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colBackorder, 8, fmt.Sprintf("%d", it.BackorderQuantity), "1", 0, "C", false, 0, "")
+			pdf.Ln(8)
+		}
+
+		// Total summary row
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colNo+colName+colLot+colOrdered, 8, "Total Shipped", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colShipped, 8, fmt.Sprintf("%d", sh.TotalQuantity), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colBackorder, 8, "", "1", 0, "C", true, 0, "")
+		pdf.Ln(12)
+	}
+
+	// === Notes ===
+	if sh.Notes != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 7, "Notes")
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, sh.Notes, "", "", false)
+	}
+
+	// === Signature ===
+	pdf.Ln(15)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(60, 7, "Sender")
+	pdf.Cell(60, 7, "Courier")
+	pdf.Cell(60, 7, "Receiver")
+	pdf.Ln(20)
+	pdf.Cell(60, 7, "(..................)")
+	pdf.Cell(60, 7, "(..................)")
+	pdf.Cell(60, 7, "(..................)")
+	pdf.Ln(10)
+
+	// Footer
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.Cell(0, 5, fmt.Sprintf("Generated at %s", time.Now().Format("02 January 2006 15:04:05")))
+
+	// Output to bytes
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	filename := fmt.Sprintf("DO_%s_%s.pdf", sh.ShipmentNumber, time.Now().Format("20060102150405"))
+	return filename, buf.Bytes(), nil
+}
@@ -0,0 +1,149 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+func GenerateGoodsReceiptPDF(gr *models.GoodsReceipt) (string, []byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// === Header ===
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "GOODS RECEIPT NOTE")
+	pdf.Ln(12)
+
+	// helper row
+	row := func(label, val string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, label)
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(0, 7, val)
+		pdf.Ln(7)
+	}
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	// === Meta Info ===
+	row("GRN Number:", gr.GRNumber)
+	row("Receipt Date:", gr.ReceiptDate.Format("02 January 2006"))
+	row("PO Reference:", gr.PurchaseOrder.PONumber)
+	row("Delivery Note No:", orDash(gr.DeliveryNoteNumber))
+	row("Vehicle No:", orDash(gr.VehicleNumber))
+	row("Warehouse:", orDash(gr.Warehouse.Name))
+	row("Received By:", orDash(gr.ReceiverName))
+	pdf.Ln(4)
+
+	// === Supplier ===
+	if gr.Supplier.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Supplier")
+		pdf.Ln(9)
+
+		row("Name:", gr.Supplier.Name)
+		if v := gr.Supplier.Code; v != "" {
+			row("Code:", v)
+		}
+		if v := pstr(gr.Supplier.Phone); v != "" {
+			row("Phone:", v)
+		}
+		pdf.Ln(2)
+	}
+
+	// === Items (No | Item Name | Lot | Expired | Received | Rejected) ===
+	if len(gr.GoodsReceiptItems) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Received Items")
+		pdf.Ln(10)
+
+		colNo := 10.0
+		colName := 70.0
+		colLot := 35.0
+		colExp := 28.0
+		colRecv := 22.0
+		colRej := 22.0
+
+		// header
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.CellFormat(colNo, 8, "No", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colName, 8, "Item Name", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colLot, 8, "Lot", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colExp, 8, "Expired", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colRecv, 8, "Received", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colRej, 8, "Rejected", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+
+		// rows
+		pdf.SetFont("Arial", "", 9)
+		for i, it := range gr.GoodsReceiptItems {
+			name := it.Item.Name
+			if name == "" {
+				name = "Unknown Item"
+			}
+			expired := "-"
+			if it.ExpiredAt != nil {
+				expired = it.ExpiredAt.Format("02/01/2006")
+			}
+
+			pdf.CellFormat(colNo, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colName, 8, name, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colLot, 8, orDash(it.LotNumber), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colExp, 8, expired, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colRecv, 8, fmt.Sprintf("%d", it.ReceivedQuantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colRej, 8, fmt.Sprintf("%d", it.RejectedQuantity), "1", 0, "C", false, 0, "")
+			pdf.Ln(8)
+		}
+
+		// summary
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colNo+colName+colLot+colExp, 8, "Total", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colRecv, 8, fmt.Sprintf("%d", gr.TotalReceived), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colRej, 8, fmt.Sprintf("%d", gr.TotalRejected), "1", 0, "C", true, 0, "")
+		pdf.Ln(12)
+	}
+
+	// === Notes ===
+	if gr.Notes != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 7, "Notes")
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, gr.Notes, "", "", false)
+	}
+
+	// === Signature ===
+	pdf.Ln(14)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(80, 7, "Received By")
+	pdf.Cell(80, 7, "Delivered By")
+	pdf.Ln(20)
+	pdf.Cell(80, 7, fmt.Sprintf("(%s)", orDash(gr.ReceiverName)))
+	pdf.Cell(80, 7, "(..................)")
+	pdf.Ln(10)
+
+	// Footer
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.Cell(0, 5, fmt.Sprintf("Generated at %s", time.Now().Format("02 January 2006 15:04:05")))
+
+	// Output
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate Goods Receipt PDF: %w", err)
+	}
+	filename := fmt.Sprintf("%s_%s.pdf", gr.GRNumber, time.Now().Format("20060102150405"))
+	return filename, buf.Bytes(), nil
+}
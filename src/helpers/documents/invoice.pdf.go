@@ -33,6 +33,22 @@ func formatIDR(n int) string {
 	return out
 }
 
+// formatPercent menampilkan tarif (mis. 11 -> "11%", 1.5 -> "1.5%").
+func formatPercent(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+}
+
+// lineDiscountLabel menampilkan diskon baris (kosong = "-").
+func lineDiscountLabel(discountType string, discountValue float64, discountAmount int) string {
+	if discountAmount <= 0 {
+		return "-"
+	}
+	if discountType == "percent" {
+		return formatPercent(discountValue)
+	}
+	return "Rp " + formatIDR(discountAmount)
+}
+
 func GenerateInvoicePDF(so *models.SalesOrder) (string, []byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 15)
@@ -55,6 +71,9 @@ func GenerateInvoicePDF(so *models.SalesOrder) (string, []byte, error) {
 	// === Meta Info ===
 	pdf.SetFont("Arial", "", 11)
 	invoiceNo := fmt.Sprintf("INV-%s", so.SONumber)
+	if so.InvoiceNumber != nil {
+		invoiceNo = *so.InvoiceNumber
+	}
 	row("Invoice Number:", invoiceNo)
 	row("Invoice Date:", time.Now().Format("02 January 2006"))
 	row("SO Reference:", so.SONumber)
@@ -87,7 +106,7 @@ func GenerateInvoicePDF(so *models.SalesOrder) (string, []byte, error) {
 		pdf.Ln(2)
 	}
 
-	// === Items (No | Item Name | Quantity | Unit Price | Subtotal) ===
+	// === Items (No | Item Name | Quantity | Unit Price | Discount | Subtotal) ===
 	if len(so.SalesOrderItems) > 0 {
 		pdf.Ln(2)
 		pdf.SetFont("Arial", "B", 13)
@@ -95,10 +114,12 @@ func GenerateInvoicePDF(so *models.SalesOrder) (string, []byte, error) {
 		pdf.Ln(10)
 
 		colNo := 10.0
-		colName := 80.0
-		colQty := 25.0
-		colPrice := 35.0
+		colName := 65.0
+		colQty := 20.0
+		colPrice := 30.0
+		colDiscount := 25.0
 		colSubtotal := 35.0
+		colLabel := colNo + colName + colQty + colPrice + colDiscount
 
 		// header
 		pdf.SetFont("Arial", "B", 10)
@@ -107,58 +128,101 @@ func GenerateInvoicePDF(so *models.SalesOrder) (string, []byte, error) {
 		pdf.CellFormat(colName, 8, "Item Name", "1", 0, "C", true, 0, "")
 		pdf.CellFormat(colQty, 8, "Quantity", "1", 0, "C", true, 0, "")
 		pdf.CellFormat(colPrice, 8, "Unit Price", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colDiscount, 8, "Discount", "1", 0, "C", true, 0, "")
 		pdf.CellFormat(colSubtotal, 8, "Subtotal", "1", 0, "C", true, 0, "")
 		pdf.Ln(8)
 
 		// rows
 		pdf.SetFont("Arial", "", 9)
-		var grandTotal int
+		var subtotal int
+		hasExempt := false
 		for i, it := range so.SalesOrderItems {
 			name := it.Item.Name
 			if name == "" {
 				name = "Unknown Item"
 			}
+			if it.TaxExempt {
+				name += " *"
+				hasExempt = true
+			}
 			sub := it.TotalPrice
 			if sub == 0 {
-				sub = it.Quantity * it.UnitPrice
+				sub = it.Quantity*it.UnitPrice - it.DiscountAmount
 			}
-			grandTotal += sub
+			subtotal += sub
 
 			pdf.CellFormat(colNo, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
 			pdf.CellFormat(colName, 8, name, "1", 0, "L", false, 0, "")
 			pdf.CellFormat(colQty, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
 			pdf.CellFormat(colPrice, 8, "Rp "+formatIDR(it.UnitPrice), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colDiscount, 8, lineDiscountLabel(it.DiscountType, it.DiscountValue, it.DiscountAmount), "1", 0, "R", false, 0, "")
 			pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(sub), "1", 0, "R", false, 0, "")
 			pdf.Ln(8)
 		}
 
-		// summary (subtotal / dp / paid / remaining)
-		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(colNo+colName+colQty+colPrice, 8, "Subtotal", "1", 0, "R", true, 0, "")
-		pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(grandTotal), "1", 0, "R", true, 0, "")
-		pdf.Ln(8)
+		// summary (subtotal / diskon / DPP / PPN / grand total / retur / dp / paid / remaining)
+		summaryRow := func(label, value string, bold bool) {
+			if bold {
+				pdf.SetFont("Arial", "B", 10)
+			} else {
+				pdf.SetFont("Arial", "", 10)
+			}
+			pdf.CellFormat(colLabel, 8, label, "1", 0, "R", bold, 0, "")
+			pdf.CellFormat(colSubtotal, 8, value, "1", 0, "R", bold, 0, "")
+			pdf.Ln(8)
+		}
+
+		summaryRow("Subtotal", "Rp "+formatIDR(subtotal), true)
+
+		// SO lama (sebelum ada rincian pajak) tidak punya GrandTotal: total = Σ baris
+		grandTotal := so.GrandTotal
+		if so.Subtotal > 0 {
+			if so.DiscountAmount > 0 {
+				label := "Order Discount"
+				if so.DiscountType == "percent" {
+					label += " (" + formatPercent(so.DiscountValue) + ")"
+				}
+				summaryRow(label, "- Rp "+formatIDR(so.DiscountAmount), false)
+			}
+			if exempt := so.GrandTotal - so.TaxBase - so.TaxAmount; exempt > 0 {
+				summaryRow("Non-Taxable (PPN Exempt)", "Rp "+formatIDR(exempt), false)
+			}
+			summaryRow("DPP (Tax Base)", "Rp "+formatIDR(so.TaxBase), false)
+			ppnLabel := "PPN " + formatPercent(so.TaxRate)
+			if so.TaxInclusive {
+				ppnLabel += " (included)"
+			}
+			summaryRow(ppnLabel, "Rp "+formatIDR(so.TaxAmount), false)
+		} else {
+			grandTotal = subtotal
+		}
+		summaryRow("Grand Total", "Rp "+formatIDR(grandTotal), true)
+
+		// selisih grand total vs total tagihan = nilai credit note dari retur
+		if credited := grandTotal - so.TotalAmount; credited > 0 {
+			summaryRow("Returns (Credit Note)", "- Rp "+formatIDR(credited), false)
+		}
 
 		if so.DPAmount > 0 {
-			pdf.SetFont("Arial", "", 10)
-			pdf.CellFormat(colNo+colName+colQty+colPrice, 8, "Down Payment (DP)", "1", 0, "R", false, 0, "")
-			pdf.CellFormat(colSubtotal, 8, "- Rp "+formatIDR(so.DPAmount), "1", 0, "R", false, 0, "")
-			pdf.Ln(8)
+			summaryRow("Down Payment (DP)", "- Rp "+formatIDR(so.DPAmount), false)
 		}
 
 		if so.PaidAmount > 0 {
-			pdf.CellFormat(colNo+colName+colQty+colPrice, 8, "Paid to Date", "1", 0, "R", false, 0, "")
-			pdf.CellFormat(colSubtotal, 8, "- Rp "+formatIDR(so.PaidAmount), "1", 0, "R", false, 0, "")
-			pdf.Ln(8)
+			summaryRow("Paid to Date", "- Rp "+formatIDR(so.PaidAmount), false)
 		}
 
 		remaining := so.TotalAmount - so.PaidAmount
 		if remaining < 0 {
 			remaining = 0
 		}
-		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(colNo+colName+colQty+colPrice, 8, "Remaining", "1", 0, "R", true, 0, "")
-		pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(remaining), "1", 0, "R", true, 0, "")
-		pdf.Ln(12)
+		summaryRow("Remaining", "Rp "+formatIDR(remaining), true)
+
+		if hasExempt {
+			pdf.SetFont("Arial", "I", 8)
+			pdf.Cell(0, 5, "* PPN exempt item")
+			pdf.Ln(5)
+		}
+		pdf.Ln(4)
 	}
 
 	// === Notes ===
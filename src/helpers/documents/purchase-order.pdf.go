@@ -84,36 +84,78 @@ func GeneratePurchaseOrderPDF(po *models.PurchaseOrder) (string, []byte, error)
 		pdf.SetFont("Arial", "B", 10)
 		pdf.SetFillColor(240, 240, 240)
 		pdf.CellFormat(10, 8, "No", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(70, 8, "Item Name", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(18, 8, "Qty", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(32, 8, "Unit Price", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(55, 8, "Item Name", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(15, 8, "Qty", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(28, 8, "Unit Price", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(22, 8, "Discount", "1", 0, "R", true, 0, "")
 		pdf.CellFormat(32, 8, "Total Price", "1", 0, "R", true, 0, "")
 		pdf.CellFormat(18, 8, "Status", "1", 0, "C", true, 0, "")
 		pdf.Ln(8)
 
 		// rows
 		pdf.SetFont("Arial", "", 9)
+		hasExempt := false
 		for i, it := range po.PurchaseOrderItems {
 			name := it.Item.Name
 			if name == "" {
 				name = "Unknown Item"
 			}
+			if it.TaxExempt {
+				name += " *"
+				hasExempt = true
+			}
 			pdf.CellFormat(10, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
-			pdf.CellFormat(70, 8, name, "1", 0, "L", false, 0, "")
-			pdf.CellFormat(18, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
-			pdf.CellFormat(32, 8, formatRupiah(it.UnitPrice), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(55, 8, name, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(15, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(28, 8, formatRupiah(it.UnitPrice), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(22, 8, lineDiscountLabel(it.DiscountType, it.DiscountValue, it.DiscountAmount), "1", 0, "R", false, 0, "")
 			pdf.CellFormat(32, 8, formatRupiah(it.TotalPrice), "1", 0, "R", false, 0, "")
 			pdf.CellFormat(18, 8, it.Status, "1", 0, "C", false, 0, "")
 			pdf.Ln(8)
 		}
 
-		// total
-		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(100, 8, "", "0", 0, "R", false, 0, "")
-		pdf.CellFormat(30, 8, "TOTAL", "1", 0, "R", true, 0, "")
-		pdf.CellFormat(32, 8, formatRupiah(po.TotalAmount), "1", 0, "R", true, 0, "")
-		pdf.CellFormat(18, 8, "", "1", 0, "C", true, 0, "")
-		pdf.Ln(12)
+		// total (subtotal / diskon / DPP / PPN / grand total)
+		totalRow := func(label, value string, bold bool) {
+			if bold {
+				pdf.SetFont("Arial", "B", 10)
+			} else {
+				pdf.SetFont("Arial", "", 10)
+			}
+			pdf.CellFormat(70, 8, "", "0", 0, "R", false, 0, "")
+			pdf.CellFormat(60, 8, label, "1", 0, "R", bold, 0, "")
+			pdf.CellFormat(32, 8, value, "1", 0, "R", bold, 0, "")
+			pdf.Ln(8)
+		}
+
+		if po.Subtotal > 0 {
+			totalRow("Subtotal", formatRupiah(po.Subtotal), false)
+			if po.DiscountAmount > 0 {
+				label := "Order Discount"
+				if po.DiscountType == "percent" {
+					label += " (" + formatPercent(po.DiscountValue) + ")"
+				}
+				totalRow(label, "- "+formatRupiah(po.DiscountAmount), false)
+			}
+			if exempt := po.GrandTotal - po.TaxBase - po.TaxAmount; exempt > 0 {
+				totalRow("Non-Taxable (PPN Exempt)", formatRupiah(exempt), false)
+			}
+			totalRow("DPP (Tax Base)", formatRupiah(po.TaxBase), false)
+			ppnLabel := "PPN " + formatPercent(float64(po.Tax))
+			if po.TaxInclusive {
+				ppnLabel += " (included)"
+			}
+			totalRow(ppnLabel, formatRupiah(po.TaxAmount), false)
+			totalRow("GRAND TOTAL", formatRupiah(po.GrandTotal), true)
+		} else {
+			totalRow("TOTAL", formatRupiah(po.TotalAmount), true)
+		}
+
+		if hasExempt {
+			pdf.SetFont("Arial", "I", 8)
+			pdf.Cell(0, 5, "* PPN exempt item")
+			pdf.Ln(5)
+		}
+		pdf.Ln(4)
 	}
 
 	// Payment
@@ -122,6 +164,18 @@ func GeneratePurchaseOrderPDF(po *models.PurchaseOrder) (string, []byte, error)
 	pdf.Ln(9)
 
 	pdf.SetFont("Arial", "", 11)
+	// selisih grand total vs total hutang = nilai debit note dari retur ke supplier
+	// (PO lama tanpa rincian pajak memakai Σ total baris)
+	lineTotal := po.GrandTotal
+	if po.Subtotal == 0 {
+		lineTotal = 0
+		for _, it := range po.PurchaseOrderItems {
+			lineTotal += it.TotalPrice
+		}
+	}
+	if debited := lineTotal - po.TotalAmount; len(po.PurchaseOrderItems) > 0 && debited > 0 {
+		row("Returns (Debit Note):", "- "+formatRupiah(debited))
+	}
 	row("Total Amount:", formatRupiah(po.TotalAmount))
 	row("Paid Amount:", formatRupiah(po.PaidAmount))
 	row("Remaining:", formatRupiah(po.TotalAmount-po.PaidAmount))
@@ -0,0 +1,149 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+func GenerateQuotationPDF(q *models.SalesQuotation) (string, []byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// === Header ===
+	pdf.SetFont("Arial", "B", 18)
+	pdf.Cell(0, 10, "QUOTATION")
+	pdf.Ln(12)
+
+	// helper row
+	row := func(label, val string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(45, 7, label)
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(0, 7, val)
+		pdf.Ln(7)
+	}
+
+	// === Meta Info ===
+	row("Quotation Number:", q.QuotationNumber)
+	if q.Revision > 0 {
+		row("Revision:", fmt.Sprintf("%d", q.Revision))
+	}
+	row("Quotation Date:", q.QuotationDate.Format("02 January 2006"))
+	row("Valid Until:", q.ValidUntil.Format("02 January 2006"))
+	row("Term of Payment:", q.TermOfPayment)
+	row("Status:", q.Status)
+	if q.SalesPerson.Name != "" {
+		row("Sales Person:", q.SalesPerson.Name)
+	}
+	pdf.Ln(4)
+
+	// === Quote To / Customer ===
+	if q.Customer.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Quote To")
+		pdf.Ln(9)
+
+		row("Name:", q.Customer.Name)
+		if v := q.Customer.Email; v != nil {
+			row("Email:", *v)
+		}
+		if v := q.Customer.Phone; v != nil {
+			row("Phone:", *v)
+		}
+		if v := q.Customer.Address; v != nil {
+			pdf.SetFont("Arial", "B", 11)
+			pdf.Cell(45, 7, "Address:")
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(0, 6, *v, "", "", false)
+		}
+		pdf.Ln(2)
+	}
+
+	// === Items (No | Item Name | Quantity | Unit Price | Subtotal) ===
+	if len(q.SalesQuotationItems) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.Cell(0, 8, "Quoted Items")
+		pdf.Ln(10)
+
+		colNo := 10.0
+		colName := 80.0
+		colQty := 25.0
+		colPrice := 35.0
+		colSubtotal := 35.0
+
+		// header
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.CellFormat(colNo, 8, "No", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colName, 8, "Item Name", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colQty, 8, "Quantity", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colPrice, 8, "Unit Price", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colSubtotal, 8, "Subtotal", "1", 0, "C", true, 0, "")
+		pdf.Ln(8)
+
+		// rows
+		pdf.SetFont("Arial", "", 9)
+		for i, it := range q.SalesQuotationItems {
+			name := it.Item.Name
+			if name == "" {
+				name = "Unknown Item"
+			}
+
+			pdf.CellFormat(colNo, 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colName, 8, name, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colQty, 8, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colPrice, 8, "Rp "+formatIDR(it.UnitPrice), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(it.TotalPrice), "1", 0, "R", false, 0, "")
+			pdf.Ln(8)
+		}
+
+		// summary
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colNo+colName+colQty+colPrice, 8, "Total", "1", 0, "R", true, 0, "")
+		pdf.CellFormat(colSubtotal, 8, "Rp "+formatIDR(q.TotalAmount), "1", 0, "R", true, 0, "")
+		pdf.Ln(12)
+	}
+
+	// === Notes ===
+	if q.Notes != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(0, 7, "Notes")
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, q.Notes, "", "", false)
+	}
+
+	pdf.Ln(2)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.MultiCell(0, 5, fmt.Sprintf("This quotation is valid until %s. Prices are subject to change after this date.", q.ValidUntil.Format("02 January 2006")), "", "", false)
+
+	// === Signature ===
+	pdf.Ln(14)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(80, 7, "Issued By")
+	pdf.Cell(80, 7, "Customer Approval")
+	pdf.Ln(20)
+	pdf.Cell(80, 7, "(..................)")
+	pdf.Cell(80, 7, "(..................)")
+	pdf.Ln(10)
+
+	// Footer
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.Cell(0, 5, fmt.Sprintf("Generated at %s", time.Now().Format("02 January 2006 15:04:05")))
+
+	// Output
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate Quotation PDF: %w", err)
+	}
+	filename := fmt.Sprintf("QT_%s_R%d_%s.pdf", q.QuotationNumber, q.Revision, time.Now().Format("20060102150405"))
+	return filename, buf.Bytes(), nil
+}
@@ -15,11 +15,17 @@ func GenerateReceiptPDF(so *models.SalesOrder) (string, []byte, error) {
 		return "", nil, fmt.Errorf("no payments found for this sales order")
 	}
 
-	// pilih pembayaran terakhir (by PaymentDate)
+	// pilih pembayaran terakhir (by PaymentDate); payment yang di-void & entri reversal dilewati
 	pays := make([]models.Payment, 0, len(so.Payments))
 	for _, p := range so.Payments {
+		if p.VoidedAt != nil || p.PaymentType == "Reversal" {
+			continue
+		}
 		pays = append(pays, p)
 	}
+	if len(pays) == 0 {
+		return "", nil, fmt.Errorf("no active payments found for this sales order")
+	}
 	sort.Slice(pays, func(i, j int) bool {
 		return pays[i].PaymentDate.After(pays[j].PaymentDate)
 	})
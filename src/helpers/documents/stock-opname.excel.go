@@ -0,0 +1,191 @@
+package documents
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/xuri/excelize/v2"
+)
+
+const stockOpnameSheet = "Stock Opname"
+
+var stockOpnameHeaders = []string{
+	"Item Code", "Item Name", "Lot Number", "Expired At",
+	"System Qty", "Counted Qty", "Notes",
+}
+
+// GenerateStockOpnameCountSheet membuat lembar hitung dari snapshot opname.
+// Kolom: Item Code | Item Name | Lot Number | Expired At | System Qty | Counted Qty | Notes
+// Lembar yang sama (kolom Counted Qty diisi) di-upload balik lewat ParseStockOpnameCountSheet.
+func GenerateStockOpnameCountSheet(op *models.StockOpname) (*excelize.File, string, error) {
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", stockOpnameSheet)
+
+	for i, h := range stockOpnameHeaders {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		_ = f.SetCellValue(stockOpnameSheet, cell, h)
+	}
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "FFFFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#2980B9"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{
+			{Type: "left", Color: "DDDDDD", Style: 1},
+			{Type: "right", Color: "DDDDDD", Style: 1},
+			{Type: "top", Color: "DDDDDD", Style: 1},
+			{Type: "bottom", Color: "DDDDDD", Style: 1},
+		},
+	})
+	rowStyle, _ := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{Vertical: "center"},
+		Border: []excelize.Border{
+			{Type: "left", Color: "DDDDDD", Style: 1},
+			{Type: "right", Color: "DDDDDD", Style: 1},
+			{Type: "top", Color: "DDDDDD", Style: 1},
+			{Type: "bottom", Color: "DDDDDD", Style: 1},
+		},
+	})
+	_ = f.SetCellStyle(stockOpnameSheet, "A1", "G1", headerStyle)
+
+	for r, it := range op.StockOpnameItems {
+		row := r + 2
+		expiredAt := ""
+		if it.ExpiredAt != nil {
+			expiredAt = it.ExpiredAt.Format("2006-01-02")
+		}
+		var counted interface{}
+		if it.CountedQuantity != nil {
+			counted = *it.CountedQuantity
+		}
+		values := []interface{}{
+			it.Item.Code,
+			it.Item.Name,
+			it.LotNumber,
+			expiredAt,
+			it.SystemQuantity,
+			counted,
+			it.Notes,
+		}
+		for c, v := range values {
+			cell, _ := excelize.CoordinatesToCellName(c+1, row)
+			_ = f.SetCellValue(stockOpnameSheet, cell, v)
+		}
+		left, _ := excelize.CoordinatesToCellName(1, row)
+		right, _ := excelize.CoordinatesToCellName(len(stockOpnameHeaders), row)
+		_ = f.SetCellStyle(stockOpnameSheet, left, right, rowStyle)
+	}
+
+	_ = f.SetColWidth(stockOpnameSheet, "A", "A", 15)
+	_ = f.SetColWidth(stockOpnameSheet, "B", "B", 30)
+	_ = f.SetColWidth(stockOpnameSheet, "C", "C", 18)
+	_ = f.SetColWidth(stockOpnameSheet, "D", "D", 13)
+	_ = f.SetColWidth(stockOpnameSheet, "E", "F", 12)
+	_ = f.SetColWidth(stockOpnameSheet, "G", "G", 30)
+
+	filename := fmt.Sprintf("stock_opname_%s_%s.xlsx", op.OpnameNumber, time.Now().Format("20060102_150405"))
+	return f, filename, nil
+}
+
+// ParseStockOpnameCountSheet membaca lembar hitung hasil upload. Kolom dicari lewat header
+// (Item Code, Lot Number, Counted Qty wajib; Expired At & Notes opsional). Baris dengan
+// Counted Qty kosong dilewati.
+func ParseStockOpnameCountSheet(r io.Reader) ([]models.StockOpnameCountRow, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excel file: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("excel file has no sheet")
+	}
+	sheet := sheets[0]
+	for _, s := range sheets {
+		if s == stockOpnameSheet {
+			sheet = s
+		}
+	}
+
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("sheet %s has no data rows", sheet)
+	}
+
+	col := make(map[string]int)
+	for i, h := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"item code", "lot number", "counted qty"} {
+		if _, ok := col[required]; !ok {
+			return nil, fmt.Errorf("missing column %q in header row", required)
+		}
+	}
+
+	cellAt := func(row []string, name string) string {
+		i, ok := col[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	out := make([]models.StockOpnameCountRow, 0, len(rows)-1)
+	for idx, row := range rows[1:] {
+		rowNum := idx + 2
+		countedRaw := cellAt(row, "counted qty")
+		if countedRaw == "" {
+			continue
+		}
+		counted, err := strconv.Atoi(strings.ReplaceAll(countedRaw, ",", ""))
+		if err != nil || counted < 0 {
+			return nil, fmt.Errorf("row %d: invalid counted qty %q", rowNum, countedRaw)
+		}
+
+		code := cellAt(row, "item code")
+		lotNumber := cellAt(row, "lot number")
+		if code == "" || lotNumber == "" {
+			return nil, fmt.Errorf("row %d: item code and lot number are required", rowNum)
+		}
+
+		var expiredAt *time.Time
+		if raw := cellAt(row, "expired at"); raw != "" {
+			t, err := parseSheetDate(raw)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid expired at %q", rowNum, raw)
+			}
+			expiredAt = &t
+		}
+
+		out = append(out, models.StockOpnameCountRow{
+			Row:             rowNum,
+			ItemCode:        code,
+			LotNumber:       lotNumber,
+			ExpiredAt:       expiredAt,
+			CountedQuantity: counted,
+			Notes:           cellAt(row, "notes"),
+		})
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no counted quantity found in sheet %s", sheet)
+	}
+	return out, nil
+}
+
+func parseSheetDate(raw string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "02 Jan 2006", "01-02-06"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format")
+}
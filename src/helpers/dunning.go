@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var defaultDunningStages = []int{1, 7, 30}
+
+// DunningStages membaca tahap penagihan (hari lewat jatuh tempo) dari env DUNNING_STAGES
+// (contoh: "1,7,30"). Hasil unik & urut menaik; fallback ke 1/7/30.
+func DunningStages() []int {
+	raw := strings.TrimSpace(os.Getenv("DUNNING_STAGES"))
+	if raw == "" {
+		return append([]int(nil), defaultDunningStages...)
+	}
+
+	seen := make(map[int]bool)
+	stages := make([]int, 0)
+	for _, part := range strings.Split(raw, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 || seen[n] {
+			continue
+		}
+		seen[n] = true
+		stages = append(stages, n)
+	}
+	if len(stages) == 0 {
+		return append([]int(nil), defaultDunningStages...)
+	}
+
+	sort.Ints(stages)
+	return stages
+}
@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var defaultExpiryAlertDays = []int{90, 30, 7}
+
+// ExpiryAlertDays membaca horizon peringatan expiry dari env EXPIRY_ALERT_DAYS
+// (contoh: "90,30,7"). Hasil unik & urut menurun; fallback ke 90/30/7.
+func ExpiryAlertDays() []int {
+	raw := strings.TrimSpace(os.Getenv("EXPIRY_ALERT_DAYS"))
+	if raw == "" {
+		return append([]int(nil), defaultExpiryAlertDays...)
+	}
+
+	seen := make(map[int]bool)
+	days := make([]int, 0)
+	for _, part := range strings.Split(raw, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 || seen[n] {
+			continue
+		}
+		seen[n] = true
+		days = append(days, n)
+	}
+	if len(days) == 0 {
+		return append([]int(nil), defaultExpiryAlertDays...)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(days)))
+	return days
+}
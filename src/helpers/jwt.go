@@ -14,14 +14,16 @@ var JWT_SECRET_KEY = os.Getenv("JWT_SECRET_KEY")
 var mySigningKey = []byte(JWT_SECRET_KEY)
 
 type MyCustomClaims struct {
-	ID     uuid.UUID   `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	RoleID uuid.UUID   `json:"role_id"`
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	RoleID    uuid.UUID `json:"role_id"`
+	SessionID uuid.UUID `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func CreateToken(user *models.User) (string, error) {
+// CreateToken membuat access token berumur pendek yang terikat ke satu session.
+func CreateToken(user *models.User, sessionID uuid.UUID, expiresAt time.Time) (string, error) {
 	var roleID uuid.UUID
 	if user.RoleID != nil {
 		roleID = *user.RoleID
@@ -30,12 +32,14 @@ func CreateToken(user *models.User) (string, error) {
 	}
 
 	claims := MyCustomClaims{
-		ID:     user.ID,
-		Name:   user.Name,
-		Email:  user.Email,
-		RoleID: roleID,
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		RoleID:    roleID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -54,7 +58,7 @@ func CreateToken(user *models.User) (string, error) {
 func ValidateToken(tokenString string) (*MyCustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &MyCustomClaims{}, func(token *jwt.Token) (any, error) {
 		return mySigningKey, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -63,8 +67,16 @@ func ValidateToken(tokenString string) (*MyCustomClaims, error) {
 	claims, ok := token.Claims.(*MyCustomClaims)
 	
 	if !ok || !token.Valid {
-		return nil, err
+		return nil, fmt.Errorf("invalid token claims")
 	}
 	
 	return claims, nil
 }
+
+func AccessTokenTTL() time.Duration {
+	return time.Duration(EnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute
+}
+
+func RefreshTokenTTL() time.Duration {
+	return time.Duration(EnvInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour
+}
@@ -0,0 +1,216 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type MailMessage struct {
+	To          []string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Attachments []MailAttachment
+}
+
+// MailSender adalah abstraksi pengiriman email; implementasi dipilih via env MAIL_DRIVER.
+type MailSender interface {
+	Send(msg MailMessage) error
+}
+
+var (
+	mailSenderMu sync.RWMutex
+	mailSender   MailSender
+)
+
+// GetMailSender mengembalikan sender aktif. Default:
+//   - MAIL_DRIVER=smtp → SMTP (GMAIL_SENDER / GMAIL_PASSWORD, host default smtp.gmail.com:587)
+//   - selain itu → FileMailSender yang menulis .eml ke MAIL_FILE_DIR (default ./tmp/mails)
+func GetMailSender() MailSender {
+	mailSenderMu.RLock()
+	s := mailSender
+	mailSenderMu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	mailSenderMu.Lock()
+	defer mailSenderMu.Unlock()
+	if mailSender == nil {
+		mailSender = newMailSenderFromEnv()
+	}
+	return mailSender
+}
+
+// SetMailSender mengganti sender aktif (mis. provider lain).
+func SetMailSender(s MailSender) {
+	mailSenderMu.Lock()
+	mailSender = s
+	mailSenderMu.Unlock()
+}
+
+func newMailSenderFromEnv() MailSender {
+	if strings.EqualFold(os.Getenv("MAIL_DRIVER"), "smtp") {
+		host := os.Getenv("MAIL_SMTP_HOST")
+		if host == "" {
+			host = "smtp.gmail.com"
+		}
+		port := os.Getenv("MAIL_SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		return &SMTPMailSender{
+			Host:     host,
+			Port:     port,
+			From:     os.Getenv("GMAIL_SENDER"),
+			Password: os.Getenv("GMAIL_PASSWORD"),
+		}
+	}
+
+	dir := os.Getenv("MAIL_FILE_DIR")
+	if dir == "" {
+		dir = filepath.Join("tmp", "mails")
+	}
+	return &FileMailSender{Dir: dir, From: os.Getenv("GMAIL_SENDER")}
+}
+
+// ==============================
+// SMTP
+// ==============================
+
+type SMTPMailSender struct {
+	Host     string
+	Port     string
+	From     string
+	Password string
+}
+
+func (s *SMTPMailSender) Send(msg MailMessage) error {
+	if s.From == "" {
+		return fmt.Errorf("mail sender is not configured")
+	}
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mail has no recipient")
+	}
+
+	raw, err := buildMIMEMessage(s.From, msg)
+	if err != nil {
+		return err
+	}
+
+	auth := smtp.PlainAuth("", s.From, s.Password, s.Host)
+	if err := smtp.SendMail(s.Host+":"+s.Port, auth, s.From, msg.To, raw); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
+
+// ==============================
+// File (local/dev stand-in)
+// ==============================
+
+type FileMailSender struct {
+	Dir  string
+	From string
+}
+
+func (s *FileMailSender) Send(msg MailMessage) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mail has no recipient")
+	}
+	from := s.From
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	raw, err := buildMIMEMessage(from, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("create mail dir: %w", err)
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102_150405"), uuid.NewString()[:8])
+	if err := os.WriteFile(filepath.Join(s.Dir, name), raw, 0o644); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+	return nil
+}
+
+// ==============================
+// MIME builder
+// ==============================
+
+func buildMIMEMessage(from string, msg MailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mw.Boundary())
+
+	if msg.TextBody != "" {
+		part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+		if err != nil {
+			return nil, err
+		}
+		part.Write([]byte(msg.TextBody))
+	}
+	if msg.HTMLBody != "" {
+		part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}})
+		if err != nil {
+			return nil, err
+		}
+		part.Write([]byte(msg.HTMLBody))
+	}
+
+	for _, att := range msg.Attachments {
+		ct := att.ContentType
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {ct},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", att.Filename)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(att.Data)
+		for i := 0; i < len(encoded); i += 76 {
+			end := i + 76
+			if end > len(encoded) {
+				end = len(encoded)
+			}
+			part.Write([]byte(encoded[i:end] + "\r\n"))
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
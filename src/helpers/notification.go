@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"log"
 	"strings"
 
@@ -14,6 +15,19 @@ var allowedRoles = map[string][]string{
 	// Low stock
 	"low_stock": {"SUPERADMIN", "DEVELOPER", "SALES"},
 	"consigment_item": {"SUPERADMIN", "DEVELOPER", "SALES"},
+	// Expiry
+	"near_expiry":   {"SUPERADMIN", "DEVELOPER", "SALES"},
+	"expired_stock": {"SUPERADMIN", "DEVELOPER", "SALES"},
+	// Purchase order approval (permintaan approval dikirim ke role langkah berikutnya)
+	"po_approval_result": {"SUPERADMIN", "DEVELOPER"},
+	// Credit hold (audit SO yang diloloskan melewati batas kredit)
+	"credit_override": {"SUPERADMIN", "DEVELOPER"},
+	// Harga jual di bawah price list
+	"price_below_list": {"SUPERADMIN", "DEVELOPER"},
+	// Hutang PO yang segera jatuh tempo (finance)
+	"po_payment_due": {"SUPERADMIN", "DEVELOPER"},
+	// Piutang SO lewat jatuh tempo tanpa akun sales person (dunning)
+	"so_overdue": {"SUPERADMIN", "DEVELOPER"},
 }
 
 func SendNotificationAuto(
@@ -28,39 +42,74 @@ func SendNotificationAuto(
 		return nil
 	}
 
+	return SendNotificationToRoles(rolesAllowed, notifType, title, message, metadata)
+}
+
+// SendNotificationToRoles mengirim notifikasi ke semua user dengan role tertentu
+// (nama role, case-insensitive). Dipakai bila penerima ditentukan data, bukan tipe notifikasi.
+func SendNotificationToRoles(
+	roles []string,
+	notifType string,
+	title string,
+	message string,
+	metadata map[string]interface{},
+) error {
 	var users []models.User
 	if err := configs.DB.Preload("Role").Find(&users).Error; err != nil {
 		return err
 	}
 
 	for _, user := range users {
+		if user.Role == nil {
+			continue
+		}
 		role := strings.ToUpper(user.Role.Name)
-		for _, allowedRole := range rolesAllowed {
-			if role == allowedRole {
-				notification := models.Notification{
-					ID:       uuid.New(),
-					UserID:   user.ID,
-					Type:     notifType,
-					Title:    title,
-					Message:  message,
-					IsRead:   false,
-					Metadata: metadata,
-				}
-				
-				if err := configs.DB.Create(&notification).Error; err != nil {
+		for _, allowedRole := range roles {
+			if role == strings.ToUpper(allowedRole) {
+				if err := notifyUser(user.ID, notifType, title, message, metadata); err != nil {
 					log.Printf("❌ Failed to save notification to DB for user %s: %v", user.ID, err)
-					continue
-				}
-
-				if err := configs.DB.Preload("User.Role").First(&notification, "id = ?", notification.ID).Error; err != nil {
-					log.Printf("❌ Failed to load notification with user relation: %v", err)
-					continue
 				}
-
-				websockets.SendToUser(user.ID.String(), "notification", notification)
+				break
 			}
 		}
 	}
 
 	return nil
 }
+
+// SendNotificationToUser mengirim notifikasi ke satu user.
+func SendNotificationToUser(
+	userID uuid.UUID,
+	notifType string,
+	title string,
+	message string,
+	metadata map[string]interface{},
+) error {
+	return notifyUser(userID, notifType, title, message, metadata)
+}
+
+// notifyUser menyimpan notifikasi lalu mengirimkannya lewat websocket. Error hanya dikembalikan
+// bila notifikasi gagal disimpan; gagal push websocket cukup dicatat di log.
+func notifyUser(userID uuid.UUID, notifType, title, message string, metadata map[string]interface{}) error {
+	notification := models.Notification{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Type:     notifType,
+		Title:    title,
+		Message:  message,
+		IsRead:   false,
+		Metadata: metadata,
+	}
+
+	if err := configs.DB.Create(&notification).Error; err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+
+	if err := configs.DB.Preload("User.Role").First(&notification, "id = ?", notification.ID).Error; err != nil {
+		log.Printf("❌ Failed to load notification with user relation: %v", err)
+		return nil
+	}
+
+	websockets.SendToUser(userID.String(), "notification", notification)
+	return nil
+}
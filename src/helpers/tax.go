@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Jenis diskon baris maupun order.
+const (
+	DiscountTypePercent = "percent"
+	DiscountTypeAmount  = "amount"
+)
+
+const defaultPPNRate = 11.0
+
+// PPNRate membaca tarif PPN default (persen) dari env PPN_RATE; fallback 11.
+// Nilai 0 valid (PPN tidak dipungut).
+func PPNRate() float64 {
+	raw := strings.TrimSpace(os.Getenv("PPN_RATE"))
+	if raw == "" {
+		return defaultPPNRate
+	}
+	rate, err := strconv.ParseFloat(raw, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		return defaultPPNRate
+	}
+	return rate
+}
+
+// PricingLine adalah input satu baris dokumen (SO/PO).
+type PricingLine struct {
+	Quantity      int
+	UnitPrice     int
+	DiscountType  string // percent, amount, kosong = tanpa diskon
+	DiscountValue float64
+	TaxExempt     bool // barang bebas PPN (mis. alat kesehatan tertentu)
+}
+
+// PricingInput adalah input perhitungan total dokumen.
+type PricingInput struct {
+	Lines         []PricingLine
+	DiscountType  string // diskon order: percent, amount, kosong = tanpa diskon
+	DiscountValue float64
+	TaxRate       float64 // persen
+	TaxInclusive  bool    // true = harga satuan sudah termasuk PPN
+}
+
+type PricingLineResult struct {
+	Gross         int // qty × harga satuan
+	Discount      int // diskon baris
+	Total         int // Gross - Discount
+	OrderDiscount int // porsi diskon order yang dialokasikan ke baris ini
+	TaxAmount     int // porsi PPN baris ini
+	NetAmount     int // nilai tagihan baris (Total - OrderDiscount, + PPN bila exclusive)
+}
+
+type PricingResult struct {
+	Lines          []PricingLineResult
+	Subtotal       int // Σ total baris setelah diskon baris
+	DiscountAmount int // diskon order
+	TaxBase        int // DPP
+	TaxAmount      int // PPN
+	ExemptAmount   int // nilai barang bebas PPN setelah diskon
+	GrandTotal     int
+}
+
+// CalculatePricing menghitung subtotal, diskon, DPP, PPN & grand total secara konsisten untuk SO dan PO.
+// Diskon order dialokasikan proporsional ke tiap baris agar porsi kena pajak & bebas pajak terpisah;
+// PPN dihitung sekali dari total DPP lalu dialokasikan ke baris kena pajak (sisa pembulatan ke baris terakhir).
+func CalculatePricing(in PricingInput) (*PricingResult, error) {
+	if in.TaxRate < 0 || in.TaxRate > 100 {
+		return nil, errors.New("tax rate must be between 0 and 100")
+	}
+
+	res := &PricingResult{Lines: make([]PricingLineResult, len(in.Lines))}
+
+	totals := make([]int, len(in.Lines))
+	for i, l := range in.Lines {
+		gross := l.Quantity * l.UnitPrice
+		discount, err := discountValue(l.DiscountType, l.DiscountValue, gross)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		res.Lines[i].Gross = gross
+		res.Lines[i].Discount = discount
+		res.Lines[i].Total = gross - discount
+		totals[i] = gross - discount
+		res.Subtotal += gross - discount
+	}
+
+	orderDiscount, err := discountValue(in.DiscountType, in.DiscountValue, res.Subtotal)
+	if err != nil {
+		return nil, fmt.Errorf("order discount: %w", err)
+	}
+	res.DiscountAmount = orderDiscount
+
+	shares := allocateProportional(orderDiscount, totals)
+	taxableWeights := make([]int, len(in.Lines))
+	taxableAfter := 0
+	for i, l := range in.Lines {
+		res.Lines[i].OrderDiscount = shares[i]
+		after := totals[i] - shares[i]
+		if l.TaxExempt {
+			res.ExemptAmount += after
+			continue
+		}
+		taxableWeights[i] = after
+		taxableAfter += after
+	}
+
+	if in.TaxInclusive {
+		res.TaxBase = int(math.Round(float64(taxableAfter) * 100 / (100 + in.TaxRate)))
+		res.TaxAmount = taxableAfter - res.TaxBase
+	} else {
+		res.TaxBase = taxableAfter
+		res.TaxAmount = int(math.Round(float64(taxableAfter) * in.TaxRate / 100))
+	}
+
+	taxShares := allocateProportional(res.TaxAmount, taxableWeights)
+	for i := range in.Lines {
+		res.Lines[i].TaxAmount = taxShares[i]
+		net := totals[i] - shares[i]
+		if !in.TaxInclusive {
+			net += taxShares[i]
+		}
+		res.Lines[i].NetAmount = net
+		res.GrandTotal += net
+	}
+
+	return res, nil
+}
+
+// ValidDiscountType true bila jenis diskon dikenali (kosong = tanpa diskon).
+func ValidDiscountType(t string) bool {
+	return t == "" || t == DiscountTypePercent || t == DiscountTypeAmount
+}
+
+func discountValue(discountType string, value float64, base int) (int, error) {
+	if value < 0 {
+		return 0, errors.New("discount cannot be negative")
+	}
+	switch discountType {
+	case "":
+		return 0, nil
+	case DiscountTypePercent:
+		if value > 100 {
+			return 0, errors.New("discount percent cannot exceed 100")
+		}
+		return int(math.Round(float64(base) * value / 100)), nil
+	case DiscountTypeAmount:
+		amount := int(math.Round(value))
+		if amount > base {
+			return 0, fmt.Errorf("discount %d exceeds amount %d", amount, base)
+		}
+		return amount, nil
+	default:
+		return 0, fmt.Errorf("invalid discount type %q", discountType)
+	}
+}
+
+// allocateProportional membagi total ke tiap bobot secara proporsional; sisa pembulatan
+// masuk ke baris berbobot terakhir sehingga Σ hasil selalu = total.
+func allocateProportional(total int, weights []int) []int {
+	shares := make([]int, len(weights))
+	sum, last := 0, -1
+	for i, w := range weights {
+		if w > 0 {
+			sum += w
+			last = i
+		}
+	}
+	if total == 0 || sum == 0 {
+		return shares
+	}
+
+	allocated := 0
+	for i, w := range weights {
+		if w <= 0 || i == last {
+			continue
+		}
+		shares[i] = int(math.Round(float64(total) * float64(w) / float64(sum)))
+		allocated += shares[i]
+	}
+	shares[last] = total - allocated
+	return shares
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// GenerateOpaqueToken membuat token acak (hex) beserta sha256-nya untuk disimpan di DB.
+func GenerateOpaqueToken(byteLen int) (plain string, hash string, err error) {
+	buf := make([]byte, byteLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	plain = hex.EncodeToString(buf)
+	return plain, HashOpaqueToken(plain), nil
+}
+
+func HashOpaqueToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignDocumentLink membuat HMAC-SHA256 untuk link dokumen publik (id + waktu kedaluwarsa unix).
+// Secret diambil dari DOCUMENT_LINK_SECRET, fallback ke JWT_SECRET_KEY.
+func SignDocumentLink(linkID string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, documentLinkSecret())
+	mac.Write([]byte(fmt.Sprintf("%s.%d", linkID, expiresAt)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyDocumentLink(linkID string, expiresAt int64, signature string) bool {
+	expected, err := hex.DecodeString(SignDocumentLink(linkID, expiresAt))
+	if err != nil {
+		return false
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, given)
+}
+
+func documentLinkSecret() []byte {
+	if secret := os.Getenv("DOCUMENT_LINK_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET_KEY"))
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+)
+
+func StartDunningReminderScheduler(loc *time.Location) {
+	go func() {
+		for {
+			now := time.Now().In(loc)
+			nextRun := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, loc)
+			if !now.Before(nextRun) {
+				nextRun = nextRun.Add(24 * time.Hour)
+			}
+
+			d := time.Until(nextRun)
+			log.Printf("[Dunning] Sleep until %s (in %s)\n", nextRun.Format(time.RFC3339), d)
+			time.Sleep(d)
+
+			if err := runDunningReminder(loc); err != nil {
+				log.Printf("[Dunning] ERROR: %v\n", err)
+			}
+		}
+	}()
+}
+
+// runDunningReminder mengirim pengingat bertahap (DUNNING_STAGES) untuk SO lewat jatuh tempo.
+func runDunningReminder(loc *time.Location) error {
+	dunningService := services.NewDunningService(
+		repositories.NewDunningReminderRepository(configs.DB),
+		repositories.NewSalesOrderRepository(configs.DB),
+		repositories.NewUserRepository(configs.DB),
+		helpers.GetMailSender(),
+	)
+
+	sent, err := dunningService.RunDunning(time.Now().In(loc), helpers.DunningStages())
+	if err != nil {
+		return fmt.Errorf("run dunning: %w", err)
+	}
+
+	log.Printf("[Dunning] Sent reminders for %d sales orders\n", len(sent))
+	return nil
+}
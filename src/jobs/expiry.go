@@ -0,0 +1,127 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+)
+
+func StartExpiryMonitorScheduler(loc *time.Location) {
+	go func() {
+		for {
+			now := time.Now().In(loc)
+			nextRun := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, loc)
+			if !now.Before(nextRun) {
+				nextRun = nextRun.Add(24 * time.Hour)
+			}
+
+			d := time.Until(nextRun)
+			log.Printf("[ExpiryMonitor] Sleep until %s (in %s)\n", nextRun.Format(time.RFC3339), d)
+			time.Sleep(d)
+
+			if err := runExpiryMonitor(loc); err != nil {
+				log.Printf("[ExpiryMonitor] ERROR: %v\n", err)
+			}
+		}
+	}()
+}
+
+// runExpiryMonitor mengirim notifikasi near_expiry untuk lot yang tepat berada di salah satu
+// horizon (mis. 90/30/7 hari lagi), expired_stock untuk lot yang expired hari ini, dan satu
+// ringkasan expired_stock bila masih ada lot expired yang belum dikeluarkan dari gudang.
+func runExpiryMonitor(loc *time.Location) error {
+	itemLotService := services.NewItemLotService(
+		repositories.NewItemLotRepository(configs.DB),
+		repositories.NewItemStockRepository(configs.DB),
+		repositories.NewWarehouseRepository(configs.DB),
+		repositories.NewItemRepository(configs.DB),
+		repositories.NewItemHistoryRepository(configs.DB),
+	)
+
+	horizons := helpers.ExpiryAlertDays()
+	isHorizon := make(map[int]bool, len(horizons))
+	for _, h := range horizons {
+		isHorizon[h] = true
+	}
+
+	report, err := itemLotService.GetNearExpiryStock(horizons[0], nil, true, time.Now().In(loc))
+	if err != nil {
+		return fmt.Errorf("query lots: %w", err)
+	}
+	if len(report.Lots) == 0 {
+		log.Println("[ExpiryMonitor] No lots in window")
+		return nil
+	}
+
+	sent := 0
+	staleLots, staleQty, staleValue := 0, 0, 0
+	for _, lot := range report.Lots {
+		expiredAt := lot.ExpiredAt.In(loc)
+
+		metadata := map[string]interface{}{
+			"item_id":        lot.ItemID.String(),
+			"item_name":      lot.ItemName,
+			"code":           lot.ItemCode,
+			"lot_id":         lot.LotID.String(),
+			"lot_number":     lot.LotNumber,
+			"warehouse_id":   lot.WarehouseID.String(),
+			"warehouse_name": lot.WarehouseName,
+			"expired_at":     expiredAt.Format(time.RFC3339),
+			"days_left":      lot.DaysLeft,
+			"quantity":       lot.Quantity,
+			"cost_value":     lot.CostValue,
+		}
+
+		switch {
+		case lot.DaysLeft > 0 && isHorizon[lot.DaysLeft]:
+			title := fmt.Sprintf("Mendekati Expired: %s", lot.ItemName)
+			msg := fmt.Sprintf("Lot %s di %s expired %d hari lagi (%s). Qty: %d %s, nilai: Rp %d.",
+				lot.LotNumber, lot.WarehouseName, lot.DaysLeft, expiredAt.Format("02 Jan 2006"),
+				lot.Quantity, lot.UoM, lot.CostValue)
+			if err := helpers.SendNotificationAuto("near_expiry", title, msg, metadata); err != nil {
+				log.Printf("[ExpiryMonitor] failed to send notif for lot %s: %v\n", lot.LotID, err)
+				continue
+			}
+			sent++
+		case lot.DaysLeft == 0:
+			title := fmt.Sprintf("Stok Expired: %s", lot.ItemName)
+			msg := fmt.Sprintf("Lot %s di %s expired hari ini (%s). Qty: %d %s, nilai: Rp %d. Jangan dijual, pindahkan ke karantina.",
+				lot.LotNumber, lot.WarehouseName, expiredAt.Format("02 Jan 2006"),
+				lot.Quantity, lot.UoM, lot.CostValue)
+			if err := helpers.SendNotificationAuto("expired_stock", title, msg, metadata); err != nil {
+				log.Printf("[ExpiryMonitor] failed to send notif for lot %s: %v\n", lot.LotID, err)
+				continue
+			}
+			sent++
+		case lot.DaysLeft < 0:
+			staleLots++
+			staleQty += lot.Quantity
+			staleValue += lot.CostValue
+		}
+	}
+
+	if staleLots > 0 {
+		title := "Stok Expired Masih di Gudang"
+		msg := fmt.Sprintf("%d lot expired (total qty %d, nilai Rp %d) masih tercatat sebagai stok. Segera lakukan pemusnahan/retur.",
+			staleLots, staleQty, staleValue)
+		metadata := map[string]interface{}{
+			"expired_lots": staleLots,
+			"quantity":     staleQty,
+			"cost_value":   staleValue,
+			"generated_at": time.Now().In(loc).Format(time.RFC3339),
+		}
+		if err := helpers.SendNotificationAuto("expired_stock", title, msg, metadata); err != nil {
+			log.Printf("[ExpiryMonitor] failed to send expired summary: %v\n", err)
+		} else {
+			sent++
+		}
+	}
+
+	log.Printf("[ExpiryMonitor] Sent %d expiry notifications (%d lots scanned)\n", sent, len(report.Lots))
+	return nil
+}
@@ -4,5 +4,9 @@ import "time"
 
 func StartAll(loc *time.Location) {
 	StartConsignmentDueReminderScheduler(loc)
+	StartExpiryMonitorScheduler(loc)
 	StartDatabaseBackupScheduler(loc)
+	StartSalesQuotationExpiryScheduler(loc)
+	StartPayableDueReminderScheduler(loc)
+	StartDunningReminderScheduler(loc)
 }
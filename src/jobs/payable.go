@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+)
+
+func StartPayableDueReminderScheduler(loc *time.Location) {
+	go func() {
+		for {
+			now := time.Now().In(loc)
+			nextRun := time.Date(now.Year(), now.Month(), now.Day(), 8, 0, 0, 0, loc)
+			if !now.Before(nextRun) {
+				nextRun = nextRun.Add(24 * time.Hour)
+			}
+
+			d := time.Until(nextRun)
+			log.Printf("[PayableDue] Sleep until %s (in %s)\n", nextRun.Format(time.RFC3339), d)
+			time.Sleep(d)
+
+			if err := runPayableDueReminder(loc); err != nil {
+				log.Printf("[PayableDue] ERROR: %v\n", err)
+			}
+		}
+	}()
+}
+
+// runPayableDueReminder memberi tahu finance PO belum lunas yang jatuh tempo hari ini s/d 3 hari lagi.
+func runPayableDueReminder(loc *time.Location) error {
+	apRepo := repositories.NewAccountsPayableRepository(configs.DB)
+
+	now := time.Now().In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 3).Add(24*time.Hour - time.Nanosecond)
+
+	pos, err := apRepo.FindPayablesDueBetween(nil, start, end)
+	if err != nil {
+		return fmt.Errorf("query purchase orders: %w", err)
+	}
+	if len(pos) == 0 {
+		log.Println("[PayableDue] No payables in window")
+		return nil
+	}
+
+	for _, po := range pos {
+		if po.DueDate == nil {
+			continue
+		}
+		dueDate := po.DueDate.In(loc)
+		daysLeft := int(time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, loc).Sub(start) / (24 * time.Hour))
+		outstanding := po.TotalAmount - po.PaidAmount
+
+		title := fmt.Sprintf("Pengingat Pembayaran PO: %s", po.PONumber)
+		var when string
+		switch daysLeft {
+		case 0:
+			when = "Jatuh tempo hari ini"
+		default:
+			when = fmt.Sprintf("Jatuh tempo %d hari lagi", daysLeft)
+		}
+
+		msg := fmt.Sprintf("%s (Due: %s). Supplier: %s, sisa tagihan: Rp %d.",
+			when, dueDate.Format("02 Jan 2006"), po.Supplier.Name, outstanding)
+
+		metadata := map[string]interface{}{
+			"purchase_order_id": po.ID.String(),
+			"po_number":         po.PONumber,
+			"supplier_id":       po.SupplierID.String(),
+			"supplier_name":     po.Supplier.Name,
+			"due_date":          dueDate.Format(time.RFC3339),
+			"days_left":         daysLeft,
+			"outstanding":       outstanding,
+			"payment_status":    po.PaymentStatus,
+		}
+
+		if err := helpers.SendNotificationAuto("po_payment_due", title, msg, metadata); err != nil {
+			log.Printf("[PayableDue] failed to send notif for PO %s: %v\n", po.ID, err)
+		}
+	}
+
+	log.Printf("[PayableDue] Sent reminders for %d purchase orders\n", len(pos))
+	return nil
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/services"
+)
+
+func StartSalesQuotationExpiryScheduler(loc *time.Location) {
+	go func() {
+		for {
+			now := time.Now().In(loc)
+			nextRun := time.Date(now.Year(), now.Month(), now.Day(), 0, 30, 0, 0, loc)
+			if !now.Before(nextRun) {
+				nextRun = nextRun.Add(24 * time.Hour)
+			}
+
+			d := time.Until(nextRun)
+			log.Printf("[QuotationExpiry] Sleep until %s (in %s)\n", nextRun.Format(time.RFC3339), d)
+			time.Sleep(d)
+
+			if err := runSalesQuotationExpiry(loc); err != nil {
+				log.Printf("[QuotationExpiry] ERROR: %v\n", err)
+			}
+		}
+	}()
+}
+
+// runSalesQuotationExpiry menandai quotation Draft/Sent yang lewat masa berlaku sebagai Expired
+// dan memberi tahu pembuatnya agar bisa direvisi.
+func runSalesQuotationExpiry(loc *time.Location) error {
+	quotationService := services.NewSalesQuotationService(
+		repositories.NewSalesQuotationRepository(configs.DB),
+		repositories.NewSalesOrderRepository(configs.DB),
+		repositories.NewSalesPersonRepository(configs.DB),
+		repositories.NewCustomerRepository(configs.DB),
+		repositories.NewItemRepository(configs.DB),
+		repositories.NewPaymentRepository(configs.DB),
+		repositories.NewItemHistoryRepository(configs.DB),
+		repositories.NewItemLotRepository(configs.DB),
+		repositories.NewItemStockRepository(configs.DB),
+		repositories.NewWarehouseRepository(configs.DB),
+		repositories.NewNumberSequenceRepository(configs.DB),
+		repositories.NewShipmentRepository(configs.DB),
+		repositories.NewCreditOverrideRepository(configs.DB),
+		repositories.NewPriceListRepository(configs.DB),
+	)
+
+	expired, err := quotationService.ExpireSalesQuotations(time.Now().In(loc))
+	if err != nil {
+		return fmt.Errorf("expire quotations: %w", err)
+	}
+	if len(expired) == 0 {
+		log.Println("[QuotationExpiry] No quotations to expire")
+		return nil
+	}
+
+	for _, q := range expired {
+		if q.CreatedBy == nil {
+			continue
+		}
+		metadata := map[string]interface{}{
+			"sales_quotation_id": q.ID.String(),
+			"quotation_number":   q.QuotationNumber,
+			"revision":           q.Revision,
+			"valid_until":        q.ValidUntil.In(loc).Format(time.RFC3339),
+		}
+		if err := helpers.SendNotificationToUser(
+			*q.CreatedBy,
+			"quotation_expired",
+			"Quotation Expired",
+			fmt.Sprintf("Quotation %s (rev. %d) expired on %s", q.QuotationNumber, q.Revision, q.ValidUntil.In(loc).Format("02 Jan 2006")),
+			metadata,
+		); err != nil {
+			log.Printf("[QuotationExpiry] failed to notify creator of %s: %v\n", q.QuotationNumber, err)
+		}
+	}
+
+	log.Printf("[QuotationExpiry] Marked %d quotation(s) as expired\n", len(expired))
+	return nil
+}
@@ -1,13 +1,17 @@
 package middlewares
 
 import (
+	"errors"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
 	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
 func JWTProtected(ctx *fiber.Ctx) error {
@@ -22,26 +26,78 @@ func JWTProtected(ctx *fiber.Ctx) error {
 		return helpers.Response(ctx, fiber.StatusUnauthorized, "Invalid Authorization format", nil)
 	}
 
-	tokenStr := parts[1]
+	return authenticateToken(ctx, parts[1])
+}
+
+// WebSocketAuth memvalidasi handshake /ws dengan aturan yang sama seperti JWTProtected.
+// Browser tidak bisa mengirim header Authorization saat upgrade, jadi token juga diterima
+// lewat query ?token= .
+func WebSocketAuth(ctx *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	tokenStr := strings.TrimSpace(ctx.Query("token"))
+	if tokenStr == "" {
+		if parts := strings.Split(ctx.Get("Authorization"), " "); len(parts) == 2 && parts[0] == "Bearer" {
+			tokenStr = parts[1]
+		}
+	}
+	if tokenStr == "" {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Missing access token", nil)
+	}
+
+	return authenticateToken(ctx, tokenStr)
+}
 
+func authenticateToken(ctx *fiber.Ctx, tokenStr string) error {
 	claims, err := helpers.ValidateToken(tokenStr)
 	if err != nil {
 		log.Printf("Invalid token: %v", err)
 		return helpers.Response(ctx, fiber.StatusUnauthorized, "Invalid token", nil)
 	}
 
+	// access token wajib terikat ke session yang masih aktif (logout / reset password / ganti role mencabutnya)
+	if claims.SessionID == uuid.Nil {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Session has been revoked", nil)
+	}
+
+	sessionRepo := repositories.NewUserSessionRepository(configs.DB)
+	session, err := sessionRepo.FindById(nil, claims.SessionID.String())
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserSessionNotFound) {
+			return helpers.Response(ctx, fiber.StatusUnauthorized, "Session has been revoked", nil)
+		}
+		log.Printf("Session fetch error: %v", err)
+		return helpers.Response(ctx, fiber.StatusInternalServerError, "Internal server error", nil)
+	}
+
+	if session.RevokedAt != nil || session.UserID != claims.ID {
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Session has been revoked", nil)
+	}
+
 	userRepo := repositories.NewUserRepository(configs.DB)
-	user, err := userRepo.FindByEmailOrUsername(nil, claims.Email)
+	user, err := userRepo.FindById(nil, claims.ID.String(), false)
 	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return helpers.Response(ctx, fiber.StatusUnauthorized, "User not found", nil)
+		}
 		log.Printf("User fetch error: %v", err)
 		return helpers.Response(ctx, fiber.StatusInternalServerError, "Internal server error", nil)
 	}
 
-	if user == nil {
-		return helpers.Response(ctx, fiber.StatusNotFound, "User not found", nil)
+	if user.RoleID == nil || *user.RoleID != claims.RoleID {
+		now := time.Now()
+		session.RevokedAt = &now
+		session.RevokedReason = "role changed"
+		if _, err := sessionRepo.Update(nil, session); err != nil {
+			log.Printf("Failed to revoke session %s: %v", session.ID, err)
+		}
+		return helpers.Response(ctx, fiber.StatusUnauthorized, "Role has changed, please login again", nil)
 	}
 
 	ctx.Locals("userInfo", user)
+	ctx.Locals("sessionID", session.ID)
 
 	return ctx.Next()
 }
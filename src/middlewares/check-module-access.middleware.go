@@ -38,7 +38,7 @@ func RBACMiddleware(ctx *fiber.Ctx) error {
 	for i := range modules {
 		if matchDynamicPath(requestPath, modules[i].Route) {
 			matchedModule = &modules[i]
-			fmt.Printf("Matched module: %s (ID: %s) with route: %s\n", modules[i].Name, modules[i].ID, modules[i].Route)
+			fmt.Printf("Matched module: %s (ID: %d) with route: %s\n", modules[i].Name, modules[i].ID, modules[i].Route)
 			break
 		}
 	}
@@ -20,6 +20,9 @@ func RunMigration() {
 		&models.Category{},
 		&models.UoM{},
 		&models.Item{},
+		&models.Warehouse{},
+		&models.ItemLot{},
+		&models.ItemStock{},
 		&models.ItemHistory{},
 		&models.Area{},
 		&models.CustomerType{},
@@ -33,6 +36,39 @@ func RunMigration() {
 		&models.SalesOrder{},
 		&models.SalesOrderItem{},
 		&models.Notification{},
+		&models.StockTransfer{},
+		&models.StockTransferItem{},
+		&models.StockTransferLot{},
+		&models.PasswordResetToken{},
+		&models.PasswordResetAudit{},
+		&models.UserSession{},
+		&models.DocumentLink{},
+		&models.SalesReturn{},
+		&models.SalesReturnItem{},
+		&models.StockOpname{},
+		&models.StockOpnameItem{},
+		&models.PurchaseReturn{},
+		&models.PurchaseReturnItem{},
+		&models.GoodsReceipt{},
+		&models.GoodsReceiptItem{},
+		&models.NumberSequence{},
+		&models.NumberSequenceCounter{},
+		&models.PurchaseOrderApprovalRule{},
+		&models.PurchaseOrderApproval{},
+		&models.SalesQuotation{},
+		&models.SalesQuotationItem{},
+		&models.Shipment{},
+		&models.ShipmentItem{},
+		&models.CreditOverride{},
+		&models.PriceList{},
+		&models.PriceListItem{},
+		&models.PaymentRun{},
+		&models.PaymentRunItem{},
+		&models.DunningReminder{},
+		&models.PaymentReceipt{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
+		&models.BankStatementSuggestion{},
 	)
 	
 	var count int64
@@ -113,6 +149,39 @@ func RunMigration() {
 	} else {
 		fmt.Println("Items are already seeded")
 	}
+
+	configs.DB.Model((&models.Warehouse{})).Count(&count)
+	if count == 0 {
+		if err := seeders.SeedWarehouses(configs.DB); err != nil {
+			fmt.Println("Seeding warehouses failed:", err)
+		} else {
+			fmt.Println("Seeding warehouses successful")
+		}
+	} else {
+		fmt.Println("Warehouses are already seeded")
+	}
+
+	configs.DB.Model((&models.ItemLot{})).Count(&count)
+	if count == 0 {
+		if err := seeders.SeedItemLots(configs.DB); err != nil {
+			fmt.Println("Seeding item lots failed:", err)
+		} else {
+			fmt.Println("Seeding item lots successful")
+		}
+	} else {
+		fmt.Println("Item lots are already seeded")
+	}
+
+	configs.DB.Model((&models.ItemStock{})).Count(&count)
+	if count == 0 {
+		if err := seeders.SeedItemStocks(configs.DB); err != nil {
+			fmt.Println("Seeding item stocks failed:", err)
+		} else {
+			fmt.Println("Seeding item stocks successful")
+		}
+	} else {
+		fmt.Println("Item stocks are already seeded")
+	}
 	
 	configs.DB.Model((&models.Area{})).Count(&count)
 	if count == 0 {
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Bucket umur hutang sama dengan piutang (ARBucket*); PO yang belum jatuh tempo masuk 0-30.
+
+type APAgingRequest struct {
+	AsOf       string `query:"as_of"` // YYYY-MM-DD, kosong = hari ini
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid"`
+	Search     string `query:"search"` // nama / kode supplier, nomor PO
+}
+
+type APAgingPayable struct {
+	PurchaseOrderID uuid.UUID  `json:"purchase_order_id"`
+	PONumber        string     `json:"po_number"`
+	PODate          time.Time  `json:"po_date"`
+	TermOfPayment   string     `json:"term_of_payment"`
+	DueDate         *time.Time `json:"due_date"`
+	TotalAmount     int        `json:"total_amount"`
+	PaidAmount      int        `json:"paid_amount"`
+	Outstanding     int        `json:"outstanding"`
+	DaysOverdue     int        `json:"days_overdue"` // <= 0 = belum jatuh tempo
+	Bucket          string     `json:"bucket"`
+}
+
+type APAgingSupplier struct {
+	SupplierID   uuid.UUID        `json:"supplier_id"`
+	SupplierName string           `json:"supplier_name"`
+	SupplierCode string           `json:"supplier_code"`
+	Buckets      ARAgingBuckets   `json:"buckets"`
+	Payables     []APAgingPayable `json:"payables"`
+}
+
+type ResponseAPAging struct {
+	AsOf      time.Time         `json:"as_of"`
+	Suppliers []APAgingSupplier `json:"suppliers"`
+	Totals    ARAgingBuckets    `json:"totals"`
+}
+
+type APForecastRequest struct {
+	StartDate  string `query:"start_date"`                             // YYYY-MM-DD, kosong = hari ini
+	Days       int    `query:"days" validate:"omitempty,min=1,max=90"` // kosong = 7 hari
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid"`
+}
+
+// APForecastDay adalah rencana kas keluar pada satu tanggal jatuh tempo.
+type APForecastDay struct {
+	Date     time.Time        `json:"date"`
+	Amount   int              `json:"amount"`
+	Payables []APAgingPayable `json:"payables"`
+}
+
+type ResponseAPForecast struct {
+	StartDate     time.Time        `json:"start_date"`
+	EndDate       time.Time        `json:"end_date"`
+	OverdueAmount int              `json:"overdue_amount"` // sudah lewat jatuh tempo sebelum start_date
+	Overdue       []APAgingPayable `json:"overdue"`
+	Days          []APForecastDay  `json:"days"`
+	TotalDue      int              `json:"total_due"`      // jatuh tempo dalam rentang
+	TotalCashOut  int              `json:"total_cash_out"` // overdue + jatuh tempo dalam rentang
+}
+
+// PaymentRun mengelompokkan beberapa pembayaran PO yang dibayarkan bersamaan.
+// Proposed -> Executed (Payment dibuat per baris) atau Cancelled.
+type PaymentRun struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	RunNumber     string     `gorm:"uniqueIndex;not null" json:"run_number"`
+	ScheduledDate time.Time  `gorm:"not null" json:"scheduled_date"`
+	PaymentMethod string     `json:"payment_method"`
+	Status        string     `gorm:"not null;default:'Proposed'" json:"status"` // Proposed, Executed, Cancelled
+	TotalAmount   int        `gorm:"not null;default:0" json:"total_amount"`
+	Notes         string     `json:"notes"`
+	CreatedBy     *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	ExecutedBy    *uuid.UUID `gorm:"type:uuid" json:"executed_by"`
+	ExecutedAt    *time.Time `json:"executed_at"`
+	CancelledAt   *time.Time `json:"cancelled_at"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	CreatedByUser  *User            `gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"created_by_user,omitempty"`
+	ExecutedByUser *User            `gorm:"foreignKey:ExecutedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"executed_by_user,omitempty"`
+	Items          []PaymentRunItem `gorm:"foreignKey:PaymentRunID;constraint:OnDelete:CASCADE;" json:"items,omitempty"`
+}
+
+type PaymentRunItem struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PaymentRunID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"payment_run_id"`
+	PurchaseOrderID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_id"`
+	SupplierID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	DueDate         *time.Time `json:"due_date"`
+	Amount          int        `gorm:"not null" json:"amount"`
+	PaymentID       *uuid.UUID `gorm:"type:uuid" json:"payment_id"` // terisi saat run dieksekusi
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PurchaseOrder PurchaseOrder `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order"`
+	Supplier      Supplier      `gorm:"foreignKey:SupplierID" json:"supplier"`
+	Payment       *Payment      `gorm:"foreignKey:PaymentID" json:"payment,omitempty"`
+}
+
+type PaymentRunProposalRequest struct {
+	DueUntil   string `query:"due_until"` // YYYY-MM-DD, kosong = 7 hari dari hari ini
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid"`
+}
+
+// PaymentRunProposalSupplier mengelompokkan usulan pembayaran per supplier (satu transfer per supplier).
+type PaymentRunProposalSupplier struct {
+	SupplierID   uuid.UUID        `json:"supplier_id"`
+	SupplierName string           `json:"supplier_name"`
+	Amount       int              `json:"amount"`
+	Payables     []APAgingPayable `json:"payables"`
+}
+
+type ResponsePaymentRunProposal struct {
+	DueUntil    time.Time                    `json:"due_until"`
+	Suppliers   []PaymentRunProposalSupplier `json:"suppliers"`
+	TotalAmount int                          `json:"total_amount"`
+}
+
+type PaymentRunItemRequest struct {
+	PurchaseOrderID uuid.UUID `json:"purchase_order_id" validate:"required"`
+	Amount          int       `json:"amount" validate:"min=0"` // 0 = seluruh sisa tagihan
+}
+
+type PaymentRunCreateRequest struct {
+	ScheduledDate time.Time               `json:"scheduled_date" validate:"required"`
+	PaymentMethod string                  `json:"payment_method"`
+	Notes         string                  `json:"notes"`
+	Items         []PaymentRunItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PaymentRunExecuteRequest struct {
+	PaymentDate     *time.Time `json:"payment_date"`     // kosong = scheduled_date
+	ReferenceNumber string     `json:"reference_number"` // kosong = nomor payment run
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bucket umur piutang (hari lewat jatuh tempo). Invoice yang belum jatuh tempo masuk 0-30.
+const (
+	ARBucket0To30  = "0-30"
+	ARBucket31To60 = "31-60"
+	ARBucket61To90 = "61-90"
+	ARBucketOver90 = "90+"
+)
+
+type ARAgingRequest struct {
+	AsOf          string `query:"as_of"` // YYYY-MM-DD, kosong = hari ini
+	AreaID        string `query:"area_id" validate:"omitempty,uuid"`
+	SalesPersonID string `query:"sales_person_id" validate:"omitempty,uuid"`
+	CustomerID    string `query:"customer_id" validate:"omitempty,uuid"`
+	Search        string `query:"search"` // nama / nomor customer
+}
+
+type ARAgingBuckets struct {
+	Days0To30  int `json:"days_0_30"`
+	Days31To60 int `json:"days_31_60"`
+	Days61To90 int `json:"days_61_90"`
+	Over90     int `json:"over_90"`
+	Total      int `json:"total"`
+}
+
+// Add menambahkan outstanding ke bucket yang sesuai.
+func (b *ARAgingBuckets) Add(bucket string, amount int) {
+	switch bucket {
+	case ARBucket0To30:
+		b.Days0To30 += amount
+	case ARBucket31To60:
+		b.Days31To60 += amount
+	case ARBucket61To90:
+		b.Days61To90 += amount
+	default:
+		b.Over90 += amount
+	}
+	b.Total += amount
+}
+
+type ARAgingInvoice struct {
+	SalesOrderID    uuid.UUID  `json:"sales_order_id"`
+	SONumber        string     `json:"so_number"`
+	SalesPersonName string     `json:"sales_person_name"`
+	SODate          time.Time  `json:"so_date"`
+	DueDate         *time.Time `json:"due_date"`
+	TotalAmount     int        `json:"total_amount"`
+	PaidAmount      int        `json:"paid_amount"`
+	Outstanding     int        `json:"outstanding"`
+	DaysOverdue     int        `json:"days_overdue"` // <= 0 = belum jatuh tempo
+	Bucket          string     `json:"bucket"`
+}
+
+type ARAgingCustomer struct {
+	CustomerID    uuid.UUID        `json:"customer_id"`
+	CustomerName  string           `json:"customer_name"`
+	CustomerNomor string           `json:"customer_nomor"`
+	AreaName      string           `json:"area_name"`
+	Buckets       ARAgingBuckets   `json:"buckets"`
+	Invoices      []ARAgingInvoice `json:"invoices"`
+}
+
+type ResponseARAging struct {
+	AsOf      time.Time         `json:"as_of"`
+	Customers []ARAgingCustomer `json:"customers"`
+	Totals    ARAgingBuckets    `json:"totals"`
+}
+
+type CustomerStatementRequest struct {
+	StartDate string `query:"start_date"` // YYYY-MM-DD, kosong = awal bulan ini
+	EndDate   string `query:"end_date"`   // YYYY-MM-DD, kosong = hari ini
+}
+
+// StatementLine adalah satu mutasi di rekening koran customer.
+type StatementLine struct {
+	Date      time.Time `json:"date"`
+	Type      string    `json:"type"` // Invoice, Payment, Refund, Reversal, CreditNote
+	Reference string    `json:"reference"`
+	SONumber  string    `json:"so_number"`
+	Debit     int       `json:"debit"`
+	Credit    int       `json:"credit"`
+	Balance   int       `json:"balance"` // saldo berjalan setelah baris ini
+}
+
+type ResponseCustomerStatement struct {
+	Customer       Customer        `json:"customer"`
+	StartDate      time.Time       `json:"start_date"`
+	EndDate        time.Time       `json:"end_date"`
+	OpeningBalance int             `json:"opening_balance"`
+	Lines          []StatementLine `json:"lines"`
+	TotalDebit     int             `json:"total_debit"`
+	TotalCredit    int             `json:"total_credit"`
+	ClosingBalance int             `json:"closing_balance"`
+}
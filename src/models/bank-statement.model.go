@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Status baris mutasi bank dalam antrian rekonsiliasi.
+const (
+	BankLineUnmatched   = "Unmatched"   // tidak ada kandidat order
+	BankLineSuggested   = "Suggested"   // ada kandidat, perlu dipilih manual
+	BankLineAutoMatched = "AutoMatched" // satu kandidat dengan skor tinggi, tinggal dikonfirmasi
+	BankLineConfirmed   = "Confirmed"   // payment sudah dibuat
+	BankLineIgnored     = "Ignored"     // bukan pembayaran order (biaya admin, bunga, dll.)
+)
+
+// BankStatement adalah satu file mutasi rekening yang diimpor.
+type BankStatement struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Bank          string     `gorm:"not null" json:"bank"` // nama parser: generic, bca, mandiri, bni, bri
+	AccountNumber string     `json:"account_number"`
+	Filename      string     `json:"filename"`
+	PeriodStart   *time.Time `json:"period_start"`
+	PeriodEnd     *time.Time `json:"period_end"`
+	TotalLines    int        `gorm:"not null;default:0" json:"total_lines"`
+	SkippedLines  int        `gorm:"not null;default:0" json:"skipped_lines"` // sudah pernah diimpor
+	ImportedBy    *uuid.UUID `gorm:"type:uuid" json:"imported_by"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	ImportedByUser *User               `gorm:"foreignKey:ImportedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"imported_by_user,omitempty"`
+	Lines          []BankStatementLine `gorm:"foreignKey:BankStatementID;constraint:OnDelete:CASCADE;" json:"lines,omitempty"`
+}
+
+// BankStatementLine adalah satu mutasi. Credit dicocokkan ke SO (uang masuk), Debit ke PO (uang keluar).
+type BankStatementLine struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	BankStatementID uuid.UUID  `gorm:"type:uuid;not null;index" json:"bank_statement_id"`
+	LineNo          int        `gorm:"not null" json:"line_no"`
+	TransactionDate time.Time  `gorm:"not null;index" json:"transaction_date"`
+	Description     string     `json:"description"`
+	Reference       string     `json:"reference"`
+	Amount          int        `gorm:"not null" json:"amount"`
+	Direction       string     `gorm:"not null" json:"direction"` // Credit, Debit
+	Balance         *int       `json:"balance"`
+	Fingerprint     string     `gorm:"uniqueIndex;not null" json:"-"` // mencegah mutasi yang sama diimpor dua kali
+	Status          string     `gorm:"not null;default:'Unmatched';index" json:"status"`
+	ReceiptID       *uuid.UUID `gorm:"type:uuid" json:"receipt_id"` // terisi setelah dikonfirmasi
+	ConfirmedBy     *uuid.UUID `gorm:"type:uuid" json:"confirmed_by"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	IgnoreReason    string     `json:"ignore_reason"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	BankStatement *BankStatement            `gorm:"foreignKey:BankStatementID" json:"bank_statement,omitempty"`
+	Receipt       *PaymentReceipt           `gorm:"foreignKey:ReceiptID" json:"receipt,omitempty"`
+	Suggestions   []BankStatementSuggestion `gorm:"foreignKey:LineID;constraint:OnDelete:CASCADE;" json:"suggestions,omitempty"`
+}
+
+// BankStatementSuggestion adalah kandidat order untuk satu mutasi beserta skor kecocokannya.
+type BankStatementSuggestion struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	LineID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"line_id"`
+	OrderType       string     `gorm:"not null" json:"order_type"` // SO, PO
+	SalesOrderID    *uuid.UUID `gorm:"type:uuid" json:"sales_order_id,omitempty"`
+	PurchaseOrderID *uuid.UUID `gorm:"type:uuid" json:"purchase_order_id,omitempty"`
+	OrderNumber     string     `json:"order_number"`
+	PartyName       string     `json:"party_name"`
+	Outstanding     int        `json:"outstanding"`
+	Score           int        `gorm:"not null" json:"score"` // 0-100
+	Reasons         string     `json:"reasons"`               // mis. "reference, amount"
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BankStatementConfirmRequest struct {
+	CustomerID    *uuid.UUID                 `json:"customer_id"`    // hanya bila seluruh mutasi dicatat sebagai deposit customer
+	SupplierID    *uuid.UUID                 `json:"supplier_id"`    // hanya bila seluruh mutasi dicatat sebagai deposit supplier
+	PaymentMethod string                     `json:"payment_method"` // kosong = Transfer
+	Notes         string                     `json:"notes"`
+	Allocations   []PaymentAllocationRequest `json:"allocations" validate:"omitempty,dive"` // kosong = kandidat teratas
+}
+
+type BankStatementIgnoreRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
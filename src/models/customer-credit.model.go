@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditOverride adalah jejak audit setiap SO yang tetap diproses walau customer
+// sedang credit hold (melewati batas kredit atau punya tagihan lewat jatuh tempo).
+type CreditOverride struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	SalesOrderID   uuid.UUID `gorm:"type:uuid;not null;index" json:"sales_order_id"`
+	CustomerID     uuid.UUID `gorm:"type:uuid;not null;index" json:"customer_id"`
+	Stage          string    `gorm:"size:20;not null" json:"stage"` // Create, Confirm
+	OrderAmount    int       `gorm:"not null" json:"order_amount"`
+	Outstanding    int       `gorm:"not null" json:"outstanding"` // piutang terbuka sebelum SO ini
+	CreditLimit    int       `gorm:"not null" json:"credit_limit"`
+	OverdueDays    int       `gorm:"not null;default:0" json:"overdue_days"`
+	MaxOverdueDays int       `gorm:"not null;default:0" json:"max_overdue_days"`
+	HoldReasons    string    `gorm:"not null" json:"hold_reasons"`
+	Reason         string    `gorm:"not null" json:"reason"`
+	ApprovedBy     uuid.UUID `gorm:"type:uuid;not null" json:"approved_by"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	SalesOrder     *SalesOrder `gorm:"foreignKey:SalesOrderID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"sales_order,omitempty"`
+	Customer       *Customer   `gorm:"foreignKey:CustomerID;references:ID" json:"customer,omitempty"`
+	ApprovedByUser *User       `gorm:"foreignKey:ApprovedBy;references:ID" json:"approved_by_user,omitempty"`
+}
+
+// ResponseGetCustomerCredit merangkum posisi kredit customer saat ini.
+type ResponseGetCustomerCredit struct {
+	CustomerID     uuid.UUID `json:"customer_id"`
+	CustomerName   string    `json:"customer_name"`
+	CreditLimit    int       `json:"credit_limit"`     // 0 = tanpa batas
+	MaxOverdueDays int       `json:"max_overdue_days"` // 0 = tanpa batas
+	Outstanding    int       `json:"outstanding"`      // SUM(total_amount - paid_amount) SO terbuka
+	Available      *int      `json:"available"`        // nil bila tanpa batas
+	OverdueAmount  int       `json:"overdue_amount"`
+	OverdueDays    int       `json:"overdue_days"` // umur tagihan tertua yang lewat jatuh tempo
+	OpenOrders     int       `json:"open_orders"`
+	OnHold         bool      `json:"on_hold"`
+	HoldReasons    []string  `json:"hold_reasons,omitempty"`
+}
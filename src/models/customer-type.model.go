@@ -13,6 +13,10 @@ type CustomerType struct {
 	Color string `gorm:"size:20" json:"color"`
 	Description *string `json:"description"`
 
+	// batas kredit default untuk customer bertipe ini (0 = tanpa batas)
+	CreditLimit    int `gorm:"not null;default:0" json:"credit_limit"`
+	MaxOverdueDays int `gorm:"not null;default:0" json:"max_overdue_days"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -25,6 +29,8 @@ type ResponseGetCustomerType struct {
 	Name  string    `json:"name"`
 	Color string    `json:"color"`
 	Description *string `json:"description"`
+	CreditLimit    int `json:"credit_limit"`
+	MaxOverdueDays int `json:"max_overdue_days"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -35,6 +41,8 @@ type CustomerTypeCreateRequest struct {
 	Name  string `json:"name"`
 	Color string `json:"color"`
 	Description *string `json:"description"`
+	CreditLimit    *int `json:"credit_limit" validate:"omitempty,min=0"`
+	MaxOverdueDays *int `json:"max_overdue_days" validate:"omitempty,min=0"`
 }
 
 type CustomerTypeIsHardDeleteRequest struct {
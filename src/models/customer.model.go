@@ -20,6 +20,10 @@ type Customer struct {
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
 
+	// override batas kredit per customer; nil = ikut CustomerType (0 = tanpa batas)
+	CreditLimit    *int `json:"credit_limit,omitempty"`
+	MaxOverdueDays *int `json:"max_overdue_days,omitempty"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -39,6 +43,8 @@ type ResponseGetCustomer struct {
 	Email     *string  `json:"email,omitempty"`
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
+	CreditLimit    *int `json:"credit_limit,omitempty"`
+	MaxOverdueDays *int `json:"max_overdue_days,omitempty"`
 
 	CustomerType CustomerType `json:"customer_type"`
 	Area         Area `json:"area"`
@@ -58,6 +64,8 @@ type CustomerCreateRequest struct {
 	Email     *string  `json:"email,omitempty"`
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
+	CreditLimit    *int `json:"credit_limit,omitempty" validate:"omitempty,min=-1"`      // -1 = hapus override (ikut CustomerType)
+	MaxOverdueDays *int `json:"max_overdue_days,omitempty" validate:"omitempty,min=-1"`
 }
 
 type CustomerIsHardDeleteRequest struct {
@@ -67,4 +75,17 @@ type CustomerIsHardDeleteRequest struct {
 
 type CustomerRestoreRequest struct {
 	IDs []uuid.UUID `json:"ids" validate:"required,dive,required"`
-}
\ No newline at end of file
+}
+
+// EffectiveCreditLimit mengembalikan batas kredit & maksimal hari lewat jatuh tempo
+// yang berlaku: override customer bila diisi, selain itu default dari CustomerType.
+func (c Customer) EffectiveCreditLimit() (limit int, maxOverdueDays int) {
+	limit, maxOverdueDays = c.CustomerType.CreditLimit, c.CustomerType.MaxOverdueDays
+	if c.CreditLimit != nil {
+		limit = *c.CreditLimit
+	}
+	if c.MaxOverdueDays != nil {
+		maxOverdueDays = *c.MaxOverdueDays
+	}
+	return limit, maxOverdueDays
+}
@@ -12,4 +12,12 @@ type DashboardSummary struct {
 	LowStockItems MetricWithChange[int64]   `json:"low_stock_items"`
 	ActiveOrders  MetricWithChange[int64]   `json:"active_orders"`
 	TotalValue    MetricWithChange[float64] `json:"total_value"`
+	Stock         StockAvailabilitySummary  `json:"stock"`
+}
+
+// StockAvailabilitySummary: available = on_hand - reserved (reserved = SO Confirmed/Shipped yang belum Delivered)
+type StockAvailabilitySummary struct {
+	OnHand    int64 `json:"on_hand"`
+	Reserved  int64 `json:"reserved"`
+	Available int64 `json:"available"`
 }
\ No newline at end of file
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DocumentTypeDeliveryOrder    = "so_delivery_order"
+	DocumentTypeInvoice          = "so_invoice"
+	DocumentTypeReceipt          = "so_receipt"
+	DocumentTypePurchaseOrder    = "po_document"
+	DocumentTypeSalesReportPDF   = "sales_report_pdf"
+	DocumentTypeSalesReportExcel = "sales_report_excel"
+)
+
+// DocumentLink adalah link dokumen yang bisa dibuka tanpa login (mis. dikirim ke customer).
+// URL-nya ditandatangani HMAC (id + expires); baris ini menyimpan status one-time & revoke.
+type DocumentLink struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	DocumentType   string     `gorm:"size:50;not null;index" json:"document_type"`
+	ResourceID     *uuid.UUID `gorm:"type:uuid;index" json:"resource_id"`
+	Query          string     `gorm:"type:text" json:"query"` // query string filter laporan excel
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	OneTime        bool       `gorm:"default:false" json:"one_time"`
+	UsedAt         *time.Time `json:"used_at"`
+	AccessCount    int        `gorm:"default:0" json:"access_count"`
+	LastAccessedIP string     `json:"last_accessed_ip"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedBy      uuid.UUID  `gorm:"type:uuid;not null;index" json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ResponseGetDocumentLink struct {
+	ID           uuid.UUID  `json:"id"`
+	DocumentType string     `json:"document_type"`
+	ResourceID   *uuid.UUID `json:"resource_id"`
+	Query        string     `json:"query,omitempty"`
+	URL          string     `json:"url"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	OneTime      bool       `json:"one_time"`
+	UsedAt       *time.Time `json:"used_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type DocumentLinkCreateRequest struct {
+	DocumentType     string     `json:"document_type" validate:"required,oneof=so_delivery_order so_invoice so_receipt po_document sales_report_pdf sales_report_excel"`
+	ResourceID       *uuid.UUID `json:"resource_id"`
+	Query            string     `json:"query"` // mis. "period=month&area_id=..." untuk sales_report_excel
+	ExpiresInMinutes int        `json:"expires_in_minutes" validate:"omitempty,min=1"`
+	OneTime          bool       `json:"one_time"`
+}
+
+// DocumentLinkAccessRequest adalah query string pada URL publik.
+type DocumentLinkAccessRequest struct {
+	Expires   int64  `query:"expires" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
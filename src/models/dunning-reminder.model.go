@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DunningReminder mencatat satu pengingat tagihan SO per tahap; unik per (SO, tahap)
+// sehingga job yang berjalan ulang tidak mengirim pengingat yang sama dua kali.
+type DunningReminder struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	SalesOrderID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:ux_dunning_so_stage" json:"sales_order_id"`
+	CustomerID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"customer_id"`
+	Stage          int        `gorm:"not null;uniqueIndex:ux_dunning_so_stage" json:"stage"` // hari lewat jatuh tempo yang memicu tahap ini
+	Level          int        `gorm:"not null" json:"level"`                                 // urutan tahap (1 = pengingat pertama)
+	DaysOverdue    int        `gorm:"not null" json:"days_overdue"`
+	Outstanding    int        `gorm:"not null" json:"outstanding"`
+	EmailTo        string     `json:"email_to"`
+	EmailStatus    string     `gorm:"not null;default:'Pending'" json:"email_status"` // Pending, Sent, Failed, Skipped (customer tanpa email)
+	EmailError     string     `json:"email_error"`
+	NotifiedUserID *uuid.UUID `gorm:"type:uuid" json:"notified_user_id"` // user akun sales person penanggung jawab
+	SentAt         time.Time  `gorm:"not null" json:"sent_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SalesOrder SalesOrder `gorm:"foreignKey:SalesOrderID" json:"sales_order"`
+	Customer   Customer   `gorm:"foreignKey:CustomerID" json:"customer"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GoodsReceipt (GRN) mencatat satu kali kedatangan barang atas PO: surat jalan supplier,
+// penerima, dan qty per baris. Qty yang diterima langsung masuk ke lot saat GRN dibuat.
+type GoodsReceipt struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	GRNumber           string     `gorm:"uniqueIndex;not null" json:"gr_number"`
+	PurchaseOrderID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_id"`
+	SupplierID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	WarehouseID        uuid.UUID  `gorm:"type:uuid;not null" json:"warehouse_id"`
+	ReceiptDate        time.Time  `gorm:"not null" json:"receipt_date"`
+	DeliveryNoteNumber string     `json:"delivery_note_number"` // nomor surat jalan supplier
+	VehicleNumber      string     `json:"vehicle_number"`
+	ReceiverName       string     `json:"receiver_name"` // nama petugas gudang yang menerima
+	ReceivedBy         *uuid.UUID `gorm:"type:uuid" json:"received_by"`
+	TotalReceived      int        `gorm:"not null;default:0" json:"total_received"`
+	TotalRejected      int        `gorm:"not null;default:0" json:"total_rejected"`
+	Notes              string     `json:"notes"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	PurchaseOrder     PurchaseOrder      `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order"`
+	Supplier          Supplier           `gorm:"foreignKey:SupplierID" json:"supplier"`
+	Warehouse         Warehouse          `gorm:"foreignKey:WarehouseID" json:"warehouse"`
+	ReceivedByUser    *User              `gorm:"foreignKey:ReceivedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"received_by_user,omitempty"`
+	GoodsReceiptItems []GoodsReceiptItem `gorm:"foreignKey:GoodsReceiptID;constraint:OnDelete:CASCADE;" json:"goods_receipt_items,omitempty"`
+}
+
+type GoodsReceiptItem struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	GoodsReceiptID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"goods_receipt_id"`
+	PurchaseOrderItemID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_item_id"`
+	ItemID              uuid.UUID  `gorm:"type:uuid;not null" json:"item_id"`
+	ReceivedQuantity    int        `gorm:"not null;default:0" json:"received_quantity"`
+	RejectedQuantity    int        `gorm:"not null;default:0" json:"rejected_quantity"` // ditolak saat bongkar, tidak masuk stok
+	LotNumber           string     `json:"lot_number"`
+	ExpiredAt           *time.Time `json:"expired_at"`
+	LotID               *uuid.UUID `gorm:"type:uuid" json:"lot_id"` // lot tujuan (kosong jika tidak ada qty diterima)
+	Notes               string     `json:"notes"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type ResponseGetGoodsReceipt struct {
+	ID                 uuid.UUID      `json:"id"`
+	GRNumber           string         `json:"gr_number"`
+	PurchaseOrderID    uuid.UUID      `json:"purchase_order_id"`
+	SupplierID         uuid.UUID      `json:"supplier_id"`
+	WarehouseID        uuid.UUID      `json:"warehouse_id"`
+	ReceiptDate        time.Time      `json:"receipt_date"`
+	DeliveryNoteNumber string         `json:"delivery_note_number"`
+	VehicleNumber      string         `json:"vehicle_number"`
+	ReceiverName       string         `json:"receiver_name"`
+	ReceivedBy         *uuid.UUID     `json:"received_by"`
+	TotalReceived      int            `json:"total_received"`
+	TotalRejected      int            `json:"total_rejected"`
+	Notes              string         `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	PurchaseOrder     PurchaseOrder      `json:"purchase_order"`
+	Supplier          Supplier           `json:"supplier"`
+	Warehouse         Warehouse          `json:"warehouse"`
+	ReceivedByUser    *User              `json:"received_by_user,omitempty"`
+	GoodsReceiptItems []GoodsReceiptItem `json:"goods_receipt_items,omitempty"`
+}
@@ -10,7 +10,9 @@ import (
 type ItemHistory struct {
 	ID           uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
 	ItemID       uuid.UUID      `gorm:"type:uuid;not null" json:"item_id"`
-	ChangeType   string         `gorm:"not null" json:"change_type"` // enum: create_price, create_stock, update_stock, update_price,
+	LotID        *uuid.UUID     `gorm:"type:uuid;index" json:"lot_id"` // lot yang tersentuh (khusus perubahan stok)
+	WarehouseID  *uuid.UUID     `gorm:"type:uuid;index" json:"warehouse_id"`
+	ChangeType   string         `gorm:"not null" json:"change_type"` // enum: create_price, create_stock, update_stock, update_price, stock_opname, price_list
 	Description  string         `json:"description"`
 	OldPrice     int            `json:"old_price"`
 	NewPrice     int            `json:"new_price"`
@@ -25,7 +27,9 @@ type ItemHistory struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
-	Item          Item  `gorm:"foreignKey:ItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"item"`
+	Item          Item     `gorm:"foreignKey:ItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"item"`
+	Lot           *ItemLot `gorm:"foreignKey:LotID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"lot,omitempty"`
+	Warehouse     *Warehouse `gorm:"foreignKey:WarehouseID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"warehouse,omitempty"`
 	CreatedByUser *User `gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"created_by_user,omitempty"`
 	UpdatedByUser *User `gorm:"foreignKey:UpdatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"updated_by_user,omitempty"`
 	DeletedByUser *User `gorm:"foreignKey:DeletedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"deleted_by_user,omitempty"`
@@ -34,6 +38,8 @@ type ItemHistory struct {
 type ResponseGetItemHistory struct {
 	ID           uuid.UUID      `json:"id"`
 	ItemID       uuid.UUID      `json:"item_id"`
+	LotID        *uuid.UUID     `json:"lot_id"`
+	WarehouseID  *uuid.UUID     `json:"warehouse_id"`
 	ChangeType   string         `json:"change_type"`
 	Description  string         `json:"description"`
 	OldPrice     int            `json:"old_price"`
@@ -49,7 +55,9 @@ type ResponseGetItemHistory struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
-	Item          Item  `json:"item"`
+	Item          Item     `json:"item"`
+	Lot           *ItemLot `json:"lot,omitempty"`
+	Warehouse     *Warehouse `json:"warehouse,omitempty"`
 	CreatedByUser *User `json:"created_by_user,omitempty"`
 	UpdatedByUser *User `json:"updated_by_user,omitempty"`
 	DeletedByUser *User `json:"deleted_by_user,omitempty"`
@@ -61,6 +69,11 @@ type ItemHistoryCreateRequest struct {
 	NewPrice    int       `json:"new_price"`
 	NewStock    int       `json:"new_stock"`
 	Description string    `json:"description" validate:"required"`
+
+	// opsional untuk perubahan stok: gudang & lot tujuan/sumber penyesuaian
+	WarehouseID *uuid.UUID `json:"warehouse_id"` // kosong = gudang default
+	LotNumber string     `json:"lot_number"`
+	ExpiredAt *time.Time `json:"expired_at"`
 }
 
 type ItemHistoryIsHardDeleteRequest struct {
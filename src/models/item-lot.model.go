@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ItemLot struct {
+	ID                  uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	ItemID              uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:ux_item_lot_number" json:"item_id"`
+	WarehouseID         uuid.UUID      `gorm:"type:uuid;uniqueIndex:ux_item_lot_number;index" json:"warehouse_id"`
+	LotNumber           string         `gorm:"size:100;not null;uniqueIndex:ux_item_lot_number" json:"lot_number"`
+	ExpiredAt           *time.Time     `gorm:"index" json:"expired_at"` // nullable: lot tanpa expiry diambil paling akhir (FEFO)
+	Quantity            int            `gorm:"not null;default:0" json:"quantity"`
+	ReceivedAt          time.Time      `json:"received_at"`
+	PurchaseOrderItemID *uuid.UUID     `gorm:"type:uuid" json:"purchase_order_item_id,omitempty"`
+	Notes               string         `json:"notes"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Item      Item       `gorm:"foreignKey:ItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"item"`
+	Warehouse *Warehouse `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+}
+
+type ResponseGetItemLot struct {
+	ID                  uuid.UUID  `json:"id"`
+	ItemID              uuid.UUID  `json:"item_id"`
+	WarehouseID         uuid.UUID  `json:"warehouse_id"`
+	LotNumber           string     `json:"lot_number"`
+	ExpiredAt           *time.Time `json:"expired_at"`
+	Quantity            int        `json:"quantity"`
+	ReceivedAt          time.Time  `json:"received_at"`
+	PurchaseOrderItemID *uuid.UUID `json:"purchase_order_item_id,omitempty"`
+	Notes               string     `json:"notes"`
+	IsExpired           bool       `json:"is_expired"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	Warehouse *Warehouse `json:"warehouse,omitempty"`
+}
+
+// LotReceipt describes stock coming into a lot (PO receipt, initial stock, adjustment).
+type LotReceipt struct {
+	WarehouseID         uuid.UUID
+	LotNumber           string
+	ExpiredAt           *time.Time
+	Quantity            int
+	PurchaseOrderItemID *uuid.UUID
+	Notes               string
+}
+
+// LotConsumption is one lot touched when stock is taken out in FEFO order.
+type LotConsumption struct {
+	LotID       uuid.UUID  `json:"lot_id"`
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	LotNumber   string     `json:"lot_number"`
+	ExpiredAt   *time.Time `json:"expired_at"`
+	Quantity    int        `json:"quantity"`
+}
+
+// ResponseGetNearExpiryLot adalah satu lot dengan expiry dalam horizon, beserta nilainya at cost.
+type ResponseGetNearExpiryLot struct {
+	LotID         uuid.UUID  `json:"lot_id"`
+	ItemID        uuid.UUID  `json:"item_id"`
+	ItemCode      string     `json:"item_code"`
+	ItemName      string     `json:"item_name"`
+	UoM           string     `json:"uom"`
+	WarehouseID   uuid.UUID  `json:"warehouse_id"`
+	WarehouseName string     `json:"warehouse_name"`
+	LotNumber     string     `json:"lot_number"`
+	ExpiredAt     *time.Time `json:"expired_at"`
+	DaysLeft      int        `json:"days_left"`
+	IsExpired     bool       `json:"is_expired"`
+	Quantity      int        `json:"quantity"`
+	UnitCost      int        `json:"unit_cost"`
+	CostValue     int        `json:"cost_value"`
+	CostSource    string     `json:"cost_source"` // lot_po | last_po | item_price
+}
+
+type NearExpiryReport struct {
+	AsOf          time.Time                  `json:"as_of"`
+	Days          int                        `json:"days"`
+	TotalLots     int                        `json:"total_lots"`
+	TotalQuantity int                        `json:"total_quantity"`
+	TotalValue    int                        `json:"total_value"`
+	ExpiredLots   int                        `json:"expired_lots"`
+	ExpiredValue  int                        `json:"expired_value"`
+	Lots          []ResponseGetNearExpiryLot `json:"lots"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ItemStock adalah saldo stok per item per gudang (= total qty lot di gudang tsb).
+type ItemStock struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ItemID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:ux_item_stock_warehouse" json:"item_id"`
+	WarehouseID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:ux_item_stock_warehouse;index" json:"warehouse_id"`
+	Stock       int       `gorm:"not null;default:0" json:"stock"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Item      Item      `gorm:"foreignKey:ItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"item"`
+	Warehouse Warehouse `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"warehouse"`
+}
+
+type ResponseGetItemStock struct {
+	ID          uuid.UUID `json:"id"`
+	ItemID      uuid.UUID `json:"item_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Stock       int       `json:"stock"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Item      *Item      `json:"item,omitempty"`
+	Warehouse *Warehouse `json:"warehouse,omitempty"`
+}
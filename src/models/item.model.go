@@ -15,11 +15,13 @@ type Item struct {
 		UoMID      uuid.UUID      `gorm:"column:uom_id;type:uuid;not null" json:"uom_id"`
 		Price      int            `gorm:"not null" json:"price"`
 		Stock      int            `gorm:"not null" json:"stock"`
+		ReservedStock int         `gorm:"not null;default:0" json:"reserved_stock"` // qty yang sudah dijanjikan ke SO Confirmed/Shipped
 		LowStock   int            `gorm:"not null" json:"low_stock"`
 		ImageID    *uuid.UUID     `gorm:"type:uuid" json:"image_id,omitempty"`
 		Description string        `json:"description"`
 		Batch       int           `gorm:"default:0" json:"batch"`
 		IsConsignment bool        `gorm:"default:false" json:"is_consignment"`
+		TaxExempt     bool        `gorm:"not null;default:false" json:"tax_exempt"` // bebas PPN (mis. alat kesehatan tertentu)
 		DueDate    *time.Time     `json:"due_date"`
 		ExpiredAt  time.Time     `json:"expired_at"`
 		CreatedAt  time.Time      `json:"created_at"`
@@ -38,6 +40,8 @@ type ResponseGetItem struct {
 	Code        string         `json:"code"`
 	Price       int            `json:"price"`
 	Stock       int            `json:"stock"`
+	ReservedStock  int         `json:"reserved_stock"`
+	AvailableStock int         `json:"available_stock"` // stock - reserved_stock
 	LowStock    int            `json:"low_stock"`
 	ImageID     *uuid.UUID     `json:"image_id,omitempty"`
 	CategoryID  uuid.UUID      `json:"category_id"`
@@ -45,6 +49,7 @@ type ResponseGetItem struct {
 	Description string         `json:"description"`
 	Batch       int            `json:"batch"`
 	IsConsignment bool        `json:"is_consignment"`
+	TaxExempt     bool        `json:"tax_exempt"`
 	DueDate     *time.Time      `json:"due_date"`
 	ExpiredAt   time.Time      `json:"expired_at"`
 
@@ -69,8 +74,10 @@ type ItemCreateRequest struct {
 	Description string         `json:"description" xml:"description" form:"description"`
 	Batch       int            `json:"batch" xml:"batch" form:"batch" validate:"required"`
 	IsConsignment bool        `json:"is_consignment" xml:"is_consignment" form:"is_consignment"`
+	TaxExempt     bool        `json:"tax_exempt" xml:"tax_exempt" form:"tax_exempt"`
 	DueDate     *time.Time      `json:"due_date" xml:"due_date" form:"due_date"`
 	ExpiredAt   time.Time      `json:"expired_at" xml:"expired_at" form:"expired_at" validate:"required"`
+	WarehouseID *uuid.UUID     `json:"warehouse_id" xml:"warehouse_id" form:"warehouse_id"` // gudang stok awal (kosong = gudang default)
 }
 
 type ItemUpdateRequest struct {
@@ -84,6 +91,7 @@ type ItemUpdateRequest struct {
 	Description string         `json:"description" xml:"description" form:"description"`
 	Batch       int            `json:"batch" xml:"batch" form:"batch"`
 	IsConsignment bool        `json:"is_consignment" xml:"is_consignment" form:"is_consignment"`
+	TaxExempt     *bool       `json:"tax_exempt" xml:"tax_exempt" form:"tax_exempt"` // nil = tidak diubah
 	DueDate     *time.Time      `json:"due_date" xml:"due_date" form:"due_date"`
 	ExpiredAt   time.Time      `json:"expired_at" xml:"expired_at" form:"expired_at"`
 }
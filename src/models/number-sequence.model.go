@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jenis dokumen yang nomornya diambil dari number sequence
+const (
+	SequencePurchaseOrder  = "purchase_order"
+	SequenceSalesOrder     = "sales_order"
+	SequenceSalesQuotation = "sales_quotation"
+	SequenceShipment       = "shipment"
+	SequenceStockTransfer  = "stock_transfer"
+	SequenceStockOpname    = "stock_opname"
+	SequenceGoodsReceipt   = "goods_receipt"
+	SequenceSalesReturn    = "sales_return"
+	SequenceCreditNote     = "credit_note"
+	SequencePurchaseReturn = "purchase_return"
+	SequenceDebitNote      = "debit_note"
+	SequenceInvoice        = "invoice"
+	SequencePaymentRun     = "payment_run"
+	SequencePaymentReceipt = "payment_receipt"
+)
+
+// NumberSequence menyimpan format penomoran per jenis dokumen.
+// Token format: {PREFIX}, {YYYY}, {YY}, {MM}, {SEQ} (SEQ di-pad sesuai Padding).
+type NumberSequence struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	DocumentType string     `gorm:"size:50;uniqueIndex;not null" json:"document_type"`
+	Name         string     `gorm:"not null" json:"name"`
+	Prefix       string     `gorm:"not null" json:"prefix"`
+	Format       string     `gorm:"not null" json:"format"`
+	Padding      int        `gorm:"not null;default:4" json:"padding"`
+	ResetPeriod  string     `gorm:"not null;default:'Yearly'" json:"reset_period"` // Never, Yearly, Monthly
+	UpdatedBy    *uuid.UUID `gorm:"type:uuid" json:"updated_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	Counters []NumberSequenceCounter `gorm:"foreignKey:SequenceID;constraint:OnDelete:CASCADE;" json:"counters,omitempty"`
+}
+
+// NumberSequenceCounter adalah counter per periode reset ("" untuk Never, "2026" untuk Yearly,
+// "2026-10" untuk Monthly). Baris ini dikunci FOR UPDATE di transaksi pembuat dokumen,
+// jadi nomor tidak bentrok dan tidak bolong (rollback ikut membatalkan kenaikan counter).
+type NumberSequenceCounter struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	SequenceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_sequence_period" json:"sequence_id"`
+	Period     string    `gorm:"size:10;not null;uniqueIndex:idx_sequence_period" json:"period"`
+	LastValue  int       `gorm:"not null;default:0" json:"last_value"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ResponseGetNumberSequence struct {
+	ID           uuid.UUID  `json:"id"`
+	DocumentType string     `json:"document_type"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"`
+	Format       string     `json:"format"`
+	Padding      int        `json:"padding"`
+	ResetPeriod  string     `json:"reset_period"`
+	CurrentValue int        `json:"current_value"` // nilai terakhir pada periode berjalan
+	NextNumber   string     `json:"next_number"`   // pratinjau, belum dipesan
+	UpdatedBy    *uuid.UUID `json:"updated_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type NumberSequenceUpdateRequest struct {
+	Prefix      string `json:"prefix" validate:"required,max=20"`
+	Format      string `json:"format" validate:"required,max=60"`
+	Padding     int    `json:"padding" validate:"required,min=1,max=10"`
+	ResetPeriod string `json:"reset_period" validate:"required,oneof=Never Yearly Monthly"`
+}
@@ -12,27 +12,46 @@ type PaginationRequest struct {
 	CategoryID string `query:"category_id"` // untuk paginated model item
 	UoMID      string `query:"uom_id"`      // untuk paginated model item
 	Batch 				string `query:"batch"`       // untuk paginated model item
-	ItemID     string `query:"item_id"`     // untuk paginated model item history
+	ItemID     string `query:"item_id"`     // untuk paginated model item history && price list
 	ChangeType string `query:"change_type"` // untuk paginated model item history
 
 	AreaID         string `query:"area_id"`          // untuk paginated model customer && sales report
-	CustomerTypeID string `query:"customer_type_id"` // untuk paginated model customer
+	CustomerTypeID string `query:"customer_type_id"` // untuk paginated model customer && price list
 
-	SupplierID    string `query:"supplier_id"`     // untuk paginated model purchase order
+	SupplierID    string `query:"supplier_id"`     // untuk paginated model purchase order && purchase return && goods receipt && payment receipt
 	POStatus      string `query:"po_status"`       // untuk paginated model purchase order
 	PaymentStatus string `query:"payment_status"`  // untuk paginated model purchase order && sales report
 	TermOfPayment string `query:"term_of_payment"` // untuk paginated model purchase order
 
-	PurchaseOrderID string `query:"purchase_order_id"` // untuk paginated model payment
+	PurchaseOrderID string `query:"purchase_order_id"` // untuk paginated model payment && purchase return && goods receipt
 	PaymentType     string `query:"payment_type"`      // untuk paginated model payment
 
 	SOStatus      string `query:"so_status"`       // untuk paginated model sales order && sales report
-	CustomerID    string `query:"customer_id"`     // untuk paginated model sales order && sales report
-	SalesPersonID string `query:"sales_person_id"` // untuk paginated model sales order && sales report
+	CustomerID    string `query:"customer_id"`     // untuk paginated model sales order && sales report && sales quotation && shipment && credit override && price list && dunning reminder && payment receipt
+	SalesPersonID string `query:"sales_person_id"` // untuk paginated model sales order && sales report && sales quotation && dunning reminder
 
 	Period    string    `query:"period"`     // untuk paginated model sales report
 	StartDate time.Time `query:"start_date"` // untuk paginated model sales report
 	EndDate   time.Time `query:"end_date"`   // untuk paginated model sales report
+
+	WarehouseID    string `query:"warehouse_id"`    // untuk paginated model stock transfer && stock opname && goods receipt && shipment
+	TransferStatus string `query:"transfer_status"` // untuk paginated model stock transfer
+
+	SalesOrderID string `query:"sales_order_id"` // untuk paginated model sales return && shipment && credit override && dunning reminder
+	ReturnStatus string `query:"return_status"`  // untuk paginated model sales return && purchase return
+
+	OpnameStatus string `query:"opname_status"` // untuk paginated model stock opname
+
+	QuotationStatus string `query:"quotation_status"` // untuk paginated model sales quotation
+
+	ShipmentStatus string `query:"shipment_status"` // untuk paginated model shipment
+
+	RunStatus string `query:"run_status"` // untuk paginated model payment run
+
+	OrderType string `query:"order_type"` // untuk paginated model payment receipt
+
+	BankStatementID string `query:"bank_statement_id"` // untuk paginated model bank statement line
+	LineStatus      string `query:"line_status"`       // untuk paginated model bank statement line (kosong = antrian rekonsiliasi, all = semua)
 }
 
 type PaginationResponse struct {
@@ -117,4 +136,78 @@ type SalesReportDetailItemPaginatedResponse struct {
 type UoMPaginatedResponse struct {
 	Data       []ResponseGetUoM `json:"data"`
 	Pagination PaginationResponse `json:"pagination"`
-}
\ No newline at end of file
+}
+type WarehousePaginatedResponse struct {
+	Data       []ResponseGetWarehouse `json:"data"`
+	Pagination PaginationResponse     `json:"pagination"`
+}
+
+type StockTransferPaginatedResponse struct {
+	Data       []ResponseGetStockTransfer `json:"data"`
+	Pagination PaginationResponse         `json:"pagination"`
+}
+
+type SalesReturnPaginatedResponse struct {
+	Data       []ResponseGetSalesReturn `json:"data"`
+	Pagination PaginationResponse       `json:"pagination"`
+}
+
+type StockOpnamePaginatedResponse struct {
+	Data       []ResponseGetStockOpname `json:"data"`
+	Pagination PaginationResponse       `json:"pagination"`
+}
+
+type PurchaseReturnPaginatedResponse struct {
+	Data       []ResponseGetPurchaseReturn `json:"data"`
+	Pagination PaginationResponse          `json:"pagination"`
+}
+
+type GoodsReceiptPaginatedResponse struct {
+	Data       []ResponseGetGoodsReceipt `json:"data"`
+	Pagination PaginationResponse        `json:"pagination"`
+}
+
+type SalesQuotationPaginatedResponse struct {
+	Data       []ResponseGetSalesQuotation `json:"data"`
+	Pagination PaginationResponse          `json:"pagination"`
+}
+
+type ShipmentPaginatedResponse struct {
+	Data       []ResponseGetShipment `json:"data"`
+	Pagination PaginationResponse    `json:"pagination"`
+}
+
+type CreditOverridePaginatedResponse struct {
+	Data       []CreditOverride   `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PriceListPaginatedResponse struct {
+	Data       []PriceList        `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PaymentRunPaginatedResponse struct {
+	Data       []PaymentRun       `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type DunningReminderPaginatedResponse struct {
+	Data       []DunningReminder  `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PaymentReceiptPaginatedResponse struct {
+	Data       []PaymentReceipt   `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type BankStatementPaginatedResponse struct {
+	Data       []BankStatement    `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type BankStatementLinePaginatedResponse struct {
+	Data       []BankStatementLine `json:"data"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
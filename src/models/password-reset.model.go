@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken menyimpan hash (sha256) dari token reset; token asli hanya dikirim via email.
+type PasswordResetToken struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash   string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"` // diisi bila ada request baru sebelum token dipakai
+	RequestedIP string     `json:"requested_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+// PasswordResetAudit mencatat setiap percobaan request & redeem reset password.
+type PasswordResetAudit struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	UserID     *uuid.UUID `gorm:"type:uuid;index" json:"user_id"`
+	Identifier string     `gorm:"index" json:"identifier"`     // lowercase
+	Event      string     `gorm:"not null;index" json:"event"` // requested, unknown_identifier, rate_limited, mail_failed, redeemed, invalid_token
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	Notes      string     `json:"notes"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
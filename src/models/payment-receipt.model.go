@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentReceipt adalah satu transfer (masuk dari customer / keluar ke supplier) yang dialokasikan
+// ke beberapa SO / PO. Tiap alokasi menjadi satu Payment dengan ReceiptID; sisa yang belum
+// dialokasikan (UnallocatedAmount) menjadi deposit yang bisa dipakai untuk order berikutnya.
+type PaymentReceipt struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ReceiptNumber     string     `gorm:"uniqueIndex;not null" json:"receipt_number"`
+	OrderType         string     `gorm:"not null" json:"order_type"` // SO (terima dari customer), PO (bayar ke supplier)
+	CustomerID        *uuid.UUID `gorm:"type:uuid;index" json:"customer_id,omitempty"`
+	SupplierID        *uuid.UUID `gorm:"type:uuid;index" json:"supplier_id,omitempty"`
+	ReceiptDate       time.Time  `gorm:"not null" json:"receipt_date"`
+	Amount            int        `gorm:"not null" json:"amount"`
+	AllocatedAmount   int        `gorm:"not null;default:0" json:"allocated_amount"`
+	UnallocatedAmount int        `gorm:"not null;default:0" json:"unallocated_amount"` // saldo deposit
+	PaymentMethod     string     `json:"payment_method"`
+	ReferenceNumber   string     `gorm:"index" json:"reference_number"`
+	Notes             string     `json:"notes"`
+	CreatedBy         *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Customer      *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Supplier      *Supplier `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	CreatedByUser *User     `gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"created_by_user,omitempty"`
+	Payments      []Payment `gorm:"foreignKey:ReceiptID" json:"payments,omitempty"`
+}
+
+type PaymentAllocationRequest struct {
+	OrderID uuid.UUID `json:"order_id" validate:"required"` // ID SO / PO sesuai order_type receipt
+	Amount  int       `json:"amount" validate:"min=0"`      // 0 = seluruh sisa tagihan (dibatasi saldo receipt)
+}
+
+type PaymentReceiptCreateRequest struct {
+	OrderType       string                     `json:"order_type" validate:"required,oneof=PO SO"`
+	CustomerID      *uuid.UUID                 `json:"customer_id"` // wajib untuk SO
+	SupplierID      *uuid.UUID                 `json:"supplier_id"` // wajib untuk PO
+	ReceiptDate     time.Time                  `json:"receipt_date" validate:"required"`
+	Amount          int                        `json:"amount" validate:"required,min=1"`
+	PaymentMethod   string                     `json:"payment_method"`
+	ReferenceNumber string                     `json:"reference_number"`
+	Notes           string                     `json:"notes"`
+	Allocations     []PaymentAllocationRequest `json:"allocations" validate:"omitempty,dive"` // kosong = seluruhnya jadi deposit
+}
+
+type PaymentReceiptAllocateRequest struct {
+	PaymentDate *time.Time                 `json:"payment_date"` // kosong = hari ini
+	Allocations []PaymentAllocationRequest `json:"allocations" validate:"required,min=1,dive"`
+}
+
+type PaymentDepositRequest struct {
+	OrderType  string `query:"order_type" validate:"omitempty,oneof=PO SO"`
+	CustomerID string `query:"customer_id" validate:"omitempty,uuid"`
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid"`
+}
+
+// PaymentDepositBalance adalah total saldo deposit (receipt belum teralokasi) per customer / supplier.
+type PaymentDepositBalance struct {
+	OrderType string           `json:"order_type"`
+	PartyID   uuid.UUID        `json:"party_id"`
+	PartyName string           `json:"party_name"`
+	Balance   int              `json:"balance"`
+	Receipts  []PaymentReceipt `json:"receipts"`
+}
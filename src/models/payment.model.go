@@ -13,13 +13,21 @@ type Payment struct {
 	PurchaseOrderID *uuid.UUID     `gorm:"type:uuid" json:"purchase_order_id,omitempty"`
 	SalesOrderID    *uuid.UUID     `gorm:"type:uuid" json:"sales_order_id,omitempty"`
 
-	PaymentType     string         `gorm:"not null" json:"payment_type"` // DP, Full, Installment
+	PaymentType     string         `gorm:"not null" json:"payment_type"` // DP, Full, Installment, Refund (amount negatif, dari sales/purchase return), Reversal (amount negatif, pembatalan payment)
 	Amount          int            `gorm:"not null" json:"amount"`
 	PaymentDate     time.Time      `gorm:"not null" json:"payment_date"`
 	PaymentMethod   string         `json:"payment_method"` // Cash, Transfer, etc.
 	ReferenceNumber string         `json:"reference_number"`
 	Notes           string         `json:"notes"`
 	InvoiceID       *uuid.UUID     `gorm:"type:uuid" json:"invoice_id,omitempty"`
+	ReceiptID       *uuid.UUID     `gorm:"type:uuid;index" json:"receipt_id,omitempty"` // terisi bila alokasi dari PaymentReceipt
+	CreatedBy       *uuid.UUID     `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Void: payment asli tetap disimpan dan ditandai, pembatalannya dicatat sebagai entri Reversal
+	ReversalOfID *uuid.UUID `gorm:"type:uuid;index" json:"reversal_of_id,omitempty"` // pada entri Reversal: payment yang dibatalkan
+	VoidedAt     *time.Time `json:"voided_at,omitempty"`
+	VoidedBy     *uuid.UUID `gorm:"type:uuid" json:"voided_by,omitempty"`
+	VoidReason   string     `json:"void_reason,omitempty"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -28,6 +36,7 @@ type Payment struct {
 	PurchaseOrder *PurchaseOrder `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order,omitempty"`
 	SalesOrder    *SalesOrder    `gorm:"foreignKey:SalesOrderID" json:"sales_order,omitempty"`
 	Invoice       *Upload       `gorm:"foreignKey:InvoiceID;constraint:onUpdate:CASCADE,onDelete:SET NULL;" json:"invoice,omitempty"`
+	Receipt       *PaymentReceipt `gorm:"foreignKey:ReceiptID" json:"receipt,omitempty"`
 }
 
 type ResponseGetPayment struct {
@@ -43,6 +52,12 @@ type ResponseGetPayment struct {
 	ReferenceNumber  string         `json:"reference_number"`
 	Notes            string         `json:"notes"`
 	InvoiceID        *uuid.UUID     `json:"invoice_id,omitempty"`
+	ReceiptID        *uuid.UUID     `json:"receipt_id,omitempty"`
+	CreatedBy        *uuid.UUID     `json:"created_by,omitempty"`
+	ReversalOfID     *uuid.UUID     `json:"reversal_of_id,omitempty"`
+	VoidedAt         *time.Time     `json:"voided_at,omitempty"`
+	VoidedBy         *uuid.UUID     `json:"voided_by,omitempty"`
+	VoidReason       string         `json:"void_reason,omitempty"`
 	
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
@@ -51,6 +66,7 @@ type ResponseGetPayment struct {
 	PurchaseOrder    *PurchaseOrder `json:"purchase_order,omitempty"`
 	SalesOrder       *SalesOrder    `json:"sales_order,omitempty"`
 	Invoice          *Upload        `json:"invoice,omitempty"`
+	Receipt          *PaymentReceipt `json:"receipt,omitempty"`
 }
 
 
@@ -64,4 +80,8 @@ type PaymentCreateRequest struct {
 	PaymentMethod   string    `json:"payment_method" form:"payment_method"`
 	ReferenceNumber string    `json:"reference_number" form:"reference_number"`
 	Notes           string    `json:"notes" form:"notes"`
-}
\ No newline at end of file
+}
+
+type PaymentVoidRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderApprovalRule menentukan siapa yang harus menyetujui PO. Rule berlaku bila
+// TotalAmount >= MinAmount (0 = semua nominal) dan, jika CategoryID diisi, PO memuat item
+// dari kategori tsb. Semua rule yang cocok membentuk rantai persetujuan urut Level.
+type PurchaseOrderApprovalRule struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string     `gorm:"not null" json:"name"`
+	MinAmount   int        `gorm:"not null;default:0" json:"min_amount"`
+	CategoryID  *uuid.UUID `gorm:"type:uuid;index" json:"category_id"`
+	RoleID      uuid.UUID  `gorm:"type:uuid;not null" json:"role_id"`
+	Level       int        `gorm:"not null;default:1" json:"level"`
+	IsActive    bool       `gorm:"not null;default:true" json:"is_active"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Category *Category `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"category,omitempty"`
+	Role     *Role     `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"role,omitempty"`
+}
+
+// PurchaseOrderApproval adalah satu langkah persetujuan PO. Setiap pengajuan ulang
+// (setelah Rejected -> Draft) membuat Round baru; round lama disimpan sebagai riwayat.
+type PurchaseOrderApproval struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PurchaseOrderID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_id"`
+	Round           int        `gorm:"not null;default:1" json:"round"`
+	StepOrder       int        `gorm:"not null" json:"step_order"`
+	RoleID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"role_id"`
+	RuleID          *uuid.UUID `gorm:"type:uuid" json:"rule_id"`
+	Status          string     `gorm:"not null;default:'Pending'" json:"status"` // Pending, Approved, Rejected, Skipped
+	RequestedBy     *uuid.UUID `gorm:"type:uuid" json:"requested_by"`
+	ApproverID      *uuid.UUID `gorm:"type:uuid" json:"approver_id"`
+	Comment         string     `json:"comment"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	Role            *Role `gorm:"foreignKey:RoleID;references:ID" json:"role,omitempty"`
+	RequestedByUser *User `gorm:"foreignKey:RequestedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"requested_by_user,omitempty"`
+	Approver        *User `gorm:"foreignKey:ApproverID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"approver,omitempty"`
+}
+
+type PurchaseOrderApprovalRuleRequest struct {
+	Name        string     `json:"name" validate:"required"`
+	MinAmount   int        `json:"min_amount" validate:"min=0"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+	RoleID      uuid.UUID  `json:"role_id" validate:"required"`
+	Level       int        `json:"level" validate:"required,min=1"`
+	IsActive    *bool      `json:"is_active"` // kosong = aktif
+	Description string     `json:"description"`
+}
+
+type PurchaseOrderApprovalDecisionRequest struct {
+	Action  string `json:"action" validate:"required,oneof=Approve Reject"`
+	Comment string `json:"comment" validate:"max=1000"` // wajib saat Reject
+}
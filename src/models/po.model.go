@@ -14,14 +14,22 @@ type PurchaseOrder struct {
 	PODate            time.Time      `gorm:"not null" json:"po_date"`
 	EstimatedArrival  *time.Time     `json:"estimated_arrival"`
 	TermOfPayment     string         `gorm:"not null" json:"term_of_payment"` // Full, DP, Tempo
-	POStatus          string         `gorm:"not null;default:'Draft'" json:"po_status"` // Draft, Ordered, Received, Partial, Returned, Closed
+	POStatus          string         `gorm:"not null;default:'Draft'" json:"po_status"` // Draft, PendingApproval, Approved, Rejected, Ordered, Received, Partial, Returned, Closed
 	PaymentStatus     string         `gorm:"not null;default:'Unpaid'" json:"payment_status"` // Unpaid, Partial, Paid
+	Subtotal          int            `gorm:"not null;default:0" json:"subtotal"`        // Σ total baris setelah diskon baris
+	DiscountType      string         `gorm:"size:10" json:"discount_type"`              // diskon order: percent, amount (kosong = tanpa diskon)
+	DiscountValue     float64        `gorm:"not null;default:0" json:"discount_value"`
+	DiscountAmount    int            `gorm:"not null;default:0" json:"discount_amount"` // diskon order dalam rupiah
+	TaxInclusive      bool           `gorm:"not null;default:false" json:"tax_inclusive"` // true = harga satuan sudah termasuk PPN
+	TaxBase           int            `gorm:"not null;default:0" json:"tax_base"`        // DPP
+	TaxAmount         int            `gorm:"not null;default:0" json:"tax_amount"`      // PPN
+	GrandTotal        int            `gorm:"not null;default:0" json:"grand_total"`     // total tagihan awal; TotalAmount berkurang oleh debit note retur
 	TotalAmount       int            `gorm:"not null" json:"total_amount"`
 	PaidAmount        int            `gorm:"default:0" json:"paid_amount"`
 	DPAmount          int            `gorm:"default:0" json:"dp_amount"`
 	DueDate           *time.Time     `json:"due_date"`
 	Notes             string         `json:"notes"`
-	Tax 														float32            `gorm:"default:0" json:"tax"`
+	Tax 														float32            `gorm:"default:0" json:"tax"` // tarif PPN (%)
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -29,6 +37,7 @@ type PurchaseOrder struct {
 	Supplier          Supplier            `gorm:"foreignKey:SupplierID" json:"supplier"`
 	PurchaseOrderItems []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order_items,omitempty"`
 	Payments          []Payment           `gorm:"foreignKey:PurchaseOrderID" json:"payments,omitempty"`
+	Approvals         []PurchaseOrderApproval `gorm:"foreignKey:PurchaseOrderID;constraint:OnDelete:CASCADE;" json:"approvals,omitempty"`
 }
 
 type PurchaseOrderItem struct {
@@ -38,9 +47,14 @@ type PurchaseOrderItem struct {
 	UoMID      uuid.UUID      						`gorm:"column:uom_id;type:uuid;not null" json:"uom_id"`
 	Quantity         int            `gorm:"not null" json:"quantity"`           
 	UnitPrice        int            `gorm:"not null" json:"unit_price"`         
-	TotalPrice       int            `gorm:"not null" json:"total_price"`
+	TotalPrice       int            `gorm:"not null" json:"total_price"` // qty × harga satuan - diskon baris
+	DiscountType     string         `gorm:"size:10" json:"discount_type"` // percent, amount (kosong = tanpa diskon)
+	DiscountValue    float64        `gorm:"not null;default:0" json:"discount_value"`
+	DiscountAmount   int            `gorm:"not null;default:0" json:"discount_amount"` // diskon baris dalam rupiah
+	TaxExempt        bool           `gorm:"not null;default:false" json:"tax_exempt"`  // snapshot Item.TaxExempt
+	NetAmount        int            `gorm:"not null;default:0" json:"net_amount"`      // porsi baris di grand total (setelah diskon order & PPN)
 	ReceivedQuantity int            `gorm:"default:0" json:"received_quantity"`
-	ReturnedQuantity int            `gorm:"default:0" json:"returned_quantity"`
+	ReturnedQuantity int            `gorm:"default:0" json:"returned_quantity"` // ditolak saat penerimaan; retur setelah diterima lewat PurchaseReturn
 	Status           string         `gorm:"not null;default:'Ordered'" json:"status"` // Ordered, Received, Returned, Partial
 
 	CreatedAt        time.Time      `json:"created_at"`
@@ -61,6 +75,14 @@ type ResponseGetPurchaseOrder struct {
 	TermOfPayment     string                  `json:"term_of_payment"`
 	POStatus          string                  `json:"po_status"`
 	PaymentStatus     string                  `json:"payment_status"`
+	Subtotal          int                     `json:"subtotal"`
+	DiscountType      string                  `json:"discount_type"`
+	DiscountValue     float64                 `json:"discount_value"`
+	DiscountAmount    int                     `json:"discount_amount"`
+	TaxInclusive      bool                    `json:"tax_inclusive"`
+	TaxBase           int                     `json:"tax_base"`
+	TaxAmount         int                     `json:"tax_amount"`
+	GrandTotal        int                     `json:"grand_total"`
 	TotalAmount       int                     `json:"total_amount"`
 	PaidAmount        int                     `json:"paid_amount"`
 	DPAmount          int                     `json:"dp_amount"`
@@ -75,6 +97,7 @@ type ResponseGetPurchaseOrder struct {
 	Supplier          Supplier     `json:"supplier"`
 	PurchaseOrderItems []PurchaseOrderItem `json:"purchase_order_items,omitempty"`
 	Payments          []Payment    `json:"payments,omitempty"`
+	Approvals         []PurchaseOrderApproval `json:"approvals,omitempty"`
 }
 
 type ResponseGetPurchaseOrderItem struct {
@@ -97,18 +120,23 @@ type PurchaseOrderItemRequest struct {
 	ItemID    uuid.UUID `json:"item_id" validate:"required"`
 	Quantity  int       `json:"quantity" validate:"required,min=1"`
 	UnitPrice int       `json:"unit_price" validate:"required,min=0"`
+	DiscountType  string  `json:"discount_type" validate:"omitempty,oneof=percent amount"`
+	DiscountValue float64 `json:"discount_value" validate:"min=0"`
 }
 
 type PurchaseOrderCreateRequest struct {
 	SupplierID       uuid.UUID                  `json:"supplier_id" validate:"required"`
 	PODate           time.Time                  `json:"po_date" validate:"required"`
-	POStatus         string                     `json:"po_status" validate:"required"`
+	POStatus         string                     `json:"po_status" validate:"required,oneof=Draft PendingApproval Ordered"` // Ordered yang butuh approval otomatis jadi PendingApproval
 	EstimatedArrival *time.Time                 `json:"estimated_arrival"`
 	TermOfPayment    string                     `json:"term_of_payment" validate:"required,oneof=Full DP Tempo"`
 	DPAmount         int                        `json:"dp_amount"`
 	DueDate          *time.Time                 `json:"due_date"`
 	Notes            string                     `json:"notes"`
-	Tax 													float32                     `json:"tax"`
+	Tax              *float32                   `json:"tax" validate:"omitempty,min=0,max=100"` // tarif PPN (%), kosong = PPN_RATE
+	TaxInclusive     bool                       `json:"tax_inclusive"`
+	DiscountType     string                     `json:"discount_type" validate:"omitempty,oneof=percent amount"` // diskon order
+	DiscountValue    float64                    `json:"discount_value" validate:"min=0"`
 	Items            []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
@@ -120,20 +148,33 @@ type PurchaseOrderUpdateRequest struct {
 	DPAmount         int                        `json:"dp_amount"`
 	DueDate          *time.Time                 `json:"due_date"`
 	Notes            string                     `json:"notes"`
-	Tax 													float32                        `json:"tax"`
+	Tax              *float32                   `json:"tax" validate:"omitempty,min=0,max=100"`
+	TaxInclusive     *bool                      `json:"tax_inclusive"`
+	DiscountType     *string                    `json:"discount_type" validate:"omitempty,oneof=percent amount"` // nil = tidak diubah; discount_value 0 = hapus diskon
+	DiscountValue    *float64                   `json:"discount_value" validate:"omitempty,min=0"`
 	Items            []PurchaseOrderItemRequest `json:"items" validate:"omitempty,min=1,dive"`
 }
 
 type PurchaseOrderStatusUpdateRequest struct {
-	POStatus      string `json:"po_status" validate:"required,oneof=Draft Ordered Received Returned Closed"`
+	POStatus      string `json:"po_status" validate:"required,oneof=Draft PendingApproval Ordered Received Returned Closed"` // Approved/Rejected lewat endpoint approval
 	PaymentStatus string `json:"payment_status" validate:"omitempty,oneof=Unpaid Partial Paid"`
 }
 
 type ReceiveItemsRequest struct {
+	WarehouseID *uuid.UUID `json:"warehouse_id"` // gudang penerima (kosong = gudang default)
+	// data kedatangan untuk dokumen GRN
+	ReceiptDate        *time.Time `json:"receipt_date"` // kosong = hari ini
+	DeliveryNoteNumber string     `json:"delivery_note_number"`
+	VehicleNumber      string     `json:"vehicle_number"`
+	ReceiverName       string     `json:"receiver_name"` // kosong = nama user yang login
+	Notes              string     `json:"notes"`
 	Items []struct {
 		PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" validate:"required"`
 		ReceivedQuantity    int       `json:"received_quantity" validate:"min=0"`
 		ReturnedQuantity    int       `json:"returned_quantity" validate:"min=0"`
+		LotNumber           string     `json:"lot_number"` // kosong = pakai nomor PO
+		ExpiredAt           *time.Time `json:"expired_at"`
+		Notes               string     `json:"notes"`
 	} `json:"items" validate:"required,min=1,dive"`
 }
 
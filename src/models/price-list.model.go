@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceList adalah daftar harga jual untuk satu CustomerType atau satu Customer (tepat salah satu).
+// Saat SO dibuat, list milik customer didahulukan dari list tipe customer; bila tidak ada yang
+// berlaku, harga jatuh ke Item.Price.
+type PriceList struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Name           string     `gorm:"size:150;not null" json:"name"`
+	CustomerTypeID *uuid.UUID `gorm:"type:uuid;index" json:"customer_type_id"`
+	CustomerID     *uuid.UUID `gorm:"type:uuid;index" json:"customer_id"`
+	ValidFrom      time.Time  `gorm:"not null" json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"` // nil = berlaku tanpa batas
+	IsActive       bool       `gorm:"not null;default:true" json:"is_active"`
+	EnforceMinimum bool       `gorm:"not null;default:false" json:"enforce_minimum"` // true = harga manual di bawah list ditolak, false = hanya ditandai
+	Description    string     `json:"description"`
+	CreatedBy      *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	CustomerType   *CustomerType   `gorm:"foreignKey:CustomerTypeID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_type,omitempty"`
+	Customer       *Customer       `gorm:"foreignKey:CustomerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer,omitempty"`
+	PriceListItems []PriceListItem `gorm:"foreignKey:PriceListID;constraint:OnDelete:CASCADE;" json:"price_list_items,omitempty"`
+}
+
+// PriceListItem adalah harga per item; beberapa baris untuk item yang sama membentuk
+// quantity break (baris dengan MinQuantity terbesar yang <= qty order yang dipakai).
+type PriceListItem struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	PriceListID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_price_list_item_break" json:"price_list_id"`
+	ItemID      uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_price_list_item_break" json:"item_id"`
+	MinQuantity int       `gorm:"not null;default:1;uniqueIndex:idx_price_list_item_break" json:"min_quantity"`
+	UnitPrice   int       `gorm:"not null" json:"unit_price"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PriceList *PriceList `gorm:"foreignKey:PriceListID" json:"price_list,omitempty"`
+	Item      Item       `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type PriceListItemRequest struct {
+	ItemID      uuid.UUID `json:"item_id" validate:"required"`
+	MinQuantity int       `json:"min_quantity" validate:"min=0"` // 0 = 1
+	UnitPrice   int       `json:"unit_price" validate:"required,min=1"`
+}
+
+type PriceListCreateRequest struct {
+	Name           string                 `json:"name" validate:"required,max=150"`
+	CustomerTypeID *uuid.UUID             `json:"customer_type_id"`
+	CustomerID     *uuid.UUID             `json:"customer_id"`
+	ValidFrom      time.Time              `json:"valid_from" validate:"required"`
+	ValidUntil     *time.Time             `json:"valid_until"`
+	IsActive       *bool                  `json:"is_active"` // kosong = aktif
+	EnforceMinimum bool                   `json:"enforce_minimum"`
+	Description    string                 `json:"description"`
+	Items          []PriceListItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PriceListIsHardDeleteRequest struct {
+	IsHardDelete string      `json:"is_hard_delete" validate:"required"`
+	IDs          []uuid.UUID `json:"ids" validate:"required,dive,required"`
+}
+
+type PriceListRestoreRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,dive,required"`
+}
+
+// ResolvedPrice adalah hasil penentuan harga satu item untuk customer & qty tertentu.
+type ResolvedPrice struct {
+	ItemID         uuid.UUID  `json:"item_id"`
+	Quantity       int        `json:"quantity"`
+	UnitPrice      int        `json:"unit_price"`
+	Source         string     `json:"source"` // customer_price_list, customer_type_price_list, item_price
+	PriceListID    *uuid.UUID `json:"price_list_id,omitempty"`
+	PriceListName  string     `json:"price_list_name,omitempty"`
+	MinQuantity    int        `json:"min_quantity,omitempty"`
+	EnforceMinimum bool       `json:"enforce_minimum"`
+}
+
+type PriceResolveRequest struct {
+	CustomerID string `query:"customer_id" validate:"required,uuid"`
+	ItemID     string `query:"item_id" validate:"required,uuid"`
+	Quantity   int    `query:"quantity" validate:"min=0"` // 0 = 1
+	Date       string `query:"date"`                      // YYYY-MM-DD, kosong = hari ini
+}
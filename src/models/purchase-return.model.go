@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PurchaseReturn adalah retur barang ke supplier atas baris PO yang sudah diterima.
+// Saat Completed: qty dikeluarkan dari lot di gudang, dan nilai retur diterbitkan sebagai
+// debit note (mengurangi hutang PO) atau refund dari supplier.
+type PurchaseReturn struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ReturnNumber    string     `gorm:"uniqueIndex;not null" json:"return_number"`
+	DebitNoteNumber *string    `gorm:"uniqueIndex" json:"debit_note_number"` // terbit saat Completed
+	PurchaseOrderID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_id"`
+	SupplierID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	WarehouseID     *uuid.UUID `gorm:"type:uuid" json:"warehouse_id"` // gudang asal barang (kosong = gudang default)
+	ReturnDate      time.Time  `gorm:"not null" json:"return_date"`
+	Status          string     `gorm:"not null;default:'Draft'" json:"status"`              // Draft, Completed, Cancelled
+	SettlementType  string     `gorm:"not null;default:'DebitNote'" json:"settlement_type"` // DebitNote, Refund
+	DebitAmount     int        `gorm:"not null;default:0" json:"debit_amount"`
+	RefundAmount    int        `gorm:"default:0" json:"refund_amount"`
+	RefundMethod    string     `json:"refund_method"`
+	RefundPaymentID *uuid.UUID `gorm:"type:uuid" json:"refund_payment_id"`
+	Notes           string     `json:"notes"`
+	CreatedBy       *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	CompletedBy     *uuid.UUID `gorm:"type:uuid" json:"completed_by"`
+	CompletedAt     *time.Time `json:"completed_at"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	PurchaseOrder       PurchaseOrder        `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order"`
+	Supplier            Supplier             `gorm:"foreignKey:SupplierID" json:"supplier"`
+	Warehouse           *Warehouse           `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	RefundPayment       *Payment             `gorm:"foreignKey:RefundPaymentID" json:"refund_payment,omitempty"`
+	PurchaseReturnItems []PurchaseReturnItem `gorm:"foreignKey:PurchaseReturnID;constraint:OnDelete:CASCADE;" json:"purchase_return_items,omitempty"`
+}
+
+type PurchaseReturnItem struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PurchaseReturnID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_return_id"`
+	PurchaseOrderItemID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_item_id"`
+	ItemID              uuid.UUID  `gorm:"type:uuid;not null" json:"item_id"`
+	Quantity            int        `gorm:"not null" json:"quantity"`
+	UnitPrice           int        `gorm:"not null" json:"unit_price"`
+	TotalPrice          int        `gorm:"not null" json:"total_price"`
+	Reason              string     `gorm:"not null" json:"reason"`
+	LotNumber           string     `gorm:"not null" json:"lot_number"` // lot yang dikeluarkan
+	LotID               *uuid.UUID `gorm:"type:uuid" json:"lot_id"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type ResponseGetPurchaseReturn struct {
+	ID              uuid.UUID      `json:"id"`
+	ReturnNumber    string         `json:"return_number"`
+	DebitNoteNumber *string        `json:"debit_note_number"`
+	PurchaseOrderID uuid.UUID      `json:"purchase_order_id"`
+	SupplierID      uuid.UUID      `json:"supplier_id"`
+	WarehouseID     *uuid.UUID     `json:"warehouse_id"`
+	ReturnDate      time.Time      `json:"return_date"`
+	Status          string         `json:"status"`
+	SettlementType  string         `json:"settlement_type"`
+	DebitAmount     int            `json:"debit_amount"`
+	RefundAmount    int            `json:"refund_amount"`
+	RefundMethod    string         `json:"refund_method"`
+	RefundPaymentID *uuid.UUID     `json:"refund_payment_id"`
+	Notes           string         `json:"notes"`
+	CreatedBy       *uuid.UUID     `json:"created_by"`
+	CompletedBy     *uuid.UUID     `json:"completed_by"`
+	CompletedAt     *time.Time     `json:"completed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	PurchaseOrder       PurchaseOrder        `json:"purchase_order"`
+	Supplier            Supplier             `json:"supplier"`
+	Warehouse           *Warehouse           `json:"warehouse,omitempty"`
+	RefundPayment       *Payment             `json:"refund_payment,omitempty"`
+	PurchaseReturnItems []PurchaseReturnItem `json:"purchase_return_items,omitempty"`
+}
+
+type PurchaseReturnItemRequest struct {
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" validate:"required"`
+	Quantity            int       `json:"quantity" validate:"required,min=1"`
+	Reason              string    `json:"reason" validate:"required"`
+	LotNumber           string    `json:"lot_number"` // kosong = pakai nomor PO (default lot saat receive)
+}
+
+type PurchaseReturnCreateRequest struct {
+	PurchaseOrderID uuid.UUID                   `json:"purchase_order_id" validate:"required"`
+	WarehouseID     *uuid.UUID                  `json:"warehouse_id"`
+	ReturnDate      time.Time                   `json:"return_date" validate:"required"`
+	SettlementType  string                      `json:"settlement_type" validate:"omitempty,oneof=DebitNote Refund"`
+	RefundAmount    int                         `json:"refund_amount" validate:"omitempty,min=0"` // kosong = seluruh nilai retur
+	RefundMethod    string                      `json:"refund_method"`
+	Notes           string                      `json:"notes"`
+	Items           []PurchaseReturnItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PurchaseReturnStatusUpdateRequest struct {
+	Status string `json:"status" validate:"required,oneof=Completed Cancelled"`
+	Notes  string `json:"notes"`
+}
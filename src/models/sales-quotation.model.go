@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SalesQuotation adalah penawaran harga ke customer sebelum ada SO. Revisi memakai nomor yang
+// sama dengan Revision bertambah; versi lama menjadi Superseded. Quotation Accepted dapat
+// dikonversi menjadi SalesOrder dengan harga yang ditawarkan.
+type SalesQuotation struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	QuotationNumber string     `gorm:"not null;uniqueIndex:idx_quotation_revision" json:"quotation_number"`
+	Revision        int        `gorm:"not null;default:0;uniqueIndex:idx_quotation_revision" json:"revision"`
+	PreviousID      *uuid.UUID `gorm:"type:uuid" json:"previous_id"` // versi sebelum direvisi
+	CustomerID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"customer_id"`
+	SalesPersonID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"sales_person_id"`
+	QuotationDate   time.Time  `gorm:"not null" json:"quotation_date"`
+	ValidUntil      time.Time  `gorm:"not null" json:"valid_until"`            // berlaku s.d. akhir hari ini
+	TermOfPayment   string     `gorm:"not null" json:"term_of_payment"`        // Full, DP, Tempo
+	Status          string     `gorm:"not null;default:'Draft'" json:"status"` // Draft, Sent, Accepted, Rejected, Expired, Superseded, Converted
+	TotalAmount     int        `gorm:"not null" json:"total_amount"`
+	Notes           string     `json:"notes"`
+	StatusReason    string     `json:"status_reason"`                   // alasan penolakan dari customer
+	SalesOrderID    *uuid.UUID `gorm:"type:uuid" json:"sales_order_id"` // terisi saat Converted
+	CreatedBy       *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	SentAt          *time.Time `json:"sent_at"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	ConvertedAt     *time.Time `json:"converted_at"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Customer            Customer             `gorm:"foreignKey:CustomerID" json:"customer"`
+	SalesPerson         SalesPerson          `gorm:"foreignKey:SalesPersonID" json:"sales_person"`
+	SalesOrder          *SalesOrder          `gorm:"foreignKey:SalesOrderID" json:"sales_order,omitempty"`
+	SalesQuotationItems []SalesQuotationItem `gorm:"foreignKey:SalesQuotationID;constraint:OnDelete:CASCADE;" json:"sales_quotation_items,omitempty"`
+}
+
+type SalesQuotationItem struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	SalesQuotationID uuid.UUID `gorm:"type:uuid;not null;index" json:"sales_quotation_id"`
+	ItemID           uuid.UUID `gorm:"type:uuid;not null" json:"item_id"`
+	UoMID            uuid.UUID `gorm:"column:uom_id;type:uuid;not null" json:"uom_id"`
+	Quantity         int       `gorm:"not null" json:"quantity"`
+	UnitPrice        int       `gorm:"not null" json:"unit_price"`
+	TotalPrice       int       `gorm:"not null" json:"total_price"`
+	Notes            string    `json:"notes"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+	UoM  UoM  `gorm:"foreignKey:UoMID" json:"uom"`
+}
+
+type ResponseGetSalesQuotation struct {
+	ID              uuid.UUID      `json:"id"`
+	QuotationNumber string         `json:"quotation_number"`
+	Revision        int            `json:"revision"`
+	PreviousID      *uuid.UUID     `json:"previous_id"`
+	CustomerID      uuid.UUID      `json:"customer_id"`
+	SalesPersonID   uuid.UUID      `json:"sales_person_id"`
+	QuotationDate   time.Time      `json:"quotation_date"`
+	ValidUntil      time.Time      `json:"valid_until"`
+	TermOfPayment   string         `json:"term_of_payment"`
+	Status          string         `json:"status"`
+	TotalAmount     int            `json:"total_amount"`
+	Notes           string         `json:"notes"`
+	StatusReason    string         `json:"status_reason"`
+	SalesOrderID    *uuid.UUID     `json:"sales_order_id"`
+	CreatedBy       *uuid.UUID     `json:"created_by"`
+	SentAt          *time.Time     `json:"sent_at"`
+	DecidedAt       *time.Time     `json:"decided_at"`
+	ConvertedAt     *time.Time     `json:"converted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	Customer            Customer             `json:"customer"`
+	SalesPerson         SalesPerson          `json:"sales_person"`
+	SalesOrder          *SalesOrder          `json:"sales_order,omitempty"`
+	SalesQuotationItems []SalesQuotationItem `json:"sales_quotation_items,omitempty"`
+}
+
+type SalesQuotationItemRequest struct {
+	ItemID    uuid.UUID `json:"item_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	UnitPrice int       `json:"unit_price" validate:"min=0"` // 0 = harga item saat ini
+	Notes     string    `json:"notes"`
+}
+
+// SalesQuotationCreateRequest juga dipakai untuk update Draft dan untuk membuat revisi.
+type SalesQuotationCreateRequest struct {
+	CustomerID    uuid.UUID                   `json:"customer_id" validate:"required"`
+	SalesPersonID uuid.UUID                   `json:"sales_person_id" validate:"required"`
+	QuotationDate time.Time                   `json:"quotation_date" validate:"required"`
+	ValidUntil    time.Time                   `json:"valid_until" validate:"required"`
+	TermOfPayment string                      `json:"term_of_payment" validate:"required,oneof=Full DP Tempo"`
+	Notes         string                      `json:"notes"`
+	Items         []SalesQuotationItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type SalesQuotationStatusUpdateRequest struct {
+	Status string `json:"status" validate:"required,oneof=Sent Accepted Rejected"`
+	Reason string `json:"reason"` // wajib saat Rejected
+}
+
+type SalesQuotationConvertRequest struct {
+	SOStatus         string     `json:"so_status" validate:"omitempty,oneof=Draft Confirmed"` // kosong = Draft
+	SODate           *time.Time `json:"so_date"`                                              // kosong = hari ini
+	EstimatedArrival *time.Time `json:"estimated_arrival"`
+	DPAmount         int        `json:"dp_amount" validate:"min=0"`
+	DueDate          *time.Time `json:"due_date"`
+	Notes            string     `json:"notes"`
+
+	// quotation tidak menyimpan diskon order & PPN; keduanya ditentukan saat konversi dan SO
+	// dihitung ulang dari harga yang ditawarkan
+	DiscountType  string   `json:"discount_type" validate:"omitempty,oneof=percent amount"`
+	DiscountValue float64  `json:"discount_value" validate:"min=0"`
+	TaxRate       *float64 `json:"tax_rate" validate:"omitempty,min=0,max=100"` // kosong = PPN_RATE
+	TaxInclusive  bool     `json:"tax_inclusive"`                               // true = harga quotation sudah termasuk PPN
+
+	CreditOverrideReason string `json:"credit_override_reason" validate:"max=500"` // diteruskan ke SO bila customer sedang credit hold
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SalesReturn adalah retur barang dari customer atas SO yang sudah Delivered.
+// Saat Completed: qty Resellable masuk lagi ke stok, qty Quarantine hanya dicatat,
+// dan nilai retur diterbitkan sebagai credit note (mengurangi tagihan SO) atau refund.
+type SalesReturn struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ReturnNumber     string     `gorm:"uniqueIndex;not null" json:"return_number"`
+	CreditNoteNumber *string    `gorm:"uniqueIndex" json:"credit_note_number"` // terbit saat Completed
+	SalesOrderID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"sales_order_id"`
+	CustomerID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"customer_id"`
+	WarehouseID      *uuid.UUID `gorm:"type:uuid" json:"warehouse_id"` // gudang restock (kosong = gudang default)
+	ReturnDate       time.Time  `gorm:"not null" json:"return_date"`
+	Status           string     `gorm:"not null;default:'Draft'" json:"status"`               // Draft, Completed, Cancelled
+	SettlementType   string     `gorm:"not null;default:'CreditNote'" json:"settlement_type"` // CreditNote, Refund
+	CreditAmount     int        `gorm:"not null;default:0" json:"credit_amount"`
+	RefundAmount     int        `gorm:"default:0" json:"refund_amount"`
+	RefundMethod     string     `json:"refund_method"`
+	RefundPaymentID  *uuid.UUID `gorm:"type:uuid" json:"refund_payment_id"`
+	Notes            string     `json:"notes"`
+	CreatedBy        *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	CompletedBy      *uuid.UUID `gorm:"type:uuid" json:"completed_by"`
+	CompletedAt      *time.Time `json:"completed_at"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	SalesOrder       SalesOrder        `gorm:"foreignKey:SalesOrderID" json:"sales_order"`
+	Customer         Customer          `gorm:"foreignKey:CustomerID" json:"customer"`
+	Warehouse        *Warehouse        `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	RefundPayment    *Payment          `gorm:"foreignKey:RefundPaymentID" json:"refund_payment,omitempty"`
+	SalesReturnItems []SalesReturnItem `gorm:"foreignKey:SalesReturnID;constraint:OnDelete:CASCADE;" json:"sales_return_items,omitempty"`
+}
+
+type SalesReturnItem struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	SalesReturnID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"sales_return_id"`
+	SalesOrderItemID uuid.UUID  `gorm:"type:uuid;not null;index" json:"sales_order_item_id"`
+	ItemID           uuid.UUID  `gorm:"type:uuid;not null" json:"item_id"`
+	Quantity         int        `gorm:"not null" json:"quantity"`
+	UnitPrice        int        `gorm:"not null" json:"unit_price"`
+	TotalPrice       int        `gorm:"not null" json:"total_price"`
+	Reason           string     `gorm:"not null" json:"reason"`
+	Condition        string     `gorm:"not null" json:"condition"` // Resellable, Quarantine
+	LotNumber        *string    `json:"lot_number"`
+	ExpiredAt        *time.Time `json:"expired_at"`
+	LotID            *uuid.UUID `gorm:"type:uuid" json:"lot_id"` // lot tujuan restock (khusus Resellable)
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type ResponseGetSalesReturn struct {
+	ID               uuid.UUID      `json:"id"`
+	ReturnNumber     string         `json:"return_number"`
+	CreditNoteNumber *string        `json:"credit_note_number"`
+	SalesOrderID     uuid.UUID      `json:"sales_order_id"`
+	CustomerID       uuid.UUID      `json:"customer_id"`
+	WarehouseID      *uuid.UUID     `json:"warehouse_id"`
+	ReturnDate       time.Time      `json:"return_date"`
+	Status           string         `json:"status"`
+	SettlementType   string         `json:"settlement_type"`
+	CreditAmount     int            `json:"credit_amount"`
+	RefundAmount     int            `json:"refund_amount"`
+	RefundMethod     string         `json:"refund_method"`
+	RefundPaymentID  *uuid.UUID     `json:"refund_payment_id"`
+	Notes            string         `json:"notes"`
+	CreatedBy        *uuid.UUID     `json:"created_by"`
+	CompletedBy      *uuid.UUID     `json:"completed_by"`
+	CompletedAt      *time.Time     `json:"completed_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	SalesOrder       SalesOrder        `json:"sales_order"`
+	Customer         Customer          `json:"customer"`
+	Warehouse        *Warehouse        `json:"warehouse,omitempty"`
+	RefundPayment    *Payment          `json:"refund_payment,omitempty"`
+	SalesReturnItems []SalesReturnItem `json:"sales_return_items,omitempty"`
+}
+
+type SalesReturnItemRequest struct {
+	SalesOrderItemID uuid.UUID  `json:"sales_order_item_id" validate:"required"`
+	Quantity         int        `json:"quantity" validate:"required,min=1"`
+	Reason           string     `json:"reason" validate:"required"`
+	Condition        string     `json:"condition" validate:"required,oneof=Resellable Quarantine"`
+	LotNumber        *string    `json:"lot_number"` // wajib untuk Resellable
+	ExpiredAt        *time.Time `json:"expired_at"`
+}
+
+type SalesReturnCreateRequest struct {
+	SalesOrderID   uuid.UUID                `json:"sales_order_id" validate:"required"`
+	WarehouseID    *uuid.UUID               `json:"warehouse_id"`
+	ReturnDate     time.Time                `json:"return_date" validate:"required"`
+	SettlementType string                   `json:"settlement_type" validate:"omitempty,oneof=CreditNote Refund"`
+	RefundAmount   int                      `json:"refund_amount" validate:"omitempty,min=0"` // kosong = seluruh nilai retur
+	RefundMethod   string                   `json:"refund_method"`
+	Notes          string                   `json:"notes"`
+	Items          []SalesReturnItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type SalesReturnStatusUpdateRequest struct {
+	Status string `json:"status" validate:"required,oneof=Completed Cancelled"`
+	Notes  string `json:"notes"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Shipment adalah satu kali pengiriman atas SO (delivery order). Stok dikurangi per shipment,
+// sehingga SO bisa dikirim bertahap; sisa qty yang belum dikirim menjadi backorder.
+type Shipment struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ShipmentNumber string     `gorm:"uniqueIndex;not null" json:"shipment_number"`
+	SalesOrderID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"sales_order_id"`
+	CustomerID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"customer_id"`
+	WarehouseID    uuid.UUID  `gorm:"type:uuid;not null" json:"warehouse_id"`
+	ShipmentDate   time.Time  `gorm:"not null" json:"shipment_date"`
+	Courier        string     `json:"courier"`
+	TrackingNumber string     `json:"tracking_number"`
+	Status         string     `gorm:"not null;default:'Shipped'" json:"status"` // Shipped, Delivered
+	TotalQuantity  int        `gorm:"not null;default:0" json:"total_quantity"`
+	ShippedBy      *uuid.UUID `gorm:"type:uuid" json:"shipped_by"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	ReceiverName   string     `json:"receiver_name"` // nama penerima di customer
+	Notes          string     `json:"notes"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	SalesOrder    SalesOrder     `gorm:"foreignKey:SalesOrderID" json:"sales_order"`
+	Customer      Customer       `gorm:"foreignKey:CustomerID" json:"customer"`
+	Warehouse     Warehouse      `gorm:"foreignKey:WarehouseID" json:"warehouse"`
+	ShippedByUser *User          `gorm:"foreignKey:ShippedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"shipped_by_user,omitempty"`
+	ShipmentItems []ShipmentItem `gorm:"foreignKey:ShipmentID;constraint:OnDelete:CASCADE;" json:"shipment_items,omitempty"`
+}
+
+type ShipmentItem struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ShipmentID        uuid.UUID `gorm:"type:uuid;not null;index" json:"shipment_id"`
+	SalesOrderItemID  uuid.UUID `gorm:"type:uuid;not null;index" json:"sales_order_item_id"`
+	ItemID            uuid.UUID `gorm:"type:uuid;not null" json:"item_id"`
+	OrderedQuantity   int       `gorm:"not null" json:"ordered_quantity"`
+	Quantity          int       `gorm:"not null" json:"quantity"`
+	BackorderQuantity int       `gorm:"not null;default:0" json:"backorder_quantity"` // sisa baris SO setelah shipment ini
+	LotNumbers        string    `json:"lot_numbers"`                                  // lot yang diambil FEFO, dipisah koma
+	Notes             string    `json:"notes"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type ResponseGetShipment struct {
+	ID             uuid.UUID      `json:"id"`
+	ShipmentNumber string         `json:"shipment_number"`
+	SalesOrderID   uuid.UUID      `json:"sales_order_id"`
+	CustomerID     uuid.UUID      `json:"customer_id"`
+	WarehouseID    uuid.UUID      `json:"warehouse_id"`
+	ShipmentDate   time.Time      `json:"shipment_date"`
+	Courier        string         `json:"courier"`
+	TrackingNumber string         `json:"tracking_number"`
+	Status         string         `json:"status"`
+	TotalQuantity  int            `json:"total_quantity"`
+	ShippedBy      *uuid.UUID     `json:"shipped_by"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	ReceiverName   string         `json:"receiver_name"`
+	Notes          string         `json:"notes"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	SalesOrder    SalesOrder     `json:"sales_order"`
+	Customer      Customer       `json:"customer"`
+	Warehouse     Warehouse      `json:"warehouse"`
+	ShippedByUser *User          `json:"shipped_by_user,omitempty"`
+	ShipmentItems []ShipmentItem `json:"shipment_items,omitempty"`
+}
+
+type ShipmentItemRequest struct {
+	SalesOrderItemID uuid.UUID `json:"sales_order_item_id" validate:"required"`
+	Quantity         int       `json:"quantity" validate:"min=0"`
+	Notes            string    `json:"notes"`
+}
+
+type ShipmentCreateRequest struct {
+	WarehouseID    *uuid.UUID            `json:"warehouse_id"`  // gudang asal (kosong = gudang default)
+	ShipmentDate   *time.Time            `json:"shipment_date"` // kosong = hari ini
+	Courier        string                `json:"courier"`
+	TrackingNumber string                `json:"tracking_number"`
+	Notes          string                `json:"notes"`
+	Items          []ShipmentItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ShipmentDeliveredRequest struct {
+	ReceiverName string     `json:"receiver_name"`
+	DeliveredAt  *time.Time `json:"delivered_at"` // kosong = sekarang
+	Notes        string     `json:"notes"`
+}
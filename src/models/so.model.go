@@ -10,13 +10,23 @@ import (
 type SalesOrder struct {
 	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	SONumber   string    `gorm:"uniqueIndex;not null" json:"so_number"`
+	InvoiceNumber *string `gorm:"uniqueIndex" json:"invoice_number"`
 	SalesPersonID uuid.UUID `gorm:"type:uuid;not null" json:"sales_person_id"`
 	CustomerID    uuid.UUID `gorm:"type:uuid;not null" json:"customer_id"`
 	SODate     time.Time `gorm:"not null" json:"so_date"`
 	EstimatedArrival  *time.Time     `json:"estimated_arrival"`
 	TermOfPayment     string         `gorm:"not null" json:"term_of_payment"` // Full, DP, Tempo
-	SOStatus string `gorm:"not null;default:'Draft'" json:"so_status"` 	// Draft, Confirmed, Shipped, Delivered, Closed
+	SOStatus string `gorm:"not null;default:'Draft'" json:"so_status"` 	// Draft, Confirmed, PartiallyShipped, Shipped, Delivered, Closed, Cancelled
 	PaymentStatus string `gorm:"not null;default:'Unpaid'" json:"payment_status"`	// Unpaid, Partial, Paid
+	Subtotal          int            `gorm:"not null;default:0" json:"subtotal"`        // Σ total baris setelah diskon baris
+	DiscountType      string         `gorm:"size:10" json:"discount_type"`              // diskon order: percent, amount (kosong = tanpa diskon)
+	DiscountValue     float64        `gorm:"not null;default:0" json:"discount_value"`
+	DiscountAmount    int            `gorm:"not null;default:0" json:"discount_amount"` // diskon order dalam rupiah
+	TaxRate           float64        `gorm:"not null;default:0" json:"tax_rate"`        // tarif PPN (%) yang dipakai
+	TaxInclusive      bool           `gorm:"not null;default:false" json:"tax_inclusive"` // true = harga satuan sudah termasuk PPN
+	TaxBase           int            `gorm:"not null;default:0" json:"tax_base"`        // DPP
+	TaxAmount         int            `gorm:"not null;default:0" json:"tax_amount"`      // PPN
+	GrandTotal        int            `gorm:"not null;default:0" json:"grand_total"`     // total tagihan awal; TotalAmount berkurang oleh credit note retur
 	TotalAmount       int            `gorm:"not null" json:"total_amount"`
 	PaidAmount        int            `gorm:"default:0" json:"paid_amount"`
 	DPAmount          int            `gorm:"default:0" json:"dp_amount"`
@@ -31,6 +41,7 @@ type SalesOrder struct {
 	Customer    Customer         `gorm:"foreignKey:CustomerID" json:"customer"`
 	SalesOrderItems       []SalesOrderItem `gorm:"foreignKey:SalesOrderID" json:"sales_order_items,omitempty"`
 	Payments    []Payment        `gorm:"foreignKey:SalesOrderID" json:"payments"`
+	Shipments   []Shipment       `gorm:"foreignKey:SalesOrderID" json:"shipments,omitempty"`
 }
 
 type SalesOrderItem struct {
@@ -40,7 +51,17 @@ type SalesOrderItem struct {
 	UoMID      uuid.UUID      `gorm:"column:uom_id;type:uuid;not null" json:"uom_id"`
 	Quantity     int       `gorm:"not null" json:"quantity"`
 	UnitPrice    int       `gorm:"not null" json:"unit_price"`
-	TotalPrice   int       `gorm:"not null" json:"total_price"`
+	TotalPrice   int       `gorm:"not null" json:"total_price"` // qty × harga satuan - diskon baris
+	DiscountType   string  `gorm:"size:10" json:"discount_type"` // percent, amount (kosong = tanpa diskon)
+	DiscountValue  float64 `gorm:"not null;default:0" json:"discount_value"`
+	DiscountAmount int     `gorm:"not null;default:0" json:"discount_amount"` // diskon baris dalam rupiah
+	TaxExempt      bool    `gorm:"not null;default:false" json:"tax_exempt"`  // snapshot Item.TaxExempt
+	NetAmount      int     `gorm:"not null;default:0" json:"net_amount"`      // porsi baris di grand total (setelah diskon order & PPN)
+	ReservedQuantity int   `gorm:"not null;default:0" json:"reserved_quantity"` // qty yang masih di-reserve di Item.ReservedStock
+	ShippedQuantity  int   `gorm:"not null;default:0" json:"shipped_quantity"`  // akumulasi qty dari seluruh shipment
+	ListPrice        int        `gorm:"not null;default:0" json:"list_price"`     // harga hasil resolve price list / Item.Price saat SO dibuat
+	PriceListID      *uuid.UUID `gorm:"type:uuid" json:"price_list_id"`           // nil = harga dari Item.Price
+	BelowListPrice   bool       `gorm:"not null;default:false" json:"below_list_price"` // UnitPrice manual di bawah ListPrice
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -54,6 +75,7 @@ type SalesOrderItem struct {
 type ResponseGetSalesOrder struct {
 	ID            uuid.UUID             `json:"id"`
 	SONumber      string                `json:"so_number"`
+	InvoiceNumber *string               `json:"invoice_number"`
 	SalesPersonID uuid.UUID             `json:"sales_person_id"`
 	CustomerID    uuid.UUID             `json:"customer_id"`
 	SODate        time.Time             `json:"so_date"`
@@ -61,6 +83,15 @@ type ResponseGetSalesOrder struct {
 	TermOfPayment string                `json:"term_of_payment"`
 	SOStatus      string                `json:"so_status"`
 	PaymentStatus string                `json:"payment_status"`
+	Subtotal       int                  `json:"subtotal"`
+	DiscountType   string               `json:"discount_type"`
+	DiscountValue  float64              `json:"discount_value"`
+	DiscountAmount int                  `json:"discount_amount"`
+	TaxRate        float64              `json:"tax_rate"`
+	TaxInclusive   bool                 `json:"tax_inclusive"`
+	TaxBase        int                  `json:"tax_base"`
+	TaxAmount      int                  `json:"tax_amount"`
+	GrandTotal     int                  `json:"grand_total"`
 	TotalAmount   int                   `json:"total_amount"`
 	PaidAmount    int                   `json:"paid_amount"`
 	DPAmount      int                   `json:"dp_amount"`
@@ -74,6 +105,10 @@ type ResponseGetSalesOrder struct {
 	Customer      Customer    `json:"customer"`
 	SalesOrderItems         []SalesOrderItem `json:"sales_order_items,omitempty"`
 	Payments      []Payment   `json:"payments,omitempty"`
+	Shipments     []Shipment  `json:"shipments,omitempty"`
+
+	ShippedQuantity   int `json:"shipped_quantity"`   // total qty terkirim dari seluruh baris
+	BackorderQuantity int `json:"backorder_quantity"` // total qty yang belum terkirim
 }
 
 type ResponseGetSalesOrderItem struct {
@@ -94,7 +129,9 @@ type ResponseGetSalesOrderItem struct {
 type SalesOrderItemRequest struct {
 	ItemID    uuid.UUID `json:"item_id" validate:"required"`
 	Quantity  int       `json:"quantity" validate:"required,min=1"`
-	UnitPrice int       `json:"unit_price" validate:"required,min=0"`
+	UnitPrice int       `json:"unit_price" validate:"min=0"` // 0 = otomatis dari price list
+	DiscountType  string  `json:"discount_type" validate:"omitempty,oneof=percent amount"`
+	DiscountValue float64 `json:"discount_value" validate:"min=0"`
 }
 
 type SalesOrderCreateRequest struct {
@@ -108,6 +145,12 @@ type SalesOrderCreateRequest struct {
 	DueDate          *time.Time              `json:"due_date"`
 	Notes            string                  `json:"notes"`
 	Items            []SalesOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	DiscountType     string                  `json:"discount_type" validate:"omitempty,oneof=percent amount"` // diskon order
+	DiscountValue    float64                 `json:"discount_value" validate:"min=0"`
+	TaxRate          *float64                `json:"tax_rate" validate:"omitempty,min=0,max=100"` // kosong = PPN_RATE
+	TaxInclusive     bool                    `json:"tax_inclusive"`
+
+	CreditOverrideReason string `json:"credit_override_reason" validate:"max=500"` // wajib bila customer sedang credit hold
 }
 
 type SalesOrderUpdateRequest struct {
@@ -120,11 +163,17 @@ type SalesOrderUpdateRequest struct {
 	DueDate          *time.Time              `json:"due_date"`
 	Notes            string                  `json:"notes"`
 	Items            []SalesOrderItemRequest `json:"items" validate:"omitempty,min=1,dive"`
+	DiscountType     *string                 `json:"discount_type" validate:"omitempty,oneof=percent amount"` // nil = tidak diubah; discount_value 0 = hapus diskon
+	DiscountValue    *float64                `json:"discount_value" validate:"omitempty,min=0"`
+	TaxRate          *float64                `json:"tax_rate" validate:"omitempty,min=0,max=100"`
+	TaxInclusive     *bool                   `json:"tax_inclusive"`
 }
 
 type SalesOrderStatusUpdateRequest struct {
-	SOStatus      string `json:"so_status" validate:"required,oneof=Draft Confirmed Shipped Delivered Closed"`
+	SOStatus      string `json:"so_status" validate:"required,oneof=Draft Confirmed Shipped Delivered Closed Cancelled"`
 	PaymentStatus string `json:"payment_status" validate:"omitempty,oneof=Unpaid Partial Paid"`
+	WarehouseID   *uuid.UUID `json:"warehouse_id"` // gudang asal saat Shipped / Delivered (kosong = gudang default)
+	CreditOverrideReason string `json:"credit_override_reason" validate:"max=500"` // wajib saat Confirmed bila customer sedang credit hold
 }
 
 type SalesOrderIsHardDeleteRequest struct {
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockOpname adalah sesi hitung fisik (cycle count) untuk satu gudang, opsional dibatasi
+// satu kategori. Saat dibuat, qty sistem per lot di-snapshot; qty hitung diisi manual atau
+// lewat upload Excel; setelah Approved selisihnya diposting sebagai history "stock_opname".
+type StockOpname struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	OpnameNumber       string     `gorm:"uniqueIndex;not null" json:"opname_number"`
+	WarehouseID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"warehouse_id"`
+	CategoryID         *uuid.UUID `gorm:"type:uuid;index" json:"category_id"`     // kosong = semua item
+	Status             string     `gorm:"not null;default:'Draft'" json:"status"` // Draft, Submitted, Approved, Rejected, Cancelled
+	SnapshotAt         time.Time  `gorm:"not null" json:"snapshot_at"`
+	TotalVarianceQty   int        `gorm:"default:0" json:"total_variance_qty"`
+	TotalVarianceValue int        `gorm:"default:0" json:"total_variance_value"`
+	Notes              string     `json:"notes"`
+	ReviewNotes        string     `json:"review_notes"`
+	CreatedBy          *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	SubmittedBy        *uuid.UUID `gorm:"type:uuid" json:"submitted_by"`
+	SubmittedAt        *time.Time `json:"submitted_at"`
+	ReviewedBy         *uuid.UUID `gorm:"type:uuid" json:"reviewed_by"` // supervisor yang approve / reject
+	ReviewedAt         *time.Time `json:"reviewed_at"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Warehouse        Warehouse         `gorm:"foreignKey:WarehouseID" json:"warehouse"`
+	Category         *Category         `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	StockOpnameItems []StockOpnameItem `gorm:"foreignKey:StockOpnameID;constraint:OnDelete:CASCADE;" json:"stock_opname_items,omitempty"`
+}
+
+type StockOpnameItem struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	StockOpnameID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"stock_opname_id"`
+	ItemID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"item_id"`
+	LotID            *uuid.UUID `gorm:"type:uuid" json:"lot_id"` // kosong = lot ditemukan saat hitung, belum ada di sistem
+	LotNumber        string     `gorm:"size:100;not null" json:"lot_number"`
+	ExpiredAt        *time.Time `json:"expired_at"`
+	SystemQuantity   int        `gorm:"not null;default:0" json:"system_quantity"`
+	CountedQuantity  *int       `json:"counted_quantity"` // nil = belum dihitung
+	VarianceQuantity int        `gorm:"default:0" json:"variance_quantity"`
+	UnitCost         int        `gorm:"default:0" json:"unit_cost"`
+	VarianceValue    int        `gorm:"default:0" json:"variance_value"`
+	Notes            string     `json:"notes"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Item Item `gorm:"foreignKey:ItemID" json:"item"`
+}
+
+type ResponseGetStockOpname struct {
+	ID                 uuid.UUID      `json:"id"`
+	OpnameNumber       string         `json:"opname_number"`
+	WarehouseID        uuid.UUID      `json:"warehouse_id"`
+	CategoryID         *uuid.UUID     `json:"category_id"`
+	Status             string         `json:"status"`
+	SnapshotAt         time.Time      `json:"snapshot_at"`
+	TotalLines         int            `json:"total_lines"`
+	CountedLines       int            `json:"counted_lines"`
+	TotalVarianceQty   int            `json:"total_variance_qty"`
+	TotalVarianceValue int            `json:"total_variance_value"`
+	Notes              string         `json:"notes"`
+	ReviewNotes        string         `json:"review_notes"`
+	CreatedBy          *uuid.UUID     `json:"created_by"`
+	SubmittedBy        *uuid.UUID     `json:"submitted_by"`
+	SubmittedAt        *time.Time     `json:"submitted_at"`
+	ReviewedBy         *uuid.UUID     `json:"reviewed_by"`
+	ReviewedAt         *time.Time     `json:"reviewed_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	Warehouse        Warehouse         `json:"warehouse"`
+	Category         *Category         `json:"category,omitempty"`
+	StockOpnameItems []StockOpnameItem `json:"stock_opname_items,omitempty"`
+}
+
+type StockOpnameCreateRequest struct {
+	WarehouseID *uuid.UUID `json:"warehouse_id"` // kosong = gudang default
+	CategoryID  *uuid.UUID `json:"category_id"`  // kosong = semua item
+	Notes       string     `json:"notes"`
+}
+
+// StockOpnameCountRequest mengisi qty hitung. Baris snapshot dirujuk lewat StockOpnameItemID;
+// lot yang tidak ada di snapshot dikirim dengan ItemID + LotNumber (+ ExpiredAt).
+type StockOpnameCountRequest struct {
+	StockOpnameItemID *uuid.UUID `json:"stock_opname_item_id"`
+	ItemID            *uuid.UUID `json:"item_id"`
+	LotNumber         string     `json:"lot_number"`
+	ExpiredAt         *time.Time `json:"expired_at"`
+	CountedQuantity   int        `json:"counted_quantity" validate:"min=0"`
+	Notes             string     `json:"notes"`
+}
+
+type StockOpnameCountsUpdateRequest struct {
+	Items []StockOpnameCountRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// StockOpnameCountRow adalah satu baris hasil parsing lembar hitung Excel.
+type StockOpnameCountRow struct {
+	Row             int
+	ItemCode        string
+	LotNumber       string
+	ExpiredAt       *time.Time
+	CountedQuantity int
+	Notes           string
+}
+
+type StockOpnameStatusUpdateRequest struct {
+	Status string `json:"status" validate:"required,oneof=Submitted Approved Rejected Cancelled"`
+	Notes  string `json:"notes"`
+}
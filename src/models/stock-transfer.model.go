@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockTransfer struct {
+	ID                     uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TransferNumber         string         `gorm:"uniqueIndex;not null" json:"transfer_number"`
+	SourceWarehouseID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID      `gorm:"type:uuid;not null;index" json:"destination_warehouse_id"`
+	TransferDate           time.Time      `gorm:"not null" json:"transfer_date"`
+	Status                 string         `gorm:"not null;default:'Draft'" json:"status"` // Draft, InTransit, Received, Cancelled
+	ShippedAt              *time.Time     `json:"shipped_at"`
+	ReceivedAt             *time.Time     `json:"received_at"`
+	Notes                  string         `json:"notes"`
+	CreatedBy              *uuid.UUID     `gorm:"type:uuid" json:"created_by"`
+	ShippedBy              *uuid.UUID     `gorm:"type:uuid" json:"shipped_by"`
+	ReceivedBy             *uuid.UUID     `gorm:"type:uuid" json:"received_by"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	SourceWarehouse      Warehouse           `gorm:"foreignKey:SourceWarehouseID" json:"source_warehouse"`
+	DestinationWarehouse Warehouse           `gorm:"foreignKey:DestinationWarehouseID" json:"destination_warehouse"`
+	StockTransferItems   []StockTransferItem `gorm:"foreignKey:StockTransferID" json:"stock_transfer_items,omitempty"`
+}
+
+type StockTransferItem struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	StockTransferID uuid.UUID `gorm:"type:uuid;not null;index" json:"stock_transfer_id"`
+	ItemID          uuid.UUID `gorm:"type:uuid;not null" json:"item_id"`
+	Quantity        int       `gorm:"not null" json:"quantity"`
+	LotNumber       *string   `json:"lot_number"` // kosong = ambil FEFO di gudang asal
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	Item              Item               `gorm:"foreignKey:ItemID" json:"item"`
+	StockTransferLots []StockTransferLot `gorm:"foreignKey:StockTransferItemID;constraint:OnDelete:CASCADE;" json:"stock_transfer_lots,omitempty"`
+}
+
+// StockTransferLot mencatat lot yang benar-benar keluar dari gudang asal saat dikirim,
+// supaya lot & expiry yang sama bisa diterima di gudang tujuan.
+type StockTransferLot struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	StockTransferItemID uuid.UUID  `gorm:"type:uuid;not null;index" json:"stock_transfer_item_id"`
+	LotNumber           string     `gorm:"size:100;not null" json:"lot_number"`
+	ExpiredAt           *time.Time `json:"expired_at"`
+	Quantity            int        `gorm:"not null" json:"quantity"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+type ResponseGetStockTransfer struct {
+	ID                     uuid.UUID      `json:"id"`
+	TransferNumber         string         `json:"transfer_number"`
+	SourceWarehouseID      uuid.UUID      `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID      `json:"destination_warehouse_id"`
+	TransferDate           time.Time      `json:"transfer_date"`
+	Status                 string         `json:"status"`
+	ShippedAt              *time.Time     `json:"shipped_at"`
+	ReceivedAt             *time.Time     `json:"received_at"`
+	Notes                  string         `json:"notes"`
+	CreatedBy              *uuid.UUID     `json:"created_by"`
+	ShippedBy              *uuid.UUID     `json:"shipped_by"`
+	ReceivedBy             *uuid.UUID     `json:"received_by"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `json:"deleted_at,omitempty"`
+
+	SourceWarehouse      Warehouse           `json:"source_warehouse"`
+	DestinationWarehouse Warehouse           `json:"destination_warehouse"`
+	StockTransferItems   []StockTransferItem `json:"stock_transfer_items,omitempty"`
+}
+
+type StockTransferItemRequest struct {
+	ItemID    uuid.UUID `json:"item_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	LotNumber *string   `json:"lot_number"`
+}
+
+type StockTransferCreateRequest struct {
+	SourceWarehouseID      uuid.UUID                  `json:"source_warehouse_id" validate:"required"`
+	DestinationWarehouseID uuid.UUID                  `json:"destination_warehouse_id" validate:"required"`
+	TransferDate           time.Time                  `json:"transfer_date" validate:"required"`
+	Notes                  string                     `json:"notes"`
+	Items                  []StockTransferItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockTransferUpdateRequest struct {
+	SourceWarehouseID      *uuid.UUID                 `json:"source_warehouse_id"`
+	DestinationWarehouseID *uuid.UUID                 `json:"destination_warehouse_id"`
+	TransferDate           *time.Time                 `json:"transfer_date"`
+	Notes                  *string                    `json:"notes"`
+	Items                  []StockTransferItemRequest `json:"items" validate:"omitempty,min=1,dive"`
+}
+
+type StockTransferStatusUpdateRequest struct {
+	Status string `json:"status" validate:"required,oneof=InTransit Received Cancelled"`
+	Notes  string `json:"notes"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSession adalah satu login per device. Access token membawa ID session (sid) sehingga
+// session yang di-revoke langsung ditolak JWTProtected; refresh token dirotasi setiap dipakai.
+type UserSession struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	UserID              uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	RoleID              *uuid.UUID `gorm:"type:uuid" json:"role_id"` // role saat login; berubah = wajib login ulang
+	DeviceID            string     `gorm:"size:100;index" json:"device_id"`
+	DeviceName          string     `json:"device_name"`
+	UserAgent           string     `json:"user_agent"`
+	IPAddress           string     `json:"ip_address"`
+	RefreshTokenHash    string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	PreviousRefreshHash *string    `gorm:"size:64;index" json:"-"` // untuk deteksi pemakaian ulang refresh token lama
+	RefreshExpiresAt    time.Time  `gorm:"not null" json:"refresh_expires_at"`
+	LastUsedAt          time.Time  `json:"last_used_at"`
+	RevokedAt           *time.Time `gorm:"index" json:"revoked_at"`
+	RevokedReason       string     `json:"revoked_reason"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+type ResponseGetUserSession struct {
+	ID               uuid.UUID `json:"id"`
+	DeviceID         string    `json:"device_id"`
+	DeviceName       string    `json:"device_name"`
+	UserAgent        string    `json:"user_agent"`
+	IPAddress        string    `json:"ip_address"`
+	LastUsedAt       time.Time `json:"last_used_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+	IsCurrent        bool      `json:"is_current"`
+}
+
+type AuthTokenResponse struct {
+	User             *User     `json:"user,omitempty"`
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	SessionID        uuid.UUID `json:"session_id"`
+}
+
+type UserRefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
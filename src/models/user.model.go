@@ -64,12 +64,18 @@ type UserCheckIdentifierRequest struct {
 
 type UserForgotPasswordRequest struct {
 	Identifier    string `json:"identifier" validate:"required"`
-	Password string `json:"password" validate:"required"`
+}
+
+type UserForgotPasswordConfirmRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
 }
 
 type UserLoginRequest struct {
 	Identifier    string `json:"identifier" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	DeviceID   string `json:"device_id"`   // opsional; login ulang di device yang sama menggantikan session lama
+	DeviceName string `json:"device_name"` // opsional, label untuk daftar session
 }
 
 type UserResetPasswordRequest struct {
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Warehouse struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Code        string         `gorm:"uniqueIndex;not null" json:"code"`
+	Address     *string        `json:"address"`
+	Description *string        `json:"description"`
+	IsDefault   bool           `gorm:"default:false" json:"is_default"` // dipakai bila warehouse tidak dipilih saat terima/kirim barang
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	ItemStocks []ItemStock `gorm:"foreignKey:WarehouseID" json:"item_stocks,omitempty"`
+}
+
+type ResponseGetWarehouse struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Code        string         `json:"code"`
+	Address     *string        `json:"address"`
+	Description *string        `json:"description"`
+	IsDefault   bool           `json:"is_default"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty"`
+}
+
+type WarehouseCreateRequest struct {
+	Name        string  `json:"name" xml:"name" form:"name" validate:"required"`
+	Code        string  `json:"code" xml:"code" form:"code" validate:"required"`
+	Address     *string `json:"address" xml:"address" form:"address"`
+	Description *string `json:"description" xml:"description" form:"description"`
+	IsDefault   bool    `json:"is_default" xml:"is_default" form:"is_default"`
+}
+
+type WarehouseUpdateRequest struct {
+	Name        string  `json:"name" xml:"name" form:"name"`
+	Code        string  `json:"code" xml:"code" form:"code"`
+	Address     *string `json:"address" xml:"address" form:"address"`
+	Description *string `json:"description" xml:"description" form:"description"`
+	IsDefault   *bool   `json:"is_default" xml:"is_default" form:"is_default"`
+}
+
+type WarehouseIsHardDeleteRequest struct {
+	IsHardDelete string      `json:"is_hard_delete" validate:"required"`
+	IDs          []uuid.UUID `json:"ids" validate:"required,dive,required"`
+}
+
+type WarehouseRestoreRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,dive,required"`
+}
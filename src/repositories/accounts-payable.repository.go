@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// status PO yang belum menjadi kewajiban ke supplier
+var nonPayablePOStatuses = []string{"Draft", "PendingApproval", "Approved", "Rejected"}
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type AccountsPayableRepository interface {
+	FindOpenPayables(tx *gorm.DB, supplierID string, search string, asOf *time.Time) ([]models.PurchaseOrder, error)
+	FindPayablesDueBefore(tx *gorm.DB, until time.Time, supplierID string) ([]models.PurchaseOrder, error)
+	FindPayablesDueBetween(tx *gorm.DB, start, end time.Time) ([]models.PurchaseOrder, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type AccountsPayableRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAccountsPayableRepository(db *gorm.DB) *AccountsPayableRepositoryImpl {
+	return &AccountsPayableRepositoryImpl{DB: db}
+}
+
+func (r *AccountsPayableRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// openPayables memilih PO yang masih punya sisa hutang; asOf terisi = posisi hutang pada tanggal tersebut.
+func (r *AccountsPayableRepositoryImpl) openPayables(tx *gorm.DB, asOf *time.Time) *gorm.DB {
+	query := r.useDB(tx).
+		Joins("JOIN suppliers ON suppliers.id = purchase_orders.supplier_id").
+		Preload("Supplier").
+		Where("purchase_orders.po_status NOT IN ?", nonPayablePOStatuses)
+	return filterOpenAsOf(query, "purchase_orders", "po_date", "purchase_order_id", asOf)
+}
+
+func filterPayablesBySupplier(query *gorm.DB, supplierID string) *gorm.DB {
+	if supplierID == "" {
+		return query
+	}
+	if supplierUUID, err := uuid.Parse(supplierID); err == nil {
+		query = query.Where("purchase_orders.supplier_id = ?", supplierUUID)
+	}
+	return query
+}
+
+// ---------- Reads ----------
+
+// FindOpenPayables mengembalikan PO yang masih punya sisa hutang, urut per supplier lalu jatuh tempo.
+func (r *AccountsPayableRepositoryImpl) FindOpenPayables(tx *gorm.DB, supplierID string, search string, asOf *time.Time) ([]models.PurchaseOrder, error) {
+	var pos []models.PurchaseOrder
+
+	query := filterPayablesBySupplier(r.openPayables(tx, asOf), supplierID)
+
+	if search != "" {
+		searchPattern := "%" + strings.ToLower(search) + "%"
+		query = query.Where(`
+			LOWER(suppliers.name) LIKE ? OR
+			LOWER(suppliers.code) LIKE ? OR
+			LOWER(purchase_orders.po_number) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.
+		Order("suppliers.name ASC, purchase_orders.due_date ASC NULLS LAST, purchase_orders.po_date ASC").
+		Find(&pos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order")
+	}
+	return pos, nil
+}
+
+// FindPayablesDueBefore mengembalikan PO belum lunas yang jatuh tempo s/d until (termasuk yang sudah lewat).
+func (r *AccountsPayableRepositoryImpl) FindPayablesDueBefore(tx *gorm.DB, until time.Time, supplierID string) ([]models.PurchaseOrder, error) {
+	var pos []models.PurchaseOrder
+
+	query := filterPayablesBySupplier(r.openPayables(tx, nil), supplierID).
+		Where("purchase_orders.due_date IS NOT NULL AND purchase_orders.due_date <= ?", until)
+
+	if err := query.
+		Order("purchase_orders.due_date ASC, suppliers.name ASC").
+		Find(&pos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order")
+	}
+	return pos, nil
+}
+
+// FindPayablesDueBetween mengembalikan PO belum lunas yang jatuh tempo di rentang [start, end].
+func (r *AccountsPayableRepositoryImpl) FindPayablesDueBetween(tx *gorm.DB, start, end time.Time) ([]models.PurchaseOrder, error) {
+	var pos []models.PurchaseOrder
+	if err := r.openPayables(tx, nil).
+		Where("purchase_orders.due_date BETWEEN ? AND ?", start, end).
+		Order("purchase_orders.due_date ASC, suppliers.name ASC").
+		Find(&pos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order")
+	}
+	return pos, nil
+}
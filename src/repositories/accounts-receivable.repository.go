@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type AccountsReceivableRepository interface {
+	FindOpenInvoices(tx *gorm.DB, req *models.ARAgingRequest, asOf *time.Time) ([]models.SalesOrder, error)
+	FindCustomerInvoices(tx *gorm.DB, customerID uuid.UUID, until time.Time) ([]models.SalesOrder, error)
+	FindCustomerPayments(tx *gorm.DB, customerID uuid.UUID, until time.Time) ([]models.Payment, error)
+	FindCustomerCreditNotes(tx *gorm.DB, customerID uuid.UUID) ([]models.SalesReturn, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type AccountsReceivableRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAccountsReceivableRepository(db *gorm.DB) *AccountsReceivableRepositoryImpl {
+	return &AccountsReceivableRepositoryImpl{DB: db}
+}
+
+func (r *AccountsReceivableRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+// FindOpenInvoices mengembalikan SO yang masih punya sisa tagihan (bukan Draft/Cancelled),
+// urut per customer lalu jatuh tempo. asOf terisi = posisi piutang pada tanggal tersebut.
+func (r *AccountsReceivableRepositoryImpl) FindOpenInvoices(tx *gorm.DB, req *models.ARAgingRequest, asOf *time.Time) ([]models.SalesOrder, error) {
+	var sos []models.SalesOrder
+
+	query := r.useDB(tx).
+		Joins("JOIN customers ON customers.id = sales_orders.customer_id AND customers.deleted_at IS NULL").
+		Preload("Customer").
+		Preload("Customer.Area").
+		Preload("SalesPerson").
+		Where("sales_orders.so_status NOT IN ?", []string{"Draft", "Cancelled"})
+	query = filterOpenAsOf(query, "sales_orders", "so_date", "sales_order_id", asOf)
+
+	if req.AreaID != "" {
+		if areaUUID, err := uuid.Parse(req.AreaID); err == nil {
+			query = query.Where("customers.area_id = ?", areaUUID)
+		}
+	}
+
+	if req.SalesPersonID != "" {
+		if spUUID, err := uuid.Parse(req.SalesPersonID); err == nil {
+			query = query.Where("sales_orders.sales_person_id = ?", spUUID)
+		}
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("sales_orders.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(customers.name) LIKE ? OR
+			LOWER(customers.nomor) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.
+		Order("customers.name ASC, sales_orders.due_date ASC NULLS LAST, sales_orders.so_date ASC").
+		Find(&sos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_order")
+	}
+	return sos, nil
+}
+
+// FindCustomerInvoices mengembalikan seluruh SO customer (bukan Draft/Cancelled) s/d tanggal until.
+func (r *AccountsReceivableRepositoryImpl) FindCustomerInvoices(tx *gorm.DB, customerID uuid.UUID, until time.Time) ([]models.SalesOrder, error) {
+	var sos []models.SalesOrder
+	if err := r.useDB(tx).
+		Where("customer_id = ?", customerID).
+		Where("so_status NOT IN ?", []string{"Draft", "Cancelled"}).
+		Where("so_date <= ?", until).
+		Order("so_date ASC, so_number ASC").
+		Find(&sos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_order")
+	}
+	return sos, nil
+}
+
+// FindCustomerPayments mengembalikan pembayaran (termasuk refund bernilai negatif) atas SO customer s/d until.
+func (r *AccountsReceivableRepositoryImpl) FindCustomerPayments(tx *gorm.DB, customerID uuid.UUID, until time.Time) ([]models.Payment, error) {
+	var payments []models.Payment
+	if err := r.useDB(tx).
+		Joins("JOIN sales_orders ON sales_orders.id = payments.sales_order_id").
+		Preload("SalesOrder").
+		Where("sales_orders.customer_id = ? AND sales_orders.deleted_at IS NULL", customerID).
+		Where("sales_orders.so_status NOT IN ?", []string{"Draft", "Cancelled"}).
+		Where("payments.payment_date <= ?", until).
+		Order("payments.payment_date ASC, payments.created_at ASC").
+		Find(&payments).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment")
+	}
+	return payments, nil
+}
+
+// FindCustomerCreditNotes mengembalikan seluruh sales return Completed (credit note) customer;
+// dipakai juga untuk merekonstruksi nilai invoice awal (TotalAmount SO sudah dikurangi credit note).
+func (r *AccountsReceivableRepositoryImpl) FindCustomerCreditNotes(tx *gorm.DB, customerID uuid.UUID) ([]models.SalesReturn, error) {
+	var returns []models.SalesReturn
+	if err := r.useDB(tx).
+		Preload("SalesOrder").
+		Where("customer_id = ? AND status = ?", customerID, "Completed").
+		Order("completed_at ASC").
+		Find(&returns).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_return")
+	}
+	return returns, nil
+}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type BankStatementRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.BankStatement, int64, error)
+	FindById(tx *gorm.DB, statementId string) (*models.BankStatement, error)
+	FindLinesPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.BankStatementLine, int64, error)
+	FindLineById(tx *gorm.DB, lineId string, forUpdate bool) (*models.BankStatementLine, error)
+	FindOpenLines(tx *gorm.DB, statementID uuid.UUID) ([]models.BankStatementLine, error)
+	FindExistingFingerprints(tx *gorm.DB, fingerprints []string) (map[string]bool, error)
+	Insert(tx *gorm.DB, statement *models.BankStatement) (*models.BankStatement, error)
+	UpdateLine(tx *gorm.DB, line *models.BankStatementLine) error
+	ReplaceSuggestions(tx *gorm.DB, lineID uuid.UUID, suggestions []models.BankStatementSuggestion) error
+}
+
+// status baris yang masih menunggu rekonsiliasi
+var openBankLineStatuses = []string{models.BankLineUnmatched, models.BankLineSuggested, models.BankLineAutoMatched}
+
+// ==============================
+// Implementation
+// ==============================
+
+type BankStatementRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewBankStatementRepository(db *gorm.DB) *BankStatementRepositoryImpl {
+	return &BankStatementRepositoryImpl{DB: db}
+}
+
+func (r *BankStatementRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+func orderSuggestions(db *gorm.DB) *gorm.DB {
+	return db.Order("score DESC, outstanding ASC")
+}
+
+// ---------- Reads ----------
+
+func (r *BankStatementRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.BankStatement, int64, error) {
+	var (
+		statements []models.BankStatement
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("ImportedByUser")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("bank_statements.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("bank_statements.deleted_at IS NULL")
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(bank_statements.filename) LIKE ? OR
+			LOWER(bank_statements.account_number) LIKE ? OR
+			LOWER(bank_statements.bank) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.BankStatement{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "bank_statement")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("bank_statements.created_at DESC").Offset(offset).Limit(req.Limit).Find(&statements).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "bank_statement")
+	}
+
+	return statements, totalCount, nil
+}
+
+func (r *BankStatementRepositoryImpl) FindById(tx *gorm.DB, statementId string) (*models.BankStatement, error) {
+	var statement models.BankStatement
+	if err := r.useDB(tx).
+		Preload("ImportedByUser").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("line_no ASC") }).
+		Preload("Lines.Suggestions", orderSuggestions).
+		Preload("Lines.Receipt").
+		First(&statement, "id = ?", statementId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "bank_statement")
+	}
+	return &statement, nil
+}
+
+// FindLinesPaginated menampilkan antrian rekonsiliasi; tanpa filter status hanya baris yang belum selesai.
+func (r *BankStatementRepositoryImpl) FindLinesPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.BankStatementLine, int64, error) {
+	var (
+		lines      []models.BankStatementLine
+		totalCount int64
+	)
+
+	query := r.useDB(tx).
+		Joins("JOIN bank_statements ON bank_statements.id = bank_statement_lines.bank_statement_id AND bank_statements.deleted_at IS NULL").
+		Preload("BankStatement").
+		Preload("Suggestions", orderSuggestions).
+		Preload("Receipt")
+
+	switch req.LineStatus {
+	case "":
+		query = query.Where("bank_statement_lines.status IN ?", openBankLineStatuses)
+	case "all":
+		// no filter
+	default:
+		query = query.Where("bank_statement_lines.status = ?", req.LineStatus)
+	}
+
+	if req.BankStatementID != "" {
+		if statementUUID, err := uuid.Parse(req.BankStatementID); err == nil {
+			query = query.Where("bank_statement_lines.bank_statement_id = ?", statementUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(bank_statement_lines.description) LIKE ? OR
+			LOWER(bank_statement_lines.reference) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.BankStatementLine{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "bank_statement")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("bank_statement_lines.transaction_date ASC, bank_statement_lines.line_no ASC").Offset(offset).Limit(req.Limit).Find(&lines).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "bank_statement")
+	}
+
+	return lines, totalCount, nil
+}
+
+func (r *BankStatementRepositoryImpl) FindLineById(tx *gorm.DB, lineId string, forUpdate bool) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci baris saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&line, "id = ?", lineId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "bank_statement")
+		}
+	}
+
+	if err := db.
+		Preload("BankStatement").
+		Preload("Suggestions", orderSuggestions).
+		Preload("Receipt").
+		First(&line, "id = ?", lineId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "bank_statement")
+	}
+	return &line, nil
+}
+
+func (r *BankStatementRepositoryImpl) FindOpenLines(tx *gorm.DB, statementID uuid.UUID) ([]models.BankStatementLine, error) {
+	var lines []models.BankStatementLine
+	if err := r.useDB(tx).
+		Where("bank_statement_id = ? AND status IN ?", statementID, openBankLineStatuses).
+		Order("line_no ASC").
+		Find(&lines).Error; err != nil {
+		return nil, HandleDatabaseError(err, "bank_statement")
+	}
+	return lines, nil
+}
+
+func (r *BankStatementRepositoryImpl) FindExistingFingerprints(tx *gorm.DB, fingerprints []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(fingerprints) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	if err := r.useDB(tx).Model(&models.BankStatementLine{}).
+		Where("fingerprint IN ?", fingerprints).
+		Pluck("fingerprint", &found).Error; err != nil {
+		return nil, HandleDatabaseError(err, "bank_statement")
+	}
+	for _, fp := range found {
+		existing[fp] = true
+	}
+	return existing, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *BankStatementRepositoryImpl) Insert(tx *gorm.DB, statement *models.BankStatement) (*models.BankStatement, error) {
+	if statement.ID == uuid.Nil {
+		return nil, fmt.Errorf("bank statement ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("ImportedByUser", "Lines.BankStatement", "Lines.Receipt").Create(statement).Error; err != nil {
+		return nil, HandleDatabaseError(err, "bank_statement")
+	}
+	return statement, nil
+}
+
+func (r *BankStatementRepositoryImpl) UpdateLine(tx *gorm.DB, line *models.BankStatementLine) error {
+	if line.ID == uuid.Nil {
+		return fmt.Errorf("bank statement line ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(line).Error; err != nil {
+		return HandleDatabaseError(err, "bank_statement")
+	}
+	return nil
+}
+
+func (r *BankStatementRepositoryImpl) ReplaceSuggestions(tx *gorm.DB, lineID uuid.UUID, suggestions []models.BankStatementSuggestion) error {
+	db := r.useDB(tx)
+	if err := db.Where("line_id = ?", lineID).Delete(&models.BankStatementSuggestion{}).Error; err != nil {
+		return HandleDatabaseError(err, "bank_statement")
+	}
+	if len(suggestions) == 0 {
+		return nil
+	}
+	if err := db.Create(&suggestions).Error; err != nil {
+		return HandleDatabaseError(err, "bank_statement")
+	}
+	return nil
+}
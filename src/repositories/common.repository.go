@@ -31,6 +31,28 @@ var (
 	ErrPurchaseOrderItemNotFound = errors.New("purchase order item not found")
 	ErrNotificationNotFound = errors.New("notification not found")
 	ErrUoMNotFound = errors.New("UoM not found")
+	ErrItemLotNotFound = errors.New("item lot not found")
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	ErrItemStockNotFound = errors.New("item stock not found")
+	ErrStockTransferNotFound = errors.New("stock transfer not found")
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrUserSessionNotFound = errors.New("user session not found")
+	ErrDocumentLinkNotFound = errors.New("document link not found")
+	ErrSalesReturnNotFound = errors.New("sales return not found")
+	ErrStockOpnameNotFound = errors.New("stock opname not found")
+	ErrPurchaseReturnNotFound = errors.New("purchase return not found")
+	ErrGoodsReceiptNotFound = errors.New("goods receipt not found")
+	ErrNumberSequenceNotFound = errors.New("number sequence not found")
+	ErrPurchaseOrderApprovalNotFound = errors.New("purchase order approval not found")
+	ErrPurchaseOrderApprovalRuleNotFound = errors.New("purchase order approval rule not found")
+	ErrSalesQuotationNotFound = errors.New("sales quotation not found")
+	ErrShipmentNotFound = errors.New("shipment not found")
+	ErrCreditOverrideNotFound = errors.New("credit override not found")
+	ErrPriceListNotFound = errors.New("price list not found")
+	ErrPaymentRunNotFound = errors.New("payment run not found")
+	ErrDunningReminderNotFound = errors.New("dunning reminder not found")
+	ErrPaymentReceiptNotFound = errors.New("payment receipt not found")
+	ErrBankStatementNotFound = errors.New("bank statement not found")
 	ErrDatabase        = errors.New("database error")
 	ErrUniqueViolation = errors.New("unique constraint violation")
 )
@@ -80,6 +102,50 @@ func HandleDatabaseError(err error, entity string) error {
 			return ErrNotificationNotFound
 		case "uom":
 			return ErrUoMNotFound
+		case "item_lot":
+			return ErrItemLotNotFound
+		case "warehouse":
+			return ErrWarehouseNotFound
+		case "item_stock":
+			return ErrItemStockNotFound
+		case "stock_transfer":
+			return ErrStockTransferNotFound
+		case "password_reset_token":
+			return ErrPasswordResetTokenNotFound
+		case "user_session":
+			return ErrUserSessionNotFound
+		case "document_link":
+			return ErrDocumentLinkNotFound
+		case "sales_return":
+			return ErrSalesReturnNotFound
+		case "stock_opname":
+			return ErrStockOpnameNotFound
+		case "purchase_return":
+			return ErrPurchaseReturnNotFound
+		case "goods_receipt":
+			return ErrGoodsReceiptNotFound
+		case "number_sequence":
+			return ErrNumberSequenceNotFound
+		case "purchase_order_approval":
+			return ErrPurchaseOrderApprovalNotFound
+		case "purchase_order_approval_rule":
+			return ErrPurchaseOrderApprovalRuleNotFound
+		case "sales_quotation":
+			return ErrSalesQuotationNotFound
+		case "shipment":
+			return ErrShipmentNotFound
+		case "credit_override":
+			return ErrCreditOverrideNotFound
+		case "price_list":
+			return ErrPriceListNotFound
+		case "payment_run":
+			return ErrPaymentRunNotFound
+		case "dunning_reminder":
+			return ErrDunningReminderNotFound
+		case "payment_receipt":
+			return ErrPaymentReceiptNotFound
+		case "bank_statement":
+			return ErrBankStatementNotFound
 		default:
 			return fmt.Errorf("%w: entity not found", ErrDatabase)
 		}
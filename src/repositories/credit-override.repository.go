@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type CreditOverrideRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.CreditOverride, int64, error)
+	FindBySalesOrder(tx *gorm.DB, salesOrderID string) ([]models.CreditOverride, error)
+	Insert(tx *gorm.DB, override *models.CreditOverride) (*models.CreditOverride, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type CreditOverrideRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCreditOverrideRepository(db *gorm.DB) *CreditOverrideRepositoryImpl {
+	return &CreditOverrideRepositoryImpl{DB: db}
+}
+
+func (r *CreditOverrideRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *CreditOverrideRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.CreditOverride, int64, error) {
+	var (
+		overrides  []models.CreditOverride
+		totalCount int64
+	)
+
+	query := r.useDB(tx).
+		Preload("SalesOrder").
+		Preload("Customer").
+		Preload("ApprovedByUser")
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("credit_overrides.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.SalesOrderID != "" {
+		if soUUID, err := uuid.Parse(req.SalesOrderID); err == nil {
+			query = query.Where("credit_overrides.sales_order_id = ?", soUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(credit_overrides.reason) LIKE ? OR
+			LOWER(credit_overrides.hold_reasons) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.CreditOverride{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "credit_override")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("credit_overrides.created_at DESC").Offset(offset).Limit(req.Limit).Find(&overrides).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "credit_override")
+	}
+
+	return overrides, totalCount, nil
+}
+
+func (r *CreditOverrideRepositoryImpl) FindBySalesOrder(tx *gorm.DB, salesOrderID string) ([]models.CreditOverride, error) {
+	var overrides []models.CreditOverride
+	if err := r.useDB(tx).
+		Preload("ApprovedByUser").
+		Where("sales_order_id = ?", salesOrderID).
+		Order("created_at ASC").
+		Find(&overrides).Error; err != nil {
+		return nil, HandleDatabaseError(err, "credit_override")
+	}
+	return overrides, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *CreditOverrideRepositoryImpl) Insert(tx *gorm.DB, override *models.CreditOverride) (*models.CreditOverride, error) {
+	if override.ID == uuid.Nil {
+		return nil, fmt.Errorf("credit override ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("SalesOrder", "Customer", "ApprovedByUser").Create(override).Error; err != nil {
+		return nil, HandleDatabaseError(err, "credit_override")
+	}
+	return override, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type DocumentLinkRepository interface {
+	FindById(tx *gorm.DB, linkId string, forUpdate bool) (*models.DocumentLink, error)
+	Insert(tx *gorm.DB, link *models.DocumentLink) (*models.DocumentLink, error)
+	Update(tx *gorm.DB, link *models.DocumentLink) (*models.DocumentLink, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type DocumentLinkRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewDocumentLinkRepository(db *gorm.DB) *DocumentLinkRepositoryImpl {
+	return &DocumentLinkRepositoryImpl{DB: db}
+}
+
+func (r *DocumentLinkRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *DocumentLinkRepositoryImpl) FindById(tx *gorm.DB, linkId string, forUpdate bool) (*models.DocumentLink, error) {
+	var link models.DocumentLink
+	db := r.useDB(tx)
+	if forUpdate {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := db.First(&link, "id = ?", linkId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "document_link")
+	}
+	return &link, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *DocumentLinkRepositoryImpl) Insert(tx *gorm.DB, link *models.DocumentLink) (*models.DocumentLink, error) {
+	if link.ID == uuid.Nil {
+		return nil, fmt.Errorf("document link ID cannot be empty")
+	}
+	if err := r.useDB(tx).Create(link).Error; err != nil {
+		return nil, HandleDatabaseError(err, "document_link")
+	}
+	return link, nil
+}
+
+func (r *DocumentLinkRepositoryImpl) Update(tx *gorm.DB, link *models.DocumentLink) (*models.DocumentLink, error) {
+	if link.ID == uuid.Nil {
+		return nil, fmt.Errorf("document link ID cannot be empty")
+	}
+	if err := r.useDB(tx).Save(link).Error; err != nil {
+		return nil, HandleDatabaseError(err, "document_link")
+	}
+	return link, nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type DunningReminderRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.DunningReminder, int64, error)
+	FindOverdueSalesOrders(tx *gorm.DB, dueBefore time.Time) ([]models.SalesOrder, error)
+	FindBySalesOrders(tx *gorm.DB, soIDs []uuid.UUID) ([]models.DunningReminder, error)
+	InsertIfMissing(tx *gorm.DB, reminder *models.DunningReminder) (bool, error)
+	Update(tx *gorm.DB, reminder *models.DunningReminder) (*models.DunningReminder, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type DunningReminderRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewDunningReminderRepository(db *gorm.DB) *DunningReminderRepositoryImpl {
+	return &DunningReminderRepositoryImpl{DB: db}
+}
+
+func (r *DunningReminderRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *DunningReminderRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.DunningReminder, int64, error) {
+	var (
+		reminders  []models.DunningReminder
+		totalCount int64
+	)
+
+	query := r.useDB(tx).
+		Joins("JOIN sales_orders ON sales_orders.id = dunning_reminders.sales_order_id").
+		Preload("SalesOrder").
+		Preload("Customer")
+
+	if req.SalesOrderID != "" {
+		if soUUID, err := uuid.Parse(req.SalesOrderID); err == nil {
+			query = query.Where("dunning_reminders.sales_order_id = ?", soUUID)
+		}
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("dunning_reminders.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.SalesPersonID != "" {
+		if spUUID, err := uuid.Parse(req.SalesPersonID); err == nil {
+			query = query.Where("sales_orders.sales_person_id = ?", spUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(sales_orders.so_number) LIKE ? OR
+			LOWER(dunning_reminders.email_to) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.DunningReminder{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "dunning_reminder")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("dunning_reminders.sent_at DESC").Offset(offset).Limit(req.Limit).Find(&reminders).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "dunning_reminder")
+	}
+
+	return reminders, totalCount, nil
+}
+
+// FindOverdueSalesOrders mengembalikan SO Tempo aktif yang belum lunas dan jatuh tempo sebelum dueBefore.
+func (r *DunningReminderRepositoryImpl) FindOverdueSalesOrders(tx *gorm.DB, dueBefore time.Time) ([]models.SalesOrder, error) {
+	var sos []models.SalesOrder
+	if err := r.useDB(tx).
+		Preload("Customer").
+		Preload("SalesPerson").
+		Where("term_of_payment = ?", "Tempo").
+		Where("payment_status <> ?", "Paid").
+		Where("so_status NOT IN ?", []string{"Draft", "Cancelled"}).
+		Where("due_date IS NOT NULL AND due_date < ?", dueBefore).
+		Where("total_amount > paid_amount").
+		Order("due_date ASC").
+		Find(&sos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_order")
+	}
+	return sos, nil
+}
+
+func (r *DunningReminderRepositoryImpl) FindBySalesOrders(tx *gorm.DB, soIDs []uuid.UUID) ([]models.DunningReminder, error) {
+	var reminders []models.DunningReminder
+	if len(soIDs) == 0 {
+		return reminders, nil
+	}
+	if err := r.useDB(tx).
+		Where("sales_order_id IN ?", soIDs).
+		Order("stage ASC").
+		Find(&reminders).Error; err != nil {
+		return nil, HandleDatabaseError(err, "dunning_reminder")
+	}
+	return reminders, nil
+}
+
+// ---------- Mutations ----------
+
+// InsertIfMissing mencatat pengingat; false bila tahap tersebut sudah tercatat untuk SO yang sama.
+// Baris yang email-nya Failed diambil alih (ditimpa) sehingga tahap tersebut dicoba kirim ulang.
+func (r *DunningReminderRepositoryImpl) InsertIfMissing(tx *gorm.DB, reminder *models.DunningReminder) (bool, error) {
+	if reminder.ID == uuid.Nil {
+		return false, fmt.Errorf("dunning reminder ID cannot be empty")
+	}
+	res := r.useDB(tx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "sales_order_id"}, {Name: "stage"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"id", "customer_id", "level", "days_overdue", "outstanding", "email_to",
+				"email_status", "email_error", "notified_user_id", "sent_at", "updated_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: "dunning_reminders", Name: "email_status"}, Value: "Failed"},
+			}},
+		}).
+		Create(reminder)
+	if res.Error != nil {
+		return false, HandleDatabaseError(res.Error, "dunning_reminder")
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *DunningReminderRepositoryImpl) Update(tx *gorm.DB, reminder *models.DunningReminder) (*models.DunningReminder, error) {
+	if reminder.ID == uuid.Nil {
+		return nil, fmt.Errorf("dunning reminder ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(reminder).Error; err != nil {
+		return nil, HandleDatabaseError(err, "dunning_reminder")
+	}
+	return reminder, nil
+}
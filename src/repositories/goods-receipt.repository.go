@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type GoodsReceiptRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.GoodsReceipt, int64, error)
+	FindById(tx *gorm.DB, receiptId string) (*models.GoodsReceipt, error)
+	FindByPurchaseOrder(tx *gorm.DB, poId string) ([]models.GoodsReceipt, error)
+	Insert(tx *gorm.DB, receipt *models.GoodsReceipt) (*models.GoodsReceipt, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type GoodsReceiptRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewGoodsReceiptRepository(db *gorm.DB) *GoodsReceiptRepositoryImpl {
+	return &GoodsReceiptRepositoryImpl{DB: db}
+}
+
+func (r *GoodsReceiptRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *GoodsReceiptRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.GoodsReceipt, int64, error) {
+	var (
+		receipts   []models.GoodsReceipt
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("PurchaseOrder").
+		Preload("Supplier").
+		Preload("Warehouse").
+		Preload("GoodsReceiptItems").
+		Preload("GoodsReceiptItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("goods_receipts.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("goods_receipts.deleted_at IS NULL")
+	}
+
+	if req.PurchaseOrderID != "" {
+		if poUUID, err := uuid.Parse(req.PurchaseOrderID); err == nil {
+			query = query.Where("goods_receipts.purchase_order_id = ?", poUUID)
+		}
+	}
+
+	if req.SupplierID != "" {
+		if supplierUUID, err := uuid.Parse(req.SupplierID); err == nil {
+			query = query.Where("goods_receipts.supplier_id = ?", supplierUUID)
+		}
+	}
+
+	if req.WarehouseID != "" {
+		if warehouseUUID, err := uuid.Parse(req.WarehouseID); err == nil {
+			query = query.Where("goods_receipts.warehouse_id = ?", warehouseUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(goods_receipts.gr_number) LIKE ? OR
+			LOWER(goods_receipts.delivery_note_number) LIKE ? OR
+			LOWER(goods_receipts.receiver_name) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.GoodsReceipt{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "goods_receipt")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("goods_receipts.receipt_date DESC, goods_receipts.created_at DESC").Offset(offset).Limit(req.Limit).Find(&receipts).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "goods_receipt")
+	}
+
+	return receipts, totalCount, nil
+}
+
+func (r *GoodsReceiptRepositoryImpl) FindById(tx *gorm.DB, receiptId string) (*models.GoodsReceipt, error) {
+	var receipt models.GoodsReceipt
+	if err := r.useDB(tx).
+		Preload("PurchaseOrder").
+		Preload("Supplier").
+		Preload("Warehouse").
+		Preload("ReceivedByUser").
+		Preload("GoodsReceiptItems").
+		Preload("GoodsReceiptItems.Item").
+		First(&receipt, "id = ?", receiptId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "goods_receipt")
+	}
+	return &receipt, nil
+}
+
+// FindByPurchaseOrder mengembalikan riwayat penerimaan satu PO, urut dari kedatangan pertama.
+func (r *GoodsReceiptRepositoryImpl) FindByPurchaseOrder(tx *gorm.DB, poId string) ([]models.GoodsReceipt, error) {
+	var receipts []models.GoodsReceipt
+	if err := r.useDB(tx).
+		Preload("Warehouse").
+		Preload("ReceivedByUser").
+		Preload("GoodsReceiptItems").
+		Preload("GoodsReceiptItems.Item").
+		Where("purchase_order_id = ?", poId).
+		Order("receipt_date ASC, created_at ASC").
+		Find(&receipts).Error; err != nil {
+		return nil, HandleDatabaseError(err, "goods_receipt")
+	}
+	return receipts, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *GoodsReceiptRepositoryImpl) Insert(tx *gorm.DB, receipt *models.GoodsReceipt) (*models.GoodsReceipt, error) {
+	if receipt.ID == uuid.Nil {
+		return nil, fmt.Errorf("goods receipt ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("PurchaseOrder", "Supplier", "Warehouse", "ReceivedByUser", "GoodsReceiptItems.Item").Create(receipt).Error; err != nil {
+		return nil, HandleDatabaseError(err, "goods_receipt")
+	}
+	return receipt, nil
+}
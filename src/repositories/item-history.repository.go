@@ -50,8 +50,8 @@ func (r *ItemHistoryRepositoryImpl) FindAllByItem(tx *gorm.DB, itemID uuid.UUID,
 
 	var changeGroup []string
 	switch strings.ToLower(changeType) {
-	case "stock", "create_stock", "update_stock":
-		changeGroup = []string{"create_stock", "update_stock"}
+	case "stock", "create_stock", "update_stock", "stock_opname":
+		changeGroup = []string{"create_stock", "update_stock", "stock_opname"}
 	case "price", "create_price", "update_price":
 		changeGroup = []string{"create_price", "update_price"}
 	default:
@@ -75,6 +75,7 @@ func (r *ItemHistoryRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.Pa
 
 	query := r.useDB(tx).Unscoped().
 		Preload("Item").
+		Preload("Lot").
 		Preload("CreatedByUser").
 		Preload("UpdatedByUser")
 
@@ -97,8 +98,8 @@ func (r *ItemHistoryRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.Pa
 
 	if req.ChangeType != "" {
 		switch strings.ToLower(req.ChangeType) {
-		case "stock", "create_stock", "update_stock":
-			query = query.Where("change_type IN ?", []string{"create_stock", "update_stock"})
+		case "stock", "create_stock", "update_stock", "stock_opname":
+			query = query.Where("change_type IN ?", []string{"create_stock", "update_stock", "stock_opname"})
 		case "price", "create_price", "update_price":
 			query = query.Where("change_type IN ?", []string{"create_price", "update_price"})
 		default:
@@ -149,6 +150,7 @@ func (r *ItemHistoryRepositoryImpl) FindById(tx *gorm.DB, itemHistoryId string,
 
 	if err := db.
 		Preload("Item").
+		Preload("Lot").
 		Preload("CreatedByUser").
 		Preload("UpdatedByUser").
 		First(&ih, "id = ?", itemHistoryId).Error; err != nil {
@@ -163,8 +165,8 @@ func (r *ItemHistoryRepositoryImpl) FindLastByItem(tx *gorm.DB, itemID uuid.UUID
 
 	var changeGroup []string
 	switch strings.ToLower(changeType) {
-	case "stock", "create_stock", "update_stock":
-		changeGroup = []string{"create_stock", "update_stock"}
+	case "stock", "create_stock", "update_stock", "stock_opname":
+		changeGroup = []string{"create_stock", "update_stock", "stock_opname"}
 	case "price", "create_price", "update_price":
 		changeGroup = []string{"create_price", "update_price"}
 	default:
@@ -173,6 +175,7 @@ func (r *ItemHistoryRepositoryImpl) FindLastByItem(tx *gorm.DB, itemID uuid.UUID
 
 	if err := db.
 		Preload("Item").
+		Preload("Lot").
 		Preload("CreatedByUser").
 		Preload("UpdatedByUser").
 		Where("item_id = ? AND change_type IN ?", itemID, changeGroup).
@@ -228,6 +231,7 @@ func (r *ItemHistoryRepositoryImpl) Restore(tx *gorm.DB, itemHistoryId string) (
 	var restored models.ItemHistory
 	if err := db.
 		Preload("Item").
+		Preload("Lot").
 		Preload("CreatedByUser").
 		Preload("UpdatedByUser").
 		First(&restored, "id = ?", itemHistoryId).Error; err != nil {
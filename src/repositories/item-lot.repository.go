@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type ItemLotRepository interface {
+	FindAllByItem(tx *gorm.DB, itemID uuid.UUID, includeEmpty bool) ([]models.ItemLot, error)
+	FindById(tx *gorm.DB, lotId string) (*models.ItemLot, error)
+	FindByItemAndLotNumber(tx *gorm.DB, itemID, warehouseID uuid.UUID, lotNumber string) (*models.ItemLot, error)
+	FindAvailableFEFO(tx *gorm.DB, itemID, warehouseID uuid.UUID, asOf time.Time) ([]models.ItemLot, error)
+	SumQuantityByItem(tx *gorm.DB, itemID uuid.UUID) (int, error)
+	SumQuantityByItemAndWarehouse(tx *gorm.DB, itemID, warehouseID uuid.UUID) (int, error)
+	FindExpiringUntil(tx *gorm.DB, until time.Time, warehouseID *uuid.UUID) ([]models.ItemLot, error)
+	FindForSnapshot(tx *gorm.DB, warehouseID uuid.UUID, categoryID *uuid.UUID) ([]models.ItemLot, error)
+	FindUnitCosts(tx *gorm.DB, lotIDs []uuid.UUID) (map[uuid.UUID]LotUnitCost, error)
+	Insert(tx *gorm.DB, lot *models.ItemLot) (*models.ItemLot, error)
+	Update(tx *gorm.DB, lot *models.ItemLot) (*models.ItemLot, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type ItemLotRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewItemLotRepository(db *gorm.DB) *ItemLotRepositoryImpl {
+	return &ItemLotRepositoryImpl{DB: db}
+}
+
+func (r *ItemLotRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *ItemLotRepositoryImpl) FindAllByItem(tx *gorm.DB, itemID uuid.UUID, includeEmpty bool) ([]models.ItemLot, error) {
+	var lots []models.ItemLot
+	query := r.useDB(tx).Preload("Warehouse").Where("item_id = ?", itemID)
+	if !includeEmpty {
+		query = query.Where("quantity > 0")
+	}
+
+	if err := query.
+		Order("expired_at ASC NULLS LAST").
+		Order("received_at ASC").
+		Find(&lots).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lots, nil
+}
+
+func (r *ItemLotRepositoryImpl) FindById(tx *gorm.DB, lotId string) (*models.ItemLot, error) {
+	var lot models.ItemLot
+	if err := r.useDB(tx).First(&lot, "id = ?", lotId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return &lot, nil
+}
+
+func (r *ItemLotRepositoryImpl) FindByItemAndLotNumber(tx *gorm.DB, itemID, warehouseID uuid.UUID, lotNumber string) (*models.ItemLot, error) {
+	var lot models.ItemLot
+	if err := r.useDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("item_id = ? AND warehouse_id = ? AND lot_number = ?", itemID, warehouseID, lotNumber).
+		First(&lot).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return &lot, nil
+}
+
+// FindAvailableFEFO mengunci lot di satu gudang yang masih ada qty & belum expired,
+// urut expiry paling dekat dulu (lot tanpa expiry paling akhir).
+func (r *ItemLotRepositoryImpl) FindAvailableFEFO(tx *gorm.DB, itemID, warehouseID uuid.UUID, asOf time.Time) ([]models.ItemLot, error) {
+	var lots []models.ItemLot
+	if err := r.useDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("item_id = ? AND warehouse_id = ? AND quantity > 0", itemID, warehouseID).
+		Where("expired_at IS NULL OR expired_at >= ?", asOf).
+		Order("expired_at ASC NULLS LAST").
+		Order("received_at ASC").
+		Find(&lots).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lots, nil
+}
+
+func (r *ItemLotRepositoryImpl) SumQuantityByItem(tx *gorm.DB, itemID uuid.UUID) (int, error) {
+	var total int
+	if err := r.useDB(tx).
+		Model(&models.ItemLot{}).
+		Where("item_id = ?", itemID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, HandleDatabaseError(err, "item_lot")
+	}
+	return total, nil
+}
+
+func (r *ItemLotRepositoryImpl) SumQuantityByItemAndWarehouse(tx *gorm.DB, itemID, warehouseID uuid.UUID) (int, error) {
+	var total int
+	if err := r.useDB(tx).
+		Model(&models.ItemLot{}).
+		Where("item_id = ? AND warehouse_id = ?", itemID, warehouseID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, HandleDatabaseError(err, "item_lot")
+	}
+	return total, nil
+}
+
+// FindExpiringUntil mengambil lot yang masih ada qty dengan expiry <= until
+// (termasuk yang sudah expired), urut expiry terdekat dulu.
+func (r *ItemLotRepositoryImpl) FindExpiringUntil(tx *gorm.DB, until time.Time, warehouseID *uuid.UUID) ([]models.ItemLot, error) {
+	var lots []models.ItemLot
+	query := r.useDB(tx).
+		Preload("Item").
+		Preload("Item.UoM").
+		Preload("Warehouse").
+		Joins("JOIN items ON items.id = item_lots.item_id AND items.deleted_at IS NULL").
+		Where("item_lots.quantity > 0").
+		Where("item_lots.expired_at IS NOT NULL AND item_lots.expired_at <= ?", until)
+
+	if warehouseID != nil && *warehouseID != uuid.Nil {
+		query = query.Where("item_lots.warehouse_id = ?", *warehouseID)
+	}
+
+	if err := query.
+		Order("item_lots.expired_at ASC").
+		Order("items.name ASC").
+		Find(&lots).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lots, nil
+}
+
+// LotUnitCost adalah harga beli per unit sebuah lot. Source "lot_po" bila lot berasal
+// dari penerimaan PO, "last_po" bila diambil dari PO terakhir item tsb.
+type LotUnitCost struct {
+	LotID    uuid.UUID
+	UnitCost *int
+	Source   string
+}
+
+func (r *ItemLotRepositoryImpl) FindUnitCosts(tx *gorm.DB, lotIDs []uuid.UUID) (map[uuid.UUID]LotUnitCost, error) {
+	out := make(map[uuid.UUID]LotUnitCost, len(lotIDs))
+	if len(lotIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		LotID   uuid.UUID
+		LotCost *int
+		LastPO  *int
+	}
+	if err := r.useDB(tx).
+		Table("item_lots AS l").
+		Select(`l.id AS lot_id,
+			poi.unit_price AS lot_cost,
+			(SELECT p2.unit_price FROM purchase_order_items p2
+				WHERE p2.item_id = l.item_id AND p2.deleted_at IS NULL
+				ORDER BY p2.created_at DESC LIMIT 1) AS last_po`).
+		Joins("LEFT JOIN purchase_order_items poi ON poi.id = l.purchase_order_item_id").
+		Where("l.id IN ?", lotIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+
+	for _, row := range rows {
+		switch {
+		case row.LotCost != nil:
+			out[row.LotID] = LotUnitCost{LotID: row.LotID, UnitCost: row.LotCost, Source: "lot_po"}
+		case row.LastPO != nil:
+			out[row.LotID] = LotUnitCost{LotID: row.LotID, UnitCost: row.LastPO, Source: "last_po"}
+		}
+	}
+	return out, nil
+}
+
+// FindForSnapshot mengambil lot ber-qty di satu gudang (opsional satu kategori) untuk stock opname.
+func (r *ItemLotRepositoryImpl) FindForSnapshot(tx *gorm.DB, warehouseID uuid.UUID, categoryID *uuid.UUID) ([]models.ItemLot, error) {
+	var lots []models.ItemLot
+	query := r.useDB(tx).
+		Preload("Item").
+		Joins("JOIN items ON items.id = item_lots.item_id AND items.deleted_at IS NULL").
+		Where("item_lots.warehouse_id = ? AND item_lots.quantity > 0", warehouseID)
+
+	if categoryID != nil && *categoryID != uuid.Nil {
+		query = query.Where("items.category_id = ?", *categoryID)
+	}
+
+	if err := query.
+		Order("items.name ASC").
+		Order("item_lots.expired_at ASC NULLS LAST").
+		Find(&lots).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lots, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *ItemLotRepositoryImpl) Insert(tx *gorm.DB, lot *models.ItemLot) (*models.ItemLot, error) {
+	if lot.ID == uuid.Nil {
+		return nil, fmt.Errorf("item lot ID cannot be empty")
+	}
+	if err := r.useDB(tx).Create(lot).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lot, nil
+}
+
+func (r *ItemLotRepositoryImpl) Update(tx *gorm.DB, lot *models.ItemLot) (*models.ItemLot, error) {
+	if lot.ID == uuid.Nil {
+		return nil, fmt.Errorf("item lot ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("Item", "Warehouse").Save(lot).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_lot")
+	}
+	return lot, nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type ItemStockRepository interface {
+	FindAllByItem(tx *gorm.DB, itemID uuid.UUID) ([]models.ItemStock, error)
+	FindAllByWarehouse(tx *gorm.DB, warehouseID uuid.UUID) ([]models.ItemStock, error)
+	FindByItemAndWarehouse(tx *gorm.DB, itemID, warehouseID uuid.UUID) (*models.ItemStock, error)
+	Upsert(tx *gorm.DB, itemID, warehouseID uuid.UUID, stock int) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type ItemStockRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewItemStockRepository(db *gorm.DB) *ItemStockRepositoryImpl {
+	return &ItemStockRepositoryImpl{DB: db}
+}
+
+func (r *ItemStockRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *ItemStockRepositoryImpl) FindAllByItem(tx *gorm.DB, itemID uuid.UUID) ([]models.ItemStock, error) {
+	var stocks []models.ItemStock
+	if err := r.useDB(tx).
+		Preload("Warehouse").
+		Where("item_id = ?", itemID).
+		Find(&stocks).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_stock")
+	}
+	return stocks, nil
+}
+
+func (r *ItemStockRepositoryImpl) FindAllByWarehouse(tx *gorm.DB, warehouseID uuid.UUID) ([]models.ItemStock, error) {
+	var stocks []models.ItemStock
+	if err := r.useDB(tx).
+		Preload("Item").
+		Preload("Item.UoM").
+		Where("warehouse_id = ?", warehouseID).
+		Find(&stocks).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_stock")
+	}
+	return stocks, nil
+}
+
+func (r *ItemStockRepositoryImpl) FindByItemAndWarehouse(tx *gorm.DB, itemID, warehouseID uuid.UUID) (*models.ItemStock, error) {
+	var stock models.ItemStock
+	if err := r.useDB(tx).
+		Where("item_id = ? AND warehouse_id = ?", itemID, warehouseID).
+		First(&stock).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item_stock")
+	}
+	return &stock, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *ItemStockRepositoryImpl) Upsert(tx *gorm.DB, itemID, warehouseID uuid.UUID, stock int) error {
+	row := models.ItemStock{
+		ID:          uuid.New(),
+		ItemID:      itemID,
+		WarehouseID: warehouseID,
+		Stock:       stock,
+	}
+	if err := r.useDB(tx).
+		Omit("Item", "Warehouse").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "item_id"}, {Name: "warehouse_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"stock", "updated_at"}),
+		}).
+		Create(&row).Error; err != nil {
+		return HandleDatabaseError(err, "item_stock")
+	}
+	return nil
+}
@@ -20,15 +20,18 @@ type ItemRepository interface {
 	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.Item, int64, error)
 	FindById(tx *gorm.DB, itemId string, includeTrashed bool) (*models.Item, error)
 	FindByName(tx *gorm.DB, itemName string) (*models.Item, error)
+	FindByCodes(tx *gorm.DB, codes []string) ([]models.Item, error)
 	FindConsignmentDueBetween(tx *gorm.DB, start, end time.Time) ([]models.Item, error)
 	CountAllThisMonth(tx *gorm.DB) (int64, error)
 	CountAllLastMonth(tx *gorm.DB) (int64, error)
 	CountLowStockNow(tx *gorm.DB) (int64, error)
 	CountLowStockLastMonth(tx *gorm.DB) (int64, error)
+	SumStockTotals(tx *gorm.DB) (onHand int64, reserved int64, err error)
 	Insert(tx *gorm.DB, item *models.Item) (*models.Item, error)
 	Update(tx *gorm.DB, item *models.Item) (*models.Item, error)
 	Delete(tx *gorm.DB, itemId string, isHardDelete bool) error
 	Restore(tx *gorm.DB, itemId string) (*models.Item, error)
+	AdjustReservedStock(tx *gorm.DB, itemID uuid.UUID, delta int) error
 }
 
 // ==============================
@@ -160,6 +163,19 @@ func (r *ItemRepositoryImpl) FindByName(tx *gorm.DB, itemName string) (*models.I
 	return &item, nil
 }
 
+func (r *ItemRepositoryImpl) FindByCodes(tx *gorm.DB, codes []string) ([]models.Item, error) {
+	var items []models.Item
+	if len(codes) == 0 {
+		return items, nil
+	}
+	if err := r.useDB(tx).
+		Where("code IN ?", codes).
+		Find(&items).Error; err != nil {
+		return nil, HandleDatabaseError(err, "item")
+	}
+	return items, nil
+}
+
 func (r *ItemRepositoryImpl) FindConsignmentDueBetween(tx *gorm.DB, start, end time.Time) ([]models.Item, error) {
 	var items []models.Item
 	db := r.useDB(tx).
@@ -194,7 +210,7 @@ func (r *ItemRepositoryImpl) CountAllLastMonth(tx *gorm.DB) (int64, error) {
 func (r *ItemRepositoryImpl) CountLowStockNow(tx *gorm.DB) (int64, error) {
 	var count int64
 	err := r.useDB(tx).Model(&models.Item{}).
-		Where("stock <= low_stock").
+		Where("stock - reserved_stock <= low_stock").
 		Count(&count).Error
 	return count, err
 }
@@ -203,11 +219,22 @@ func (r *ItemRepositoryImpl) CountLowStockLastMonth(tx *gorm.DB) (int64, error)
 	var count int64
 	err := r.useDB(tx).Model(&models.Item{}).
 		Where("DATE_TRUNC('month', created_at) = DATE_TRUNC('month', NOW() - INTERVAL '1 month')").
-		Where("stock <= low_stock").
+		Where("stock - reserved_stock <= low_stock").
 		Count(&count).Error
 	return count, err
 }
 
+func (r *ItemRepositoryImpl) SumStockTotals(tx *gorm.DB) (int64, int64, error) {
+	var totals struct {
+		OnHand   int64
+		Reserved int64
+	}
+	err := r.useDB(tx).Model(&models.Item{}).
+		Select("COALESCE(SUM(stock), 0) AS on_hand, COALESCE(SUM(reserved_stock), 0) AS reserved").
+		Scan(&totals).Error
+	return totals.OnHand, totals.Reserved, err
+}
+
 // ---------- Mutations ----------
 
 func (r *ItemRepositoryImpl) Insert(tx *gorm.DB, item *models.Item) (*models.Item, error) {
@@ -224,7 +251,8 @@ func (r *ItemRepositoryImpl) Update(tx *gorm.DB, item *models.Item) (*models.Ite
 	if item.ID == uuid.Nil {
 		return nil, fmt.Errorf("item ID cannot be empty")
 	}
-	if err := r.useDB(tx).Save(item).Error; err != nil {
+	// reserved_stock hanya diubah lewat AdjustReservedStock supaya tidak tertimpa nilai basi
+	if err := r.useDB(tx).Omit("ReservedStock").Save(item).Error; err != nil {
 		return nil, HandleDatabaseError(err, "item")
 	}
 	return item, nil
@@ -271,3 +299,16 @@ func (r *ItemRepositoryImpl) Restore(tx *gorm.DB, itemId string) (*models.Item,
 	}
 	return &restored, nil
 }
+
+// AdjustReservedStock menambah (delta > 0) atau melepas (delta < 0) reservasi stok item.
+func (r *ItemRepositoryImpl) AdjustReservedStock(tx *gorm.DB, itemID uuid.UUID, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	if err := r.useDB(tx).Model(&models.Item{}).
+		Where("id = ?", itemID).
+		Update("reserved_stock", gorm.Expr("GREATEST(reserved_stock + ?, 0)", delta)).Error; err != nil {
+		return HandleDatabaseError(err, "item")
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type NumberSequenceRepository interface {
+	FindAll(tx *gorm.DB) ([]models.NumberSequence, error)
+	FindById(tx *gorm.DB, sequenceId string) (*models.NumberSequence, error)
+	FindByDocumentType(tx *gorm.DB, documentType string) (*models.NumberSequence, error)
+	FindCounter(tx *gorm.DB, sequenceID uuid.UUID, period string) (*models.NumberSequenceCounter, error)
+	FindLastNumber(tx *gorm.DB, table, column, prefix string) (string, error)
+	InsertIfMissing(tx *gorm.DB, sequence *models.NumberSequence) error
+	Update(tx *gorm.DB, sequence *models.NumberSequence) (*models.NumberSequence, error)
+	LockCounter(tx *gorm.DB, sequenceID uuid.UUID, period string) (*models.NumberSequenceCounter, bool, error)
+	UpdateCounter(tx *gorm.DB, counter *models.NumberSequenceCounter) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type NumberSequenceRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewNumberSequenceRepository(db *gorm.DB) *NumberSequenceRepositoryImpl {
+	return &NumberSequenceRepositoryImpl{DB: db}
+}
+
+func (r *NumberSequenceRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *NumberSequenceRepositoryImpl) FindAll(tx *gorm.DB) ([]models.NumberSequence, error) {
+	var sequences []models.NumberSequence
+	if err := r.useDB(tx).Order("document_type ASC").Find(&sequences).Error; err != nil {
+		return nil, HandleDatabaseError(err, "number_sequence")
+	}
+	return sequences, nil
+}
+
+func (r *NumberSequenceRepositoryImpl) FindById(tx *gorm.DB, sequenceId string) (*models.NumberSequence, error) {
+	var sequence models.NumberSequence
+	if err := r.useDB(tx).First(&sequence, "id = ?", sequenceId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "number_sequence")
+	}
+	return &sequence, nil
+}
+
+func (r *NumberSequenceRepositoryImpl) FindByDocumentType(tx *gorm.DB, documentType string) (*models.NumberSequence, error) {
+	var sequence models.NumberSequence
+	if err := r.useDB(tx).Where("document_type = ?", documentType).First(&sequence).Error; err != nil {
+		return nil, HandleDatabaseError(err, "number_sequence")
+	}
+	return &sequence, nil
+}
+
+// FindCounter membaca counter tanpa lock (untuk pratinjau). Counter yang belum ada = nil.
+func (r *NumberSequenceRepositoryImpl) FindCounter(tx *gorm.DB, sequenceID uuid.UUID, period string) (*models.NumberSequenceCounter, error) {
+	var counters []models.NumberSequenceCounter
+	if err := r.useDB(tx).
+		Where("sequence_id = ? AND period = ?", sequenceID, period).
+		Limit(1).
+		Find(&counters).Error; err != nil {
+		return nil, HandleDatabaseError(err, "number_sequence")
+	}
+	if len(counters) == 0 {
+		return nil, nil
+	}
+	return &counters[0], nil
+}
+
+// FindLastNumber mencari nomor dokumen terbesar dengan prefix tertentu di tabel sumber.
+// Dipakai sekali saat counter periode baru dibuat, supaya melanjutkan nomor lama. Diurutkan
+// per panjang dulu agar 10000 tetap di atas 9999.
+func (r *NumberSequenceRepositoryImpl) FindLastNumber(tx *gorm.DB, table, column, prefix string) (string, error) {
+	var last []string
+	if err := r.useDB(tx).
+		Table(table).
+		Where(column+" LIKE ?", prefix+"%").
+		Order("LENGTH("+column+") DESC, "+column+" DESC").
+		Limit(1).
+		Pluck(column, &last).Error; err != nil {
+		return "", HandleDatabaseError(err, "number_sequence")
+	}
+	if len(last) == 0 {
+		return "", nil
+	}
+	return last[0], nil
+}
+
+// ---------- Mutations ----------
+
+// InsertIfMissing membuat sequence default; aman dipanggil bersamaan (unique document_type).
+func (r *NumberSequenceRepositoryImpl) InsertIfMissing(tx *gorm.DB, sequence *models.NumberSequence) error {
+	if sequence.ID == uuid.Nil {
+		return fmt.Errorf("number sequence ID cannot be empty")
+	}
+	if err := r.useDB(tx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "document_type"}}, DoNothing: true}).
+		Create(sequence).Error; err != nil {
+		return HandleDatabaseError(err, "number_sequence")
+	}
+	return nil
+}
+
+func (r *NumberSequenceRepositoryImpl) Update(tx *gorm.DB, sequence *models.NumberSequence) (*models.NumberSequence, error) {
+	if sequence.ID == uuid.Nil {
+		return nil, fmt.Errorf("number sequence ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(sequence).Error; err != nil {
+		return nil, HandleDatabaseError(err, "number_sequence")
+	}
+	return sequence, nil
+}
+
+// LockCounter mengambil counter periode dengan SELECT ... FOR UPDATE, membuatnya dulu bila
+// belum ada. created = true bila baris baru dibuat oleh transaksi ini.
+func (r *NumberSequenceRepositoryImpl) LockCounter(tx *gorm.DB, sequenceID uuid.UUID, period string) (*models.NumberSequenceCounter, bool, error) {
+	db := r.useDB(tx)
+
+	fresh := models.NumberSequenceCounter{ID: uuid.New(), SequenceID: sequenceID, Period: period}
+	res := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sequence_id"}, {Name: "period"}},
+		DoNothing: true,
+	}).Create(&fresh)
+	if res.Error != nil {
+		return nil, false, HandleDatabaseError(res.Error, "number_sequence")
+	}
+
+	var counter models.NumberSequenceCounter
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sequence_id = ? AND period = ?", sequenceID, period).
+		First(&counter).Error; err != nil {
+		return nil, false, HandleDatabaseError(err, "number_sequence")
+	}
+	return &counter, res.RowsAffected > 0, nil
+}
+
+func (r *NumberSequenceRepositoryImpl) UpdateCounter(tx *gorm.DB, counter *models.NumberSequenceCounter) error {
+	if err := r.useDB(tx).
+		Model(&models.NumberSequenceCounter{}).
+		Where("id = ?", counter.ID).
+		Update("last_value", counter.LastValue).Error; err != nil {
+		return HandleDatabaseError(err, "number_sequence")
+	}
+	return nil
+}
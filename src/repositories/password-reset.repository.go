@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PasswordResetRepository interface {
+	FindTokenByHash(tx *gorm.DB, tokenHash string) (*models.PasswordResetToken, error)
+	CountAuditSince(tx *gorm.DB, identifier string, events []string, since time.Time) (int64, error)
+	InsertToken(tx *gorm.DB, token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	UpdateToken(tx *gorm.DB, token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	RevokeActiveTokens(tx *gorm.DB, userID uuid.UUID, at time.Time) error
+	InsertAudit(tx *gorm.DB, audit *models.PasswordResetAudit) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PasswordResetRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepositoryImpl {
+	return &PasswordResetRepositoryImpl{DB: db}
+}
+
+func (r *PasswordResetRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PasswordResetRepositoryImpl) FindTokenByHash(tx *gorm.DB, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.useDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return nil, HandleDatabaseError(err, "password_reset_token")
+	}
+	return &token, nil
+}
+
+func (r *PasswordResetRepositoryImpl) CountAuditSince(tx *gorm.DB, identifier string, events []string, since time.Time) (int64, error) {
+	var count int64
+	if err := r.useDB(tx).
+		Model(&models.PasswordResetAudit{}).
+		Where("identifier = ? AND event IN ? AND created_at >= ?", identifier, events, since).
+		Count(&count).Error; err != nil {
+		return 0, HandleDatabaseError(err, "password_reset_audit")
+	}
+	return count, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PasswordResetRepositoryImpl) InsertToken(tx *gorm.DB, token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	if token.ID == uuid.Nil {
+		return nil, fmt.Errorf("password reset token ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("User").Create(token).Error; err != nil {
+		return nil, HandleDatabaseError(err, "password_reset_token")
+	}
+	return token, nil
+}
+
+func (r *PasswordResetRepositoryImpl) UpdateToken(tx *gorm.DB, token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	if token.ID == uuid.Nil {
+		return nil, fmt.Errorf("password reset token ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("User").Save(token).Error; err != nil {
+		return nil, HandleDatabaseError(err, "password_reset_token")
+	}
+	return token, nil
+}
+
+func (r *PasswordResetRepositoryImpl) RevokeActiveTokens(tx *gorm.DB, userID uuid.UUID, at time.Time) error {
+	if err := r.useDB(tx).
+		Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error; err != nil {
+		return HandleDatabaseError(err, "password_reset_token")
+	}
+	return nil
+}
+
+func (r *PasswordResetRepositoryImpl) InsertAudit(tx *gorm.DB, audit *models.PasswordResetAudit) error {
+	if audit.ID == uuid.Nil {
+		audit.ID = uuid.New()
+	}
+	if err := r.useDB(tx).Create(audit).Error; err != nil {
+		return HandleDatabaseError(err, "password_reset_audit")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PaymentReceiptRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PaymentReceipt, int64, error)
+	FindById(tx *gorm.DB, receiptId string, forUpdate bool) (*models.PaymentReceipt, error)
+	FindWithBalance(tx *gorm.DB, req *models.PaymentDepositRequest) ([]models.PaymentReceipt, error)
+	Insert(tx *gorm.DB, receipt *models.PaymentReceipt) (*models.PaymentReceipt, error)
+	Update(tx *gorm.DB, receipt *models.PaymentReceipt) (*models.PaymentReceipt, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PaymentReceiptRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPaymentReceiptRepository(db *gorm.DB) *PaymentReceiptRepositoryImpl {
+	return &PaymentReceiptRepositoryImpl{DB: db}
+}
+
+func (r *PaymentReceiptRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PaymentReceiptRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PaymentReceipt, int64, error) {
+	var (
+		receipts   []models.PaymentReceipt
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("Customer").
+		Preload("Supplier").
+		Preload("CreatedByUser")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("payment_receipts.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("payment_receipts.deleted_at IS NULL")
+	}
+
+	if req.OrderType != "" {
+		query = query.Where("payment_receipts.order_type = ?", req.OrderType)
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("payment_receipts.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.SupplierID != "" {
+		if supplierUUID, err := uuid.Parse(req.SupplierID); err == nil {
+			query = query.Where("payment_receipts.supplier_id = ?", supplierUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(payment_receipts.receipt_number) LIKE ? OR
+			LOWER(payment_receipts.reference_number) LIKE ? OR
+			LOWER(payment_receipts.notes) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.PaymentReceipt{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "payment_receipt")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("payment_receipts.receipt_date DESC, payment_receipts.created_at DESC").Offset(offset).Limit(req.Limit).Find(&receipts).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "payment_receipt")
+	}
+
+	return receipts, totalCount, nil
+}
+
+func (r *PaymentReceiptRepositoryImpl) FindById(tx *gorm.DB, receiptId string, forUpdate bool) (*models.PaymentReceipt, error) {
+	var receipt models.PaymentReceipt
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&receipt, "id = ?", receiptId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "payment_receipt")
+		}
+	}
+
+	if err := db.
+		Preload("Customer").
+		Preload("Supplier").
+		Preload("CreatedByUser").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("payment_date ASC, created_at ASC") }).
+		Preload("Payments.SalesOrder").
+		Preload("Payments.PurchaseOrder").
+		First(&receipt, "id = ?", receiptId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_receipt")
+	}
+
+	return &receipt, nil
+}
+
+// FindWithBalance mengembalikan receipt yang masih punya saldo belum teralokasi (deposit), terlama dulu.
+func (r *PaymentReceiptRepositoryImpl) FindWithBalance(tx *gorm.DB, req *models.PaymentDepositRequest) ([]models.PaymentReceipt, error) {
+	var receipts []models.PaymentReceipt
+
+	query := r.useDB(tx).
+		Preload("Customer").
+		Preload("Supplier").
+		Where("unallocated_amount > 0")
+
+	if req.OrderType != "" {
+		query = query.Where("order_type = ?", req.OrderType)
+	}
+	if req.CustomerID != "" {
+		query = query.Where("customer_id = ?", req.CustomerID)
+	}
+	if req.SupplierID != "" {
+		query = query.Where("supplier_id = ?", req.SupplierID)
+	}
+
+	if err := query.Order("receipt_date ASC, created_at ASC").Find(&receipts).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_receipt")
+	}
+	return receipts, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PaymentReceiptRepositoryImpl) Insert(tx *gorm.DB, receipt *models.PaymentReceipt) (*models.PaymentReceipt, error) {
+	if receipt.ID == uuid.Nil {
+		return nil, fmt.Errorf("payment receipt ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Create(receipt).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_receipt")
+	}
+	return receipt, nil
+}
+
+func (r *PaymentReceiptRepositoryImpl) Update(tx *gorm.DB, receipt *models.PaymentReceipt) (*models.PaymentReceipt, error) {
+	if receipt.ID == uuid.Nil {
+		return nil, fmt.Errorf("payment receipt ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(receipt).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_receipt")
+	}
+	return receipt, nil
+}
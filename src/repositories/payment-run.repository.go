@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PaymentRunRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PaymentRun, int64, error)
+	FindById(tx *gorm.DB, runId string, forUpdate bool) (*models.PaymentRun, error)
+	FindOpenItemsByPurchaseOrders(tx *gorm.DB, poIDs []uuid.UUID, excludeRunID *uuid.UUID) ([]models.PaymentRunItem, error)
+	Insert(tx *gorm.DB, run *models.PaymentRun) (*models.PaymentRun, error)
+	Update(tx *gorm.DB, run *models.PaymentRun) (*models.PaymentRun, error)
+	UpdateItem(tx *gorm.DB, item *models.PaymentRunItem) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PaymentRunRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPaymentRunRepository(db *gorm.DB) *PaymentRunRepositoryImpl {
+	return &PaymentRunRepositoryImpl{DB: db}
+}
+
+func (r *PaymentRunRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PaymentRunRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PaymentRun, int64, error) {
+	var (
+		runs       []models.PaymentRun
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("CreatedByUser").
+		Preload("Items").
+		Preload("Items.PurchaseOrder").
+		Preload("Items.Supplier")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("payment_runs.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("payment_runs.deleted_at IS NULL")
+	}
+
+	if req.RunStatus != "" {
+		query = query.Where("payment_runs.status = ?", req.RunStatus)
+	}
+
+	if req.SupplierID != "" {
+		if supplierUUID, err := uuid.Parse(req.SupplierID); err == nil {
+			query = query.Where("EXISTS (SELECT 1 FROM payment_run_items pri WHERE pri.payment_run_id = payment_runs.id AND pri.supplier_id = ?)", supplierUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(payment_runs.run_number) LIKE ? OR
+			LOWER(payment_runs.notes) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.PaymentRun{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "payment_run")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("payment_runs.scheduled_date DESC, payment_runs.created_at DESC").Offset(offset).Limit(req.Limit).Find(&runs).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "payment_run")
+	}
+
+	return runs, totalCount, nil
+}
+
+func (r *PaymentRunRepositoryImpl) FindById(tx *gorm.DB, runId string, forUpdate bool) (*models.PaymentRun, error) {
+	var run models.PaymentRun
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&run, "id = ?", runId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "payment_run")
+		}
+	}
+
+	if err := db.
+		Preload("CreatedByUser").
+		Preload("ExecutedByUser").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("due_date ASC NULLS LAST, created_at ASC") }).
+		Preload("Items.PurchaseOrder").
+		Preload("Items.Supplier").
+		Preload("Items.Payment").
+		First(&run, "id = ?", runId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_run")
+	}
+
+	return &run, nil
+}
+
+// FindOpenItemsByPurchaseOrders mengembalikan baris payment run berstatus Proposed untuk PO tertentu,
+// dipakai agar satu PO tidak diusulkan di dua run sekaligus.
+func (r *PaymentRunRepositoryImpl) FindOpenItemsByPurchaseOrders(tx *gorm.DB, poIDs []uuid.UUID, excludeRunID *uuid.UUID) ([]models.PaymentRunItem, error) {
+	var items []models.PaymentRunItem
+	if len(poIDs) == 0 {
+		return items, nil
+	}
+
+	query := r.useDB(tx).
+		Joins("JOIN payment_runs ON payment_runs.id = payment_run_items.payment_run_id").
+		Preload("PurchaseOrder").
+		Where("payment_runs.status = ? AND payment_runs.deleted_at IS NULL", "Proposed").
+		Where("payment_run_items.purchase_order_id IN ?", poIDs)
+	if excludeRunID != nil {
+		query = query.Where("payment_runs.id <> ?", *excludeRunID)
+	}
+
+	if err := query.Find(&items).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_run")
+	}
+	return items, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PaymentRunRepositoryImpl) Insert(tx *gorm.DB, run *models.PaymentRun) (*models.PaymentRun, error) {
+	if run.ID == uuid.Nil {
+		return nil, fmt.Errorf("payment run ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("CreatedByUser", "ExecutedByUser", "Items.PurchaseOrder", "Items.Supplier", "Items.Payment").Create(run).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_run")
+	}
+	return run, nil
+}
+
+func (r *PaymentRunRepositoryImpl) Update(tx *gorm.DB, run *models.PaymentRun) (*models.PaymentRun, error) {
+	if run.ID == uuid.Nil {
+		return nil, fmt.Errorf("payment run ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(run).Error; err != nil {
+		return nil, HandleDatabaseError(err, "payment_run")
+	}
+	return run, nil
+}
+
+func (r *PaymentRunRepositoryImpl) UpdateItem(tx *gorm.DB, item *models.PaymentRunItem) error {
+	if item.ID == uuid.Nil {
+		return fmt.Errorf("payment run item ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(item).Error; err != nil {
+		return HandleDatabaseError(err, "payment_run")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/google/uuid"
@@ -19,6 +20,8 @@ type PaymentRepository interface {
 	FindById(tx *gorm.DB, paymentId string, includeTrashed bool) (*models.Payment, error)
 	FindByPurchaseOrderId(tx *gorm.DB, poId string) ([]models.Payment, error)
 	FindBySalesOrderId(tx *gorm.DB, soId string) ([]models.Payment, error)
+	SumAmountByOrder(tx *gorm.DB, orderType string, orderID uuid.UUID) (int, error)
+	SumAmountByReceipt(tx *gorm.DB, receiptID uuid.UUID) (int, error)
 	Insert(tx *gorm.DB, payment *models.Payment) (*models.Payment, error)
 	Update(tx *gorm.DB, payment *models.Payment) (*models.Payment, error)
 	Delete(tx *gorm.DB, paymentId string, isHardDelete bool) error
@@ -125,6 +128,7 @@ func (r *PaymentRepositoryImpl) FindById(tx *gorm.DB, paymentId string, includeT
 		Preload("PurchaseOrder").
 		Preload("PurchaseOrder.Supplier").
 		Preload("Invoice").
+		Preload("Receipt").
 		First(&payment, "id = ?", paymentId).Error; err != nil {
 		return nil, HandleDatabaseError(err, "payment")
 	}
@@ -135,6 +139,7 @@ func (r *PaymentRepositoryImpl) FindByPurchaseOrderId(tx *gorm.DB, poId string)
 	var payments []models.Payment
 	if err := r.useDB(tx).
 		Preload("Invoice").
+		Preload("Receipt").
 		Where("purchase_order_id = ?", poId).
 		Find(&payments).Error; err != nil {
 		return nil, HandleDatabaseError(err, "payment")
@@ -146,6 +151,7 @@ func (r *PaymentRepositoryImpl) FindBySalesOrderId(tx *gorm.DB, soId string) ([]
 	var payments []models.Payment
 	if err := r.useDB(tx).
 		Preload("Invoice").
+		Preload("Receipt").
 		Where("sales_order_id = ?", soId).
 		Find(&payments).Error; err != nil {
 		return nil, HandleDatabaseError(err, "payment")
@@ -153,6 +159,51 @@ func (r *PaymentRepositoryImpl) FindBySalesOrderId(tx *gorm.DB, soId string) ([]
 	return payments, nil
 }
 
+// SumAmountByOrder menjumlahkan seluruh entri ledger (termasuk DP, refund & reversal) milik satu PO / SO.
+func (r *PaymentRepositoryImpl) SumAmountByOrder(tx *gorm.DB, orderType string, orderID uuid.UUID) (int, error) {
+	column := "sales_order_id"
+	if orderType == "PO" {
+		column = "purchase_order_id"
+	}
+
+	var total int
+	if err := r.useDB(tx).Model(&models.Payment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where(column+" = ?", orderID).
+		Scan(&total).Error; err != nil {
+		return 0, HandleDatabaseError(err, "payment")
+	}
+	return total, nil
+}
+
+// SumAmountByReceipt menjumlahkan alokasi (dikurangi reversal) dari satu payment receipt.
+func (r *PaymentRepositoryImpl) SumAmountByReceipt(tx *gorm.DB, receiptID uuid.UUID) (int, error) {
+	var total int
+	if err := r.useDB(tx).Model(&models.Payment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("receipt_id = ?", receiptID).
+		Scan(&total).Error; err != nil {
+		return 0, HandleDatabaseError(err, "payment")
+	}
+	return total, nil
+}
+
+// filterOpenAsOf membatasi order (sales_orders / purchase_orders) yang masih punya sisa tagihan.
+// asOf nil = saldo saat ini (paid_amount); selain itu hanya order bertanggal s/d asOf dan paid_amount
+// dihitung ulang dari ledger payment (termasuk refund & reversal) yang bertanggal s/d asOf.
+func filterOpenAsOf(query *gorm.DB, table, dateColumn, paymentColumn string, asOf *time.Time) *gorm.DB {
+	if asOf == nil {
+		return query.Where(table + ".total_amount > " + table + ".paid_amount")
+	}
+
+	paid := "COALESCE((SELECT SUM(payments.amount) FROM payments WHERE payments." + paymentColumn + " = " + table + ".id" +
+		" AND payments.deleted_at IS NULL AND payments.payment_date <= ?), 0)"
+	return query.
+		Select(table+".*, "+paid+" AS paid_amount", *asOf).
+		Where(table+"."+dateColumn+" <= ?", *asOf).
+		Where(table+".total_amount > "+paid, *asOf)
+}
+
 // ---------- Mutations ----------
 
 func (r *PaymentRepositoryImpl) Insert(tx *gorm.DB, payment *models.Payment) (*models.Payment, error) {
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PurchaseOrderApprovalRepository interface {
+	// rules
+	FindAllRules(tx *gorm.DB) ([]models.PurchaseOrderApprovalRule, error)
+	FindActiveRules(tx *gorm.DB) ([]models.PurchaseOrderApprovalRule, error)
+	FindRuleById(tx *gorm.DB, ruleId string) (*models.PurchaseOrderApprovalRule, error)
+	InsertRule(tx *gorm.DB, rule *models.PurchaseOrderApprovalRule) (*models.PurchaseOrderApprovalRule, error)
+	UpdateRule(tx *gorm.DB, rule *models.PurchaseOrderApprovalRule) (*models.PurchaseOrderApprovalRule, error)
+	DeleteRule(tx *gorm.DB, ruleId string) error
+
+	// approval steps
+	FindByPurchaseOrder(tx *gorm.DB, purchaseOrderID uuid.UUID) ([]models.PurchaseOrderApproval, error)
+	FindPendingPurchaseOrderIDsByRole(tx *gorm.DB, roleID uuid.UUID) ([]uuid.UUID, error)
+	FindLastRound(tx *gorm.DB, purchaseOrderID uuid.UUID) (int, error)
+	InsertMany(tx *gorm.DB, approvals []models.PurchaseOrderApproval) error
+	Update(tx *gorm.DB, approval *models.PurchaseOrderApproval) error
+	SkipPending(tx *gorm.DB, purchaseOrderID uuid.UUID) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PurchaseOrderApprovalRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPurchaseOrderApprovalRepository(db *gorm.DB) *PurchaseOrderApprovalRepositoryImpl {
+	return &PurchaseOrderApprovalRepositoryImpl{DB: db}
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PurchaseOrderApprovalRepositoryImpl) FindAllRules(tx *gorm.DB) ([]models.PurchaseOrderApprovalRule, error) {
+	var rules []models.PurchaseOrderApprovalRule
+	if err := r.useDB(tx).
+		Preload("Category").
+		Preload("Role").
+		Order("level ASC, min_amount ASC").
+		Find(&rules).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval_rule")
+	}
+	return rules, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) FindActiveRules(tx *gorm.DB) ([]models.PurchaseOrderApprovalRule, error) {
+	var rules []models.PurchaseOrderApprovalRule
+	if err := r.useDB(tx).
+		Where("is_active = ?", true).
+		Order("level ASC, min_amount ASC").
+		Find(&rules).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval_rule")
+	}
+	return rules, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) FindRuleById(tx *gorm.DB, ruleId string) (*models.PurchaseOrderApprovalRule, error) {
+	var rule models.PurchaseOrderApprovalRule
+	if err := r.useDB(tx).
+		Preload("Category").
+		Preload("Role").
+		First(&rule, "id = ?", ruleId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval_rule")
+	}
+	return &rule, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) FindByPurchaseOrder(tx *gorm.DB, purchaseOrderID uuid.UUID) ([]models.PurchaseOrderApproval, error) {
+	var approvals []models.PurchaseOrderApproval
+	if err := r.useDB(tx).
+		Preload("Role").
+		Preload("RequestedByUser").
+		Preload("Approver").
+		Where("purchase_order_id = ?", purchaseOrderID).
+		Order("round ASC, step_order ASC").
+		Find(&approvals).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return approvals, nil
+}
+
+// FindPendingPurchaseOrderIDsByRole mengembalikan PO yang langkah Pending paling awalnya
+// (langkah yang sedang berjalan) menunggu role tersebut.
+func (r *PurchaseOrderApprovalRepositoryImpl) FindPendingPurchaseOrderIDsByRole(tx *gorm.DB, roleID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.useDB(tx).
+		Table("purchase_order_approvals AS a").
+		Joins("JOIN purchase_orders po ON po.id = a.purchase_order_id AND po.deleted_at IS NULL").
+		Where("a.status = ? AND a.role_id = ? AND po.po_status = ?", "Pending", roleID, "PendingApproval").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM purchase_order_approvals b
+			WHERE b.purchase_order_id = a.purchase_order_id
+			  AND b.round = a.round
+			  AND b.status = 'Pending'
+			  AND b.step_order < a.step_order)`).
+		Order("a.created_at ASC").
+		Pluck("a.purchase_order_id", &ids).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return ids, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) FindLastRound(tx *gorm.DB, purchaseOrderID uuid.UUID) (int, error) {
+	var round int
+	if err := r.useDB(tx).
+		Model(&models.PurchaseOrderApproval{}).
+		Where("purchase_order_id = ?", purchaseOrderID).
+		Select("COALESCE(MAX(round), 0)").
+		Scan(&round).Error; err != nil {
+		return 0, HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return round, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PurchaseOrderApprovalRepositoryImpl) InsertRule(tx *gorm.DB, rule *models.PurchaseOrderApprovalRule) (*models.PurchaseOrderApprovalRule, error) {
+	if err := r.useDB(tx).Omit(clause.Associations).Create(rule).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval_rule")
+	}
+	return rule, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) UpdateRule(tx *gorm.DB, rule *models.PurchaseOrderApprovalRule) (*models.PurchaseOrderApprovalRule, error) {
+	if rule.ID == uuid.Nil {
+		return nil, fmt.Errorf("approval rule ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(rule).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_order_approval_rule")
+	}
+	return rule, nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) DeleteRule(tx *gorm.DB, ruleId string) error {
+	res := r.useDB(tx).Delete(&models.PurchaseOrderApprovalRule{}, "id = ?", ruleId)
+	if res.Error != nil {
+		return HandleDatabaseError(res.Error, "purchase_order_approval_rule")
+	}
+	if res.RowsAffected == 0 {
+		return ErrPurchaseOrderApprovalRuleNotFound
+	}
+	return nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) InsertMany(tx *gorm.DB, approvals []models.PurchaseOrderApproval) error {
+	if len(approvals) == 0 {
+		return nil
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Create(&approvals).Error; err != nil {
+		return HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return nil
+}
+
+func (r *PurchaseOrderApprovalRepositoryImpl) Update(tx *gorm.DB, approval *models.PurchaseOrderApproval) error {
+	if approval.ID == uuid.Nil {
+		return fmt.Errorf("approval ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(approval).Error; err != nil {
+		return HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return nil
+}
+
+// SkipPending menutup sisa langkah yang belum diputuskan (mis. setelah ada penolakan).
+func (r *PurchaseOrderApprovalRepositoryImpl) SkipPending(tx *gorm.DB, purchaseOrderID uuid.UUID) error {
+	if err := r.useDB(tx).
+		Model(&models.PurchaseOrderApproval{}).
+		Where("purchase_order_id = ? AND status = ?", purchaseOrderID, "Pending").
+		Update("status", "Skipped").Error; err != nil {
+		return HandleDatabaseError(err, "purchase_order_approval")
+	}
+	return nil
+}
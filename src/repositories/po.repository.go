@@ -3,7 +3,6 @@ package repositories
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/google/uuid"
@@ -31,8 +30,6 @@ type PurchaseOrderRepository interface {
 
 	Delete(tx *gorm.DB, poId string, isHardDelete bool) error
 	Restore(tx *gorm.DB, poId string) (*models.PurchaseOrder, error)
-
-	GenerateNextPONumber(tx *gorm.DB) (string, error)
 }
 
 // ==============================
@@ -154,6 +151,9 @@ func (r *PurchaseOrderRepositoryImpl) FindById(tx *gorm.DB, poId string, include
 		Preload("PurchaseOrderItems.Item.Category").
 		Preload("Payments").
 		Preload("Payments.Invoice").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("round ASC, step_order ASC") }).
+		Preload("Approvals.Role").
+		Preload("Approvals.Approver").
 		First(&po, "id = ?", poId).Error; err != nil {
 		return nil, HandleDatabaseError(err, "purchase_order")
 	}
@@ -289,34 +289,3 @@ func (r *PurchaseOrderRepositoryImpl) Restore(tx *gorm.DB, poId string) (*models
 	}
 	return &restored, nil
 }
-
-// ---------- Utilities ----------
-
-func (r *PurchaseOrderRepositoryImpl) GenerateNextPONumber(tx *gorm.DB) (string, error) {
-	var lastPO models.PurchaseOrder
-	currentYear := time.Now().Year()
-	prefix := fmt.Sprintf("PO-%d-", currentYear)
-
-	db := r.useDB(tx)
-
-	err := db.Where("po_number LIKE ?", prefix+"%").
-		Order("po_number DESC").
-		First(&lastPO).Error
-
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return "", err
-	}
-
-	nextNumber := 1
-	if err != gorm.ErrRecordNotFound {
-		parts := strings.Split(lastPO.PONumber, "-")
-		if len(parts) >= 3 {
-			var parsed int
-			if n, scanErr := fmt.Sscanf(parts[2], "%d", &parsed); scanErr == nil && n == 1 {
-				nextNumber = parsed + 1
-			}
-		}
-	}
-
-	return fmt.Sprintf("%s%04d", prefix, nextNumber), nil
-}
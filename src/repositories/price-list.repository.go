@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PriceListRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PriceList, int64, error)
+	FindById(tx *gorm.DB, priceListId string, includeTrashed bool) (*models.PriceList, error)
+	FindApplicableItems(tx *gorm.DB, customerID uuid.UUID, customerTypeID uuid.UUID, itemID uuid.UUID, at time.Time) ([]models.PriceListItem, error)
+	Insert(tx *gorm.DB, priceList *models.PriceList) (*models.PriceList, error)
+	Update(tx *gorm.DB, priceList *models.PriceList) (*models.PriceList, error)
+	ReplaceItems(tx *gorm.DB, priceListID uuid.UUID, items []models.PriceListItem) error
+	Delete(tx *gorm.DB, priceListId string, isHardDelete bool) error
+	Restore(tx *gorm.DB, priceListId string) (*models.PriceList, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PriceListRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPriceListRepository(db *gorm.DB) *PriceListRepositoryImpl {
+	return &PriceListRepositoryImpl{DB: db}
+}
+
+func (r *PriceListRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PriceListRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PriceList, int64, error) {
+	var (
+		priceLists []models.PriceList
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("CustomerType").
+		Preload("Customer")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("price_lists.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("price_lists.deleted_at IS NULL")
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("price_lists.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.CustomerTypeID != "" {
+		if typeUUID, err := uuid.Parse(req.CustomerTypeID); err == nil {
+			query = query.Where("price_lists.customer_type_id = ?", typeUUID)
+		}
+	}
+
+	if req.ItemID != "" {
+		if itemUUID, err := uuid.Parse(req.ItemID); err == nil {
+			query = query.Where("EXISTS (SELECT 1 FROM price_list_items pli WHERE pli.price_list_id = price_lists.id AND pli.item_id = ?)", itemUUID)
+		}
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(price_lists.name) LIKE ? OR
+			LOWER(price_lists.description) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.PriceList{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "price_list")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("price_lists.valid_from DESC, price_lists.created_at DESC").Offset(offset).Limit(req.Limit).Find(&priceLists).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "price_list")
+	}
+
+	return priceLists, totalCount, nil
+}
+
+func (r *PriceListRepositoryImpl) FindById(tx *gorm.DB, priceListId string, includeTrashed bool) (*models.PriceList, error) {
+	var priceList models.PriceList
+	db := r.useDB(tx)
+	if includeTrashed {
+		db = db.Unscoped()
+	}
+
+	if err := db.
+		Preload("CustomerType").
+		Preload("Customer").
+		Preload("PriceListItems", func(db *gorm.DB) *gorm.DB { return db.Order("item_id ASC, min_quantity ASC") }).
+		Preload("PriceListItems.Item").
+		First(&priceList, "id = ?", priceListId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+
+	return &priceList, nil
+}
+
+// FindApplicableItems mengembalikan semua baris harga item dari price list aktif yang berlaku
+// pada `at` dan ditujukan ke customer atau tipe customer tsb. Pemilihan prioritas dilakukan di service.
+func (r *PriceListRepositoryImpl) FindApplicableItems(tx *gorm.DB, customerID uuid.UUID, customerTypeID uuid.UUID, itemID uuid.UUID, at time.Time) ([]models.PriceListItem, error) {
+	var items []models.PriceListItem
+	if err := r.useDB(tx).
+		Joins("JOIN price_lists ON price_lists.id = price_list_items.price_list_id").
+		Preload("PriceList").
+		Where("price_list_items.item_id = ?", itemID).
+		Where("price_lists.deleted_at IS NULL AND price_lists.is_active = ?", true).
+		Where("price_lists.customer_id = ? OR price_lists.customer_type_id = ?", customerID, customerTypeID).
+		Where("price_lists.valid_from <= ?", at).
+		Where("price_lists.valid_until IS NULL OR price_lists.valid_until >= ?", at).
+		Find(&items).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+	return items, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PriceListRepositoryImpl) Insert(tx *gorm.DB, priceList *models.PriceList) (*models.PriceList, error) {
+	if priceList.ID == uuid.Nil {
+		return nil, fmt.Errorf("price list ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("CustomerType", "Customer", "PriceListItems.Item", "PriceListItems.PriceList").Create(priceList).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+	return priceList, nil
+}
+
+func (r *PriceListRepositoryImpl) Update(tx *gorm.DB, priceList *models.PriceList) (*models.PriceList, error) {
+	if priceList.ID == uuid.Nil {
+		return nil, fmt.Errorf("price list ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(priceList).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+	return priceList, nil
+}
+
+func (r *PriceListRepositoryImpl) ReplaceItems(tx *gorm.DB, priceListID uuid.UUID, items []models.PriceListItem) error {
+	db := r.useDB(tx)
+	if err := db.Where("price_list_id = ?", priceListID).Delete(&models.PriceListItem{}).Error; err != nil {
+		return HandleDatabaseError(err, "price_list")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := db.Omit(clause.Associations).Create(&items).Error; err != nil {
+		return HandleDatabaseError(err, "price_list")
+	}
+	return nil
+}
+
+func (r *PriceListRepositoryImpl) Delete(tx *gorm.DB, priceListId string, isHardDelete bool) error {
+	db := r.useDB(tx)
+
+	var priceList models.PriceList
+	if err := db.Unscoped().First(&priceList, "id = ?", priceListId).Error; err != nil {
+		return HandleDatabaseError(err, "price_list")
+	}
+
+	if isHardDelete {
+		if err := db.Where("price_list_id = ?", priceList.ID).Delete(&models.PriceListItem{}).Error; err != nil {
+			return HandleDatabaseError(err, "price_list")
+		}
+		if err := db.Unscoped().Delete(&priceList).Error; err != nil {
+			return HandleDatabaseError(err, "price_list")
+		}
+	} else {
+		if err := db.Delete(&priceList).Error; err != nil {
+			return HandleDatabaseError(err, "price_list")
+		}
+	}
+	return nil
+}
+
+func (r *PriceListRepositoryImpl) Restore(tx *gorm.DB, priceListId string) (*models.PriceList, error) {
+	db := r.useDB(tx)
+
+	if err := db.Unscoped().
+		Model(&models.PriceList{}).
+		Where("id = ?", priceListId).
+		Update("deleted_at", nil).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+
+	var restored models.PriceList
+	if err := db.First(&restored, "id = ?", priceListId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "price_list")
+	}
+	return &restored, nil
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type PurchaseReturnRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PurchaseReturn, int64, error)
+	FindById(tx *gorm.DB, returnId string, forUpdate bool) (*models.PurchaseReturn, error)
+	SumReturnedQuantity(tx *gorm.DB, purchaseOrderItemIDs []uuid.UUID, excludeReturnID *uuid.UUID) (map[uuid.UUID]int, error)
+	Insert(tx *gorm.DB, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error)
+	Update(tx *gorm.DB, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error)
+	UpdateItem(tx *gorm.DB, item *models.PurchaseReturnItem) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type PurchaseReturnRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPurchaseReturnRepository(db *gorm.DB) *PurchaseReturnRepositoryImpl {
+	return &PurchaseReturnRepositoryImpl{DB: db}
+}
+
+func (r *PurchaseReturnRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *PurchaseReturnRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.PurchaseReturn, int64, error) {
+	var (
+		returns    []models.PurchaseReturn
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("PurchaseOrder").
+		Preload("Supplier").
+		Preload("Warehouse").
+		Preload("PurchaseReturnItems").
+		Preload("PurchaseReturnItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("purchase_returns.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("purchase_returns.deleted_at IS NULL")
+	}
+
+	if req.PurchaseOrderID != "" {
+		if poUUID, err := uuid.Parse(req.PurchaseOrderID); err == nil {
+			query = query.Where("purchase_returns.purchase_order_id = ?", poUUID)
+		}
+	}
+
+	if req.SupplierID != "" {
+		if supplierUUID, err := uuid.Parse(req.SupplierID); err == nil {
+			query = query.Where("purchase_returns.supplier_id = ?", supplierUUID)
+		}
+	}
+
+	if req.ReturnStatus != "" {
+		query = query.Where("purchase_returns.status = ?", req.ReturnStatus)
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(purchase_returns.return_number) LIKE ? OR
+			LOWER(COALESCE(purchase_returns.debit_note_number, '')) LIKE ? OR
+			LOWER(purchase_returns.notes) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.PurchaseReturn{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "purchase_return")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("purchase_returns.created_at DESC").Offset(offset).Limit(req.Limit).Find(&returns).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "purchase_return")
+	}
+
+	return returns, totalCount, nil
+}
+
+func (r *PurchaseReturnRepositoryImpl) FindById(tx *gorm.DB, returnId string, forUpdate bool) (*models.PurchaseReturn, error) {
+	var purchaseReturn models.PurchaseReturn
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchaseReturn, "id = ?", returnId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "purchase_return")
+		}
+	}
+
+	if err := db.
+		Preload("PurchaseOrder").
+		Preload("Supplier").
+		Preload("Warehouse").
+		Preload("RefundPayment").
+		Preload("PurchaseReturnItems").
+		Preload("PurchaseReturnItems.Item").
+		First(&purchaseReturn, "id = ?", returnId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_return")
+	}
+
+	return &purchaseReturn, nil
+}
+
+// SumReturnedQuantity menjumlah qty yang sudah diretur ke supplier per baris PO (retur Draft & Completed,
+// retur Cancelled tidak dihitung).
+func (r *PurchaseReturnRepositoryImpl) SumReturnedQuantity(tx *gorm.DB, purchaseOrderItemIDs []uuid.UUID, excludeReturnID *uuid.UUID) (map[uuid.UUID]int, error) {
+	out := make(map[uuid.UUID]int, len(purchaseOrderItemIDs))
+	if len(purchaseOrderItemIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		PurchaseOrderItemID uuid.UUID
+		Quantity            int
+	}
+	query := r.useDB(tx).
+		Table("purchase_return_items AS pri").
+		Select("pri.purchase_order_item_id, COALESCE(SUM(pri.quantity), 0) AS quantity").
+		Joins("JOIN purchase_returns pr ON pr.id = pri.purchase_return_id").
+		Where("pri.purchase_order_item_id IN ?", purchaseOrderItemIDs).
+		Where("pr.deleted_at IS NULL AND pr.status <> ?", "Cancelled")
+	if excludeReturnID != nil {
+		query = query.Where("pr.id <> ?", *excludeReturnID)
+	}
+	if err := query.Group("pri.purchase_order_item_id").Scan(&rows).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_return")
+	}
+
+	for _, row := range rows {
+		out[row.PurchaseOrderItemID] = row.Quantity
+	}
+	return out, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *PurchaseReturnRepositoryImpl) Insert(tx *gorm.DB, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error) {
+	if purchaseReturn.ID == uuid.Nil {
+		return nil, fmt.Errorf("purchase return ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("PurchaseOrder", "Supplier", "Warehouse", "RefundPayment", "PurchaseReturnItems.Item").Create(purchaseReturn).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_return")
+	}
+	return purchaseReturn, nil
+}
+
+func (r *PurchaseReturnRepositoryImpl) Update(tx *gorm.DB, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error) {
+	if purchaseReturn.ID == uuid.Nil {
+		return nil, fmt.Errorf("purchase return ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(purchaseReturn).Error; err != nil {
+		return nil, HandleDatabaseError(err, "purchase_return")
+	}
+	return purchaseReturn, nil
+}
+
+func (r *PurchaseReturnRepositoryImpl) UpdateItem(tx *gorm.DB, item *models.PurchaseReturnItem) error {
+	if err := r.useDB(tx).Omit(clause.Associations).Save(item).Error; err != nil {
+		return HandleDatabaseError(err, "purchase_return")
+	}
+	return nil
+}
+
@@ -15,6 +15,7 @@ import (
 type RoleModuleRepository interface {
 	FindAll(tx *gorm.DB, roleID uuid.UUID) ([]models.RoleModule, error)
 	FindByRoleAndModule(tx *gorm.DB, roleID uuid.UUID, moduleID int) (*models.RoleModule, error)
+	HasModuleAccess(tx *gorm.DB, roleID uuid.UUID, moduleName string) (bool, error)
 	Insert(tx *gorm.DB, roleModule *models.RoleModule) (*models.RoleModule, error)
 	Update(tx *gorm.DB, roleModule *models.RoleModule) (*models.RoleModule, error)
 }
@@ -71,6 +72,18 @@ func (r *RoleModuleRepositoryImpl) FindByRoleAndModule(tx *gorm.DB, roleID uuid.
 	return &rm, nil
 }
 
+// HasModuleAccess true bila role memiliki modul (berdasarkan nama) dalam keadaan checked.
+func (r *RoleModuleRepositoryImpl) HasModuleAccess(tx *gorm.DB, roleID uuid.UUID, moduleName string) (bool, error) {
+	var count int64
+	if err := r.useDB(tx).Model(&models.RoleModule{}).
+		Joins("JOIN modules ON modules.id = role_modules.module_id AND modules.deleted_at IS NULL").
+		Where("role_modules.role_id = ? AND role_modules.checked = ? AND modules.name = ?", roleID, true, moduleName).
+		Count(&count).Error; err != nil {
+		return false, HandleDatabaseError(err, "role_module")
+	}
+	return count > 0, nil
+}
+
 // ---------- Mutations ----------
 
 func (r *RoleModuleRepositoryImpl) Insert(tx *gorm.DB, roleModule *models.RoleModule) (*models.RoleModule, error) {
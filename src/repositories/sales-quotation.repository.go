@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type SalesQuotationRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.SalesQuotation, int64, error)
+	FindById(tx *gorm.DB, quotationId string, forUpdate bool) (*models.SalesQuotation, error)
+	FindRevisions(tx *gorm.DB, quotationNumber string) ([]models.SalesQuotation, error)
+	FindExpired(tx *gorm.DB, before time.Time) ([]models.SalesQuotation, error)
+	Insert(tx *gorm.DB, quotation *models.SalesQuotation) (*models.SalesQuotation, error)
+	Update(tx *gorm.DB, quotation *models.SalesQuotation) (*models.SalesQuotation, error)
+	ReplaceItems(tx *gorm.DB, quotationID uuid.UUID, items []models.SalesQuotationItem) error
+	MarkExpired(tx *gorm.DB, quotationIDs []uuid.UUID) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type SalesQuotationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewSalesQuotationRepository(db *gorm.DB) *SalesQuotationRepositoryImpl {
+	return &SalesQuotationRepositoryImpl{DB: db}
+}
+
+func (r *SalesQuotationRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+// FindAllPaginated menampilkan versi terbaru saja, kecuali filter quotation_status=Superseded.
+func (r *SalesQuotationRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.SalesQuotation, int64, error) {
+	var (
+		quotations []models.SalesQuotation
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("Customer").
+		Preload("SalesPerson").
+		Preload("SalesQuotationItems").
+		Preload("SalesQuotationItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("sales_quotations.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("sales_quotations.deleted_at IS NULL")
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("sales_quotations.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.SalesPersonID != "" {
+		if spUUID, err := uuid.Parse(req.SalesPersonID); err == nil {
+			query = query.Where("sales_quotations.sales_person_id = ?", spUUID)
+		}
+	}
+
+	if req.QuotationStatus != "" {
+		query = query.Where("sales_quotations.status = ?", req.QuotationStatus)
+	} else {
+		query = query.Where("sales_quotations.status <> ?", "Superseded")
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(sales_quotations.quotation_number) LIKE ? OR
+			LOWER(sales_quotations.notes) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.SalesQuotation{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "sales_quotation")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("sales_quotations.created_at DESC").Offset(offset).Limit(req.Limit).Find(&quotations).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "sales_quotation")
+	}
+
+	return quotations, totalCount, nil
+}
+
+func (r *SalesQuotationRepositoryImpl) FindById(tx *gorm.DB, quotationId string, forUpdate bool) (*models.SalesQuotation, error) {
+	var quotation models.SalesQuotation
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&quotation, "id = ?", quotationId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "sales_quotation")
+		}
+	}
+
+	if err := db.
+		Preload("Customer").
+		Preload("SalesPerson").
+		Preload("SalesOrder").
+		Preload("SalesQuotationItems").
+		Preload("SalesQuotationItems.Item").
+		Preload("SalesQuotationItems.UoM").
+		First(&quotation, "id = ?", quotationId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_quotation")
+	}
+
+	return &quotation, nil
+}
+
+func (r *SalesQuotationRepositoryImpl) FindRevisions(tx *gorm.DB, quotationNumber string) ([]models.SalesQuotation, error) {
+	var quotations []models.SalesQuotation
+	if err := r.useDB(tx).
+		Preload("SalesQuotationItems").
+		Preload("SalesQuotationItems.Item").
+		Where("quotation_number = ?", quotationNumber).
+		Order("revision ASC").
+		Find(&quotations).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_quotation")
+	}
+	return quotations, nil
+}
+
+// FindExpired mengembalikan quotation Draft/Sent yang masa berlakunya habis sebelum `before`.
+func (r *SalesQuotationRepositoryImpl) FindExpired(tx *gorm.DB, before time.Time) ([]models.SalesQuotation, error) {
+	var quotations []models.SalesQuotation
+	if err := r.useDB(tx).
+		Preload("SalesPerson").
+		Where("status IN ? AND valid_until < ?", []string{"Draft", "Sent"}, before).
+		Find(&quotations).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_quotation")
+	}
+	return quotations, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *SalesQuotationRepositoryImpl) Insert(tx *gorm.DB, quotation *models.SalesQuotation) (*models.SalesQuotation, error) {
+	if quotation.ID == uuid.Nil {
+		return nil, fmt.Errorf("sales quotation ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("Customer", "SalesPerson", "SalesOrder", "SalesQuotationItems.Item", "SalesQuotationItems.UoM").Create(quotation).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_quotation")
+	}
+	return quotation, nil
+}
+
+func (r *SalesQuotationRepositoryImpl) Update(tx *gorm.DB, quotation *models.SalesQuotation) (*models.SalesQuotation, error) {
+	if quotation.ID == uuid.Nil {
+		return nil, fmt.Errorf("sales quotation ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(quotation).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_quotation")
+	}
+	return quotation, nil
+}
+
+func (r *SalesQuotationRepositoryImpl) ReplaceItems(tx *gorm.DB, quotationID uuid.UUID, items []models.SalesQuotationItem) error {
+	db := r.useDB(tx)
+	if err := db.Where("sales_quotation_id = ?", quotationID).Delete(&models.SalesQuotationItem{}).Error; err != nil {
+		return HandleDatabaseError(err, "sales_quotation")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := db.Omit(clause.Associations).Create(&items).Error; err != nil {
+		return HandleDatabaseError(err, "sales_quotation")
+	}
+	return nil
+}
+
+func (r *SalesQuotationRepositoryImpl) MarkExpired(tx *gorm.DB, quotationIDs []uuid.UUID) error {
+	if len(quotationIDs) == 0 {
+		return nil
+	}
+	if err := r.useDB(tx).
+		Model(&models.SalesQuotation{}).
+		Where("id IN ? AND status IN ?", quotationIDs, []string{"Draft", "Sent"}).
+		Update("status", "Expired").Error; err != nil {
+		return HandleDatabaseError(err, "sales_quotation")
+	}
+	return nil
+}
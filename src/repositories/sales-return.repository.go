@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type SalesReturnRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.SalesReturn, int64, error)
+	FindById(tx *gorm.DB, returnId string, forUpdate bool) (*models.SalesReturn, error)
+	SumReturnedQuantity(tx *gorm.DB, salesOrderItemIDs []uuid.UUID, excludeReturnID *uuid.UUID) (map[uuid.UUID]int, error)
+	Insert(tx *gorm.DB, salesReturn *models.SalesReturn) (*models.SalesReturn, error)
+	Update(tx *gorm.DB, salesReturn *models.SalesReturn) (*models.SalesReturn, error)
+	UpdateItem(tx *gorm.DB, item *models.SalesReturnItem) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type SalesReturnRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewSalesReturnRepository(db *gorm.DB) *SalesReturnRepositoryImpl {
+	return &SalesReturnRepositoryImpl{DB: db}
+}
+
+func (r *SalesReturnRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *SalesReturnRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.SalesReturn, int64, error) {
+	var (
+		returns    []models.SalesReturn
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("SalesOrder").
+		Preload("Customer").
+		Preload("Warehouse").
+		Preload("SalesReturnItems").
+		Preload("SalesReturnItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("sales_returns.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("sales_returns.deleted_at IS NULL")
+	}
+
+	if req.SalesOrderID != "" {
+		if soUUID, err := uuid.Parse(req.SalesOrderID); err == nil {
+			query = query.Where("sales_returns.sales_order_id = ?", soUUID)
+		}
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("sales_returns.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.ReturnStatus != "" {
+		query = query.Where("sales_returns.status = ?", req.ReturnStatus)
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(sales_returns.return_number) LIKE ? OR
+			LOWER(COALESCE(sales_returns.credit_note_number, '')) LIKE ? OR
+			LOWER(sales_returns.notes) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.SalesReturn{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "sales_return")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("sales_returns.created_at DESC").Offset(offset).Limit(req.Limit).Find(&returns).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "sales_return")
+	}
+
+	return returns, totalCount, nil
+}
+
+func (r *SalesReturnRepositoryImpl) FindById(tx *gorm.DB, returnId string, forUpdate bool) (*models.SalesReturn, error) {
+	var salesReturn models.SalesReturn
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&salesReturn, "id = ?", returnId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "sales_return")
+		}
+	}
+
+	if err := db.
+		Preload("SalesOrder").
+		Preload("Customer").
+		Preload("Warehouse").
+		Preload("RefundPayment").
+		Preload("SalesReturnItems").
+		Preload("SalesReturnItems.Item").
+		First(&salesReturn, "id = ?", returnId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_return")
+	}
+
+	return &salesReturn, nil
+}
+
+// SumReturnedQuantity menjumlah qty yang sudah diretur per baris SO (retur Draft & Completed,
+// retur Cancelled tidak dihitung).
+func (r *SalesReturnRepositoryImpl) SumReturnedQuantity(tx *gorm.DB, salesOrderItemIDs []uuid.UUID, excludeReturnID *uuid.UUID) (map[uuid.UUID]int, error) {
+	out := make(map[uuid.UUID]int, len(salesOrderItemIDs))
+	if len(salesOrderItemIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		SalesOrderItemID uuid.UUID
+		Quantity         int
+	}
+	query := r.useDB(tx).
+		Table("sales_return_items AS sri").
+		Select("sri.sales_order_item_id, COALESCE(SUM(sri.quantity), 0) AS quantity").
+		Joins("JOIN sales_returns sr ON sr.id = sri.sales_return_id").
+		Where("sri.sales_order_item_id IN ?", salesOrderItemIDs).
+		Where("sr.deleted_at IS NULL AND sr.status <> ?", "Cancelled")
+	if excludeReturnID != nil {
+		query = query.Where("sr.id <> ?", *excludeReturnID)
+	}
+	if err := query.Group("sri.sales_order_item_id").Scan(&rows).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_return")
+	}
+
+	for _, row := range rows {
+		out[row.SalesOrderItemID] = row.Quantity
+	}
+	return out, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *SalesReturnRepositoryImpl) Insert(tx *gorm.DB, salesReturn *models.SalesReturn) (*models.SalesReturn, error) {
+	if salesReturn.ID == uuid.Nil {
+		return nil, fmt.Errorf("sales return ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("SalesOrder", "Customer", "Warehouse", "RefundPayment", "SalesReturnItems.Item").Create(salesReturn).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_return")
+	}
+	return salesReturn, nil
+}
+
+func (r *SalesReturnRepositoryImpl) Update(tx *gorm.DB, salesReturn *models.SalesReturn) (*models.SalesReturn, error) {
+	if salesReturn.ID == uuid.Nil {
+		return nil, fmt.Errorf("sales return ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(salesReturn).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_return")
+	}
+	return salesReturn, nil
+}
+
+func (r *SalesReturnRepositoryImpl) UpdateItem(tx *gorm.DB, item *models.SalesReturnItem) error {
+	if err := r.useDB(tx).Omit(clause.Associations).Save(item).Error; err != nil {
+		return HandleDatabaseError(err, "sales_return")
+	}
+	return nil
+}
+
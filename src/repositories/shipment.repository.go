@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type ShipmentRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.Shipment, int64, error)
+	FindById(tx *gorm.DB, shipmentId string) (*models.Shipment, error)
+	FindBySalesOrder(tx *gorm.DB, soId string) ([]models.Shipment, error)
+	Insert(tx *gorm.DB, shipment *models.Shipment) (*models.Shipment, error)
+	Update(tx *gorm.DB, shipment *models.Shipment) (*models.Shipment, error)
+	MarkDeliveredBySalesOrder(tx *gorm.DB, soId uuid.UUID, deliveredAt time.Time) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type ShipmentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewShipmentRepository(db *gorm.DB) *ShipmentRepositoryImpl {
+	return &ShipmentRepositoryImpl{DB: db}
+}
+
+func (r *ShipmentRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *ShipmentRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.Shipment, int64, error) {
+	var (
+		shipments  []models.Shipment
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("SalesOrder").
+		Preload("Customer").
+		Preload("Warehouse").
+		Preload("ShipmentItems").
+		Preload("ShipmentItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("shipments.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("shipments.deleted_at IS NULL")
+	}
+
+	if req.SalesOrderID != "" {
+		if soUUID, err := uuid.Parse(req.SalesOrderID); err == nil {
+			query = query.Where("shipments.sales_order_id = ?", soUUID)
+		}
+	}
+
+	if req.CustomerID != "" {
+		if customerUUID, err := uuid.Parse(req.CustomerID); err == nil {
+			query = query.Where("shipments.customer_id = ?", customerUUID)
+		}
+	}
+
+	if req.WarehouseID != "" {
+		if warehouseUUID, err := uuid.Parse(req.WarehouseID); err == nil {
+			query = query.Where("shipments.warehouse_id = ?", warehouseUUID)
+		}
+	}
+
+	if req.ShipmentStatus != "" {
+		query = query.Where("shipments.status = ?", req.ShipmentStatus)
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(shipments.shipment_number) LIKE ? OR
+			LOWER(shipments.courier) LIKE ? OR
+			LOWER(shipments.tracking_number) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.Shipment{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "shipment")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("shipments.shipment_date DESC, shipments.created_at DESC").Offset(offset).Limit(req.Limit).Find(&shipments).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "shipment")
+	}
+
+	return shipments, totalCount, nil
+}
+
+func (r *ShipmentRepositoryImpl) FindById(tx *gorm.DB, shipmentId string) (*models.Shipment, error) {
+	var shipment models.Shipment
+	if err := r.useDB(tx).
+		Preload("SalesOrder").
+		Preload("Customer").
+		Preload("Warehouse").
+		Preload("ShippedByUser").
+		Preload("ShipmentItems").
+		Preload("ShipmentItems.Item").
+		First(&shipment, "id = ?", shipmentId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "shipment")
+	}
+	return &shipment, nil
+}
+
+// FindBySalesOrder mengembalikan riwayat pengiriman satu SO, urut dari shipment pertama.
+func (r *ShipmentRepositoryImpl) FindBySalesOrder(tx *gorm.DB, soId string) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	if err := r.useDB(tx).
+		Preload("Warehouse").
+		Preload("ShippedByUser").
+		Preload("ShipmentItems").
+		Preload("ShipmentItems.Item").
+		Where("sales_order_id = ?", soId).
+		Order("shipment_date ASC, created_at ASC").
+		Find(&shipments).Error; err != nil {
+		return nil, HandleDatabaseError(err, "shipment")
+	}
+	return shipments, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *ShipmentRepositoryImpl) Insert(tx *gorm.DB, shipment *models.Shipment) (*models.Shipment, error) {
+	if shipment.ID == uuid.Nil {
+		return nil, fmt.Errorf("shipment ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("SalesOrder", "Customer", "Warehouse", "ShippedByUser", "ShipmentItems.Item").Create(shipment).Error; err != nil {
+		return nil, HandleDatabaseError(err, "shipment")
+	}
+	return shipment, nil
+}
+
+func (r *ShipmentRepositoryImpl) Update(tx *gorm.DB, shipment *models.Shipment) (*models.Shipment, error) {
+	if shipment.ID == uuid.Nil {
+		return nil, fmt.Errorf("shipment ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(shipment).Error; err != nil {
+		return nil, HandleDatabaseError(err, "shipment")
+	}
+	return shipment, nil
+}
+
+// MarkDeliveredBySalesOrder menandai semua shipment SO yang masih Shipped sebagai Delivered.
+func (r *ShipmentRepositoryImpl) MarkDeliveredBySalesOrder(tx *gorm.DB, soId uuid.UUID, deliveredAt time.Time) error {
+	if err := r.useDB(tx).Model(&models.Shipment{}).
+		Where("sales_order_id = ? AND status = ?", soId, "Shipped").
+		Updates(map[string]interface{}{"status": "Delivered", "delivered_at": deliveredAt}).Error; err != nil {
+		return HandleDatabaseError(err, "shipment")
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/google/uuid"
@@ -20,6 +19,7 @@ type SalesOrderRepository interface {
 	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.SalesOrder, int64, error)
 	FindById(tx *gorm.DB, soId string, includeTrash bool) (*models.SalesOrder, error)
 	FindBySONumber(tx *gorm.DB, soNumber string) (*models.SalesOrder, error)
+	FindOpenReceivablesByCustomer(tx *gorm.DB, customerID uuid.UUID, excludeSOID uuid.UUID) ([]models.SalesOrder, error)
 
 	CountAllThisMonth(tx *gorm.DB) (int64, error)
 	CountAllLastMonth(tx *gorm.DB) (int64, error)
@@ -35,8 +35,6 @@ type SalesOrderRepository interface {
 	UpdateStatus(tx *gorm.DB, soId string, soStatus, paymentStatus string) error
 	Delete(tx *gorm.DB, soId string, isHardDelete bool) error
 	Restore(tx *gorm.DB, soId string) (*models.SalesOrder, error)
-
-	GenerateNextSONumber(tx *gorm.DB) (string, error)
 }
 
 // ==============================
@@ -165,6 +163,8 @@ func (r *SalesOrderRepositoryImpl) FindById(tx *gorm.DB, soId string, includeTra
 		Preload("SalesOrderItems.Item.Category").
 		Preload("Payments").
 		Preload("Payments.Invoice").
+		Preload("Shipments", func(db *gorm.DB) *gorm.DB { return db.Order("shipment_date ASC, created_at ASC") }).
+		Preload("Shipments.ShipmentItems").
 		First(&so, "id = ?", soId).Error; err != nil {
 		return nil, HandleDatabaseError(err, "sales_order")
 	}
@@ -180,6 +180,23 @@ func (r *SalesOrderRepositoryImpl) FindBySONumber(tx *gorm.DB, soNumber string)
 	return &so, nil
 }
 
+// FindOpenReceivablesByCustomer mengembalikan SO customer yang masih menyisakan piutang
+// (bukan Draft/Cancelled dan belum lunas). excludeSOID dipakai agar SO yang sedang diproses tidak ikut dihitung.
+func (r *SalesOrderRepositoryImpl) FindOpenReceivablesByCustomer(tx *gorm.DB, customerID uuid.UUID, excludeSOID uuid.UUID) ([]models.SalesOrder, error) {
+	var sos []models.SalesOrder
+	if err := r.useDB(tx).
+		Where("customer_id = ?", customerID).
+		Where("id <> ?", excludeSOID).
+		Where("so_status NOT IN ?", []string{"Draft", "Cancelled"}).
+		Where("payment_status <> ?", "Paid").
+		Where("total_amount > paid_amount").
+		Order("due_date ASC NULLS LAST, so_date ASC").
+		Find(&sos).Error; err != nil {
+		return nil, HandleDatabaseError(err, "sales_order")
+	}
+	return sos, nil
+}
+
 // ---------- Counts & Sums ----------
 
 func (r *SalesOrderRepositoryImpl) CountAllThisMonth(tx *gorm.DB) (int64, error) {
@@ -202,7 +219,7 @@ func (r *SalesOrderRepositoryImpl) CountActiveThisMonth(tx *gorm.DB) (int64, err
 	var count int64
 	err := r.useDB(tx).Model(&models.SalesOrder{}).
 		Where("DATE_TRUNC('month', so_date) = DATE_TRUNC('month', NOW())").
-		Where("so_status IN ?", []string{"Confirmed", "Shipped", "Delivered"}).
+		Where("so_status IN ?", []string{"Confirmed", "PartiallyShipped", "Shipped", "Delivered"}).
 		Count(&count).Error
 	return count, err
 }
@@ -211,7 +228,7 @@ func (r *SalesOrderRepositoryImpl) CountActiveLastMonth(tx *gorm.DB) (int64, err
 	var count int64
 	err := r.useDB(tx).Model(&models.SalesOrder{}).
 		Where("DATE_TRUNC('month', so_date) = DATE_TRUNC('month', NOW() - INTERVAL '1 month')").
-		Where("so_status IN ?", []string{"Confirmed", "Shipped", "Delivered"}).
+		Where("so_status IN ?", []string{"Confirmed", "PartiallyShipped", "Shipped", "Delivered"}).
 		Count(&count).Error
 	return count, err
 }
@@ -351,34 +368,3 @@ func (r *SalesOrderRepositoryImpl) Restore(tx *gorm.DB, soId string) (*models.Sa
 
 	return &restored, nil
 }
-
-// ---------- Utilities ----------
-
-func (r *SalesOrderRepositoryImpl) GenerateNextSONumber(tx *gorm.DB) (string, error) {
-	var lastSO models.SalesOrder
-	currentYear := time.Now().Year()
-	prefix := fmt.Sprintf("SO-%d-", currentYear)
-
-	db := r.useDB(tx)
-
-	err := db.Where("so_number LIKE ?", prefix+"%").
-		Order("so_number DESC").
-		First(&lastSO).Error
-
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return "", err
-	}
-
-	nextNumber := 1
-	if err != gorm.ErrRecordNotFound {
-		parts := strings.Split(lastSO.SONumber, "-")
-		if len(parts) >= 3 {
-			var parsed int
-			if n, scanErr := fmt.Sscanf(parts[2], "%d", &parsed); scanErr == nil && n == 1 {
-				nextNumber = parsed + 1
-			}
-		}
-	}
-
-	return fmt.Sprintf("%s%04d", prefix, nextNumber), nil
-}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type StockOpnameRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.StockOpname, int64, error)
+	FindById(tx *gorm.DB, opnameId string, forUpdate bool) (*models.StockOpname, error)
+	Insert(tx *gorm.DB, opname *models.StockOpname) (*models.StockOpname, error)
+	Update(tx *gorm.DB, opname *models.StockOpname) (*models.StockOpname, error)
+	InsertItem(tx *gorm.DB, item *models.StockOpnameItem) error
+	UpdateItem(tx *gorm.DB, item *models.StockOpnameItem) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type StockOpnameRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewStockOpnameRepository(db *gorm.DB) *StockOpnameRepositoryImpl {
+	return &StockOpnameRepositoryImpl{DB: db}
+}
+
+func (r *StockOpnameRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *StockOpnameRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.StockOpname, int64, error) {
+	var (
+		opnames    []models.StockOpname
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("Warehouse").
+		Preload("Category").
+		Preload("StockOpnameItems")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("stock_opnames.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("stock_opnames.deleted_at IS NULL")
+	}
+
+	if req.WarehouseID != "" {
+		if whUUID, err := uuid.Parse(req.WarehouseID); err == nil {
+			query = query.Where("stock_opnames.warehouse_id = ?", whUUID)
+		}
+	}
+
+	if req.CategoryID != "" {
+		if categoryUUID, err := uuid.Parse(req.CategoryID); err == nil {
+			query = query.Where("stock_opnames.category_id = ?", categoryUUID)
+		}
+	}
+
+	if req.OpnameStatus != "" {
+		query = query.Where("stock_opnames.status = ?", req.OpnameStatus)
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(stock_opnames.opname_number) LIKE ? OR
+			LOWER(stock_opnames.notes) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.StockOpname{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "stock_opname")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("stock_opnames.created_at DESC").Offset(offset).Limit(req.Limit).Find(&opnames).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "stock_opname")
+	}
+
+	return opnames, totalCount, nil
+}
+
+func (r *StockOpnameRepositoryImpl) FindById(tx *gorm.DB, opnameId string, forUpdate bool) (*models.StockOpname, error) {
+	var opname models.StockOpname
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&opname, "id = ?", opnameId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "stock_opname")
+		}
+	}
+
+	if err := db.
+		Preload("Warehouse").
+		Preload("Category").
+		Preload("StockOpnameItems", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("JOIN items ON items.id = stock_opname_items.item_id").
+				Order("items.name ASC").
+				Order("stock_opname_items.lot_number ASC")
+		}).
+		Preload("StockOpnameItems.Item").
+		First(&opname, "id = ?", opnameId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_opname")
+	}
+
+	return &opname, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *StockOpnameRepositoryImpl) Insert(tx *gorm.DB, opname *models.StockOpname) (*models.StockOpname, error) {
+	if opname.ID == uuid.Nil {
+		return nil, fmt.Errorf("stock opname ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("Warehouse", "Category", "StockOpnameItems.Item").Create(opname).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_opname")
+	}
+	return opname, nil
+}
+
+func (r *StockOpnameRepositoryImpl) Update(tx *gorm.DB, opname *models.StockOpname) (*models.StockOpname, error) {
+	if opname.ID == uuid.Nil {
+		return nil, fmt.Errorf("stock opname ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(opname).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_opname")
+	}
+	return opname, nil
+}
+
+func (r *StockOpnameRepositoryImpl) InsertItem(tx *gorm.DB, item *models.StockOpnameItem) error {
+	if item.ID == uuid.Nil {
+		return fmt.Errorf("stock opname item ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Create(item).Error; err != nil {
+		return HandleDatabaseError(err, "stock_opname")
+	}
+	return nil
+}
+
+func (r *StockOpnameRepositoryImpl) UpdateItem(tx *gorm.DB, item *models.StockOpnameItem) error {
+	if err := r.useDB(tx).Omit(clause.Associations).Save(item).Error; err != nil {
+		return HandleDatabaseError(err, "stock_opname")
+	}
+	return nil
+}
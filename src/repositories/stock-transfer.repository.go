@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type StockTransferRepository interface {
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.StockTransfer, int64, error)
+	FindById(tx *gorm.DB, transferId string, forUpdate bool) (*models.StockTransfer, error)
+	Insert(tx *gorm.DB, transfer *models.StockTransfer) (*models.StockTransfer, error)
+	Update(tx *gorm.DB, transfer *models.StockTransfer) (*models.StockTransfer, error)
+	ReplaceItems(tx *gorm.DB, transferId uuid.UUID, items []models.StockTransferItem) error
+	InsertLots(tx *gorm.DB, lots []models.StockTransferLot) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type StockTransferRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewStockTransferRepository(db *gorm.DB) *StockTransferRepositoryImpl {
+	return &StockTransferRepositoryImpl{DB: db}
+}
+
+func (r *StockTransferRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *StockTransferRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.StockTransfer, int64, error) {
+	var (
+		transfers  []models.StockTransfer
+		totalCount int64
+	)
+
+	query := r.useDB(tx).Unscoped().
+		Preload("SourceWarehouse").
+		Preload("DestinationWarehouse").
+		Preload("StockTransferItems").
+		Preload("StockTransferItems.Item")
+
+	switch req.Status {
+	case "deleted":
+		query = query.Where("stock_transfers.deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("stock_transfers.deleted_at IS NULL")
+	}
+
+	if req.WarehouseID != "" {
+		if warehouseUUID, err := uuid.Parse(req.WarehouseID); err == nil {
+			query = query.Where("source_warehouse_id = ? OR destination_warehouse_id = ?", warehouseUUID, warehouseUUID)
+		}
+	}
+
+	if req.TransferStatus != "" {
+		query = query.Where("stock_transfers.status = ?", req.TransferStatus)
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(stock_transfers.transfer_number) LIKE ? OR
+			LOWER(stock_transfers.notes) LIKE ?
+		`, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.StockTransfer{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "stock_transfer")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("stock_transfers.created_at DESC").Offset(offset).Limit(req.Limit).Find(&transfers).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "stock_transfer")
+	}
+
+	return transfers, totalCount, nil
+}
+
+func (r *StockTransferRepositoryImpl) FindById(tx *gorm.DB, transferId string, forUpdate bool) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	db := r.useDB(tx)
+	if forUpdate {
+		// kunci header saja; preload relasi tidak boleh ikut FOR UPDATE (LEFT JOIN)
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, "id = ?", transferId).Error; err != nil {
+			return nil, HandleDatabaseError(err, "stock_transfer")
+		}
+	}
+
+	if err := db.
+		Preload("SourceWarehouse").
+		Preload("DestinationWarehouse").
+		Preload("StockTransferItems").
+		Preload("StockTransferItems.Item").
+		Preload("StockTransferItems.StockTransferLots").
+		First(&transfer, "id = ?", transferId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_transfer")
+	}
+
+	return &transfer, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *StockTransferRepositoryImpl) Insert(tx *gorm.DB, transfer *models.StockTransfer) (*models.StockTransfer, error) {
+	if transfer.ID == uuid.Nil {
+		return nil, fmt.Errorf("stock transfer ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("SourceWarehouse", "DestinationWarehouse", "StockTransferItems.Item").Create(transfer).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_transfer")
+	}
+	return transfer, nil
+}
+
+func (r *StockTransferRepositoryImpl) Update(tx *gorm.DB, transfer *models.StockTransfer) (*models.StockTransfer, error) {
+	if transfer.ID == uuid.Nil {
+		return nil, fmt.Errorf("stock transfer ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit(clause.Associations).Save(transfer).Error; err != nil {
+		return nil, HandleDatabaseError(err, "stock_transfer")
+	}
+	return transfer, nil
+}
+
+func (r *StockTransferRepositoryImpl) ReplaceItems(tx *gorm.DB, transferId uuid.UUID, items []models.StockTransferItem) error {
+	db := r.useDB(tx)
+	if err := db.Where("stock_transfer_id = ?", transferId).Delete(&models.StockTransferItem{}).Error; err != nil {
+		return HandleDatabaseError(err, "stock_transfer")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := db.Omit("Item", "StockTransferLots").Create(&items).Error; err != nil {
+		return HandleDatabaseError(err, "stock_transfer")
+	}
+	return nil
+}
+
+func (r *StockTransferRepositoryImpl) InsertLots(tx *gorm.DB, lots []models.StockTransferLot) error {
+	if len(lots) == 0 {
+		return nil
+	}
+	if err := r.useDB(tx).Create(&lots).Error; err != nil {
+		return HandleDatabaseError(err, "stock_transfer")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type UserSessionRepository interface {
+	FindById(tx *gorm.DB, sessionId string) (*models.UserSession, error)
+	FindByRefreshHash(tx *gorm.DB, refreshHash string) (*models.UserSession, error)
+	FindByPreviousRefreshHash(tx *gorm.DB, refreshHash string) (*models.UserSession, error)
+	FindActiveByUser(tx *gorm.DB, userID uuid.UUID) ([]models.UserSession, error)
+	Insert(tx *gorm.DB, session *models.UserSession) (*models.UserSession, error)
+	Update(tx *gorm.DB, session *models.UserSession) (*models.UserSession, error)
+	RevokeByDevice(tx *gorm.DB, userID uuid.UUID, deviceID, reason string) error
+	RevokeAllByUser(tx *gorm.DB, userID uuid.UUID, reason string, exceptID *uuid.UUID) error
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type UserSessionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) *UserSessionRepositoryImpl {
+	return &UserSessionRepositoryImpl{DB: db}
+}
+
+func (r *UserSessionRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *UserSessionRepositoryImpl) FindById(tx *gorm.DB, sessionId string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.useDB(tx).First(&session, "id = ?", sessionId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return &session, nil
+}
+
+func (r *UserSessionRepositoryImpl) FindByRefreshHash(tx *gorm.DB, refreshHash string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.useDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("refresh_token_hash = ?", refreshHash).
+		First(&session).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return &session, nil
+}
+
+func (r *UserSessionRepositoryImpl) FindByPreviousRefreshHash(tx *gorm.DB, refreshHash string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.useDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("previous_refresh_hash = ?", refreshHash).
+		First(&session).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return &session, nil
+}
+
+func (r *UserSessionRepositoryImpl) FindActiveByUser(tx *gorm.DB, userID uuid.UUID) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	if err := r.useDB(tx).
+		Where("user_id = ? AND revoked_at IS NULL AND refresh_expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return sessions, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *UserSessionRepositoryImpl) Insert(tx *gorm.DB, session *models.UserSession) (*models.UserSession, error) {
+	if session.ID == uuid.Nil {
+		return nil, fmt.Errorf("user session ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("User").Create(session).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return session, nil
+}
+
+func (r *UserSessionRepositoryImpl) Update(tx *gorm.DB, session *models.UserSession) (*models.UserSession, error) {
+	if session.ID == uuid.Nil {
+		return nil, fmt.Errorf("user session ID cannot be empty")
+	}
+	if err := r.useDB(tx).Omit("User").Save(session).Error; err != nil {
+		return nil, HandleDatabaseError(err, "user_session")
+	}
+	return session, nil
+}
+
+func (r *UserSessionRepositoryImpl) RevokeByDevice(tx *gorm.DB, userID uuid.UUID, deviceID, reason string) error {
+	if err := r.useDB(tx).
+		Model(&models.UserSession{}).
+		Where("user_id = ? AND device_id = ? AND revoked_at IS NULL", userID, deviceID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error; err != nil {
+		return HandleDatabaseError(err, "user_session")
+	}
+	return nil
+}
+
+func (r *UserSessionRepositoryImpl) RevokeAllByUser(tx *gorm.DB, userID uuid.UUID, reason string, exceptID *uuid.UUID) error {
+	query := r.useDB(tx).
+		Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != nil {
+		query = query.Where("id <> ?", *exceptID)
+	}
+	if err := query.Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error; err != nil {
+		return HandleDatabaseError(err, "user_session")
+	}
+	return nil
+}
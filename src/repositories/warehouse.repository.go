@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Interface (transaction-aware)
+// ==============================
+
+type WarehouseRepository interface {
+	FindAll(tx *gorm.DB) ([]models.Warehouse, error)
+	FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.Warehouse, int64, error)
+	FindById(tx *gorm.DB, warehouseId string, includeTrashed bool) (*models.Warehouse, error)
+	FindByCode(tx *gorm.DB, warehouseCode string) (*models.Warehouse, error)
+	FindDefault(tx *gorm.DB) (*models.Warehouse, error)
+	Insert(tx *gorm.DB, warehouse *models.Warehouse) (*models.Warehouse, error)
+	Update(tx *gorm.DB, warehouse *models.Warehouse) (*models.Warehouse, error)
+	ClearDefault(tx *gorm.DB, exceptId uuid.UUID) error
+	Delete(tx *gorm.DB, warehouseId string, isHardDelete bool) error
+	Restore(tx *gorm.DB, warehouseId string) (*models.Warehouse, error)
+}
+
+// ==============================
+// Implementation
+// ==============================
+
+type WarehouseRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWarehouseRepository(db *gorm.DB) *WarehouseRepositoryImpl {
+	return &WarehouseRepositoryImpl{DB: db}
+}
+
+func (r *WarehouseRepositoryImpl) useDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+// ---------- Reads ----------
+
+func (r *WarehouseRepositoryImpl) FindAll(tx *gorm.DB) ([]models.Warehouse, error) {
+	var warehouses []models.Warehouse
+	if err := r.useDB(tx).Unscoped().Order("is_default DESC, name ASC").Find(&warehouses).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return warehouses, nil
+}
+
+func (r *WarehouseRepositoryImpl) FindAllPaginated(tx *gorm.DB, req *models.PaginationRequest) ([]models.Warehouse, int64, error) {
+	var (
+		warehouses []models.Warehouse
+		totalCount int64
+	)
+	query := r.useDB(tx).Unscoped()
+
+	switch req.Status {
+	case "active":
+		query = query.Where("deleted_at IS NULL")
+	case "deleted":
+		query = query.Where("deleted_at IS NOT NULL")
+	case "all":
+		// no filter
+	default:
+		query = query.Where("deleted_at IS NULL")
+	}
+
+	if req.Search != "" {
+		searchPattern := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where(`
+			LOWER(name) LIKE ? OR
+			LOWER(code) LIKE ? OR
+			LOWER(COALESCE(address, '')) LIKE ?
+		`, searchPattern, searchPattern, searchPattern)
+	}
+
+	if err := query.Model(&models.Warehouse{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "warehouse")
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("is_default DESC, name ASC").Offset(offset).Limit(req.Limit).Find(&warehouses).Error; err != nil {
+		return nil, 0, HandleDatabaseError(err, "warehouse")
+	}
+
+	return warehouses, totalCount, nil
+}
+
+func (r *WarehouseRepositoryImpl) FindById(tx *gorm.DB, warehouseId string, includeTrashed bool) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	db := r.useDB(tx)
+	if includeTrashed {
+		db = db.Unscoped()
+	}
+
+	if err := db.First(&warehouse, "id = ?", warehouseId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return &warehouse, nil
+}
+
+func (r *WarehouseRepositoryImpl) FindByCode(tx *gorm.DB, warehouseCode string) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	if err := r.useDB(tx).Where("code = ?", warehouseCode).First(&warehouse).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return &warehouse, nil
+}
+
+func (r *WarehouseRepositoryImpl) FindDefault(tx *gorm.DB) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	if err := r.useDB(tx).Where("is_default = ?", true).Order("created_at ASC").First(&warehouse).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return &warehouse, nil
+}
+
+// ---------- Mutations ----------
+
+func (r *WarehouseRepositoryImpl) Insert(tx *gorm.DB, warehouse *models.Warehouse) (*models.Warehouse, error) {
+	if warehouse.ID == uuid.Nil {
+		return nil, fmt.Errorf("warehouse ID cannot be empty")
+	}
+	if err := r.useDB(tx).Create(warehouse).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return warehouse, nil
+}
+
+func (r *WarehouseRepositoryImpl) Update(tx *gorm.DB, warehouse *models.Warehouse) (*models.Warehouse, error) {
+	if warehouse.ID == uuid.Nil {
+		return nil, fmt.Errorf("warehouse ID cannot be empty")
+	}
+	if err := r.useDB(tx).Save(warehouse).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return warehouse, nil
+}
+
+// ClearDefault melepas flag default dari semua gudang selain exceptId (hanya boleh satu default).
+func (r *WarehouseRepositoryImpl) ClearDefault(tx *gorm.DB, exceptId uuid.UUID) error {
+	if err := r.useDB(tx).
+		Model(&models.Warehouse{}).
+		Where("is_default = ? AND id <> ?", true, exceptId).
+		Update("is_default", false).Error; err != nil {
+		return HandleDatabaseError(err, "warehouse")
+	}
+	return nil
+}
+
+func (r *WarehouseRepositoryImpl) Delete(tx *gorm.DB, warehouseId string, isHardDelete bool) error {
+	db := r.useDB(tx)
+
+	var warehouse models.Warehouse
+	if err := db.Unscoped().First(&warehouse, "id = ?", warehouseId).Error; err != nil {
+		return HandleDatabaseError(err, "warehouse")
+	}
+
+	if isHardDelete {
+		if err := db.Unscoped().Delete(&warehouse).Error; err != nil {
+			return HandleDatabaseError(err, "warehouse")
+		}
+	} else {
+		if err := db.Delete(&warehouse).Error; err != nil {
+			return HandleDatabaseError(err, "warehouse")
+		}
+	}
+	return nil
+}
+
+func (r *WarehouseRepositoryImpl) Restore(tx *gorm.DB, warehouseId string) (*models.Warehouse, error) {
+	db := r.useDB(tx)
+
+	if err := db.Unscoped().
+		Model(&models.Warehouse{}).
+		Where("id = ?", warehouseId).
+		Update("deleted_at", nil).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+
+	var restored models.Warehouse
+	if err := db.First(&restored, "id = ?", warehouseId).Error; err != nil {
+		return nil, HandleDatabaseError(err, "warehouse")
+	}
+	return &restored, nil
+}
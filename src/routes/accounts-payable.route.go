@@ -0,0 +1,20 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func AccountsPayableRoutes(r fiber.Router) {
+	ap := r.Group("/accounts-payable", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	ap.Get("/aging", controllers.GetAPAging)
+	ap.Get("/forecast", controllers.GetAPCashOutForecast)
+
+	ap.Get("/payment-run", controllers.GetAllPaymentRunsPaginated)
+	ap.Post("/payment-run", controllers.CreatePaymentRun)
+	ap.Get("/payment-run/proposal", controllers.ProposePaymentRun)
+	ap.Get("/payment-run/:id", controllers.GetPaymentRunByID)
+	ap.Put("/payment-run/:id/execute", controllers.ExecutePaymentRun)
+	ap.Put("/payment-run/:id/cancel", controllers.CancelPaymentRun)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func AccountsReceivableRoutes(r fiber.Router) {
+	ar := r.Group("/accounts-receivable", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	ar.Get("/aging", controllers.GetARAging)
+	ar.Get("/aging/excel", controllers.ExportARAgingExcel)
+	ar.Get("/customer/:id/statement", controllers.GetCustomerStatement)
+	ar.Get("/customer/:id/statement/pdf", controllers.ExportCustomerStatementPDF)
+}
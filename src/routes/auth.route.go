@@ -9,11 +9,16 @@ import (
 func AuthRoutes(r fiber.Router) {
 	r.Post("auth/check-identifier", controllers.CheckIdentifierController)
 	r.Post("auth/forgot-password", controllers.ForgotPasswordController)
+	r.Post("auth/forgot-password/confirm", controllers.ForgotPasswordConfirmController)
 
 	authGroup := r.Group("/auth")
 	authGroup.Post("/login", controllers.LoginController)
+	authGroup.Post("/refresh", controllers.RefreshTokenController)
 
 	// using middleware
 	authGroup.Use(middlewares.JWTProtected)
 	authGroup.Put("/reset-password", controllers.ResetPasswordController)
+	authGroup.Post("/logout", controllers.LogoutController)
+	authGroup.Post("/logout-all", controllers.LogoutAllController)
+	authGroup.Get("/sessions", controllers.GetSessionsController)
 }
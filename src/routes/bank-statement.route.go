@@ -0,0 +1,19 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func BankStatementRoutes(r fiber.Router) {
+	statements := r.Group("/bank-statement", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	statements.Get("/", controllers.GetAllBankStatementsPaginated)
+	statements.Post("/import", controllers.ImportBankStatement)
+	statements.Get("/parsers", controllers.GetBankStatementParsers)
+	statements.Get("/queue", controllers.GetBankReconciliationQueue)
+	statements.Put("/line/:id/confirm", controllers.ConfirmBankStatementLine)
+	statements.Put("/line/:id/ignore", controllers.IgnoreBankStatementLine)
+	statements.Get("/:id", controllers.GetBankStatementByID)
+	statements.Put("/:id/rematch", controllers.RematchBankStatement)
+}
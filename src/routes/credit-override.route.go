@@ -0,0 +1,13 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func CreditOverrideRoutes(r fiber.Router) {
+	overrides := r.Group("/credit-override")
+	overrides.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	overrides.Get("/", controllers.GetAllCreditOverridesPaginated)
+}
@@ -14,5 +14,6 @@ customersGroup.Post("/", controllers.CustomerControllerCreate)
 customersGroup.Put("/restore", controllers.CustomerControllerRestore)
 customersGroup.Delete("/delete", controllers.CustomerControllerDelete)
 customersGroup.Get("/:id", controllers.CustomerControllerGetByID)
+customersGroup.Get("/:id/credit", controllers.GetCustomerCredit)
 customersGroup.Put("/:id", controllers.CustomerControllerUpdate)
 	} 
\ No newline at end of file
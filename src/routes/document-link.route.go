@@ -0,0 +1,16 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func DocumentLinkRoutes(r fiber.Router) {
+	// Public endpoint: akses hanya lewat URL bertanda tangan
+	r.Get("/shared-document/:id", controllers.GetSharedDocument)
+
+	protected := r.Group("/document-link", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	protected.Post("/", controllers.CreateDocumentLink)
+	protected.Put("/:id/revoke", controllers.RevokeDocumentLink)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func DunningReminderRoutes(r fiber.Router) {
+	dunning := r.Group("/dunning-reminder", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	dunning.Get("/", controllers.GetAllDunningRemindersPaginated)
+	dunning.Post("/run", controllers.RunDunningReminders)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func GoodsReceiptRoutes(r fiber.Router) {
+	receipts := r.Group("/goods-receipt")
+	receipts.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	receipts.Get("/", controllers.GetAllGoodsReceiptsPaginated)
+
+	receipts.Get("/:id", controllers.GetGoodsReceiptByID)
+	receipts.Get("/:id/document", controllers.GenerateGoodsReceiptNote)
+}
@@ -39,6 +39,24 @@ func RouteInit(app *fiber.App) {
 	NotificationRoutes(v1)
 	SalesReportRoutes(v1)
 	UoMRoutes(v1)
+	WarehouseRoutes(v1)
+	StockTransferRoutes(v1)
+	DocumentLinkRoutes(v1)
+	SalesReturnRoutes(v1)
+	StockOpnameRoutes(v1)
+	PurchaseReturnRoutes(v1)
+	GoodsReceiptRoutes(v1)
+	NumberSequenceRoutes(v1)
+	PurchaseOrderApprovalRuleRoutes(v1)
+	SalesQuotationRoutes(v1)
+	ShipmentRoutes(v1)
+	CreditOverrideRoutes(v1)
+	PriceListRoutes(v1)
+	AccountsReceivableRoutes(v1)
+	AccountsPayableRoutes(v1)
+	DunningReminderRoutes(v1)
+	PaymentReceiptRoutes(v1)
+	BankStatementRoutes(v1)
 }
 
 // HealthCheck godoc
@@ -13,6 +13,9 @@ func ItemRoutes(r fiber.Router) {
 	itemsGroup.Post("/", controllers.ItemControllerCreate)
 	itemsGroup.Put("/restore", controllers.ItemControllerRestore)
 	itemsGroup.Delete("/delete", controllers.ItemControllerDelete)
+	itemsGroup.Get("/near-expiry", controllers.ItemControllerGetNearExpiry)
 	itemsGroup.Get("/:id", controllers.ItemControllerGetByID)
+	itemsGroup.Get("/:id/lots", controllers.ItemControllerGetLots)
+	itemsGroup.Get("/:id/stocks", controllers.ItemControllerGetStocks)
 	itemsGroup.Put("/:id", controllers.ItemControllerUpdate)
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NumberSequenceRoutes(r fiber.Router) {
+	sequences := r.Group("/number-sequence")
+	sequences.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	sequences.Get("/", controllers.GetAllNumberSequences)
+
+	sequences.Put("/:id", controllers.UpdateNumberSequence)
+}
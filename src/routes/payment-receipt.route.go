@@ -0,0 +1,16 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PaymentReceiptRoutes(r fiber.Router) {
+	receipts := r.Group("/payment-receipt", middlewares.JWTProtected, middlewares.RBACMiddleware)
+	receipts.Get("/", controllers.GetAllPaymentReceiptsPaginated)
+	receipts.Post("/", controllers.CreatePaymentReceipt)
+	receipts.Get("/deposits", controllers.GetPaymentDeposits)
+	receipts.Get("/:id", controllers.GetPaymentReceiptByID)
+	receipts.Put("/:id/allocate", controllers.AllocatePaymentReceipt)
+}
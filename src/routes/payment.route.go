@@ -12,5 +12,6 @@ func PaymentRoutes(r fiber.Router) {
 	payments.Post("/", controllers.CreatePayment)
 	payments.Get("/purchase-order/:po_id", controllers.GetPaymentsByPurchaseOrder)
 	payments.Get("/sales-order/:so_id", controllers.GetPaymentsBySalesOrder)
+	payments.Put("/:id/void", controllers.VoidPayment)
 }
 
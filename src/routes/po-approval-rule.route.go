@@ -0,0 +1,17 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PurchaseOrderApprovalRuleRoutes(r fiber.Router) {
+	rules := r.Group("/po-approval-rule")
+	rules.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	rules.Get("/", controllers.GetAllPurchaseOrderApprovalRules)
+	rules.Post("/", controllers.CreatePurchaseOrderApprovalRule)
+
+	rules.Put("/:id", controllers.UpdatePurchaseOrderApprovalRule)
+	rules.Delete("/:id", controllers.DeletePurchaseOrderApprovalRule)
+}
@@ -7,9 +7,6 @@ import (
 )
 
 func PurchaseOrderRoutes(r fiber.Router) {
-	public := r.Group("/purchase-order")
-	public.Get("/:id/document", controllers.GenerateDocumentPurchaseOrder)
-
 	protected := r.Group("/purchase-order", middlewares.JWTProtected, middlewares.RBACMiddleware)
 	protected.Get("/", controllers.GetAllPurchaseOrdersPaginated)
 	protected.Post("/", controllers.CreatePurchaseOrder)
@@ -17,9 +14,14 @@ func PurchaseOrderRoutes(r fiber.Router) {
 	protected.Get("/all", controllers.GetAllPurchaseOrders)
 	protected.Delete("/delete", controllers.DeletePurchaseOrders)
 	protected.Put("/restore", controllers.RestorePurchaseOrders)
+	protected.Get("/approvals/pending", controllers.GetMyPendingPurchaseOrderApprovals)
 
 	protected.Get("/:id", controllers.GetPurchaseOrderByID)
 	protected.Put("/:id", controllers.UpdatePurchaseOrder)
 	protected.Put("/:id/status", controllers.UpdatePurchaseOrderStatus)
 	protected.Put("/:id/receive", controllers.ReceiveItems)
+	protected.Get("/:id/receipts", controllers.GetPurchaseOrderReceipts)
+	protected.Get("/:id/approvals", controllers.GetPurchaseOrderApprovals)
+	protected.Put("/:id/approval", controllers.DecidePurchaseOrderApproval)
+	protected.Get("/:id/document", controllers.GenerateDocumentPurchaseOrder)
 }
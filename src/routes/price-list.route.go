@@ -0,0 +1,21 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PriceListRoutes(r fiber.Router) {
+	priceLists := r.Group("/price-list")
+	priceLists.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	priceLists.Get("/", controllers.GetAllPriceListsPaginated)
+	priceLists.Post("/", controllers.CreatePriceList)
+
+	priceLists.Get("/resolve", controllers.ResolvePrice)
+	priceLists.Delete("/delete", controllers.DeletePriceLists)
+	priceLists.Put("/restore", controllers.RestorePriceLists)
+
+	priceLists.Get("/:id", controllers.GetPriceListByID)
+	priceLists.Put("/:id", controllers.UpdatePriceList)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PurchaseReturnRoutes(r fiber.Router) {
+	returns := r.Group("/purchase-return")
+	returns.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	returns.Get("/", controllers.GetAllPurchaseReturnsPaginated)
+	returns.Post("/", controllers.CreatePurchaseReturn)
+
+	returns.Get("/:id", controllers.GetPurchaseReturnByID)
+	returns.Put("/:id/status", controllers.UpdatePurchaseReturnStatus)
+	returns.Get("/:id/debit-note", controllers.GenerateDebitNote)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SalesQuotationRoutes(r fiber.Router) {
+	quotations := r.Group("/sales-quotation")
+	quotations.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	quotations.Get("/", controllers.GetAllSalesQuotationsPaginated)
+	quotations.Post("/", controllers.CreateSalesQuotation)
+
+	quotations.Get("/:id", controllers.GetSalesQuotationByID)
+	quotations.Put("/:id", controllers.UpdateSalesQuotation)
+	quotations.Get("/:id/revisions", controllers.GetSalesQuotationRevisions)
+	quotations.Post("/:id/revise", controllers.ReviseSalesQuotation)
+	quotations.Put("/:id/status", controllers.UpdateSalesQuotationStatus)
+	quotations.Post("/:id/convert", controllers.ConvertSalesQuotation)
+	quotations.Get("/:id/document", controllers.GenerateQuotationDocument)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SalesReturnRoutes(r fiber.Router) {
+	returns := r.Group("/sales-return")
+	returns.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	returns.Get("/", controllers.GetAllSalesReturnsPaginated)
+	returns.Post("/", controllers.CreateSalesReturn)
+
+	returns.Get("/:id", controllers.GetSalesReturnByID)
+	returns.Put("/:id/status", controllers.UpdateSalesReturnStatus)
+	returns.Get("/:id/credit-note", controllers.GenerateCreditNote)
+}
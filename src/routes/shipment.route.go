@@ -0,0 +1,17 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ShipmentRoutes(r fiber.Router) {
+	shipments := r.Group("/shipment")
+	shipments.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	shipments.Get("/", controllers.GetAllShipmentsPaginated)
+
+	shipments.Get("/:id", controllers.GetShipmentByID)
+	shipments.Put("/:id/delivered", controllers.MarkShipmentDelivered)
+	shipments.Get("/:id/document", controllers.GenerateShipmentDeliveryOrder)
+}
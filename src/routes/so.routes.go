@@ -7,11 +7,6 @@ import (
 )
 
 func SalesOrderRoutes(r fiber.Router) {
-	public := r.Group("/sales-order")
-	public.Get("/:id/document", controllers.GenerateDocumentDeliveryOrder)
-	public.Get("/:id/invoice", controllers.GenerateInvoice)
-	public.Get("/:id/receipt", controllers.GenerateReceipt)
-
 	protected := r.Group("/sales-order", middlewares.JWTProtected, middlewares.RBACMiddleware)
 	protected.Get("/", controllers.GetAllSalesOrdersPaginated)
 	protected.Post("/", controllers.CreateSalesOrder)
@@ -23,4 +18,9 @@ func SalesOrderRoutes(r fiber.Router) {
 	protected.Get("/:id", controllers.GetSalesOrderByID)
 	protected.Put("/:id", controllers.UpdateSalesOrder)
 	protected.Put("/:id/status", controllers.UpdateSalesOrderStatus)
+	protected.Get("/:id/document", controllers.GenerateDocumentDeliveryOrder)
+	protected.Get("/:id/invoice", controllers.GenerateInvoice)
+	protected.Get("/:id/receipt", controllers.GenerateReceipt)
+	protected.Get("/:id/shipments", controllers.GetSalesOrderShipments)
+	protected.Post("/:id/shipments", controllers.CreateSalesOrderShipment)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func StockOpnameRoutes(r fiber.Router) {
+	opname := r.Group("/stock-opname")
+	opname.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	opname.Get("/", controllers.GetAllStockOpnamesPaginated)
+	opname.Post("/", controllers.CreateStockOpname)
+
+	opname.Get("/:id", controllers.GetStockOpnameByID)
+	opname.Get("/:id/count-sheet", controllers.ExportStockOpnameCountSheet)
+	opname.Put("/:id/counts", controllers.UpdateStockOpnameCounts)
+	opname.Post("/:id/upload", controllers.UploadStockOpnameCounts)
+	opname.Put("/:id/status", controllers.UpdateStockOpnameStatus)
+}
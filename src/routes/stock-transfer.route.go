@@ -0,0 +1,18 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func StockTransferRoutes(r fiber.Router) {
+	transfers := r.Group("/stock-transfer")
+	transfers.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	transfers.Get("/", controllers.GetAllStockTransfersPaginated)
+	transfers.Post("/", controllers.CreateStockTransfer)
+
+	transfers.Get("/:id", controllers.GetStockTransferByID)
+	transfers.Put("/:id", controllers.UpdateStockTransfer)
+	transfers.Put("/:id/status", controllers.UpdateStockTransferStatus)
+}
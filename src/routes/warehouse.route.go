@@ -0,0 +1,22 @@
+package routes
+
+import (
+	controllers "github.com/SalmanDMA/inventory-app/backend/src/controllers"
+	"github.com/SalmanDMA/inventory-app/backend/src/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func WarehouseRoutes(r fiber.Router) {
+	warehouses := r.Group("/warehouse")
+	warehouses.Use(middlewares.JWTProtected, middlewares.RBACMiddleware)
+	warehouses.Get("/", controllers.GetAllWarehousesPaginated)
+	warehouses.Post("/", controllers.CreateWarehouse)
+
+	warehouses.Get("/all", controllers.GetAllWarehouses)
+	warehouses.Delete("/delete", controllers.DeleteWarehouses)
+	warehouses.Put("/restore", controllers.RestoreWarehouses)
+
+	warehouses.Get("/:id", controllers.GetWarehouseByID)
+	warehouses.Get("/:id/stocks", controllers.GetWarehouseStocks)
+	warehouses.Put("/:id", controllers.UpdateWarehouse)
+}
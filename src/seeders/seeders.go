@@ -127,10 +127,27 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Customers", Route: "/dashboard/customers", Icon: "mdi:map-marker", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Customer Management Page"},
 		{Name: "Customer Types", Route: "/dashboard/customer-types", Icon: "mdi:map-marker", ModuleTypeID: moduleTypeMap["Route Hidden"], ParentID: &masterDataModule.ID, Description: "Customer Type Management Page"},
 		{Name: "Sales", Route: "/dashboard/sales", Icon: "mdi:calendar-user-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Sales Management Page"},
+		{Name: "Warehouses", Route: "/dashboard/warehouses", Icon: "mdi:warehouse", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Warehouse Management Page"},
 		{Name: "Suppliers", Route: "/dashboard/suppliers", Icon: "mdi:account-group", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Supplier Management Page"},
+		{Name: "Number Sequences", Route: "/dashboard/number-sequences", Icon: "mdi:numeric", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Document Numbering Management Page"},
+		{Name: "Price Lists", Route: "/dashboard/price-lists", Icon: "mdi:tag-multiple-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Price List Management Page"},
+		{Name: "PO Approval Rules", Route: "/dashboard/po-approval-rules", Icon: "mdi:account-check", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &masterDataModule.ID, Description: "Purchase Order Approval Rule Management Page"},
 		{Name: "Purchase Orders", Route: "/dashboard/purchase-orders", Icon: "mdi:order-bool-descending", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Purchase Order Management Page"},
+		{Name: "Goods Receipts", Route: "/dashboard/goods-receipts", Icon: "mdi:truck-check", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Goods Receipt Management Page"},
+		{Name: "Purchase Returns", Route: "/dashboard/purchase-returns", Icon: "mdi:keyboard-return", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Purchase Return Management Page"},
+		{Name: "Stock Transfers", Route: "/dashboard/stock-transfers", Icon: "mdi:truck-delivery", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Stock Transfer Management Page"},
+		{Name: "Sales Quotations", Route: "/dashboard/sales-quotations", Icon: "mdi:file-document-edit-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Sales Quotation Management Page"},
 		{Name: "Sales Orders", Route: "/dashboard/sales-orders", Icon: "mdi:order-bool-ascending", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Sales Order Management Page"},
+		{Name: "Stock Opname", Route: "/dashboard/stock-opname", Icon: "mdi:clipboard-check-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Stock Opname Management Page"},
+		{Name: "Shipments", Route: "/dashboard/shipments", Icon: "mdi:truck-fast-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Sales Order Shipment Page"},
+		{Name: "Sales Returns", Route: "/dashboard/sales-returns", Icon: "mdi:keyboard-return", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Sales Return Management Page"},
+		{Name: "Payment Receipts", Route: "/dashboard/payment-receipts", Icon: "mdi:cash-multiple", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Payment Receipt Allocation and Deposit Page"},
+		{Name: "Bank Reconciliation", Route: "/dashboard/bank-reconciliation", Icon: "mdi:bank-check", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &transactionDataModule.ID, Description: "Bank Statement Import and Reconciliation Page"},
 		{Name: "Sales Reports", Route: "/dashboard/sales-reports", Icon: "mdi:chart-line", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Sales Report Management Page"},
+		{Name: "Accounts Receivable", Route: "/dashboard/accounts-receivable", Icon: "mdi:cash-clock", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Accounts Receivable Aging and Statement Page"},
+		{Name: "Accounts Payable", Route: "/dashboard/accounts-payable", Icon: "mdi:cash-fast", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Accounts Payable Aging and Payment Run Page"},
+		{Name: "Dunning Reminders", Route: "/dashboard/dunning-reminder", Icon: "mdi:email-alert", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Overdue Sales Order Reminder History Page"},
+		{Name: "Credit Overrides", Route: "/dashboard/credit-overrides", Icon: "mdi:credit-card-lock-outline", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Credit Hold Override Audit Page"},
 		{Name: "Notifications", Route: "/dashboard/notifications", Icon: "mdi:bell", ModuleTypeID: moduleTypeMap["Route Menu"], ParentID: &analyticsModule.ID, Description: "Notification Management Page"},
 	}
 
@@ -236,6 +253,9 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Delete Item", Path: fmt.Sprintf("%s/item/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete item permanently", ParentID: &itemsModule.ID},
 		{Name: "Get Item By ID", Path: fmt.Sprintf("%s/item/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get item by ID", ParentID: &itemsModule.ID},
 		{Name: "Update Item", Path: fmt.Sprintf("%s/item/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update item by ID", ParentID: &itemsModule.ID},
+		{Name: "Get Item Lots", Path: fmt.Sprintf("%s/item/:id/lots", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get stock lots of an item (FEFO order)", ParentID: &itemsModule.ID},
+		{Name: "Get Near Expiry Items", Path: fmt.Sprintf("%s/item/near-expiry", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get lots nearing expiry with value at cost", ParentID: &itemsModule.ID},
+		{Name: "Get Item Stocks", Path: fmt.Sprintf("%s/item/:id/stocks", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get stock balance of an item per warehouse", ParentID: &itemsModule.ID},
 	}
 
 	for _, sm := range itemServiceModules {
@@ -330,6 +350,7 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Create Customer", Path: fmt.Sprintf("%s/customer", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a new customer", ParentID: &customersModule.ID},
 		{Name: "Restore Customer", Path: fmt.Sprintf("%s/customer/restore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Restore soft-deleted customer", ParentID: &customersModule.ID},
 		{Name: "Delete Customer", Path: fmt.Sprintf("%s/customer/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete customer permanently", ParentID: &customersModule.ID},
+		{Name: "Get Customer Credit", Path: fmt.Sprintf("%s/customer/:id/credit", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get customer credit limit, outstanding and hold status", ParentID: &customersModule.ID},
 	}
 
 	for _, sm := range customersModules {
@@ -398,6 +419,47 @@ func SeedModules(db *gorm.DB) error {
 		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
 	}
 
+	// Get "Warehouses" module for service parent
+	var warehousesModule models.Module
+	if err := db.Where("name = ?", "Warehouses").First(&warehousesModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Warehouses module: %w", err)
+	}
+
+	// Service routes for warehouse management
+	warehousesServiceModules := []models.Module{
+		{Name: "Get All Paginated Warehouses", Path: fmt.Sprintf("%s/warehouse", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated warehouses", ParentID: &warehousesModule.ID},
+		{Name: "Get All Warehouses", Path: fmt.Sprintf("%s/warehouse/all", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get list of all warehouses", ParentID: &warehousesModule.ID},
+		{Name: "Create Warehouse", Path: fmt.Sprintf("%s/warehouse", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a new warehouse", ParentID: &warehousesModule.ID},
+		{Name: "Restore Warehouses", Path: fmt.Sprintf("%s/warehouse/restore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Restore soft-deleted warehouses", ParentID: &warehousesModule.ID},
+		{Name: "Delete Warehouses", Path: fmt.Sprintf("%s/warehouse/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete warehouses permanently", ParentID: &warehousesModule.ID},
+		{Name: "Get Warehouse By ID", Path: fmt.Sprintf("%s/warehouse/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get warehouse by ID", ParentID: &warehousesModule.ID},
+		{Name: "Get Warehouse Stocks", Path: fmt.Sprintf("%s/warehouse/:id/stocks", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get item stock balances in a warehouse", ParentID: &warehousesModule.ID},
+		{Name: "Update Warehouse", Path: fmt.Sprintf("%s/warehouse/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update warehouse by ID", ParentID: &warehousesModule.ID},
+	}
+
+	for _, sm := range warehousesServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Stock Transfers" module for service parent
+	var stockTransfersModule models.Module
+	if err := db.Where("name = ?", "Stock Transfers").First(&stockTransfersModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Stock Transfers module: %w", err)
+	}
+
+	// Service routes for stock transfer management
+	stockTransfersServiceModules := []models.Module{
+		{Name: "Get All Paginated Stock Transfers", Path: fmt.Sprintf("%s/stock-transfer", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated stock transfers", ParentID: &stockTransfersModule.ID},
+		{Name: "Create Stock Transfer", Path: fmt.Sprintf("%s/stock-transfer", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a new stock transfer", ParentID: &stockTransfersModule.ID},
+		{Name: "Get Stock Transfer By ID", Path: fmt.Sprintf("%s/stock-transfer/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get stock transfer by ID", ParentID: &stockTransfersModule.ID},
+		{Name: "Update Stock Transfer", Path: fmt.Sprintf("%s/stock-transfer/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update draft stock transfer", ParentID: &stockTransfersModule.ID},
+		{Name: "Update Stock Transfer Status", Path: fmt.Sprintf("%s/stock-transfer/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Ship, receive or cancel a stock transfer", ParentID: &stockTransfersModule.ID},
+	}
+
+	for _, sm := range stockTransfersServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
 	// Get "Purchase Orders" module for service parent
 	var purchaseOrdersModule models.Module
 	if err := db.Where("name = ?", "Purchase Orders").First(&purchaseOrdersModule).Error; err != nil {
@@ -413,8 +475,13 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Update Purchase Order", Path: fmt.Sprintf("%s/purchase-order/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update purchase order by ID", ParentID: &purchaseOrdersModule.ID},
 		{Name: "Update Purchase Order Status", Path: fmt.Sprintf("%s/purchase-order/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update purchase order status", ParentID: &purchaseOrdersModule.ID},
 		{Name: "Receive Purchase Order Items", Path: fmt.Sprintf("%s/purchase-order/:id/receive", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Receive items for a purchase order", ParentID: &purchaseOrdersModule.ID},
+		{Name: "Get Purchase Order Receipts", Path: fmt.Sprintf("%s/purchase-order/:id/receipts", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get goods receipt history of a purchase order", ParentID: &purchaseOrdersModule.ID},
+		{Name: "Get My Pending Purchase Order Approvals", Path: fmt.Sprintf("%s/purchase-order/approvals/pending", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get purchase orders waiting for approval by the current user's role", ParentID: &purchaseOrdersModule.ID},
+		{Name: "Get Purchase Order Approvals", Path: fmt.Sprintf("%s/purchase-order/:id/approvals", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get approval history of a purchase order", ParentID: &purchaseOrdersModule.ID},
+		{Name: "Decide Purchase Order Approval", Path: fmt.Sprintf("%s/purchase-order/:id/approval", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Approve or reject a purchase order", ParentID: &purchaseOrdersModule.ID},
 		{Name: "Delete Purchase Orders", Path: fmt.Sprintf("%s/purchase-order/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete purchase orders permanently", ParentID: &purchaseOrdersModule.ID},
 		{Name: "Restore Purchase Orders", Path: fmt.Sprintf("%s/purchase-order/restore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Restore soft-deleted purchase orders", ParentID: &purchaseOrdersModule.ID},
+		{Name: "Generate Purchase Order Document", Path: fmt.Sprintf("%s/purchase-order/:id/document", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download purchase order PDF", ParentID: &purchaseOrdersModule.ID},
 	}
 
 	for _, sm := range purchaseOrdersServiceModules {
@@ -437,12 +504,206 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Update Sales Order Status", Path: fmt.Sprintf("%s/sales-order/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update sales order status", ParentID: &salesOrdersModule.ID},
 		{Name: "Delete Sales Orders", Path: fmt.Sprintf("%s/sales-order/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete sales orders permanently", ParentID: &salesOrdersModule.ID},
 		{Name: "Restore Sales Orders", Path: fmt.Sprintf("%s/sales-order/restore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Restore soft-deleted sales orders", ParentID: &salesOrdersModule.ID},
+		{Name: "Generate Delivery Order Document", Path: fmt.Sprintf("%s/sales-order/:id/document", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download delivery order PDF", ParentID: &salesOrdersModule.ID},
+		{Name: "Generate Sales Order Invoice", Path: fmt.Sprintf("%s/sales-order/:id/invoice", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download invoice PDF", ParentID: &salesOrdersModule.ID},
+		{Name: "Generate Sales Order Receipt", Path: fmt.Sprintf("%s/sales-order/:id/receipt", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download receipt PDF", ParentID: &salesOrdersModule.ID},
+		{Name: "Get Sales Order Shipments", Path: fmt.Sprintf("%s/sales-order/:id/shipments", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get shipment history of a sales order", ParentID: &salesOrdersModule.ID},
+		{Name: "Create Sales Order Shipment", Path: fmt.Sprintf("%s/sales-order/:id/shipments", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Ship part or all of a sales order", ParentID: &salesOrdersModule.ID},
+		{Name: "Create Document Link", Path: fmt.Sprintf("%s/document-link", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create signed, expiring public document link", ParentID: &salesOrdersModule.ID},
+		{Name: "Revoke Document Link", Path: fmt.Sprintf("%s/document-link/:id/revoke", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Revoke public document link", ParentID: &salesOrdersModule.ID},
 	}
 
 	for _, sm := range salesOrdersServiceModules {
 		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
 	}
 
+	// Get "Sales Returns" module for service parent
+	var salesReturnsModule models.Module
+	if err := db.Where("name = ?", "Sales Returns").First(&salesReturnsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Sales Returns module: %w", err)
+	}
+
+	// Service routes for sales return management
+	salesReturnsServiceModules := []models.Module{
+		{Name: "Get All Paginated Sales Returns", Path: fmt.Sprintf("%s/sales-return", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated sales returns", ParentID: &salesReturnsModule.ID},
+		{Name: "Create Sales Return", Path: fmt.Sprintf("%s/sales-return", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a draft sales return for a delivered sales order", ParentID: &salesReturnsModule.ID},
+		{Name: "Get Sales Return By ID", Path: fmt.Sprintf("%s/sales-return/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get sales return by ID", ParentID: &salesReturnsModule.ID},
+		{Name: "Update Sales Return Status", Path: fmt.Sprintf("%s/sales-return/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Complete or cancel a sales return", ParentID: &salesReturnsModule.ID},
+		{Name: "Generate Credit Note Document", Path: fmt.Sprintf("%s/sales-return/:id/credit-note", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download credit note PDF", ParentID: &salesReturnsModule.ID},
+	}
+
+	for _, sm := range salesReturnsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Shipments" module for service parent
+	var shipmentsModule models.Module
+	if err := db.Where("name = ?", "Shipments").First(&shipmentsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Shipments module: %w", err)
+	}
+
+	// Service routes for shipment management
+	shipmentsServiceModules := []models.Module{
+		{Name: "Get All Paginated Shipments", Path: fmt.Sprintf("%s/shipment", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated shipments", ParentID: &shipmentsModule.ID},
+		{Name: "Get Shipment By ID", Path: fmt.Sprintf("%s/shipment/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get shipment by ID", ParentID: &shipmentsModule.ID},
+		{Name: "Mark Shipment Delivered", Path: fmt.Sprintf("%s/shipment/:id/delivered", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Confirm a shipment was received by the customer", ParentID: &shipmentsModule.ID},
+		{Name: "Generate Shipment Delivery Order", Path: fmt.Sprintf("%s/shipment/:id/document", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download delivery order PDF of a shipment", ParentID: &shipmentsModule.ID},
+	}
+
+	for _, sm := range shipmentsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Price Lists" module for service parent
+	var priceListsModule models.Module
+	if err := db.Where("name = ?", "Price Lists").First(&priceListsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Price Lists module: %w", err)
+	}
+
+	// Service routes for price list management
+	priceListsServiceModules := []models.Module{
+		{Name: "Get All Paginated Price Lists", Path: fmt.Sprintf("%s/price-list", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated price lists", ParentID: &priceListsModule.ID},
+		{Name: "Create Price List", Path: fmt.Sprintf("%s/price-list", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a price list for a customer type or customer", ParentID: &priceListsModule.ID},
+		{Name: "Resolve Price", Path: fmt.Sprintf("%s/price-list/resolve", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Preview the selling price for a customer, item and quantity", ParentID: &priceListsModule.ID},
+		{Name: "Delete Price List", Path: fmt.Sprintf("%s/price-list/delete", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete price lists", ParentID: &priceListsModule.ID},
+		{Name: "Restore Price List", Path: fmt.Sprintf("%s/price-list/restore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Restore soft-deleted price lists", ParentID: &priceListsModule.ID},
+		{Name: "Get Price List By ID", Path: fmt.Sprintf("%s/price-list/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get price list by ID", ParentID: &priceListsModule.ID},
+		{Name: "Update Price List", Path: fmt.Sprintf("%s/price-list/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update a price list and its item prices", ParentID: &priceListsModule.ID},
+	}
+
+	for _, sm := range priceListsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Credit Overrides" module for service parent
+	var creditOverridesModule models.Module
+	if err := db.Where("name = ?", "Credit Overrides").First(&creditOverridesModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Credit Overrides module: %w", err)
+	}
+
+	// Service routes for credit override audit
+	creditOverridesServiceModules := []models.Module{
+		{Name: "Get All Paginated Credit Overrides", Path: fmt.Sprintf("%s/credit-override", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get audit trail of credit hold overrides", ParentID: &creditOverridesModule.ID},
+	}
+
+	for _, sm := range creditOverridesServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Sales Quotations" module for service parent
+	var salesQuotationsModule models.Module
+	if err := db.Where("name = ?", "Sales Quotations").First(&salesQuotationsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Sales Quotations module: %w", err)
+	}
+
+	// Service routes for sales quotation management
+	salesQuotationsServiceModules := []models.Module{
+		{Name: "Get All Paginated Sales Quotations", Path: fmt.Sprintf("%s/sales-quotation", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated sales quotations", ParentID: &salesQuotationsModule.ID},
+		{Name: "Create Sales Quotation", Path: fmt.Sprintf("%s/sales-quotation", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a draft sales quotation", ParentID: &salesQuotationsModule.ID},
+		{Name: "Get Sales Quotation By ID", Path: fmt.Sprintf("%s/sales-quotation/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get sales quotation by ID", ParentID: &salesQuotationsModule.ID},
+		{Name: "Update Sales Quotation", Path: fmt.Sprintf("%s/sales-quotation/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update a draft sales quotation", ParentID: &salesQuotationsModule.ID},
+		{Name: "Get Sales Quotation Revisions", Path: fmt.Sprintf("%s/sales-quotation/:id/revisions", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all revisions of a sales quotation", ParentID: &salesQuotationsModule.ID},
+		{Name: "Revise Sales Quotation", Path: fmt.Sprintf("%s/sales-quotation/:id/revise", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a new revision of a sales quotation", ParentID: &salesQuotationsModule.ID},
+		{Name: "Update Sales Quotation Status", Path: fmt.Sprintf("%s/sales-quotation/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Send, accept or reject a sales quotation", ParentID: &salesQuotationsModule.ID},
+		{Name: "Convert Sales Quotation", Path: fmt.Sprintf("%s/sales-quotation/:id/convert", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Convert an accepted quotation into a sales order", ParentID: &salesQuotationsModule.ID},
+		{Name: "Generate Quotation Document", Path: fmt.Sprintf("%s/sales-quotation/:id/document", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download quotation PDF", ParentID: &salesQuotationsModule.ID},
+	}
+
+	for _, sm := range salesQuotationsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Goods Receipts" module for service parent
+	var goodsReceiptsModule models.Module
+	if err := db.Where("name = ?", "Goods Receipts").First(&goodsReceiptsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Goods Receipts module: %w", err)
+	}
+
+	// Service routes for goods receipt management
+	goodsReceiptsServiceModules := []models.Module{
+		{Name: "Get All Paginated Goods Receipts", Path: fmt.Sprintf("%s/goods-receipt", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated goods receipts", ParentID: &goodsReceiptsModule.ID},
+		{Name: "Get Goods Receipt By ID", Path: fmt.Sprintf("%s/goods-receipt/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get goods receipt by ID", ParentID: &goodsReceiptsModule.ID},
+		{Name: "Generate Goods Receipt Document", Path: fmt.Sprintf("%s/goods-receipt/:id/document", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download goods receipt note PDF", ParentID: &goodsReceiptsModule.ID},
+	}
+
+	for _, sm := range goodsReceiptsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Number Sequences" module for service parent
+	var numberSequencesModule models.Module
+	if err := db.Where("name = ?", "Number Sequences").First(&numberSequencesModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Number Sequences module: %w", err)
+	}
+
+	// Service routes for document numbering management
+	numberSequencesServiceModules := []models.Module{
+		{Name: "Get All Number Sequences", Path: fmt.Sprintf("%s/number-sequence", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all document number sequences", ParentID: &numberSequencesModule.ID},
+		{Name: "Update Number Sequence", Path: fmt.Sprintf("%s/number-sequence/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update document number sequence format", ParentID: &numberSequencesModule.ID},
+	}
+
+	for _, sm := range numberSequencesServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "PO Approval Rules" module for service parent
+	var poApprovalRulesModule models.Module
+	if err := db.Where("name = ?", "PO Approval Rules").First(&poApprovalRulesModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch PO Approval Rules module: %w", err)
+	}
+
+	// Service routes for purchase order approval rule management
+	poApprovalRulesServiceModules := []models.Module{
+		{Name: "Get All PO Approval Rules", Path: fmt.Sprintf("%s/po-approval-rule", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all purchase order approval rules", ParentID: &poApprovalRulesModule.ID},
+		{Name: "Create PO Approval Rule", Path: fmt.Sprintf("%s/po-approval-rule", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a purchase order approval rule", ParentID: &poApprovalRulesModule.ID},
+		{Name: "Update PO Approval Rule", Path: fmt.Sprintf("%s/po-approval-rule/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Update a purchase order approval rule", ParentID: &poApprovalRulesModule.ID},
+		{Name: "Delete PO Approval Rule", Path: fmt.Sprintf("%s/po-approval-rule/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Delete a purchase order approval rule", ParentID: &poApprovalRulesModule.ID},
+	}
+
+	for _, sm := range poApprovalRulesServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Purchase Returns" module for service parent
+	var purchaseReturnsModule models.Module
+	if err := db.Where("name = ?", "Purchase Returns").First(&purchaseReturnsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Purchase Returns module: %w", err)
+	}
+
+	// Service routes for purchase return management
+	purchaseReturnsServiceModules := []models.Module{
+		{Name: "Get All Paginated Purchase Returns", Path: fmt.Sprintf("%s/purchase-return", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated purchase returns", ParentID: &purchaseReturnsModule.ID},
+		{Name: "Create Purchase Return", Path: fmt.Sprintf("%s/purchase-return", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a draft return to supplier for received purchase order lines", ParentID: &purchaseReturnsModule.ID},
+		{Name: "Get Purchase Return By ID", Path: fmt.Sprintf("%s/purchase-return/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get purchase return by ID", ParentID: &purchaseReturnsModule.ID},
+		{Name: "Update Purchase Return Status", Path: fmt.Sprintf("%s/purchase-return/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Complete or cancel a purchase return", ParentID: &purchaseReturnsModule.ID},
+		{Name: "Generate Debit Note Document", Path: fmt.Sprintf("%s/purchase-return/:id/debit-note", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download debit note PDF", ParentID: &purchaseReturnsModule.ID},
+	}
+
+	for _, sm := range purchaseReturnsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Stock Opname" module for service parent
+	var stockOpnameModule models.Module
+	if err := db.Where("name = ?", "Stock Opname").First(&stockOpnameModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Stock Opname module: %w", err)
+	}
+
+	// Service routes for stock opname management
+	stockOpnameServiceModules := []models.Module{
+		{Name: "Get All Paginated Stock Opnames", Path: fmt.Sprintf("%s/stock-opname", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated stock opname sessions", ParentID: &stockOpnameModule.ID},
+		{Name: "Create Stock Opname", Path: fmt.Sprintf("%s/stock-opname", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Open a stock opname session and snapshot system quantities", ParentID: &stockOpnameModule.ID},
+		{Name: "Get Stock Opname By ID", Path: fmt.Sprintf("%s/stock-opname/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get stock opname by ID", ParentID: &stockOpnameModule.ID},
+		{Name: "Download Stock Opname Count Sheet", Path: fmt.Sprintf("%s/stock-opname/:id/count-sheet", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Download stock opname count sheet (Excel)", ParentID: &stockOpnameModule.ID},
+		{Name: "Update Stock Opname Counts", Path: fmt.Sprintf("%s/stock-opname/:id/counts", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Enter counted quantities", ParentID: &stockOpnameModule.ID},
+		{Name: "Upload Stock Opname Counts", Path: fmt.Sprintf("%s/stock-opname/:id/upload", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Upload a filled-in count sheet", ParentID: &stockOpnameModule.ID},
+		{Name: "Update Stock Opname Status", Path: fmt.Sprintf("%s/stock-opname/:id/status", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Submit, approve, reject or cancel a stock opname", ParentID: &stockOpnameModule.ID},
+	}
+
+	for _, sm := range stockOpnameServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
 	// Get "Notifications" module for notification parent
 	var notificationsModule models.Module
 	if err := db.Where("name = ?", "Notifications").First(&notificationsModule).Error; err != nil {
@@ -475,12 +736,124 @@ func SeedModules(db *gorm.DB) error {
 		{Name: "Get All Sales Reports", Path: fmt.Sprintf("%s/sales-report", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all sales reports", ParentID: &salesReportsModule.ID},
 		{Name: "Get Summary Sales Report", Path: fmt.Sprintf("%s/sales-report/summary", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get summary sales report", ParentID: &salesReportsModule.ID},
 		{Name: "Get Chart Sales Report", Path: fmt.Sprintf("%s/sales-report/charts", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get chart sales report", ParentID: &salesReportsModule.ID},
+		{Name: "Export Sales Report Excel", Path: fmt.Sprintf("%s/sales-report/excel", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Export sales report to Excel", ParentID: &salesReportsModule.ID},
+		{Name: "Export Sales Report PDF", Path: fmt.Sprintf("%s/sales-report/:id/pdf", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Export sales report PDF", ParentID: &salesReportsModule.ID},
 	}
 
 	for _, sm := range salesReportsServiceModules {
 		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
 	}
 
+	// Get "Accounts Receivable" module for service parent
+	var accountsReceivableModule models.Module
+	if err := db.Where("name = ?", "Accounts Receivable").First(&accountsReceivableModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Accounts Receivable module: %w", err)
+	}
+
+	// Service routes for accounts receivable reporting
+	accountsReceivableServiceModules := []models.Module{
+		{Name: "Get AR Aging", Path: fmt.Sprintf("%s/accounts-receivable/aging", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get accounts receivable aging per customer", ParentID: &accountsReceivableModule.ID},
+		{Name: "Export AR Aging Excel", Path: fmt.Sprintf("%s/accounts-receivable/aging/excel", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Export accounts receivable aging to Excel", ParentID: &accountsReceivableModule.ID},
+		{Name: "Get Customer Statement", Path: fmt.Sprintf("%s/accounts-receivable/customer/:id/statement", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get customer statement of account", ParentID: &accountsReceivableModule.ID},
+		{Name: "Export Customer Statement PDF", Path: fmt.Sprintf("%s/accounts-receivable/customer/:id/statement/pdf", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Export customer statement of account PDF", ParentID: &accountsReceivableModule.ID},
+	}
+
+	for _, sm := range accountsReceivableServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Accounts Payable" module for service parent
+	var accountsPayableModule models.Module
+	if err := db.Where("name = ?", "Accounts Payable").First(&accountsPayableModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Accounts Payable module: %w", err)
+	}
+
+	// Service routes for accounts payable reporting and payment runs
+	accountsPayableServiceModules := []models.Module{
+		{Name: "Get AP Aging", Path: fmt.Sprintf("%s/accounts-payable/aging", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get accounts payable aging per supplier", ParentID: &accountsPayableModule.ID},
+		{Name: "Get AP Cash-Out Forecast", Path: fmt.Sprintf("%s/accounts-payable/forecast", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get purchase order payables due in the coming days", ParentID: &accountsPayableModule.ID},
+		{Name: "Get All Paginated Payment Runs", Path: fmt.Sprintf("%s/accounts-payable/payment-run", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated payment runs", ParentID: &accountsPayableModule.ID},
+		{Name: "Create Payment Run", Path: fmt.Sprintf("%s/accounts-payable/payment-run", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create a batched purchase order payment run", ParentID: &accountsPayableModule.ID},
+		{Name: "Propose Payment Run", Path: fmt.Sprintf("%s/accounts-payable/payment-run/proposal", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Propose purchase orders due for payment", ParentID: &accountsPayableModule.ID},
+		{Name: "Get Payment Run By ID", Path: fmt.Sprintf("%s/accounts-payable/payment-run/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get payment run by ID", ParentID: &accountsPayableModule.ID},
+		{Name: "Execute Payment Run", Path: fmt.Sprintf("%s/accounts-payable/payment-run/:id/execute", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create payments for a proposed payment run", ParentID: &accountsPayableModule.ID},
+		{Name: "Cancel Payment Run", Path: fmt.Sprintf("%s/accounts-payable/payment-run/:id/cancel", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Cancel a proposed payment run", ParentID: &accountsPayableModule.ID},
+	}
+
+	for _, sm := range accountsPayableServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Dunning Reminders" module for service parent
+	var dunningReminderModule models.Module
+	if err := db.Where("name = ?", "Dunning Reminders").First(&dunningReminderModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Dunning Reminders module: %w", err)
+	}
+
+	// Service routes for overdue sales order reminders
+	dunningReminderServiceModules := []models.Module{
+		{Name: "Get All Paginated Dunning Reminders", Path: fmt.Sprintf("%s/dunning-reminder", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated dunning reminders", ParentID: &dunningReminderModule.ID},
+		{Name: "Run Dunning Reminders", Path: fmt.Sprintf("%s/dunning-reminder/run", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Send reminders for overdue sales orders now", ParentID: &dunningReminderModule.ID},
+	}
+
+	for _, sm := range dunningReminderServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Get "Payment Receipts" module for service parent
+	var paymentReceiptsModule models.Module
+	if err := db.Where("name = ?", "Payment Receipts").First(&paymentReceiptsModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Payment Receipts module: %w", err)
+	}
+
+	// Service routes for multi-order payment allocation and deposits
+	paymentReceiptsServiceModules := []models.Module{
+		{Name: "Get All Paginated Payment Receipts", Path: fmt.Sprintf("%s/payment-receipt", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated payment receipts", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Create Payment Receipt", Path: fmt.Sprintf("%s/payment-receipt", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Record a transfer and allocate it across orders", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Get Payment Deposits", Path: fmt.Sprintf("%s/payment-receipt/deposits", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get unallocated deposit balances per customer or supplier", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Get Payment Receipt By ID", Path: fmt.Sprintf("%s/payment-receipt/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get payment receipt by ID", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Allocate Payment Receipt", Path: fmt.Sprintf("%s/payment-receipt/:id/allocate", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Apply deposit balance of a receipt to orders", ParentID: &paymentReceiptsModule.ID},
+	}
+
+	for _, sm := range paymentReceiptsServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
+	// Service routes for the payment ledger (single-order payments and voids). Route is set as well
+	// because RBACMiddleware matches requests against Route, not Path.
+	paymentServiceModules := []models.Module{
+		{Name: "Create Payment", Path: fmt.Sprintf("%s/payment", appVersion), Route: fmt.Sprintf("%s/payment", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Record a payment for one order", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Get Payments By Purchase Order", Path: fmt.Sprintf("%s/payment/purchase-order/:po_id", appVersion), Route: fmt.Sprintf("%s/payment/purchase-order/:po_id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get payments of a purchase order", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Get Payments By Sales Order", Path: fmt.Sprintf("%s/payment/sales-order/:so_id", appVersion), Route: fmt.Sprintf("%s/payment/sales-order/:so_id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get payments of a sales order", ParentID: &paymentReceiptsModule.ID},
+		{Name: "Void Payment", Path: fmt.Sprintf("%s/payment/:id/void", appVersion), Route: fmt.Sprintf("%s/payment/:id/void", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Void a payment with a reversal entry", ParentID: &paymentReceiptsModule.ID},
+	}
+
+	for _, sm := range paymentServiceModules {
+		db.Where("name = ?", sm.Name).Assign(models.Module{Route: sm.Route}).FirstOrCreate(&sm)
+	}
+
+	// Get "Bank Reconciliation" module for service parent
+	var bankReconciliationModule models.Module
+	if err := db.Where("name = ?", "Bank Reconciliation").First(&bankReconciliationModule).Error; err != nil {
+		return fmt.Errorf("failed to fetch Bank Reconciliation module: %w", err)
+	}
+
+	// Service routes for bank statement import and reconciliation
+	bankReconciliationServiceModules := []models.Module{
+		{Name: "Get All Paginated Bank Statements", Path: fmt.Sprintf("%s/bank-statement", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get all paginated imported bank statements", ParentID: &bankReconciliationModule.ID},
+		{Name: "Import Bank Statement", Path: fmt.Sprintf("%s/bank-statement/import", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Import bank statement file and match lines to open orders", ParentID: &bankReconciliationModule.ID},
+		{Name: "Get Bank Statement Parsers", Path: fmt.Sprintf("%s/bank-statement/parsers", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get supported bank statement layouts", ParentID: &bankReconciliationModule.ID},
+		{Name: "Get Bank Reconciliation Queue", Path: fmt.Sprintf("%s/bank-statement/queue", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get bank statement lines waiting for reconciliation", ParentID: &bankReconciliationModule.ID},
+		{Name: "Confirm Bank Statement Line", Path: fmt.Sprintf("%s/bank-statement/line/:id/confirm", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Create payment from a bank statement line", ParentID: &bankReconciliationModule.ID},
+		{Name: "Ignore Bank Statement Line", Path: fmt.Sprintf("%s/bank-statement/line/:id/ignore", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Remove a non-order line from the reconciliation queue", ParentID: &bankReconciliationModule.ID},
+		{Name: "Get Bank Statement By ID", Path: fmt.Sprintf("%s/bank-statement/:id", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Get bank statement by ID", ParentID: &bankReconciliationModule.ID},
+		{Name: "Rematch Bank Statement", Path: fmt.Sprintf("%s/bank-statement/:id/rematch", appVersion), ModuleTypeID: moduleTypeMap["Service API"], Description: "Recompute order suggestions for open statement lines", ParentID: &bankReconciliationModule.ID},
+	}
+
+	for _, sm := range bankReconciliationServiceModules {
+		db.Where("name = ?", sm.Name).FirstOrCreate(&sm)
+	}
+
 	log.Println("Modules seeded successfully!")
 	return nil
 }
@@ -814,6 +1187,122 @@ func SeedItems(db *gorm.DB) error {
 	})
 }
 
+// 
+// ITEM LOT SEEDER
+// 
+
+// SeedWarehouses membuat gudang default dan menempelkan lot lama (tanpa gudang) ke gudang tsb.
+func SeedWarehouses(db *gorm.DB) error {
+	log.Println("Seeding default warehouse...")
+
+	warehouse := models.Warehouse{
+		ID:        uuid.New(),
+		Name:      "Gudang Utama",
+		Code:      "main",
+		IsDefault: true,
+	}
+	if err := db.Create(&warehouse).Error; err != nil {
+		return fmt.Errorf("failed to create default warehouse: %w", err)
+	}
+
+	if err := db.Model(&models.ItemLot{}).
+		Where("warehouse_id IS NULL").
+		Update("warehouse_id", warehouse.ID).Error; err != nil {
+		return fmt.Errorf("failed to assign lots to default warehouse: %w", err)
+	}
+
+	log.Printf("SeedWarehouses: default warehouse %s created.\n", warehouse.Code)
+	return nil
+}
+
+// SeedItemLots memindahkan stok item yang belum punya lot ke satu lot pembuka
+// (nomor lot = batch, expiry = expired_at item) supaya stock = total lot.
+func SeedItemLots(db *gorm.DB) error {
+	log.Println("Seeding opening item lots...")
+
+	var warehouse models.Warehouse
+	if err := db.Where("is_default = ?", true).First(&warehouse).Error; err != nil {
+		return fmt.Errorf("failed to get default warehouse: %w", err)
+	}
+
+	var items []models.Item
+	if err := db.Where("stock > 0").Find(&items).Error; err != nil {
+		return fmt.Errorf("failed to get items: %w", err)
+	}
+
+	lots := make([]models.ItemLot, 0, len(items))
+	for _, it := range items {
+		var expiredAt *time.Time
+		if !it.ExpiredAt.IsZero() {
+			exp := it.ExpiredAt
+			expiredAt = &exp
+		}
+		lots = append(lots, models.ItemLot{
+			ID:         uuid.New(),
+			ItemID:      it.ID,
+			WarehouseID: warehouse.ID,
+			LotNumber:   fmt.Sprintf("%d", it.Batch),
+			ExpiredAt:   expiredAt,
+			Quantity:    it.Stock,
+			ReceivedAt:  it.CreatedAt,
+			Notes:       "Opening balance",
+		})
+	}
+
+	if len(lots) == 0 {
+		log.Println("SeedItemLots: nothing to insert.")
+		return nil
+	}
+	if err := db.CreateInBatches(&lots, 100).Error; err != nil {
+		return fmt.Errorf("failed to create item lots: %w", err)
+	}
+
+	log.Printf("SeedItemLots: inserted %d opening lots.\n", len(lots))
+	return nil
+}
+
+// SeedItemStocks membangun saldo per gudang dari total qty lot.
+func SeedItemStocks(db *gorm.DB) error {
+	log.Println("Seeding item stocks per warehouse...")
+
+	var lots []models.ItemLot
+	if err := db.Where("warehouse_id IS NOT NULL").Find(&lots).Error; err != nil {
+		return fmt.Errorf("failed to get item lots: %w", err)
+	}
+
+	type key struct{ itemID, warehouseID uuid.UUID }
+	totals := make(map[key]int)
+	order := make([]key, 0)
+	for _, l := range lots {
+		k := key{l.ItemID, l.WarehouseID}
+		if _, ok := totals[k]; !ok {
+			order = append(order, k)
+		}
+		totals[k] += l.Quantity
+	}
+
+	stocks := make([]models.ItemStock, 0, len(order))
+	for _, k := range order {
+		stocks = append(stocks, models.ItemStock{
+			ID:          uuid.New(),
+			ItemID:      k.itemID,
+			WarehouseID: k.warehouseID,
+			Stock:       totals[k],
+		})
+	}
+
+	if len(stocks) == 0 {
+		log.Println("SeedItemStocks: nothing to insert.")
+		return nil
+	}
+	if err := db.CreateInBatches(&stocks, 100).Error; err != nil {
+		return fmt.Errorf("failed to create item stocks: %w", err)
+	}
+
+	log.Printf("SeedItemStocks: inserted %d warehouse stock rows.\n", len(stocks))
+	return nil
+}
+
 // ======================================================================
 // SEED AREAS
 // ======================================================================
@@ -1268,6 +1757,7 @@ func SeedSalesOrders(db *gorm.DB) error {
 							Quantity:   qty,
 							UnitPrice:  unitPrice,
 							TotalPrice: lineTotal,
+							NetAmount:  lineTotal,
 							CreatedAt:  createdAt,
 							UpdatedAt:  createdAt,
 						})
@@ -1315,6 +1805,8 @@ func SeedSalesOrders(db *gorm.DB) error {
 						TermOfPayment:    term,
 						SOStatus:         soStatus,
 						PaymentStatus:    payStatus,
+						Subtotal:         totalAmount,
+						GrandTotal:       totalAmount,
 						TotalAmount:      totalAmount,
 						PaidAmount:       paidAmount,
 						DPAmount:         dpAmount,
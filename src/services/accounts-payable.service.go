@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+)
+
+type AccountsPayableService struct {
+	AccountsPayableRepository repositories.AccountsPayableRepository
+	PaymentRunRepository      repositories.PaymentRunRepository
+}
+
+func NewAccountsPayableService(
+	apRepo repositories.AccountsPayableRepository,
+	paymentRunRepo repositories.PaymentRunRepository,
+) *AccountsPayableService {
+	return &AccountsPayableService{
+		AccountsPayableRepository: apRepo,
+		PaymentRunRepository:      paymentRunRepo,
+	}
+}
+
+// GetAPAging mengelompokkan sisa hutang PO per supplier ke bucket umur 0-30/31-60/61-90/90+
+// berdasarkan DueDate (PODate bila tanpa jatuh tempo) terhadap tanggal as_of. Sisa hutang dihitung
+// dari pembayaran s/d as_of.
+func (s *AccountsPayableService) GetAPAging(req *models.APAgingRequest) (*models.ResponseAPAging, error) {
+	asOf, err := parseReportDate(req.AsOf, time.Now())
+	if err != nil {
+		return nil, errors.New("invalid as_of date, expected YYYY-MM-DD")
+	}
+	asOf = endOfDay(asOf)
+
+	pos, err := s.AccountsPayableRepository.FindOpenPayables(nil, req.SupplierID, req.Search, &asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ResponseAPAging{
+		AsOf:      asOf,
+		Suppliers: []models.APAgingSupplier{},
+	}
+	indexBySupplier := make(map[uuid.UUID]int)
+
+	for _, po := range pos {
+		payable := mapPayable(po, asOf)
+		if payable.Outstanding <= 0 {
+			continue
+		}
+
+		idx, ok := indexBySupplier[po.SupplierID]
+		if !ok {
+			result.Suppliers = append(result.Suppliers, models.APAgingSupplier{
+				SupplierID:   po.SupplierID,
+				SupplierName: po.Supplier.Name,
+				SupplierCode: po.Supplier.Code,
+				Payables:     []models.APAgingPayable{},
+			})
+			idx = len(result.Suppliers) - 1
+			indexBySupplier[po.SupplierID] = idx
+		}
+
+		supplier := &result.Suppliers[idx]
+		supplier.Payables = append(supplier.Payables, payable)
+		supplier.Buckets.Add(payable.Bucket, payable.Outstanding)
+		result.Totals.Add(payable.Bucket, payable.Outstanding)
+	}
+
+	return result, nil
+}
+
+// GetCashOutForecast menyusun rencana kas keluar per tanggal jatuh tempo untuk `days` hari ke depan
+// (default 7 = minggu ini), ditambah hutang yang sudah lewat jatuh tempo sebelum start_date.
+func (s *AccountsPayableService) GetCashOutForecast(req *models.APForecastRequest) (*models.ResponseAPForecast, error) {
+	start, err := parseReportDate(req.StartDate, time.Now())
+	if err != nil {
+		return nil, errors.New("invalid start_date, expected YYYY-MM-DD")
+	}
+	start = startOfDay(start)
+
+	days := req.Days
+	if days <= 0 {
+		days = 7
+	}
+	end := endOfDay(start.AddDate(0, 0, days-1))
+
+	pos, err := s.AccountsPayableRepository.FindPayablesDueBefore(nil, end, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &models.ResponseAPForecast{
+		StartDate: start,
+		EndDate:   end,
+		Overdue:   []models.APAgingPayable{},
+		Days:      make([]models.APForecastDay, days),
+	}
+	for i := range forecast.Days {
+		forecast.Days[i] = models.APForecastDay{
+			Date:     start.AddDate(0, 0, i),
+			Payables: []models.APAgingPayable{},
+		}
+	}
+
+	for _, po := range pos {
+		payable := mapPayable(po, start)
+		if payable.Outstanding <= 0 || po.DueDate == nil {
+			continue
+		}
+
+		due := po.DueDate.In(jakartaLoc())
+		if due.Before(start) {
+			forecast.Overdue = append(forecast.Overdue, payable)
+			forecast.OverdueAmount += payable.Outstanding
+			continue
+		}
+
+		idx := int(startOfDay(due).Sub(start).Hours() / 24)
+		if idx < 0 || idx >= days {
+			continue
+		}
+		forecast.Days[idx].Payables = append(forecast.Days[idx].Payables, payable)
+		forecast.Days[idx].Amount += payable.Outstanding
+		forecast.TotalDue += payable.Outstanding
+	}
+	forecast.TotalCashOut = forecast.OverdueAmount + forecast.TotalDue
+
+	return forecast, nil
+}
+
+// ProposePaymentRun mengusulkan PO yang jatuh tempo s/d due_until untuk dibayar bersama,
+// dikelompokkan per supplier. PO yang sudah ada di payment run Proposed lain dilewati.
+func (s *AccountsPayableService) ProposePaymentRun(req *models.PaymentRunProposalRequest) (*models.ResponsePaymentRunProposal, error) {
+	now := time.Now().In(jakartaLoc())
+	dueUntil, err := parseReportDate(req.DueUntil, now.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, errors.New("invalid due_until, expected YYYY-MM-DD")
+	}
+	dueUntil = endOfDay(dueUntil)
+
+	pos, err := s.AccountsPayableRepository.FindPayablesDueBefore(nil, dueUntil, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	poIDs := make([]uuid.UUID, 0, len(pos))
+	for _, po := range pos {
+		poIDs = append(poIDs, po.ID)
+	}
+	scheduled, err := s.PaymentRunRepository.FindOpenItemsByPurchaseOrders(nil, poIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	inRun := make(map[uuid.UUID]bool, len(scheduled))
+	for _, it := range scheduled {
+		inRun[it.PurchaseOrderID] = true
+	}
+
+	proposal := &models.ResponsePaymentRunProposal{
+		DueUntil:  dueUntil,
+		Suppliers: []models.PaymentRunProposalSupplier{},
+	}
+	indexBySupplier := make(map[uuid.UUID]int)
+
+	for _, po := range pos {
+		if inRun[po.ID] {
+			continue
+		}
+		payable := mapPayable(po, endOfDay(now))
+		if payable.Outstanding <= 0 {
+			continue
+		}
+
+		idx, ok := indexBySupplier[po.SupplierID]
+		if !ok {
+			proposal.Suppliers = append(proposal.Suppliers, models.PaymentRunProposalSupplier{
+				SupplierID:   po.SupplierID,
+				SupplierName: po.Supplier.Name,
+				Payables:     []models.APAgingPayable{},
+			})
+			idx = len(proposal.Suppliers) - 1
+			indexBySupplier[po.SupplierID] = idx
+		}
+
+		supplier := &proposal.Suppliers[idx]
+		supplier.Payables = append(supplier.Payables, payable)
+		supplier.Amount += payable.Outstanding
+		proposal.TotalAmount += payable.Outstanding
+	}
+
+	return proposal, nil
+}
+
+func mapPayable(po models.PurchaseOrder, asOf time.Time) models.APAgingPayable {
+	ref := po.PODate
+	if po.DueDate != nil {
+		ref = *po.DueDate
+	}
+	days := int(asOf.Sub(ref).Hours() / 24)
+
+	return models.APAgingPayable{
+		PurchaseOrderID: po.ID,
+		PONumber:        po.PONumber,
+		PODate:          po.PODate,
+		TermOfPayment:   po.TermOfPayment,
+		DueDate:         po.DueDate,
+		TotalAmount:     po.TotalAmount,
+		PaidAmount:      po.PaidAmount,
+		Outstanding:     po.TotalAmount - po.PaidAmount,
+		DaysOverdue:     days,
+		Bucket:          agingBucket(days),
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(jakartaLoc())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
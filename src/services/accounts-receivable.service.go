@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+type AccountsReceivableService struct {
+	AccountsReceivableRepository repositories.AccountsReceivableRepository
+	CustomerRepository           repositories.CustomerRepository
+}
+
+func NewAccountsReceivableService(
+	arRepo repositories.AccountsReceivableRepository,
+	customerRepo repositories.CustomerRepository,
+) *AccountsReceivableService {
+	return &AccountsReceivableService{
+		AccountsReceivableRepository: arRepo,
+		CustomerRepository:           customerRepo,
+	}
+}
+
+// GetARAging mengelompokkan sisa tagihan SO per customer ke bucket umur 0-30/31-60/61-90/90+
+// berdasarkan DueDate (SODate bila tanpa jatuh tempo) terhadap tanggal as_of. Sisa tagihan dihitung
+// dari pembayaran s/d as_of sehingga laporan mundur tanggal tetap benar.
+func (s *AccountsReceivableService) GetARAging(req *models.ARAgingRequest) (*models.ResponseARAging, error) {
+	asOf, err := parseReportDate(req.AsOf, time.Now())
+	if err != nil {
+		return nil, errors.New("invalid as_of date, expected YYYY-MM-DD")
+	}
+	asOf = endOfDay(asOf)
+
+	sos, err := s.AccountsReceivableRepository.FindOpenInvoices(nil, req, &asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ResponseARAging{
+		AsOf:      asOf,
+		Customers: []models.ARAgingCustomer{},
+	}
+	indexByCustomer := make(map[uuid.UUID]int)
+
+	for _, so := range sos {
+		outstanding := so.TotalAmount - so.PaidAmount
+		if outstanding <= 0 {
+			continue
+		}
+
+		ref := so.SODate
+		if so.DueDate != nil {
+			ref = *so.DueDate
+		}
+		days := int(asOf.Sub(ref).Hours() / 24)
+		bucket := agingBucket(days)
+
+		idx, ok := indexByCustomer[so.CustomerID]
+		if !ok {
+			result.Customers = append(result.Customers, models.ARAgingCustomer{
+				CustomerID:    so.CustomerID,
+				CustomerName:  so.Customer.Name,
+				CustomerNomor: so.Customer.Nomor,
+				AreaName:      so.Customer.Area.Name,
+				Invoices:      []models.ARAgingInvoice{},
+			})
+			idx = len(result.Customers) - 1
+			indexByCustomer[so.CustomerID] = idx
+		}
+
+		customer := &result.Customers[idx]
+		customer.Invoices = append(customer.Invoices, models.ARAgingInvoice{
+			SalesOrderID:    so.ID,
+			SONumber:        so.SONumber,
+			SalesPersonName: so.SalesPerson.Name,
+			SODate:          so.SODate,
+			DueDate:         so.DueDate,
+			TotalAmount:     so.TotalAmount,
+			PaidAmount:      so.PaidAmount,
+			Outstanding:     outstanding,
+			DaysOverdue:     days,
+			Bucket:          bucket,
+		})
+		customer.Buckets.Add(bucket, outstanding)
+		result.Totals.Add(bucket, outstanding)
+	}
+
+	return result, nil
+}
+
+func (s *AccountsReceivableService) GenerateARAgingExcel(req *models.ARAgingRequest) (string, *excelize.File, error) {
+	aging, err := s.GetARAging(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fileExcel, filename, err := documents.GenerateARAgingExcel(aging)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate excel: %w", err)
+	}
+	return filename, fileExcel, nil
+}
+
+// GetCustomerStatement menyusun rekening koran customer: saldo awal, mutasi invoice / credit note /
+// pembayaran / refund dalam periode beserta saldo berjalan, dan saldo akhir.
+func (s *AccountsReceivableService) GetCustomerStatement(customerID string, req *models.CustomerStatementRequest) (*models.ResponseCustomerStatement, error) {
+	customer, err := s.CustomerRepository.FindById(nil, customerID, false)
+	if err != nil {
+		return nil, errors.New("customer not found")
+	}
+
+	now := time.Now().In(jakartaLoc())
+	start, err := parseReportDate(req.StartDate, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		return nil, errors.New("invalid start_date, expected YYYY-MM-DD")
+	}
+	end, err := parseReportDate(req.EndDate, now)
+	if err != nil {
+		return nil, errors.New("invalid end_date, expected YYYY-MM-DD")
+	}
+	end = endOfDay(end)
+	if start.After(end) {
+		return nil, errors.New("start_date must be before end_date")
+	}
+
+	invoices, err := s.AccountsReceivableRepository.FindCustomerInvoices(nil, customer.ID, end)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.AccountsReceivableRepository.FindCustomerPayments(nil, customer.ID, end)
+	if err != nil {
+		return nil, err
+	}
+	creditNotes, err := s.AccountsReceivableRepository.FindCustomerCreditNotes(nil, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// TotalAmount SO sudah dikurangi credit note; nilai invoice awal = TotalAmount + Σ credit note
+	creditedBySO := make(map[uuid.UUID]int)
+	for _, cn := range creditNotes {
+		creditedBySO[cn.SalesOrderID] += cn.CreditAmount
+	}
+
+	entries := make([]models.StatementLine, 0, len(invoices)+len(payments)+len(creditNotes))
+	invoiced := make(map[uuid.UUID]bool, len(invoices))
+	for _, so := range invoices {
+		invoiced[so.ID] = true
+		entries = append(entries, models.StatementLine{
+			Date:      so.SODate,
+			Type:      "Invoice",
+			Reference: so.SONumber,
+			SONumber:  so.SONumber,
+			Debit:     so.TotalAmount + creditedBySO[so.ID],
+		})
+	}
+	for _, cn := range creditNotes {
+		if cn.CompletedAt == nil || cn.CompletedAt.After(end) || !invoiced[cn.SalesOrderID] || cn.CreditAmount == 0 {
+			continue
+		}
+		ref := cn.ReturnNumber
+		if cn.CreditNoteNumber != nil {
+			ref = *cn.CreditNoteNumber
+		}
+		entries = append(entries, models.StatementLine{
+			Date:      *cn.CompletedAt,
+			Type:      "CreditNote",
+			Reference: ref,
+			SONumber:  cn.SalesOrder.SONumber,
+			Credit:    cn.CreditAmount,
+		})
+	}
+	for _, p := range payments {
+		line := models.StatementLine{
+			Date:      p.PaymentDate,
+			Type:      "Payment",
+			Reference: p.ReferenceNumber,
+		}
+		if p.SalesOrder != nil {
+			line.SONumber = p.SalesOrder.SONumber
+		}
+		if line.Reference == "" {
+			line.Reference = p.PaymentType
+		}
+		if p.Amount < 0 {
+			line.Type = "Refund"
+			if p.PaymentType == "Reversal" {
+				line.Type = "Reversal"
+			}
+			line.Debit = -p.Amount
+		} else {
+			line.Credit = p.Amount
+		}
+		entries = append(entries, line)
+	}
+
+	// urut tanggal; di tanggal yang sama invoice lebih dulu dari pengurang tagihan
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return statementTypeRank(entries[i].Type) < statementTypeRank(entries[j].Type)
+	})
+
+	statement := &models.ResponseCustomerStatement{
+		Customer:  *customer,
+		StartDate: start,
+		EndDate:   end,
+		Lines:     []models.StatementLine{},
+	}
+	balance := 0
+	for _, e := range entries {
+		balance += e.Debit - e.Credit
+		if e.Date.Before(start) {
+			statement.OpeningBalance = balance
+			continue
+		}
+		e.Balance = balance
+		statement.Lines = append(statement.Lines, e)
+		statement.TotalDebit += e.Debit
+		statement.TotalCredit += e.Credit
+	}
+	statement.ClosingBalance = balance
+
+	return statement, nil
+}
+
+func (s *AccountsReceivableService) GenerateCustomerStatementPDF(customerID string, req *models.CustomerStatementRequest) (string, []byte, error) {
+	statement, err := s.GetCustomerStatement(customerID, req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filename, data, err := documents.GenerateCustomerStatementPDF(statement)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate statement PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+func agingBucket(daysOverdue int) string {
+	switch {
+	case daysOverdue <= 30:
+		return models.ARBucket0To30
+	case daysOverdue <= 60:
+		return models.ARBucket31To60
+	case daysOverdue <= 90:
+		return models.ARBucket61To90
+	default:
+		return models.ARBucketOver90
+	}
+}
+
+func statementTypeRank(t string) int {
+	switch t {
+	case "Invoice":
+		return 0
+	case "Refund", "Reversal":
+		return 1
+	case "CreditNote":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// parseReportDate membaca tanggal YYYY-MM-DD (WIB); kosong = def.
+func parseReportDate(value string, def time.Time) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, jakartaLoc())
+}
+
+func endOfDay(t time.Time) time.Time {
+	t = t.In(jakartaLoc())
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
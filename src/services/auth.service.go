@@ -3,22 +3,42 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/SalmanDMA/inventory-app/backend/src/websockets"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPasswordResetRateLimited  = errors.New("too many password reset requests, please try again later")
+	ErrPasswordResetTokenInvalid = errors.New("invalid or expired reset token")
+	ErrRefreshTokenInvalid       = errors.New("invalid or expired refresh token")
+	ErrSessionRevoked            = errors.New("session has been revoked, please login again")
 )
 
 type AuthService struct {
-	UserRepository repositories.UserRepository
+	UserRepository          repositories.UserRepository
+	PasswordResetRepository repositories.PasswordResetRepository
+	UserSessionRepository   repositories.UserSessionRepository
+	MailSender              helpers.MailSender
 }
 
-func NewAuthService(userRepo repositories.UserRepository) *AuthService {
+func NewAuthService(userRepo repositories.UserRepository, passwordResetRepo repositories.PasswordResetRepository, userSessionRepo repositories.UserSessionRepository, mailSender helpers.MailSender) *AuthService {
 	return &AuthService{
-		UserRepository: userRepo,
+		UserRepository:          userRepo,
+		PasswordResetRepository: passwordResetRepo,
+		UserSessionRepository:   userSessionRepo,
+		MailSender:              mailSender,
 	}
 }
 
@@ -26,27 +46,74 @@ func NewAuthService(userRepo repositories.UserRepository) *AuthService {
 // Reads (tanpa transaction)
 // ==============================
 
-func (s *AuthService) Login(in *models.UserLoginRequest, ctx *fiber.Ctx) (*models.User, string, error) {
-	_ = ctx
-
-	// read only
+func (s *AuthService) Login(in *models.UserLoginRequest, ctx *fiber.Ctx) (*models.AuthTokenResponse, error) {
 	user, err := s.UserRepository.FindByEmailOrUsername(nil, in.Identifier)
 	if err != nil {
 		if err == repositories.ErrUserNotFound {
-			return nil, "", errors.New("invalid email or password")
+			return nil, errors.New("invalid email or password")
 		}
-		return nil, "", err
+		return nil, err
 	}
 
 	if err := helpers.VerifyPassword(user.Password, in.Password); err != nil {
-		return nil, "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
-	token, err := helpers.CreateToken(user)
+	ip, userAgent := requestMeta(ctx)
+	deviceID := strings.TrimSpace(in.DeviceID)
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// satu session aktif per device; login ulang dari device yang sama menggantikan session lama
+	if deviceID != "" {
+		if err := s.UserSessionRepository.RevokeByDevice(tx, user.ID, deviceID, "replaced by new login"); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	plain, hash, err := helpers.GenerateOpaqueToken(32)
 	if err != nil {
-		return nil, "", err
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
-	return user, token, nil
+
+	now := time.Now()
+	session := &models.UserSession{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RoleID:           user.RoleID,
+		DeviceID:         deviceID,
+		DeviceName:       strings.TrimSpace(in.DeviceName),
+		UserAgent:        userAgent,
+		IPAddress:        ip,
+		RefreshTokenHash: hash,
+		RefreshExpiresAt: now.Add(helpers.RefreshTokenTTL()),
+		LastUsedAt:       now,
+	}
+	if _, err := s.UserSessionRepository.Insert(tx, session); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	result, err := s.issueTokens(user, session, plain)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
 }
 
 func (s *AuthService) CheckIdentifier(in *models.UserCheckIdentifierRequest, ctx *fiber.Ctx) (*models.User, error) {
@@ -110,18 +177,26 @@ func (s *AuthService) ResetPassword(userID, currentPassword, newPassword string,
 		return err
 	}
 
+	// device lain wajib login ulang; session yang sedang dipakai tetap berlaku
+	currentSession := currentSessionID(ctx)
+	if err := s.UserSessionRepository.RevokeAllByUser(tx, user.ID, "password changed", currentSession); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	websockets.DisconnectOtherSessions(user.ID.String(), currentSession)
 	return nil
 }
 
-func (s *AuthService) ForgotPassword(in *models.UserForgotPasswordRequest, ctx *fiber.Ctx) (*models.User, error) {
-	_ = ctx
-
-	if strings.TrimSpace(in.Password) == "" {
-		return nil, errors.New("new password cannot be empty")
-	}
+// RefreshToken merotasi refresh token: token lama langsung tidak berlaku dan pemakaian ulang
+// token lama dianggap kebocoran sehingga session dicabut.
+func (s *AuthService) RefreshToken(in *models.UserRefreshTokenRequest, ctx *fiber.Ctx) (*models.AuthTokenResponse, error) {
+	hash := helpers.HashOpaqueToken(strings.TrimSpace(in.RefreshToken))
+	ip, userAgent := requestMeta(ctx)
+	now := time.Now()
 
 	tx := configs.DB.Begin()
 	if tx.Error != nil {
@@ -133,23 +208,87 @@ func (s *AuthService) ForgotPassword(in *models.UserForgotPasswordRequest, ctx *
 		}
 	}()
 
-	user, err := s.UserRepository.FindByEmailOrUsername(tx, in.Identifier)
+	session, err := s.UserSessionRepository.FindByRefreshHash(tx, hash)
 	if err != nil {
+		if !errors.Is(err, repositories.ErrUserSessionNotFound) {
+			tx.Rollback()
+			return nil, err
+		}
+
+		reused, findErr := s.UserSessionRepository.FindByPreviousRefreshHash(tx, hash)
+		if findErr != nil {
+			tx.Rollback()
+			if errors.Is(findErr, repositories.ErrUserSessionNotFound) {
+				return nil, ErrRefreshTokenInvalid
+			}
+			return nil, findErr
+		}
+		if reused.RevokedAt == nil {
+			reused.RevokedAt = &now
+			reused.RevokedReason = "refresh token reuse detected"
+			if _, err := s.UserSessionRepository.Update(tx, reused); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		log.Printf("refresh token reuse detected for session %s (user %s)", reused.ID, reused.UserID)
+		return nil, ErrSessionRevoked
+	}
+
+	if session.RevokedAt != nil {
 		tx.Rollback()
-		if err == repositories.ErrUserNotFound {
-			return nil, errors.New("user not found")
+		return nil, ErrSessionRevoked
+	}
+	if now.After(session.RefreshExpiresAt) {
+		tx.Rollback()
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	user, err := s.UserRepository.FindById(tx, session.UserID.String(), false)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrRefreshTokenInvalid
 		}
 		return nil, err
 	}
 
-	hashed, err := helpers.HashPassword(in.Password)
+	// role berubah sejak login → permission lama tidak boleh terbawa
+	if !sameRole(session.RoleID, user.RoleID) {
+		session.RevokedAt = &now
+		session.RevokedReason = "role changed"
+		if _, err := s.UserSessionRepository.Update(tx, session); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil, ErrSessionRevoked
+	}
+
+	plain, newHash, err := helpers.GenerateOpaqueToken(32)
 	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	previous := session.RefreshTokenHash
+	session.PreviousRefreshHash = &previous
+	session.RefreshTokenHash = newHash
+	session.RefreshExpiresAt = now.Add(helpers.RefreshTokenTTL())
+	session.LastUsedAt = now
+	session.IPAddress = ip
+	session.UserAgent = userAgent
+	if _, err := s.UserSessionRepository.Update(tx, session); err != nil {
 		tx.Rollback()
 		return nil, err
 	}
-	user.Password = hashed
 
-	updated, err := s.UserRepository.Update(tx, user)
+	result, err := s.issueTokens(user, session, plain)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -158,5 +297,315 @@ func (s *AuthService) ForgotPassword(in *models.UserForgotPasswordRequest, ctx *
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
-	return updated, nil
+	return result, nil
+}
+
+// Logout mencabut session yang sedang dipakai (device ini saja).
+func (s *AuthService) Logout(userID uuid.UUID, ctx *fiber.Ctx) error {
+	sessionID := currentSessionID(ctx)
+	if sessionID == nil {
+		return ErrSessionRevoked
+	}
+
+	session, err := s.UserSessionRepository.FindById(nil, sessionID.String())
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionRevoked
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	session.RevokedReason = "logout"
+	if _, err := s.UserSessionRepository.Update(nil, session); err != nil {
+		return err
+	}
+	websockets.DisconnectSession(session.ID)
+	return nil
+}
+
+// LogoutAll mencabut semua session milik user di semua device.
+func (s *AuthService) LogoutAll(userID uuid.UUID, ctx *fiber.Ctx) error {
+	_ = ctx
+	if err := s.UserSessionRepository.RevokeAllByUser(nil, userID, "logout all devices", nil); err != nil {
+		return err
+	}
+	websockets.DisconnectUser(userID.String())
+	return nil
+}
+
+func (s *AuthService) GetActiveSessions(userID uuid.UUID, ctx *fiber.Ctx) ([]models.ResponseGetUserSession, error) {
+	sessions, err := s.UserSessionRepository.FindActiveByUser(nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := currentSessionID(ctx)
+	result := make([]models.ResponseGetUserSession, 0, len(sessions))
+	for _, sess := range sessions {
+		result = append(result, models.ResponseGetUserSession{
+			ID:               sess.ID,
+			DeviceID:         sess.DeviceID,
+			DeviceName:       sess.DeviceName,
+			UserAgent:        sess.UserAgent,
+			IPAddress:        sess.IPAddress,
+			LastUsedAt:       sess.LastUsedAt,
+			RefreshExpiresAt: sess.RefreshExpiresAt,
+			CreatedAt:        sess.CreatedAt,
+			IsCurrent:        current != nil && *current == sess.ID,
+		})
+	}
+	return result, nil
+}
+
+// RequestPasswordReset membuat token reset sekali pakai dan mengirimkan link-nya via email.
+// Identifier yang tidak dikenal tetap dibalas sukses supaya tidak bisa dipakai enumerasi akun.
+func (s *AuthService) RequestPasswordReset(in *models.UserForgotPasswordRequest, ctx *fiber.Ctx) error {
+	identifier := strings.ToLower(strings.TrimSpace(in.Identifier))
+	if identifier == "" {
+		return errors.New("identifier cannot be empty")
+	}
+	ip, userAgent := requestMeta(ctx)
+
+	maxRequests := helpers.EnvInt("PASSWORD_RESET_MAX_REQUESTS", 3)
+	window := time.Duration(helpers.EnvInt("PASSWORD_RESET_WINDOW_MINUTES", 60)) * time.Minute
+	count, err := s.PasswordResetRepository.CountAuditSince(nil, identifier,
+		[]string{"requested", "unknown_identifier"}, time.Now().Add(-window))
+	if err != nil {
+		return fmt.Errorf("failed to check reset rate limit: %w", err)
+	}
+	if count >= int64(maxRequests) {
+		s.writeResetAudit(nil, identifier, "rate_limited", ip, userAgent, "")
+		return ErrPasswordResetRateLimited
+	}
+
+	user, err := s.UserRepository.FindByEmailOrUsername(nil, strings.TrimSpace(in.Identifier))
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			s.writeResetAudit(nil, identifier, "unknown_identifier", ip, userAgent, "")
+			return nil
+		}
+		return err
+	}
+
+	plain, hash, err := helpers.GenerateOpaqueToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+	ttl := time.Duration(helpers.EnvInt("PASSWORD_RESET_TOKEN_TTL_MINUTES", 30)) * time.Minute
+	now := time.Now()
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// hanya token terakhir yang berlaku
+	if err := s.PasswordResetRepository.RevokeActiveTokens(tx, user.ID, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	token := &models.PasswordResetToken{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		TokenHash:   hash,
+		ExpiresAt:   now.Add(ttl),
+		RequestedIP: ip,
+	}
+	if _, err := s.PasswordResetRepository.InsertToken(tx, token); err != nil {
+		tx.Rollback()
+		return err
+	}
+	s.writeResetAudit(tx, identifier, "requested", ip, userAgent, "", user.ID)
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// gagal kirim tidak dibocorkan ke pemanggil (respons harus sama dengan akun yang tidak ada)
+	if err := s.MailSender.Send(buildPasswordResetMail(user, plain, ttl)); err != nil {
+		log.Printf("Warning: failed to send password reset email to user %s: %v", user.ID, err)
+		s.writeResetAudit(nil, identifier, "mail_failed", ip, userAgent, err.Error(), user.ID)
+	}
+	return nil
+}
+
+// ConfirmPasswordReset menukar token reset yang masih berlaku dengan password baru.
+func (s *AuthService) ConfirmPasswordReset(in *models.UserForgotPasswordConfirmRequest, ctx *fiber.Ctx) error {
+	if strings.TrimSpace(in.Password) == "" {
+		return errors.New("new password cannot be empty")
+	}
+	ip, userAgent := requestMeta(ctx)
+	hash := helpers.HashOpaqueToken(strings.TrimSpace(in.Token))
+	now := time.Now()
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	token, err := s.PasswordResetRepository.FindTokenByHash(tx, hash)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrPasswordResetTokenNotFound) {
+			s.writeResetAudit(nil, "", "invalid_token", ip, userAgent, "unknown token")
+			return ErrPasswordResetTokenInvalid
+		}
+		return err
+	}
+
+	reason := ""
+	switch {
+	case token.UsedAt != nil:
+		reason = "token already used"
+	case token.RevokedAt != nil:
+		reason = "token superseded by newer request"
+	case now.After(token.ExpiresAt):
+		reason = "token expired"
+	}
+	if reason != "" {
+		tx.Rollback()
+		s.writeResetAudit(nil, "", "invalid_token", ip, userAgent, reason, token.UserID)
+		return ErrPasswordResetTokenInvalid
+	}
+
+	user, err := s.UserRepository.FindById(tx, token.UserID.String(), false)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return ErrPasswordResetTokenInvalid
+		}
+		return err
+	}
+
+	hashed, err := helpers.HashPassword(in.Password)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	user.Password = hashed
+	if _, err := s.UserRepository.Update(tx, user); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	token.UsedAt = &now
+	if _, err := s.PasswordResetRepository.UpdateToken(tx, token); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := s.PasswordResetRepository.RevokeActiveTokens(tx, user.ID, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := s.UserSessionRepository.RevokeAllByUser(tx, user.ID, "password reset", nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+	s.writeResetAudit(tx, strings.ToLower(user.Email), "redeemed", ip, userAgent, "", user.ID)
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	websockets.DisconnectUser(user.ID.String())
+	return nil
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *AuthService) writeResetAudit(tx *gorm.DB, identifier, event, ip, userAgent, notes string, userID ...uuid.UUID) {
+	audit := &models.PasswordResetAudit{
+		ID:         uuid.New(),
+		Identifier: identifier,
+		Event:      event,
+		IPAddress:  ip,
+		UserAgent:  userAgent,
+		Notes:      notes,
+	}
+	if len(userID) > 0 {
+		audit.UserID = &userID[0]
+	}
+	if err := s.PasswordResetRepository.InsertAudit(tx, audit); err != nil {
+		log.Printf("failed to write password reset audit (%s): %v", event, err)
+	}
+}
+
+func (s *AuthService) issueTokens(user *models.User, session *models.UserSession, refreshToken string) (*models.AuthTokenResponse, error) {
+	expiresAt := time.Now().Add(helpers.AccessTokenTTL())
+	token, err := helpers.CreateToken(user, session.ID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuthTokenResponse{
+		User:             user,
+		Token:            token,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        expiresAt,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+		SessionID:        session.ID,
+	}, nil
+}
+
+func currentSessionID(ctx *fiber.Ctx) *uuid.UUID {
+	if ctx == nil {
+		return nil
+	}
+	if id, ok := ctx.Locals("sessionID").(uuid.UUID); ok && id != uuid.Nil {
+		return &id
+	}
+	return nil
+}
+
+func sameRole(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func requestMeta(ctx *fiber.Ctx) (string, string) {
+	if ctx == nil {
+		return "", ""
+	}
+	return ctx.IP(), ctx.Get(fiber.HeaderUserAgent)
+}
+
+func buildPasswordResetMail(user *models.User, token string, ttl time.Duration) helpers.MailMessage {
+	link := token
+	if base := strings.TrimSpace(os.Getenv("PASSWORD_RESET_URL")); base != "" {
+		link = fmt.Sprintf("%s?token=%s", strings.TrimRight(base, "/"), url.QueryEscape(token))
+	}
+
+	text := fmt.Sprintf(`Halo %s,
+
+Kami menerima permintaan reset password untuk akun %s.
+Gunakan link berikut untuk membuat password baru (berlaku %d menit, hanya sekali pakai):
+
+%s
+
+Abaikan email ini jika Anda tidak merasa meminta reset password.
+`, user.Name, user.Username, int(ttl.Minutes()), link)
+
+	return helpers.MailMessage{
+		To:       []string{user.Email},
+		Subject:  "Reset Password",
+		TextBody: text,
+	}
 }
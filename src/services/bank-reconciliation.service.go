@@ -0,0 +1,646 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/bankstatement"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ambang skor pencocokan mutasi bank (0-100).
+const (
+	bankMatchMinScore      = 30 // di bawah ini tidak disarankan
+	bankMatchAutoScore     = 80 // kandidat teratas dianggap cocok otomatis
+	bankMatchAutoMargin    = 20 // selisih minimal dengan kandidat kedua untuk auto-match
+	bankMatchMaxSuggestion = 5
+)
+
+type BankReconciliationService struct {
+	BankStatementRepository      repositories.BankStatementRepository
+	AccountsReceivableRepository repositories.AccountsReceivableRepository
+	AccountsPayableRepository    repositories.AccountsPayableRepository
+	PaymentReceiptService        *PaymentReceiptService
+}
+
+func NewBankReconciliationService(
+	bankStatementRepo repositories.BankStatementRepository,
+	arRepo repositories.AccountsReceivableRepository,
+	apRepo repositories.AccountsPayableRepository,
+	paymentReceiptRepo repositories.PaymentReceiptRepository,
+	paymentRepo repositories.PaymentRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	soRepo repositories.SalesOrderRepository,
+	uploadRepo repositories.UploadRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *BankReconciliationService {
+	return &BankReconciliationService{
+		BankStatementRepository:      bankStatementRepo,
+		AccountsReceivableRepository: arRepo,
+		AccountsPayableRepository:    apRepo,
+		PaymentReceiptService:        NewPaymentReceiptService(paymentReceiptRepo, paymentRepo, poRepo, soRepo, uploadRepo, numberSequenceRepo),
+	}
+}
+
+func (s *BankReconciliationService) GetAllBankStatementsPaginated(req *models.PaginationRequest) (*models.BankStatementPaginatedResponse, error) {
+	normalizePagination(req)
+
+	rows, total, err := s.BankStatementRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BankStatementPaginatedResponse{
+		Data:       rows,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *BankReconciliationService) GetBankStatementByID(statementId string) (*models.BankStatement, error) {
+	return s.BankStatementRepository.FindById(nil, statementId)
+}
+
+// GetReconciliationQueue menampilkan mutasi yang belum dikonfirmasi / diabaikan beserta kandidat ordernya.
+func (s *BankReconciliationService) GetReconciliationQueue(req *models.PaginationRequest) (*models.BankStatementLinePaginatedResponse, error) {
+	normalizePagination(req)
+
+	rows, total, err := s.BankStatementRepository.FindLinesPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BankStatementLinePaginatedResponse{
+		Data:       rows,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+// ImportBankStatement membaca file mutasi (field form "file", opsional "bank" dan "account_number"),
+// melewati mutasi yang sudah pernah diimpor, lalu mencocokkan tiap mutasi ke SO (kredit) / PO (debit)
+// yang masih terbuka.
+func (s *BankReconciliationService) ImportBankStatement(ctx *fiber.Ctx, userInfo *models.User) (*models.BankStatement, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil || fileHeader == nil {
+		return nil, errors.New("statement file is required (form field: file)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	parsed, err := bankstatement.Parse(data, ctx.FormValue("bank"))
+	if err != nil {
+		return nil, err
+	}
+	accountNumber := strings.TrimSpace(ctx.FormValue("account_number"))
+	if accountNumber == "" {
+		accountNumber = parsed.AccountNumber
+	}
+
+	statement := &models.BankStatement{
+		ID:            uuid.New(),
+		Bank:          parsed.Bank,
+		AccountNumber: accountNumber,
+		Filename:      fileHeader.Filename,
+		PeriodStart:   parsed.PeriodStart,
+		PeriodEnd:     parsed.PeriodEnd,
+		ImportedBy:    &userInfo.ID,
+	}
+
+	// mutasi identik dalam satu file dibedakan dengan urutan kemunculannya
+	occurrence := make(map[string]int)
+	fingerprints := make([]string, 0, len(parsed.Lines))
+	lines := make([]models.BankStatementLine, 0, len(parsed.Lines))
+	for _, pl := range parsed.Lines {
+		key := strings.Join([]string{
+			accountNumber,
+			pl.TransactionDate.Format("2006-01-02"),
+			pl.Direction,
+			fmt.Sprint(pl.Amount),
+			normalizeMatchText(pl.Description),
+			normalizeMatchText(pl.Reference),
+		}, "|")
+		occurrence[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, occurrence[key])))
+		fp := hex.EncodeToString(sum[:])
+
+		fingerprints = append(fingerprints, fp)
+		lines = append(lines, models.BankStatementLine{
+			ID:              uuid.New(),
+			BankStatementID: statement.ID,
+			LineNo:          pl.LineNo,
+			TransactionDate: pl.TransactionDate,
+			Description:     pl.Description,
+			Reference:       pl.Reference,
+			Amount:          pl.Amount,
+			Direction:       pl.Direction,
+			Balance:         pl.Balance,
+			Fingerprint:     fp,
+		})
+	}
+
+	// cek fingerprint dan insert dalam satu transaksi; import bersamaan yang lolos cek tertahan
+	// oleh unique index fingerprint dan ditolak di bawah
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	existing, err := s.BankStatementRepository.FindExistingFingerprints(tx, fingerprints)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	candidates, err := s.loadCandidates(tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, ln := range lines {
+		if existing[ln.Fingerprint] {
+			statement.SkippedLines++
+			continue
+		}
+		ln.Suggestions = suggestMatches(&ln, candidates)
+		ln.Status = lineStatusFor(ln.Suggestions)
+		statement.Lines = append(statement.Lines, ln)
+	}
+	statement.TotalLines = len(statement.Lines)
+
+	if statement.TotalLines == 0 {
+		tx.Rollback()
+		return nil, errors.New("all transactions in this file have already been imported")
+	}
+
+	if _, err := s.BankStatementRepository.Insert(tx, statement); err != nil {
+		tx.Rollback()
+		if repositories.IsUniqueViolation(err) {
+			return nil, errors.New("some transactions in this file were imported by another request, please retry the import")
+		}
+		return nil, fmt.Errorf("error saving bank statement: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.BankStatementRepository.FindById(nil, statement.ID.String())
+}
+
+// RematchBankStatement menghitung ulang kandidat untuk mutasi yang belum selesai, mis. setelah
+// SO / PO baru dibuat atau pembayaran lain masuk.
+func (s *BankReconciliationService) RematchBankStatement(statementId string) (*models.BankStatement, error) {
+	statement, err := s.BankStatementRepository.FindById(nil, statementId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	lines, err := s.BankStatementRepository.FindOpenLines(tx, statement.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	candidates, err := s.loadCandidates(tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for i := range lines {
+		ln := &lines[i]
+		suggestions := suggestMatches(ln, candidates)
+		if err := s.BankStatementRepository.ReplaceSuggestions(tx, ln.ID, suggestions); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		ln.Status = lineStatusFor(suggestions)
+		if err := s.BankStatementRepository.UpdateLine(tx, ln); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.BankStatementRepository.FindById(nil, statement.ID.String())
+}
+
+// ConfirmBankStatementLine membuat payment dari satu mutasi lewat PaymentReceiptService (yang
+// mencatat tiap alokasi dengan PaymentService.RecordPayment). Tanpa alokasi eksplisit dipakai
+// kandidat teratas; kelebihan nominal menjadi deposit customer / supplier. Mutasi yang dibuka ulang
+// oleh PaymentService.VoidPayment dialokasikan dari receipt lamanya.
+func (s *BankReconciliationService) ConfirmBankStatementLine(lineId string, req *models.BankStatementConfirmRequest, userInfo *models.User) (*models.BankStatementLine, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	line, err := s.BankStatementRepository.FindLineById(tx, lineId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if line.Status == models.BankLineConfirmed || line.Status == models.BankLineIgnored {
+		tx.Rollback()
+		return nil, fmt.Errorf("bank statement line is already %s", strings.ToLower(line.Status))
+	}
+
+	orderType := "SO"
+	if line.Direction == "Debit" {
+		orderType = "PO"
+	}
+
+	allocations := req.Allocations
+	if len(allocations) == 0 && req.CustomerID == nil && req.SupplierID == nil {
+		if len(line.Suggestions) == 0 {
+			tx.Rollback()
+			return nil, errors.New("no matching order found, please choose the orders to allocate")
+		}
+		top := line.Suggestions[0]
+		orderID := top.SalesOrderID
+		if top.OrderType == "PO" {
+			orderID = top.PurchaseOrderID
+		}
+		if orderID == nil {
+			tx.Rollback()
+			return nil, errors.New("suggested order is no longer available")
+		}
+		allocations = []models.PaymentAllocationRequest{{OrderID: *orderID}}
+	}
+
+	paymentMethod := strings.TrimSpace(req.PaymentMethod)
+	if paymentMethod == "" {
+		paymentMethod = "Transfer"
+	}
+	reference := strings.TrimSpace(line.Reference)
+	if reference == "" {
+		reference = truncateText(strings.TrimSpace(line.Description), 100)
+	}
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		notes = fmt.Sprintf("Bank statement %s line %d", strings.ToUpper(line.BankStatement.Bank), line.LineNo)
+	}
+
+	receiptReq := &models.PaymentReceiptCreateRequest{
+		OrderType:       orderType,
+		CustomerID:      req.CustomerID,
+		SupplierID:      req.SupplierID,
+		ReceiptDate:     line.TransactionDate,
+		Amount:          line.Amount,
+		PaymentMethod:   paymentMethod,
+		ReferenceNumber: reference,
+		Notes:           notes,
+		Allocations:     allocations,
+	}
+
+	// customer / supplier receipt mengikuti order pertama bila tidak diisi
+	if len(allocations) > 0 && receiptReq.CustomerID == nil && receiptReq.SupplierID == nil {
+		if orderType == "SO" {
+			var so models.SalesOrder
+			if err := tx.Select("id", "customer_id").First(&so, "id = ?", allocations[0].OrderID).Error; err != nil {
+				tx.Rollback()
+				return nil, repositories.HandleDatabaseError(err, "sales_order")
+			}
+			receiptReq.CustomerID = &so.CustomerID
+		} else {
+			var po models.PurchaseOrder
+			if err := tx.Select("id", "supplier_id").First(&po, "id = ?", allocations[0].OrderID).Error; err != nil {
+				tx.Rollback()
+				return nil, repositories.HandleDatabaseError(err, "purchase_order")
+			}
+			receiptReq.SupplierID = &po.SupplierID
+		}
+	}
+
+	var receipt *models.PaymentReceipt
+	if line.ReceiptID != nil {
+		// mutasi dibuka ulang karena payment-nya di-void: saldo receipt lama dialokasikan lagi
+		// supaya transfer yang sama tidak tercatat dua kali
+		receipt, err = s.PaymentReceiptService.PaymentReceiptRepository.FindById(tx, line.ReceiptID.String(), true)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if len(allocations) > 0 {
+			if err := s.PaymentReceiptService.allocate(tx, receipt, allocations, line.TransactionDate); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+	} else {
+		receipt, err = s.PaymentReceiptService.CreateReceipt(tx, receiptReq, userInfo)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	line.Status = models.BankLineConfirmed
+	line.ReceiptID = &receipt.ID
+	line.ConfirmedBy = &userInfo.ID
+	line.ConfirmedAt = &now
+	if err := s.BankStatementRepository.UpdateLine(tx, line); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating bank statement line: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.BankStatementRepository.FindLineById(nil, line.ID.String(), false)
+}
+
+// IgnoreBankStatementLine mengeluarkan mutasi non-order (biaya admin, bunga, pajak) dari antrian.
+func (s *BankReconciliationService) IgnoreBankStatementLine(lineId string, req *models.BankStatementIgnoreRequest, userInfo *models.User) (*models.BankStatementLine, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	line, err := s.BankStatementRepository.FindLineById(tx, lineId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if line.Status == models.BankLineConfirmed {
+		tx.Rollback()
+		return nil, errors.New("confirmed bank statement lines cannot be ignored")
+	}
+
+	now := time.Now()
+	line.Status = models.BankLineIgnored
+	line.IgnoreReason = req.Reason
+	line.ConfirmedBy = &userInfo.ID
+	line.ConfirmedAt = &now
+	if err := s.BankStatementRepository.UpdateLine(tx, line); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating bank statement line: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.BankStatementRepository.FindLineById(nil, line.ID.String(), false)
+}
+
+// ---------- Matching ----------
+
+type reconciliationCandidate struct {
+	orderType   string // SO, PO
+	orderID     uuid.UUID
+	number      string
+	partyName   string
+	total       int
+	outstanding int
+	orderDate   time.Time
+}
+
+type reconciliationCandidates struct {
+	credit []reconciliationCandidate // SO terbuka
+	debit  []reconciliationCandidate // PO terbuka
+}
+
+func (s *BankReconciliationService) loadCandidates(tx *gorm.DB) (*reconciliationCandidates, error) {
+	sos, err := s.AccountsReceivableRepository.FindOpenInvoices(tx, &models.ARAgingRequest{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := s.AccountsPayableRepository.FindOpenPayables(tx, "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &reconciliationCandidates{
+		credit: make([]reconciliationCandidate, 0, len(sos)),
+		debit:  make([]reconciliationCandidate, 0, len(pos)),
+	}
+	for _, so := range sos {
+		c.credit = append(c.credit, reconciliationCandidate{
+			orderType:   "SO",
+			orderID:     so.ID,
+			number:      so.SONumber,
+			partyName:   so.Customer.Name,
+			total:       so.TotalAmount,
+			outstanding: so.TotalAmount - so.PaidAmount,
+			orderDate:   so.SODate,
+		})
+	}
+	for _, po := range pos {
+		c.debit = append(c.debit, reconciliationCandidate{
+			orderType:   "PO",
+			orderID:     po.ID,
+			number:      po.PONumber,
+			partyName:   po.Supplier.Name,
+			total:       po.TotalAmount,
+			outstanding: po.TotalAmount - po.PaidAmount,
+			orderDate:   po.PODate,
+		})
+	}
+	return c, nil
+}
+
+// suggestMatches memberi skor tiap order terbuka terhadap satu mutasi:
+// nomor order di berita transfer (60, sebagian 40), nominal (= sisa 30, = total 20, lebih kecil 5),
+// nama customer / supplier (15) dan tanggal mutasi setelah tanggal order (5).
+func suggestMatches(line *models.BankStatementLine, candidates *reconciliationCandidates) []models.BankStatementSuggestion {
+	pool := candidates.credit
+	if line.Direction == "Debit" {
+		pool = candidates.debit
+	}
+
+	text := normalizeMatchText(line.Reference + " " + line.Description)
+	digits := onlyDigits(line.Reference + " " + line.Description)
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToUpper(line.Reference + " " + line.Description)) {
+		words[normalizeMatchText(w)] = true
+	}
+
+	suggestions := make([]models.BankStatementSuggestion, 0)
+	for _, c := range pool {
+		score := 0
+		reasons := make([]string, 0, 4)
+
+		number := normalizeMatchText(c.number)
+		numberDigits := onlyDigits(c.number)
+		switch {
+		case number != "" && strings.Contains(text, number):
+			score += 60
+			reasons = append(reasons, "reference")
+		case len(numberDigits) >= 6 && strings.Contains(digits, numberDigits):
+			score += 40
+			reasons = append(reasons, "reference (partial)")
+		}
+
+		switch {
+		case line.Amount == c.outstanding:
+			score += 30
+			reasons = append(reasons, "amount")
+		case line.Amount == c.total:
+			score += 20
+			reasons = append(reasons, "amount (total)")
+		case line.Amount < c.outstanding:
+			score += 5
+			reasons = append(reasons, "amount (partial)")
+		}
+
+		if nameMatches(c.partyName, words) {
+			score += 15
+			reasons = append(reasons, "name")
+		}
+
+		if !line.TransactionDate.Before(startOfDay(c.orderDate)) {
+			score += 5
+			reasons = append(reasons, "date")
+		}
+
+		if score > 100 {
+			score = 100
+		}
+		if score < bankMatchMinScore {
+			continue
+		}
+
+		sg := models.BankStatementSuggestion{
+			ID:          uuid.New(),
+			LineID:      line.ID,
+			OrderType:   c.orderType,
+			OrderNumber: c.number,
+			PartyName:   c.partyName,
+			Outstanding: c.outstanding,
+			Score:       score,
+			Reasons:     strings.Join(reasons, ", "),
+		}
+		orderID := c.orderID
+		if c.orderType == "PO" {
+			sg.PurchaseOrderID = &orderID
+		} else {
+			sg.SalesOrderID = &orderID
+		}
+		suggestions = append(suggestions, sg)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Outstanding < suggestions[j].Outstanding
+	})
+	if len(suggestions) > bankMatchMaxSuggestion {
+		suggestions = suggestions[:bankMatchMaxSuggestion]
+	}
+	return suggestions
+}
+
+func lineStatusFor(suggestions []models.BankStatementSuggestion) string {
+	switch {
+	case len(suggestions) == 0:
+		return models.BankLineUnmatched
+	case suggestions[0].Score >= bankMatchAutoScore &&
+		(len(suggestions) == 1 || suggestions[0].Score-suggestions[1].Score >= bankMatchAutoMargin):
+		return models.BankLineAutoMatched
+	default:
+		return models.BankLineSuggested
+	}
+}
+
+// kata umum pada nama customer / supplier yang tidak membedakan satu pihak dengan lainnya
+var genericPartyWords = map[string]bool{
+	"PT": true, "CV": true, "TBK": true, "UD": true, "RS": true, "RSU": true, "RSUD": true,
+	"RUMAH": true, "SAKIT": true, "UMUM": true, "DAERAH": true, "KLINIK": true, "APOTEK": true,
+	"TOKO": true, "INDONESIA": true, "MEDIKA": true, "FARMA": true,
+}
+
+// nameMatches true bila minimal separuh kata khas nama pihak muncul di berita transfer.
+func nameMatches(name string, words map[string]bool) bool {
+	total, found := 0, 0
+	for _, w := range strings.Fields(strings.ToUpper(name)) {
+		w = normalizeMatchText(w)
+		if len(w) < 3 || genericPartyWords[w] {
+			continue
+		}
+		total++
+		if words[w] {
+			found++
+		}
+	}
+	return total > 0 && found*2 >= total
+}
+
+func normalizeMatchText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func truncateText(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
+}
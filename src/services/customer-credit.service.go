@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// creditOverrideRoles adalah role yang boleh meloloskan SO customer yang sedang credit hold.
+var creditOverrideRoles = []string{"SUPERADMIN", "DEVELOPER"}
+
+type CustomerCreditService struct {
+	CustomerRepository       repositories.CustomerRepository
+	SalesOrderRepository     repositories.SalesOrderRepository
+	CreditOverrideRepository repositories.CreditOverrideRepository
+}
+
+func NewCustomerCreditService(
+	customerRepo repositories.CustomerRepository,
+	soRepo repositories.SalesOrderRepository,
+	creditOverrideRepo repositories.CreditOverrideRepository,
+) *CustomerCreditService {
+	return &CustomerCreditService{
+		CustomerRepository:       customerRepo,
+		SalesOrderRepository:     soRepo,
+		CreditOverrideRepository: creditOverrideRepo,
+	}
+}
+
+// GetCustomerCredit menampilkan posisi kredit customer tanpa memperhitungkan order baru.
+func (s *CustomerCreditService) GetCustomerCredit(customerID string) (*models.ResponseGetCustomerCredit, error) {
+	customer, err := s.CustomerRepository.FindById(nil, customerID, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.evaluate(nil, customer, 0, uuid.Nil, time.Now())
+}
+
+func (s *CustomerCreditService) GetAllCreditOverridesPaginated(req *models.PaginationRequest) (*models.CreditOverridePaginatedResponse, error) {
+	normalizePagination(req)
+
+	overrides, totalCount, err := s.CreditOverrideRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreditOverridePaginatedResponse{
+		Data:       overrides,
+		Pagination: paginationResponse(req, totalCount),
+	}, nil
+}
+
+// EnforceCreditLimit dipanggil di dalam tx saat SO dibuat (stage Create) atau dikonfirmasi
+// (stage Confirm). Bila customer credit hold, SO hanya lolos jika ada alasan override dari
+// role yang berwenang; override tersebut dicatat sebagai CreditOverride dan dikembalikan agar
+// pemanggil mengirim notifikasinya (NotifyCreditOverride) setelah commit.
+func (s *CustomerCreditService) EnforceCreditLimit(
+	tx *gorm.DB,
+	so *models.SalesOrder,
+	stage string,
+	overrideReason string,
+	userInfo *models.User,
+) (*models.CreditOverride, error) {
+	customer, err := s.CustomerRepository.FindById(tx, so.CustomerID.String(), false)
+	if err != nil {
+		return nil, errors.New("customer not found")
+	}
+
+	orderAmount := so.TotalAmount - so.PaidAmount
+	if orderAmount < 0 {
+		orderAmount = 0
+	}
+
+	credit, err := s.evaluate(tx, customer, orderAmount, so.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !credit.OnHold {
+		return nil, nil
+	}
+
+	holdReasons := strings.Join(credit.HoldReasons, "; ")
+	overrideReason = strings.TrimSpace(overrideReason)
+	if overrideReason == "" {
+		return nil, fmt.Errorf("customer %s is on credit hold: %s (credit override reason required)", customer.Name, holdReasons)
+	}
+	if !canOverrideCredit(userInfo) {
+		return nil, fmt.Errorf("customer %s is on credit hold: %s (your role is not allowed to override)", customer.Name, holdReasons)
+	}
+
+	override := &models.CreditOverride{
+		ID:             uuid.New(),
+		SalesOrderID:   so.ID,
+		CustomerID:     customer.ID,
+		Stage:          stage,
+		OrderAmount:    orderAmount,
+		Outstanding:    credit.Outstanding,
+		CreditLimit:    credit.CreditLimit,
+		OverdueDays:    credit.OverdueDays,
+		MaxOverdueDays: credit.MaxOverdueDays,
+		HoldReasons:    holdReasons,
+		Reason:         overrideReason,
+		ApprovedBy:     userInfo.ID,
+	}
+	if _, err := s.CreditOverrideRepository.Insert(tx, override); err != nil {
+		return nil, fmt.Errorf("error recording credit override: %w", err)
+	}
+
+	override.Customer = customer
+	return override, nil
+}
+
+// NotifyCreditOverride memberi tahu role berwenang bahwa SO diloloskan dari credit hold.
+// Dipanggil setelah tx commit; override nil (customer tidak credit hold) diabaikan.
+func NotifyCreditOverride(override *models.CreditOverride, soNumber string, userInfo *models.User) {
+	if override == nil {
+		return
+	}
+
+	customerName := ""
+	if override.Customer != nil {
+		customerName = override.Customer.Name
+	}
+
+	title := "Credit Hold Override"
+	message := fmt.Sprintf("SO %s untuk %s diloloskan dari credit hold oleh %s: %s", soNumber, customerName, userInfo.Name, override.Reason)
+	metadata := map[string]interface{}{
+		"sales_order_id":     override.SalesOrderID.String(),
+		"so_number":          soNumber,
+		"customer_id":        override.CustomerID.String(),
+		"stage":              override.Stage,
+		"outstanding":        override.Outstanding,
+		"credit_limit":       override.CreditLimit,
+		"overdue_days":       override.OverdueDays,
+		"credit_override_id": override.ID.String(),
+	}
+	if err := helpers.SendNotificationAuto("credit_override", title, message, metadata); err != nil {
+		log.Printf("Warning: failed to send credit override notification for SO %s: %v", soNumber, err)
+	}
+}
+
+// evaluate menghitung piutang terbuka customer (di luar excludeSOID) ditambah orderAmount
+// lalu membandingkannya dengan batas kredit dan batas hari lewat jatuh tempo.
+func (s *CustomerCreditService) evaluate(
+	tx *gorm.DB,
+	customer *models.Customer,
+	orderAmount int,
+	excludeSOID uuid.UUID,
+	now time.Time,
+) (*models.ResponseGetCustomerCredit, error) {
+	openOrders, err := s.SalesOrderRepository.FindOpenReceivablesByCustomer(tx, customer.ID, excludeSOID)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating outstanding receivables: %w", err)
+	}
+
+	limit, maxOverdueDays := customer.EffectiveCreditLimit()
+	credit := &models.ResponseGetCustomerCredit{
+		CustomerID:     customer.ID,
+		CustomerName:   customer.Name,
+		CreditLimit:    limit,
+		MaxOverdueDays: maxOverdueDays,
+		OpenOrders:     len(openOrders),
+	}
+
+	for _, so := range openOrders {
+		remaining := so.TotalAmount - so.PaidAmount
+		credit.Outstanding += remaining
+		if so.DueDate != nil && now.After(*so.DueDate) {
+			credit.OverdueAmount += remaining
+			if days := int(now.Sub(*so.DueDate).Hours() / 24); days > credit.OverdueDays {
+				credit.OverdueDays = days
+			}
+		}
+	}
+
+	if limit > 0 {
+		available := limit - credit.Outstanding
+		credit.Available = &available
+		if credit.Outstanding+orderAmount > limit {
+			credit.HoldReasons = append(credit.HoldReasons, fmt.Sprintf(
+				"outstanding %d + order %d exceeds credit limit %d", credit.Outstanding, orderAmount, limit))
+		}
+	}
+	if maxOverdueDays > 0 && credit.OverdueDays > maxOverdueDays {
+		credit.HoldReasons = append(credit.HoldReasons, fmt.Sprintf(
+			"oldest invoice is %d days overdue (max %d)", credit.OverdueDays, maxOverdueDays))
+	}
+	credit.OnHold = len(credit.HoldReasons) > 0
+
+	return credit, nil
+}
+
+func canOverrideCredit(userInfo *models.User) bool {
+	if userInfo == nil || userInfo.Role == nil {
+		return false
+	}
+	for _, role := range creditOverrideRoles {
+		if strings.EqualFold(userInfo.Role.Name, role) {
+			return true
+		}
+	}
+	return false
+}
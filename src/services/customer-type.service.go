@@ -43,6 +43,8 @@ func (s *CustomerTypeService) GetAllCustomerTypes(userInfo *models.User) ([]mode
 			Name:        ft.Name,
 			Color:       ft.Color,
 			Description: ft.Description,
+			CreditLimit:    ft.CreditLimit,
+			MaxOverdueDays: ft.MaxOverdueDays,
 			CreatedAt:   ft.CreatedAt,
 			UpdatedAt:   ft.UpdatedAt,
 			DeletedAt:   ft.DeletedAt,
@@ -79,6 +81,8 @@ func (s *CustomerTypeService) GetAllCustomerTypesPaginated(req *models.Paginatio
 			Name:        ft.Name,
 			Color:       ft.Color,
 			Description: ft.Description,
+			CreditLimit:    ft.CreditLimit,
+			MaxOverdueDays: ft.MaxOverdueDays,
 			CreatedAt:   ft.CreatedAt,
 			UpdatedAt:   ft.UpdatedAt,
 			DeletedAt:   ft.DeletedAt,
@@ -109,6 +113,8 @@ func (s *CustomerTypeService) GetCustomerTypeByID(id string) (*models.ResponseGe
 		Name:        ft.Name,
 		Color:       ft.Color,
 		Description: ft.Description,
+		CreditLimit:    ft.CreditLimit,
+		MaxOverdueDays: ft.MaxOverdueDays,
 		CreatedAt:   ft.CreatedAt,
 		UpdatedAt:   ft.UpdatedAt,
 		DeletedAt:   ft.DeletedAt,
@@ -158,6 +164,12 @@ func (s *CustomerTypeService) CreateCustomerType(req *models.CustomerTypeCreateR
 		Color:       color,
 		Description: req.Description,
 	}
+	if req.CreditLimit != nil {
+		ft.CreditLimit = *req.CreditLimit
+	}
+	if req.MaxOverdueDays != nil {
+		ft.MaxOverdueDays = *req.MaxOverdueDays
+	}
 
 	created, err := s.CustomerTypeRepository.Insert(tx, ft)
 	if err != nil {
@@ -221,6 +233,12 @@ func (s *CustomerTypeService) UpdateCustomerType(idStr string, upd *models.Custo
 	if upd.Description != nil {
 		ft.Description = upd.Description
 	}
+	if upd.CreditLimit != nil {
+		ft.CreditLimit = *upd.CreditLimit
+	}
+	if upd.MaxOverdueDays != nil {
+		ft.MaxOverdueDays = *upd.MaxOverdueDays
+	}
 
 	updated, err := s.CustomerTypeRepository.Update(tx, ft)
 	if err != nil {
@@ -49,6 +49,8 @@ func (s *CustomerService) GetAllCustomers(userInfo *models.User) ([]models.Respo
 			Email:         f.Email,
 			Latitude:      f.Latitude,
 			Longitude:     f.Longitude,
+			CreditLimit:    f.CreditLimit,
+			MaxOverdueDays: f.MaxOverdueDays,
 			CustomerType:  f.CustomerType,
 			Area:          f.Area,
 			CreatedAt:     f.CreatedAt,
@@ -93,6 +95,8 @@ func (s *CustomerService) GetAllCustomersPaginated(req *models.PaginationRequest
 			Email:         f.Email,
 			Latitude:      f.Latitude,
 			Longitude:     f.Longitude,
+			CreditLimit:    f.CreditLimit,
+			MaxOverdueDays: f.MaxOverdueDays,
 			CustomerType:  f.CustomerType,
 			Area:          f.Area,
 			CreatedAt:     f.CreatedAt,
@@ -132,6 +136,8 @@ func (s *CustomerService) GetCustomerByID(customerId string) (*models.ResponseGe
 		Email:         f.Email,
 		Latitude:      f.Latitude,
 		Longitude:     f.Longitude,
+		CreditLimit:    f.CreditLimit,
+		MaxOverdueDays: f.MaxOverdueDays,
 		CustomerType:  f.CustomerType,
 		Area:          f.Area,
 		CreatedAt:     f.CreatedAt,
@@ -236,6 +242,8 @@ func (s *CustomerService) CreateCustomer(req *models.CustomerCreateRequest, ctx
 		Email:          req.Email,
 		Latitude:       req.Latitude,
 		Longitude:      req.Longitude,
+		CreditLimit:    customerCreditOverride(req.CreditLimit),
+		MaxOverdueDays: customerCreditOverride(req.MaxOverdueDays),
 	}
 
 	created, err := s.CustomerRepository.Insert(tx, newFac)
@@ -360,6 +368,12 @@ func (s *CustomerService) UpdateCustomer(idStr string, upd *models.CustomerCreat
 		}
 		existing.Longitude = upd.Longitude
 	}
+	if upd.CreditLimit != nil {
+		existing.CreditLimit = customerCreditOverride(upd.CreditLimit)
+	}
+	if upd.MaxOverdueDays != nil {
+		existing.MaxOverdueDays = customerCreditOverride(upd.MaxOverdueDays)
+	}
 
 	updated, err := s.CustomerRepository.Update(tx, existing)
 	if err != nil {
@@ -449,3 +463,11 @@ func (s *CustomerService) RestoreCustomers(in *models.CustomerRestoreRequest, ct
 	return restored, nil
 }
 
+// customerCreditOverride: nilai negatif berarti override dihapus sehingga customer ikut CustomerType.
+func customerCreditOverride(v *int) *int {
+	if v == nil || *v < 0 {
+		return nil
+	}
+	n := *v
+	return &n
+}
@@ -41,6 +41,12 @@ func (s *DashboardService) GetDashboardSummary() (*models.DashboardSummary, erro
 		return nil, err
 	}
 
+	// Stock on hand vs reserved
+	onHand, reserved, err := s.ItemRepository.SumStockTotals(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// Active orders (Confirmed/On-going)
 	activeNow, err := s.SalesOrderRepository.CountActiveThisMonth(nil)
 	if err != nil {
@@ -66,6 +72,11 @@ func (s *DashboardService) GetDashboardSummary() (*models.DashboardSummary, erro
 		LowStockItems: calculateMetric(lowNow, lowPrev),
 		ActiveOrders:  calculateMetric(activeNow, activePrev),
 		TotalValue:    calculateMetric(valueNow, valuePrev),
+		Stock: models.StockAvailabilitySummary{
+			OnHand:    onHand,
+			Reserved:  reserved,
+			Available: onHand - reserved,
+		},
 	}, nil
 }
 
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDocumentLinkInvalid   = errors.New("invalid document link")
+	ErrDocumentLinkExpired   = errors.New("document link has expired or is no longer valid")
+	ErrDocumentLinkForbidden = errors.New("you do not have permission to share this document")
+)
+
+// documentLinkModules memetakan jenis dokumen ke modul pemiliknya; membuat / mencabut link
+// butuh akses ke modul tersebut.
+var documentLinkModules = map[string]string{
+	models.DocumentTypeDeliveryOrder:    "Sales Orders",
+	models.DocumentTypeInvoice:          "Sales Orders",
+	models.DocumentTypeReceipt:          "Sales Orders",
+	models.DocumentTypePurchaseOrder:    "Purchase Orders",
+	models.DocumentTypeSalesReportPDF:   "Sales Reports",
+	models.DocumentTypeSalesReportExcel: "Sales Reports",
+}
+
+type DocumentLinkService struct {
+	DocumentLinkRepository  repositories.DocumentLinkRepository
+	SalesOrderRepository    repositories.SalesOrderRepository
+	PurchaseOrderRepository repositories.PurchaseOrderRepository
+	RoleModuleRepository    repositories.RoleModuleRepository
+}
+
+func NewDocumentLinkService(
+	documentLinkRepo repositories.DocumentLinkRepository,
+	soRepo repositories.SalesOrderRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	roleModuleRepo repositories.RoleModuleRepository,
+) *DocumentLinkService {
+	return &DocumentLinkService{
+		DocumentLinkRepository:  documentLinkRepo,
+		SalesOrderRepository:    soRepo,
+		PurchaseOrderRepository: poRepo,
+		RoleModuleRepository:    roleModuleRepo,
+	}
+}
+
+// ==============================
+// Mutations
+// ==============================
+
+// CreateDocumentLink membuat URL publik bertanda tangan HMAC untuk satu dokumen.
+func (s *DocumentLinkService) CreateDocumentLink(in *models.DocumentLinkCreateRequest, userInfo *models.User) (*models.ResponseGetDocumentLink, error) {
+	if err := s.authorizeDocumentType(in.DocumentType, userInfo); err != nil {
+		return nil, err
+	}
+
+	query := ""
+	switch in.DocumentType {
+	case models.DocumentTypeSalesReportExcel:
+		values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(in.Query), "?"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		values.Del("expires")
+		values.Del("signature")
+		query = values.Encode()
+	case models.DocumentTypePurchaseOrder:
+		if in.ResourceID == nil {
+			return nil, errors.New("resource_id is required for this document type")
+		}
+		if _, err := s.PurchaseOrderRepository.FindById(nil, in.ResourceID.String(), false); err != nil {
+			return nil, err
+		}
+	default:
+		if in.ResourceID == nil {
+			return nil, errors.New("resource_id is required for this document type")
+		}
+		so, err := s.SalesOrderRepository.FindById(nil, in.ResourceID.String(), false)
+		if err != nil {
+			return nil, err
+		}
+		// link publik hanya membaca dokumen, jadi invoice harus sudah diterbitkan lebih dulu
+		if in.DocumentType == models.DocumentTypeInvoice && so.InvoiceNumber == nil {
+			return nil, ErrInvoiceNotIssued
+		}
+	}
+
+	ttl := in.ExpiresInMinutes
+	if ttl <= 0 {
+		ttl = helpers.EnvInt("DOCUMENT_LINK_TTL_MINUTES", 1440)
+	}
+	if maxTTL := helpers.EnvInt("DOCUMENT_LINK_MAX_TTL_MINUTES", 10080); ttl > maxTTL {
+		return nil, fmt.Errorf("expires_in_minutes cannot exceed %d", maxTTL)
+	}
+
+	link := &models.DocumentLink{
+		ID:           uuid.New(),
+		DocumentType: in.DocumentType,
+		Query:        query,
+		// dibulatkan ke detik karena expires di URL berupa unix timestamp
+		ExpiresAt: time.Now().Add(time.Duration(ttl) * time.Minute).Truncate(time.Second),
+		OneTime:   in.OneTime,
+		CreatedBy: userInfo.ID,
+	}
+	if in.DocumentType != models.DocumentTypeSalesReportExcel {
+		link.ResourceID = in.ResourceID
+	}
+
+	if _, err := s.DocumentLinkRepository.Insert(nil, link); err != nil {
+		return nil, err
+	}
+	return s.mapDocumentLinkToResponse(link), nil
+}
+
+func (s *DocumentLinkService) RevokeDocumentLink(linkId string, userInfo *models.User) (*models.ResponseGetDocumentLink, error) {
+	link, err := s.DocumentLinkRepository.FindById(nil, linkId, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeDocumentType(link.DocumentType, userInfo); err != nil {
+		return nil, err
+	}
+	if link.RevokedAt == nil {
+		now := time.Now()
+		link.RevokedAt = &now
+		if _, err := s.DocumentLinkRepository.Update(nil, link); err != nil {
+			return nil, err
+		}
+	}
+	return s.mapDocumentLinkToResponse(link), nil
+}
+
+// ResolveDocumentLink memverifikasi tanda tangan & status link tanpa menandainya terpakai;
+// pemanggil membuat dokumennya dulu lalu memanggil RecordDocumentLinkAccess.
+func (s *DocumentLinkService) ResolveDocumentLink(linkId string, in *models.DocumentLinkAccessRequest) (*models.DocumentLink, error) {
+	if !helpers.VerifyDocumentLink(linkId, in.Expires, in.Signature) {
+		return nil, ErrDocumentLinkInvalid
+	}
+	now := time.Now()
+	if now.Unix() > in.Expires {
+		return nil, ErrDocumentLinkExpired
+	}
+
+	link, err := s.DocumentLinkRepository.FindById(nil, linkId, false)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDocumentLinkNotFound) {
+			return nil, ErrDocumentLinkInvalid
+		}
+		return nil, err
+	}
+
+	if link.ExpiresAt.Unix() != in.Expires {
+		return nil, ErrDocumentLinkInvalid
+	}
+	if err := checkDocumentLinkUsable(link, now); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// RecordDocumentLinkAccess mencatat akses setelah dokumen berhasil dibuat, sehingga link one-time
+// tidak hangus bila pembuatan dokumen gagal. Status dicek ulang dengan FOR UPDATE supaya dua
+// request bersamaan tidak sama-sama lolos.
+func (s *DocumentLinkService) RecordDocumentLinkAccess(linkId string, ip string) error {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	link, err := s.DocumentLinkRepository.FindById(tx, linkId, true)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	now := time.Now()
+	if err := checkDocumentLinkUsable(link, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if link.UsedAt == nil {
+		link.UsedAt = &now
+	}
+	link.AccessCount++
+	link.LastAccessedIP = ip
+	if _, err := s.DocumentLinkRepository.Update(tx, link); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func checkDocumentLinkUsable(link *models.DocumentLink, now time.Time) error {
+	if link.RevokedAt != nil || now.After(link.ExpiresAt) || (link.OneTime && link.UsedAt != nil) {
+		return ErrDocumentLinkExpired
+	}
+	return nil
+}
+
+// authorizeDocumentType memastikan role user punya akses ke modul pemilik dokumen
+// (developer selalu lolos, sama seperti RBACMiddleware).
+func (s *DocumentLinkService) authorizeDocumentType(documentType string, userInfo *models.User) error {
+	if userInfo.Role != nil && strings.EqualFold(userInfo.Role.Name, "developer") {
+		return nil
+	}
+	moduleName, ok := documentLinkModules[documentType]
+	if !ok || userInfo.RoleID == nil {
+		return ErrDocumentLinkForbidden
+	}
+	allowed, err := s.RoleModuleRepository.HasModuleAccess(nil, *userInfo.RoleID, moduleName)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrDocumentLinkForbidden
+	}
+	return nil
+}
+
+func (s *DocumentLinkService) mapDocumentLinkToResponse(link *models.DocumentLink) *models.ResponseGetDocumentLink {
+	return &models.ResponseGetDocumentLink{
+		ID:           link.ID,
+		DocumentType: link.DocumentType,
+		ResourceID:   link.ResourceID,
+		Query:        link.Query,
+		URL:          buildDocumentLinkURL(link),
+		ExpiresAt:    link.ExpiresAt,
+		OneTime:      link.OneTime,
+		UsedAt:       link.UsedAt,
+		RevokedAt:    link.RevokedAt,
+		CreatedBy:    link.CreatedBy,
+		CreatedAt:    link.CreatedAt,
+	}
+}
+
+func buildDocumentLinkURL(link *models.DocumentLink) string {
+	expires := link.ExpiresAt.Unix()
+	values := url.Values{}
+	values.Set("expires", fmt.Sprintf("%d", expires))
+	values.Set("signature", helpers.SignDocumentLink(link.ID.String(), expires))
+
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv("BASE_API")), "/")
+	return fmt.Sprintf("%s/api/v1/shared-document/%s?%s", base, link.ID, values.Encode())
+}
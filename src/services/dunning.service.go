@@ -0,0 +1,236 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+)
+
+type DunningService struct {
+	DunningReminderRepository repositories.DunningReminderRepository
+	SalesOrderRepository      repositories.SalesOrderRepository
+	UserRepository            repositories.UserRepository
+	MailSender                helpers.MailSender
+
+	// SendNotification mengirim notifikasi in-app ke satu user (default helpers.SendNotificationToUser).
+	SendNotification func(userID uuid.UUID, notifType, title, message string, metadata map[string]interface{}) error
+}
+
+func NewDunningService(
+	dunningReminderRepo repositories.DunningReminderRepository,
+	soRepo repositories.SalesOrderRepository,
+	userRepo repositories.UserRepository,
+	mailSender helpers.MailSender,
+) *DunningService {
+	return &DunningService{
+		DunningReminderRepository: dunningReminderRepo,
+		SalesOrderRepository:      soRepo,
+		UserRepository:            userRepo,
+		MailSender:                mailSender,
+		SendNotification:          helpers.SendNotificationToUser,
+	}
+}
+
+func (s *DunningService) GetAllDunningRemindersPaginated(req *models.PaginationRequest) (*models.DunningReminderPaginatedResponse, error) {
+	normalizePagination(req)
+
+	rows, total, err := s.DunningReminderRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DunningReminderPaginatedResponse{
+		Data:       rows,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+// RunDunning mengirim pengingat untuk SO belum lunas yang lewat jatuh tempo. Tiap SO hanya
+// menerima tahap tertinggi yang sudah dicapai dan belum pernah dikirim; tahap yang terlewat
+// (mis. job tidak jalan beberapa hari) tidak dikirim mundur. Tahap yang email-nya gagal
+// dikirim ulang pada run berikutnya.
+func (s *DunningService) RunDunning(now time.Time, stages []int) ([]models.DunningReminder, error) {
+	if len(stages) == 0 {
+		return nil, nil
+	}
+
+	today := startOfDay(now)
+	sos, err := s.DunningReminderRepository.FindOverdueSalesOrders(nil, today)
+	if err != nil {
+		return nil, fmt.Errorf("query overdue sales orders: %w", err)
+	}
+	if len(sos) == 0 {
+		return nil, nil
+	}
+
+	soIDs := make([]uuid.UUID, 0, len(sos))
+	for _, so := range sos {
+		soIDs = append(soIDs, so.ID)
+	}
+	history, err := s.DunningReminderRepository.FindBySalesOrders(nil, soIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query reminder history: %w", err)
+	}
+	lastStage := make(map[uuid.UUID]int, len(history))
+	for _, h := range history {
+		if h.EmailStatus == "Failed" {
+			continue
+		}
+		if h.Stage > lastStage[h.SalesOrderID] {
+			lastStage[h.SalesOrderID] = h.Stage
+		}
+	}
+
+	sent := make([]models.DunningReminder, 0)
+	for _, so := range sos {
+		daysOverdue := int(today.Sub(startOfDay(*so.DueDate)).Hours() / 24)
+
+		stage, level := 0, 0
+		for i, st := range stages {
+			if daysOverdue >= st {
+				stage, level = st, i+1
+			}
+		}
+		if stage == 0 || stage <= lastStage[so.ID] {
+			continue
+		}
+
+		reminder := &models.DunningReminder{
+			ID:           uuid.New(),
+			SalesOrderID: so.ID,
+			CustomerID:   so.CustomerID,
+			Stage:        stage,
+			Level:        level,
+			DaysOverdue:  daysOverdue,
+			Outstanding:  so.TotalAmount - so.PaidAmount,
+			EmailStatus:  "Pending",
+			SentAt:       now,
+		}
+
+		// klaim tahap lebih dulu; instance job lain yang berjalan bersamaan akan dilewati
+		claimed, err := s.DunningReminderRepository.InsertIfMissing(nil, reminder)
+		if err != nil {
+			log.Printf("[Dunning] failed to record reminder for SO %s: %v\n", so.SONumber, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		s.sendCustomerReminder(&so, reminder, level == len(stages))
+		reminder.NotifiedUserID = s.notifySalesPerson(&so, reminder)
+
+		if _, err := s.DunningReminderRepository.Update(nil, reminder); err != nil {
+			log.Printf("[Dunning] failed to update reminder for SO %s: %v\n", so.SONumber, err)
+		}
+		sent = append(sent, *reminder)
+	}
+
+	return sent, nil
+}
+
+// sendCustomerReminder mengirim email pengingat ke customer dan mencatat hasilnya di reminder
+// (Sent / Failed / Skipped bila customer tidak punya email). PDF invoice hanya dilampirkan bila
+// invoice SO sudah diterbitkan; job tidak menerbitkan nomor invoice sendiri.
+func (s *DunningService) sendCustomerReminder(so *models.SalesOrder, reminder *models.DunningReminder, final bool) {
+	if so.Customer.Email == nil || strings.TrimSpace(*so.Customer.Email) == "" {
+		reminder.EmailStatus = "Skipped"
+		reminder.EmailError = "customer has no email"
+		return
+	}
+	reminder.EmailTo = strings.TrimSpace(*so.Customer.Email)
+
+	msg := buildDunningMail(so, reminder, final)
+
+	if so.InvoiceNumber != nil {
+		full, err := s.SalesOrderRepository.FindById(nil, so.ID.String(), false)
+		if err != nil {
+			log.Printf("[Dunning] failed to load SO %s for invoice: %v\n", so.SONumber, err)
+		} else {
+			if filename, data, err := documents.GenerateInvoicePDF(full); err == nil {
+				msg.Attachments = append(msg.Attachments, helpers.MailAttachment{
+					Filename:    filename,
+					ContentType: "application/pdf",
+					Data:        data,
+				})
+			} else {
+				log.Printf("[Dunning] failed to generate invoice for SO %s: %v\n", so.SONumber, err)
+			}
+		}
+	}
+
+	if err := s.MailSender.Send(msg); err != nil {
+		reminder.EmailStatus = "Failed"
+		reminder.EmailError = err.Error()
+		return
+	}
+	reminder.EmailStatus = "Sent"
+}
+
+// notifySalesPerson memberi notifikasi in-app ke akun user sales person SO (dicocokkan lewat email).
+// Bila sales person belum punya akun, notifikasi dikirim ke role finance.
+func (s *DunningService) notifySalesPerson(so *models.SalesOrder, reminder *models.DunningReminder) *uuid.UUID {
+	title := fmt.Sprintf("Tagihan Lewat Jatuh Tempo: %s", so.SONumber)
+	msg := fmt.Sprintf("Pengingat ke-%d untuk %s: %s lewat jatuh tempo %d hari, sisa tagihan Rp %d.",
+		reminder.Level, so.Customer.Name, so.SONumber, reminder.DaysOverdue, reminder.Outstanding)
+	metadata := map[string]interface{}{
+		"sales_order_id": so.ID.String(),
+		"so_number":      so.SONumber,
+		"customer_id":    so.CustomerID.String(),
+		"customer_name":  so.Customer.Name,
+		"due_date":       so.DueDate.Format(time.RFC3339),
+		"days_overdue":   reminder.DaysOverdue,
+		"stage":          reminder.Stage,
+		"level":          reminder.Level,
+		"outstanding":    reminder.Outstanding,
+		"email_status":   reminder.EmailStatus,
+	}
+
+	if so.SalesPerson.Email != nil && strings.TrimSpace(*so.SalesPerson.Email) != "" {
+		if user, err := s.UserRepository.FindByEmailOrUsername(nil, strings.TrimSpace(*so.SalesPerson.Email)); err == nil && user != nil {
+			if err := s.SendNotification(user.ID, "so_overdue", title, msg, metadata); err != nil {
+				log.Printf("[Dunning] failed to notify sales person for SO %s: %v\n", so.SONumber, err)
+				return nil
+			}
+			return &user.ID
+		}
+	}
+
+	if err := helpers.SendNotificationAuto("so_overdue", title, msg, metadata); err != nil {
+		log.Printf("[Dunning] failed to send notif for SO %s: %v\n", so.SONumber, err)
+	}
+	return nil
+}
+
+func buildDunningMail(so *models.SalesOrder, reminder *models.DunningReminder, final bool) helpers.MailMessage {
+	subject := fmt.Sprintf("Pengingat Pembayaran Invoice %s", so.SONumber)
+	closing := "Mohon abaikan email ini apabila pembayaran sudah dilakukan."
+	if final {
+		subject = fmt.Sprintf("Peringatan Terakhir Pembayaran Invoice %s", so.SONumber)
+		closing = "Ini adalah pengingat terakhir. Mohon segera lakukan pembayaran atau hubungi kami untuk konfirmasi."
+	}
+
+	text := fmt.Sprintf(`Yth. %s,
+
+Invoice %s tertanggal %s telah melewati jatuh tempo pada %s (%d hari).
+Sisa tagihan yang belum dibayar: Rp %d.
+
+Invoice terlampir untuk referensi Anda.
+
+%s
+`, so.Customer.Name, so.SONumber, so.SODate.In(jakartaLoc()).Format("02 January 2006"),
+		so.DueDate.In(jakartaLoc()).Format("02 January 2006"), reminder.DaysOverdue, reminder.Outstanding, closing)
+
+	return helpers.MailMessage{
+		To:       []string{reminder.EmailTo},
+		Subject:  subject,
+		TextBody: text,
+	}
+}
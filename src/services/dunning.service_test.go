@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// stubUserRepository hanya mengimplementasikan lookup yang dipakai notifySalesPerson.
+type stubUserRepository struct {
+	repositories.UserRepository
+	user *models.User
+}
+
+func (r *stubUserRepository) FindByEmailOrUsername(tx *gorm.DB, identifier string) (*models.User, error) {
+	return r.user, nil
+}
+
+func newOverdueSalesOrder() *models.SalesOrder {
+	email := "sales@example.com"
+	due := time.Now().AddDate(0, 0, -10)
+	return &models.SalesOrder{
+		ID:          uuid.New(),
+		SONumber:    "SO-0001",
+		CustomerID:  uuid.New(),
+		DueDate:     &due,
+		Customer:    models.Customer{Name: "PT Contoh"},
+		SalesPerson: models.SalesPerson{Email: &email},
+	}
+}
+
+func TestNotifySalesPersonReturnsNilWhenNotificationFails(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	s := &DunningService{
+		UserRepository: &stubUserRepository{user: user},
+		SendNotification: func(uuid.UUID, string, string, string, map[string]interface{}) error {
+			return errors.New("insert notification failed")
+		},
+	}
+
+	reminder := &models.DunningReminder{Stage: 7, Level: 1, DaysOverdue: 10, Outstanding: 1000}
+	reminder.NotifiedUserID = s.notifySalesPerson(newOverdueSalesOrder(), reminder)
+
+	if reminder.NotifiedUserID != nil {
+		t.Fatalf("expected NotifiedUserID to stay nil when the notification write fails, got %s", reminder.NotifiedUserID)
+	}
+}
+
+func TestNotifySalesPersonRecordsRecipientOnSuccess(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	var notified uuid.UUID
+	s := &DunningService{
+		UserRepository: &stubUserRepository{user: user},
+		SendNotification: func(userID uuid.UUID, _, _, _ string, _ map[string]interface{}) error {
+			notified = userID
+			return nil
+		},
+	}
+
+	reminder := &models.DunningReminder{Stage: 7, Level: 1, DaysOverdue: 10, Outstanding: 1000}
+	reminder.NotifiedUserID = s.notifySalesPerson(newOverdueSalesOrder(), reminder)
+
+	if reminder.NotifiedUserID == nil || *reminder.NotifiedUserID != user.ID {
+		t.Fatalf("expected NotifiedUserID %s, got %v", user.ID, reminder.NotifiedUserID)
+	}
+	if notified != user.ID {
+		t.Fatalf("expected notification for %s, got %s", user.ID, notified)
+	}
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type GoodsReceiptService struct {
+	GoodsReceiptRepository  repositories.GoodsReceiptRepository
+	PurchaseOrderRepository repositories.PurchaseOrderRepository
+	ItemRepository          repositories.ItemRepository
+	ItemLotService          *ItemLotService
+	NumberSequenceService   *NumberSequenceService
+}
+
+func NewGoodsReceiptService(
+	goodsReceiptRepo repositories.GoodsReceiptRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *GoodsReceiptService {
+	return &GoodsReceiptService{
+		GoodsReceiptRepository:  goodsReceiptRepo,
+		PurchaseOrderRepository: poRepo,
+		ItemRepository:          itemRepo,
+		ItemLotService:          NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService:   NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *GoodsReceiptService) GetAllGoodsReceiptsPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.GoodsReceiptPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.GoodsReceiptRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetGoodsReceipt, 0, len(rows))
+	for _, gr := range rows {
+		data = append(data, s.mapGoodsReceiptToResponse(gr))
+	}
+
+	return &models.GoodsReceiptPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *GoodsReceiptService) GetGoodsReceiptByID(receiptId string) (*models.ResponseGetGoodsReceipt, error) {
+	gr, err := s.GoodsReceiptRepository.FindById(nil, receiptId)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapGoodsReceiptToResponse(*gr)
+	return &out, nil
+}
+
+// GetReceiptsByPurchaseOrder mengembalikan riwayat kedatangan barang untuk satu PO.
+func (s *GoodsReceiptService) GetReceiptsByPurchaseOrder(poId string) ([]models.ResponseGetGoodsReceipt, error) {
+	if _, err := s.PurchaseOrderRepository.FindById(nil, poId, false); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.GoodsReceiptRepository.FindByPurchaseOrder(nil, poId)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.ResponseGetGoodsReceipt, 0, len(rows))
+	for _, gr := range rows {
+		out = append(out, s.mapGoodsReceiptToResponse(gr))
+	}
+	return out, nil
+}
+
+func (s *GoodsReceiptService) GenerateGoodsReceiptNote(receiptId string) (string, []byte, error) {
+	gr, err := s.GoodsReceiptRepository.FindById(nil, receiptId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filename, data, err := documents.GenerateGoodsReceiptPDF(gr)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate goods receipt PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+// CreateGoodsReceipt mencatat satu kedatangan barang atas PO sebagai dokumen GRN. Qty diterima
+// langsung masuk ke lot (termasuk penerimaan parsial); qty ditolak hanya dicatat di baris PO.
+func (s *GoodsReceiptService) CreateGoodsReceipt(poId string, req *models.ReceiveItemsRequest, userInfo *models.User) (*models.GoodsReceipt, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// kunci header PO supaya dua GRN bersamaan tidak melebihi qty order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&models.PurchaseOrder{}, "id = ?", poId).Error; err != nil {
+		tx.Rollback()
+		return nil, repositories.HandleDatabaseError(err, "purchase_order")
+	}
+
+	po, err := s.PurchaseOrderRepository.FindById(tx, poId, false)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if po.POStatus != "Ordered" && po.POStatus != "Partial" {
+		tx.Rollback()
+		return nil, errors.New("can only receive items for purchase orders in 'Ordered' or 'Partial' status")
+	}
+
+	warehouse, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	grNumber, err := s.NumberSequenceService.Next(tx, models.SequenceGoodsReceipt)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating goods receipt number: %w", err)
+	}
+
+	receiptDate := time.Now()
+	if req.ReceiptDate != nil && !req.ReceiptDate.IsZero() {
+		receiptDate = *req.ReceiptDate
+	}
+	receiverName := strings.TrimSpace(req.ReceiverName)
+	if receiverName == "" {
+		receiverName = userInfo.Name
+	}
+
+	receipt := &models.GoodsReceipt{
+		ID:                 uuid.New(),
+		GRNumber:           grNumber,
+		PurchaseOrderID:    po.ID,
+		SupplierID:         po.SupplierID,
+		WarehouseID:        warehouse.ID,
+		ReceiptDate:        receiptDate,
+		DeliveryNoteNumber: strings.TrimSpace(req.DeliveryNoteNumber),
+		VehicleNumber:      strings.TrimSpace(req.VehicleNumber),
+		ReceiverName:       receiverName,
+		ReceivedBy:         &userInfo.ID,
+		Notes:              strings.TrimSpace(req.Notes),
+	}
+
+	poItems := make(map[uuid.UUID]*models.PurchaseOrderItem, len(po.PurchaseOrderItems))
+	for i := range po.PurchaseOrderItems {
+		poItems[po.PurchaseOrderItems[i].ID] = &po.PurchaseOrderItems[i]
+	}
+
+	for _, line := range req.Items {
+		poItem, ok := poItems[line.PurchaseOrderItemID]
+		if !ok {
+			tx.Rollback()
+			return nil, fmt.Errorf("purchase order item %s does not belong to PO %s", line.PurchaseOrderItemID, po.PONumber)
+		}
+
+		deltaRecv := line.ReceivedQuantity
+		deltaRet := line.ReturnedQuantity
+		if deltaRecv < 0 || deltaRet < 0 {
+			tx.Rollback()
+			return nil, errors.New("quantities must be non-negative")
+		}
+		if deltaRecv == 0 && deltaRet == 0 {
+			continue
+		}
+
+		newRecv := poItem.ReceivedQuantity + deltaRecv
+		newRet := poItem.ReturnedQuantity + deltaRet
+		if newRecv+newRet > poItem.Quantity {
+			tx.Rollback()
+			return nil, fmt.Errorf("received + returned quantity for %s cannot exceed ordered quantity (ordered %d, processed %d)",
+				poItem.Item.Name, poItem.Quantity, newRecv+newRet)
+		}
+
+		poItem.ReceivedQuantity = newRecv
+		poItem.ReturnedQuantity = newRet
+		poItem.Status = purchaseOrderItemStatusFor(poItem)
+		if err := tx.Omit(clause.Associations).Save(poItem).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error updating purchase order item: %w", err)
+		}
+
+		grItem := models.GoodsReceiptItem{
+			ID:                  uuid.New(),
+			GoodsReceiptID:      receipt.ID,
+			PurchaseOrderItemID: poItem.ID,
+			ItemID:              poItem.ItemID,
+			ReceivedQuantity:    deltaRecv,
+			RejectedQuantity:    deltaRet,
+			ExpiredAt:           line.ExpiredAt,
+			Notes:               strings.TrimSpace(line.Notes),
+		}
+
+		// setiap qty yang diterima langsung masuk ke lot (stock = total lot)
+		if deltaRecv > 0 {
+			item, err := s.ItemRepository.FindById(tx, poItem.ItemID.String(), false)
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("item not found: %w", err)
+			}
+
+			lotNumber := strings.TrimSpace(line.LotNumber)
+			if lotNumber == "" {
+				lotNumber = po.PONumber
+			}
+
+			lotReceipt := models.LotReceipt{
+				WarehouseID:         warehouse.ID,
+				LotNumber:           lotNumber,
+				ExpiredAt:           line.ExpiredAt,
+				Quantity:            deltaRecv,
+				PurchaseOrderItemID: &poItem.ID,
+				Notes:               fmt.Sprintf("Received from %s (%s)", po.PONumber, grNumber),
+			}
+			desc := fmt.Sprintf("PO received: +%d units (%s, %s) into %s", deltaRecv, po.PONumber, grNumber, warehouse.Name)
+			lot, err := s.ItemLotService.ReceiveLot(tx, item, lotReceipt, desc, userInfo.ID)
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error receiving stock for item %s: %w", item.Name, err)
+			}
+			grItem.LotNumber = lotNumber
+			grItem.LotID = &lot.ID
+		}
+
+		receipt.TotalReceived += deltaRecv
+		receipt.TotalRejected += deltaRet
+		receipt.GoodsReceiptItems = append(receipt.GoodsReceiptItems, grItem)
+	}
+
+	if len(receipt.GoodsReceiptItems) == 0 {
+		tx.Rollback()
+		return nil, errors.New("nothing to receive: all quantities are zero")
+	}
+
+	if _, err := s.GoodsReceiptRepository.Insert(tx, receipt); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating goods receipt: %w", err)
+	}
+
+	if err := s.PurchaseOrderRepository.UpdateStatus(tx, poId, purchaseOrderStatusFor(po.PurchaseOrderItems), ""); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating purchase order status: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GoodsReceiptRepository.FindById(nil, receipt.ID.String())
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *GoodsReceiptService) mapGoodsReceiptToResponse(gr models.GoodsReceipt) models.ResponseGetGoodsReceipt {
+	return models.ResponseGetGoodsReceipt{
+		ID:                 gr.ID,
+		GRNumber:           gr.GRNumber,
+		PurchaseOrderID:    gr.PurchaseOrderID,
+		SupplierID:         gr.SupplierID,
+		WarehouseID:        gr.WarehouseID,
+		ReceiptDate:        gr.ReceiptDate,
+		DeliveryNoteNumber: gr.DeliveryNoteNumber,
+		VehicleNumber:      gr.VehicleNumber,
+		ReceiverName:       gr.ReceiverName,
+		ReceivedBy:         gr.ReceivedBy,
+		TotalReceived:      gr.TotalReceived,
+		TotalRejected:      gr.TotalRejected,
+		Notes:              gr.Notes,
+		CreatedAt:          gr.CreatedAt,
+		UpdatedAt:          gr.UpdatedAt,
+		DeletedAt:          gr.DeletedAt,
+		PurchaseOrder:      gr.PurchaseOrder,
+		Supplier:           gr.Supplier,
+		Warehouse:          gr.Warehouse,
+		ReceivedByUser:     gr.ReceivedByUser,
+		GoodsReceiptItems:  gr.GoodsReceiptItems,
+	}
+}
+
+func purchaseOrderItemStatusFor(it *models.PurchaseOrderItem) string {
+	switch {
+	case it.ReceivedQuantity+it.ReturnedQuantity == 0:
+		return "Ordered"
+	case it.ReturnedQuantity == it.Quantity:
+		return "Returned"
+	case it.ReceivedQuantity == it.Quantity:
+		return "Received"
+	default:
+		return "Partial"
+	}
+}
+
+// purchaseOrderStatusFor menurunkan status PO dari seluruh barisnya (bukan hanya baris di GRN ini).
+func purchaseOrderStatusFor(items []models.PurchaseOrderItem) string {
+	allReceived, allReturned, allOrdered := true, true, true
+	for _, it := range items {
+		if it.Status != "Received" {
+			allReceived = false
+		}
+		if it.Status != "Returned" {
+			allReturned = false
+		}
+		if it.Status != "Ordered" {
+			allOrdered = false
+		}
+	}
+
+	switch {
+	case allReceived:
+		return "Received"
+	case allReturned:
+		return "Returned"
+	case allOrdered:
+		return "Ordered"
+	default:
+		return "Partial"
+	}
+}
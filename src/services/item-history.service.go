@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
@@ -17,12 +18,14 @@ import (
 type ItemHistoryService struct {
 	ItemHistoryRepository repositories.ItemHistoryRepository
 	ItemRepository        repositories.ItemRepository
+	ItemLotService        *ItemLotService
 }
 
-func NewItemHistoryService(itemHistoryRepo repositories.ItemHistoryRepository, itemRepo repositories.ItemRepository) *ItemHistoryService {
+func NewItemHistoryService(itemHistoryRepo repositories.ItemHistoryRepository, itemRepo repositories.ItemRepository, itemLotRepo repositories.ItemLotRepository, itemStockRepo repositories.ItemStockRepository, warehouseRepo repositories.WarehouseRepository) *ItemHistoryService {
 	return &ItemHistoryService{
 		ItemHistoryRepository: itemHistoryRepo,
 		ItemRepository:        itemRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
 	}
 }
 
@@ -41,6 +44,7 @@ func (service *ItemHistoryService) GetAllItemHistories(itemID uuid.UUID, changeT
 		resp = append(resp, models.ResponseGetItemHistory{
 			ID:            h.ID,
 			ItemID:        h.ItemID,
+			LotID:         h.LotID,
 			ChangeType:    h.ChangeType,
 			Description:   h.Description,
 			OldPrice:      h.OldPrice,
@@ -68,6 +72,7 @@ func (service *ItemHistoryService) GetAllItemHistories(itemID uuid.UUID, changeT
 				return uuid.Nil
 			}(),
 			Item:           h.Item,
+			Lot:            h.Lot,
 			CreatedByUser:  h.CreatedByUser,
 			UpdatedByUser:  h.UpdatedByUser,
 			CreatedAt:      h.CreatedAt,
@@ -102,6 +107,7 @@ func (service *ItemHistoryService) GetAllItemHistoriesPaginated(req *models.Pagi
 		data = append(data, models.ResponseGetItemHistory{
 			ID:            h.ID,
 			ItemID:        h.ItemID,
+			LotID:         h.LotID,
 			ChangeType:    h.ChangeType,
 			Description:   h.Description,
 			OldPrice:      h.OldPrice,
@@ -129,6 +135,7 @@ func (service *ItemHistoryService) GetAllItemHistoriesPaginated(req *models.Pagi
 				return uuid.Nil
 			}(),
 			Item:           h.Item,
+			Lot:            h.Lot,
 			CreatedByUser:  h.CreatedByUser,
 			UpdatedByUser:  h.UpdatedByUser,
 			CreatedAt:      h.CreatedAt,
@@ -213,9 +220,16 @@ func (service *ItemHistoryService) CreateItemHistory(req *models.ItemHistoryCrea
 		newH.CurrentPrice = req.NewPrice
 		item.Price = req.NewPrice
 	case "create_stock", "update_stock":
-		newH.NewStock = req.NewStock
-		newH.CurrentStock = req.NewStock
-		item.Stock = req.NewStock
+		// stok = total lot, jadi koreksi stok dijalankan lewat lot (history dicatat di sana)
+		created, err := service.adjustStockByLot(tx, item, req, userInfo)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return created, nil
 	default:
 		tx.Rollback()
 		return nil, fmt.Errorf("invalid change_type '%s'", changeType)
@@ -240,6 +254,56 @@ func (service *ItemHistoryService) CreateItemHistory(req *models.ItemHistoryCrea
 	return created, nil
 }
 
+func (service *ItemHistoryService) adjustStockByLot(tx *gorm.DB, item *models.Item, req *models.ItemHistoryCreateRequest, userInfo *models.User) (*models.ItemHistory, error) {
+	if req.NewStock < 0 {
+		return nil, errors.New("new stock cannot be negative")
+	}
+
+	warehouse, err := service.ItemLotService.ResolveWarehouse(tx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// koreksi dihitung terhadap saldo gudang yang dipilih
+	current, err := service.ItemLotService.ItemLotRepository.SumQuantityByItemAndWarehouse(tx, item.ID, warehouse.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := req.NewStock - current
+	switch {
+	case delta > 0:
+		lotNumber := strings.TrimSpace(req.LotNumber)
+		if lotNumber == "" {
+			lotNumber = "ADJ-" + time.Now().Format("20060102")
+		}
+		receipt := models.LotReceipt{
+			WarehouseID: warehouse.ID,
+			LotNumber:   lotNumber,
+			ExpiredAt:   req.ExpiredAt,
+			Quantity:    delta,
+			Notes:       req.Description,
+		}
+		if _, err := service.ItemLotService.ReceiveLot(tx, item, receipt, req.Description, userInfo.ID); err != nil {
+			return nil, err
+		}
+	case delta < 0:
+		if strings.TrimSpace(req.LotNumber) != "" {
+			if _, err := service.ItemLotService.TakeFromLot(tx, item, warehouse.ID, req.LotNumber, -delta, req.Description, userInfo.ID); err != nil {
+				return nil, err
+			}
+		} else {
+			if _, err := service.ItemLotService.ConsumeFEFO(tx, item, warehouse.ID, -delta, req.Description, userInfo.ID); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.New("new stock is the same as current stock")
+	}
+
+	return service.ItemHistoryRepository.FindLastByItem(tx, item.ID, "stock")
+}
+
 func (service *ItemHistoryService) DeleteItemHistories(req *models.ItemHistoryIsHardDeleteRequest, ctx *fiber.Ctx, userInfo *models.User) error {
 	if len(req.IDs) == 0 {
 		return errors.New("itemHistoryIds cannot be empty")
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ItemLotService struct {
+	ItemLotRepository     repositories.ItemLotRepository
+	ItemStockRepository   repositories.ItemStockRepository
+	WarehouseRepository   repositories.WarehouseRepository
+	ItemRepository        repositories.ItemRepository
+	ItemHistoryRepository repositories.ItemHistoryRepository
+}
+
+func NewItemLotService(
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+) *ItemLotService {
+	return &ItemLotService{
+		ItemLotRepository:     itemLotRepo,
+		ItemStockRepository:   itemStockRepo,
+		WarehouseRepository:   warehouseRepo,
+		ItemRepository:        itemRepo,
+		ItemHistoryRepository: itemHistoryRepo,
+	}
+}
+
+// ==============================
+// Reads (tanpa transaksi)
+// ==============================
+
+func (s *ItemLotService) GetLotsByItem(itemId string, includeEmpty bool) ([]models.ResponseGetItemLot, error) {
+	item, err := s.ItemRepository.FindById(nil, itemId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lots, err := s.ItemLotRepository.FindAllByItem(nil, item.ID, includeEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	out := make([]models.ResponseGetItemLot, 0, len(lots))
+	for _, l := range lots {
+		out = append(out, models.ResponseGetItemLot{
+			ID:                  l.ID,
+			ItemID:              l.ItemID,
+			WarehouseID:         l.WarehouseID,
+			LotNumber:           l.LotNumber,
+			ExpiredAt:           l.ExpiredAt,
+			Quantity:            l.Quantity,
+			ReceivedAt:          l.ReceivedAt,
+			PurchaseOrderItemID: l.PurchaseOrderItemID,
+			Notes:               l.Notes,
+			IsExpired:           l.ExpiredAt != nil && l.ExpiredAt.Before(now),
+			CreatedAt:           l.CreatedAt,
+			UpdatedAt:           l.UpdatedAt,
+			Warehouse:           l.Warehouse,
+		})
+	}
+	return out, nil
+}
+
+func (s *ItemLotService) GetStocksByItem(itemId string) ([]models.ResponseGetItemStock, error) {
+	item, err := s.ItemRepository.FindById(nil, itemId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := s.ItemStockRepository.FindAllByItem(nil, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.ResponseGetItemStock, 0, len(stocks))
+	for _, st := range stocks {
+		wh := st.Warehouse
+		out = append(out, models.ResponseGetItemStock{
+			ID:          st.ID,
+			ItemID:      st.ItemID,
+			WarehouseID: st.WarehouseID,
+			Stock:       st.Stock,
+			UpdatedAt:   st.UpdatedAt,
+			Warehouse:   &wh,
+		})
+	}
+	return out, nil
+}
+
+// GetNearExpiryStock mengembalikan lot yang expired dalam `days` hari ke depan (opsional
+// termasuk yang sudah expired) beserta nilainya at cost. Hari dihitung per tanggal di zona asOf.
+func (s *ItemLotService) GetNearExpiryStock(days int, warehouseID *uuid.UUID, includeExpired bool, asOf time.Time) (*models.NearExpiryReport, error) {
+	if days <= 0 {
+		days = helpers.ExpiryAlertDays()[0]
+	}
+
+	loc := asOf.Location()
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, loc)
+	until := today.AddDate(0, 0, days+1).Add(-time.Nanosecond)
+
+	lots, err := s.ItemLotRepository.FindExpiringUntil(nil, until, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	lotIDs := make([]uuid.UUID, 0, len(lots))
+	for _, l := range lots {
+		lotIDs = append(lotIDs, l.ID)
+	}
+	costs, err := s.ItemLotRepository.FindUnitCosts(nil, lotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.NearExpiryReport{
+		AsOf: asOf,
+		Days: days,
+		Lots: make([]models.ResponseGetNearExpiryLot, 0, len(lots)),
+	}
+	for _, l := range lots {
+		exp := l.ExpiredAt.In(loc)
+		expDay := time.Date(exp.Year(), exp.Month(), exp.Day(), 0, 0, 0, 0, loc)
+		daysLeft := int(expDay.Sub(today).Hours() / 24)
+		isExpired := l.ExpiredAt.Before(asOf)
+		if isExpired && !includeExpired {
+			continue
+		}
+
+		unitCost, source := l.Item.Price, "item_price"
+		if c, ok := costs[l.ID]; ok && c.UnitCost != nil {
+			unitCost, source = *c.UnitCost, c.Source
+		}
+
+		row := models.ResponseGetNearExpiryLot{
+			LotID:       l.ID,
+			ItemID:      l.ItemID,
+			ItemCode:    l.Item.Code,
+			ItemName:    l.Item.Name,
+			UoM:         l.Item.UoM.Name,
+			WarehouseID: l.WarehouseID,
+			LotNumber:   l.LotNumber,
+			ExpiredAt:   l.ExpiredAt,
+			DaysLeft:    daysLeft,
+			IsExpired:   isExpired,
+			Quantity:    l.Quantity,
+			UnitCost:    unitCost,
+			CostValue:   unitCost * l.Quantity,
+			CostSource:  source,
+		}
+		if l.Warehouse != nil {
+			row.WarehouseName = l.Warehouse.Name
+		}
+
+		report.Lots = append(report.Lots, row)
+		report.TotalLots++
+		report.TotalQuantity += row.Quantity
+		report.TotalValue += row.CostValue
+		if isExpired {
+			report.ExpiredLots++
+			report.ExpiredValue += row.CostValue
+		}
+	}
+	return report, nil
+}
+
+// ResolveWarehouse mengembalikan gudang yang dipilih, atau gudang default bila kosong.
+func (s *ItemLotService) ResolveWarehouse(tx *gorm.DB, warehouseID *uuid.UUID) (*models.Warehouse, error) {
+	if warehouseID == nil || *warehouseID == uuid.Nil {
+		wh, err := s.WarehouseRepository.FindDefault(tx)
+		if err != nil {
+			if errors.Is(err, repositories.ErrWarehouseNotFound) {
+				return nil, errors.New("no default warehouse configured, please select a warehouse")
+			}
+			return nil, fmt.Errorf("error loading default warehouse: %w", err)
+		}
+		return wh, nil
+	}
+
+	wh, err := s.WarehouseRepository.FindById(tx, warehouseID.String(), false)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWarehouseNotFound) {
+			return nil, errors.New("warehouse not found")
+		}
+		return nil, fmt.Errorf("error loading warehouse: %w", err)
+	}
+	return wh, nil
+}
+
+// ==============================
+// Stock movements (tx wajib; dipanggil dari service lain)
+// ==============================
+
+// ReceiveLot menambah qty ke lot di gudang tujuan (dibuat bila belum ada), sinkron
+// saldo gudang & Item.Stock dengan total lot, lalu mencatat history yang merujuk lot tersebut.
+func (s *ItemLotService) ReceiveLot(tx *gorm.DB, item *models.Item, in models.LotReceipt, description string, userID uuid.UUID) (*models.ItemLot, error) {
+	return s.receiveLot(tx, item, in, "", description, userID)
+}
+
+// AdjustLot menerapkan koreksi qty (delta +/-) pada satu lot dan mencatat history dengan
+// change type tertentu (mis. "stock_opname"). Delta positif membuat lot bila belum ada.
+func (s *ItemLotService) AdjustLot(tx *gorm.DB, item *models.Item, warehouseID uuid.UUID, lotNumber string, expiredAt *time.Time, delta int, changeType, description string, userID uuid.UUID) (*models.ItemLot, error) {
+	if delta > 0 {
+		return s.receiveLot(tx, item, models.LotReceipt{
+			WarehouseID: warehouseID,
+			LotNumber:   lotNumber,
+			ExpiredAt:   expiredAt,
+			Quantity:    delta,
+		}, changeType, description, userID)
+	}
+	if delta == 0 {
+		return nil, errors.New("adjustment quantity cannot be zero")
+	}
+
+	lot, err := s.ItemLotRepository.FindByItemAndLotNumber(tx, item.ID, warehouseID, strings.TrimSpace(lotNumber))
+	if err != nil {
+		if errors.Is(err, repositories.ErrItemLotNotFound) {
+			return nil, fmt.Errorf("lot %s not found for item %s in selected warehouse", lotNumber, item.Name)
+		}
+		return nil, fmt.Errorf("error loading lot %s: %w", lotNumber, err)
+	}
+	if lot.Quantity < -delta {
+		return nil, fmt.Errorf("insufficient stock in lot %s: available %d, required %d", lot.LotNumber, lot.Quantity, -delta)
+	}
+
+	if err := s.deductLot(tx, item, lot, -delta, changeType, description, userID); err != nil {
+		return nil, err
+	}
+	if err := s.syncItemStock(tx, item, warehouseID); err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+func (s *ItemLotService) receiveLot(tx *gorm.DB, item *models.Item, in models.LotReceipt, changeType, description string, userID uuid.UUID) (*models.ItemLot, error) {
+	if in.Quantity <= 0 {
+		return nil, errors.New("lot quantity must be greater than zero")
+	}
+	if in.WarehouseID == uuid.Nil {
+		return nil, errors.New("warehouse is required")
+	}
+	lotNumber := strings.TrimSpace(in.LotNumber)
+	if lotNumber == "" {
+		return nil, errors.New("lot number is required")
+	}
+
+	lot, err := s.ItemLotRepository.FindByItemAndLotNumber(tx, item.ID, in.WarehouseID, lotNumber)
+	switch {
+	case err == nil:
+		if in.ExpiredAt != nil && lot.ExpiredAt != nil && !sameDay(*in.ExpiredAt, *lot.ExpiredAt) {
+			return nil, fmt.Errorf("lot %s for item %s already registered with expiry %s",
+				lotNumber, item.Name, lot.ExpiredAt.Format("2006-01-02"))
+		}
+		if lot.ExpiredAt == nil {
+			lot.ExpiredAt = in.ExpiredAt
+		}
+		lot.Quantity += in.Quantity
+		if _, err := s.ItemLotRepository.Update(tx, lot); err != nil {
+			return nil, fmt.Errorf("error updating lot %s: %w", lotNumber, err)
+		}
+	case errors.Is(err, repositories.ErrItemLotNotFound):
+		lot = &models.ItemLot{
+			ID:                  uuid.New(),
+			ItemID:              item.ID,
+			WarehouseID:         in.WarehouseID,
+			LotNumber:           lotNumber,
+			ExpiredAt:           in.ExpiredAt,
+			Quantity:            in.Quantity,
+			ReceivedAt:          time.Now(),
+			PurchaseOrderItemID: in.PurchaseOrderItemID,
+			Notes:               in.Notes,
+		}
+		if _, err := s.ItemLotRepository.Insert(tx, lot); err != nil {
+			return nil, fmt.Errorf("error creating lot %s: %w", lotNumber, err)
+		}
+	default:
+		return nil, fmt.Errorf("error checking lot %s: %w", lotNumber, err)
+	}
+
+	oldStock := item.Stock
+	if err := s.syncItemStock(tx, item, in.WarehouseID); err != nil {
+		return nil, err
+	}
+	if err := s.writeStockHistory(tx, changeType, item.ID, &lot.ID, &in.WarehouseID, oldStock, item.Stock, description, userID); err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// ConsumeFEFO mengambil qty dari lot di satu gudang yang belum expired, expiry terdekat dulu.
+// Satu baris history dicatat per lot yang tersentuh.
+func (s *ItemLotService) ConsumeFEFO(tx *gorm.DB, item *models.Item, warehouseID uuid.UUID, qty int, description string, userID uuid.UUID) ([]models.LotConsumption, error) {
+	if qty <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+
+	lots, err := s.ItemLotRepository.FindAvailableFEFO(tx, item.ID, warehouseID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error loading lots for item %s: %w", item.Name, err)
+	}
+
+	available := 0
+	for _, l := range lots {
+		available += l.Quantity
+	}
+	if available < qty {
+		return nil, fmt.Errorf("insufficient non-expired stock for item %s in selected warehouse: available %d, required %d",
+			item.Name, available, qty)
+	}
+
+	remaining := qty
+	consumed := make([]models.LotConsumption, 0)
+	for i := range lots {
+		if remaining == 0 {
+			break
+		}
+		take := lots[i].Quantity
+		if take > remaining {
+			take = remaining
+		}
+		if err := s.deductLot(tx, item, &lots[i], take, "", description, userID); err != nil {
+			return nil, err
+		}
+		consumed = append(consumed, models.LotConsumption{
+			LotID:       lots[i].ID,
+			WarehouseID: warehouseID,
+			LotNumber:   lots[i].LotNumber,
+			ExpiredAt:   lots[i].ExpiredAt,
+			Quantity:    take,
+		})
+		remaining -= take
+	}
+
+	if err := s.syncItemStock(tx, item, warehouseID); err != nil {
+		return nil, err
+	}
+	return consumed, nil
+}
+
+// TakeFromLot mengurangi qty dari satu lot tertentu (mis. koreksi / pemusnahan lot expired).
+func (s *ItemLotService) TakeFromLot(tx *gorm.DB, item *models.Item, warehouseID uuid.UUID, lotNumber string, qty int, description string, userID uuid.UUID) (*models.ItemLot, error) {
+	if qty <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+	lot, err := s.ItemLotRepository.FindByItemAndLotNumber(tx, item.ID, warehouseID, strings.TrimSpace(lotNumber))
+	if err != nil {
+		if errors.Is(err, repositories.ErrItemLotNotFound) {
+			return nil, fmt.Errorf("lot %s not found for item %s in selected warehouse", lotNumber, item.Name)
+		}
+		return nil, fmt.Errorf("error loading lot %s: %w", lotNumber, err)
+	}
+	if lot.Quantity < qty {
+		return nil, fmt.Errorf("insufficient stock in lot %s: available %d, required %d", lot.LotNumber, lot.Quantity, qty)
+	}
+
+	if err := s.deductLot(tx, item, lot, qty, "", description, userID); err != nil {
+		return nil, err
+	}
+	if err := s.syncItemStock(tx, item, warehouseID); err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *ItemLotService) deductLot(tx *gorm.DB, item *models.Item, lot *models.ItemLot, qty int, changeType, description string, userID uuid.UUID) error {
+	lot.Quantity -= qty
+	if _, err := s.ItemLotRepository.Update(tx, lot); err != nil {
+		return fmt.Errorf("error updating lot %s: %w", lot.LotNumber, err)
+	}
+
+	oldStock := item.Stock
+	item.Stock -= qty
+	desc := fmt.Sprintf("%s [lot %s]", description, lot.LotNumber)
+	return s.writeStockHistory(tx, changeType, item.ID, &lot.ID, &lot.WarehouseID, oldStock, item.Stock, desc, userID)
+}
+
+// syncItemStock menjaga saldo ItemStock gudang = total lot di gudang tsb,
+// dan Item.Stock = total qty seluruh lot di semua gudang.
+func (s *ItemLotService) syncItemStock(tx *gorm.DB, item *models.Item, warehouseID uuid.UUID) error {
+	whTotal, err := s.ItemLotRepository.SumQuantityByItemAndWarehouse(tx, item.ID, warehouseID)
+	if err != nil {
+		return fmt.Errorf("error summing lots for item %s: %w", item.Name, err)
+	}
+	if err := s.ItemStockRepository.Upsert(tx, item.ID, warehouseID, whTotal); err != nil {
+		return fmt.Errorf("error updating warehouse stock for item %s: %w", item.Name, err)
+	}
+
+	total, err := s.ItemLotRepository.SumQuantityByItem(tx, item.ID)
+	if err != nil {
+		return fmt.Errorf("error summing lots for item %s: %w", item.Name, err)
+	}
+	item.Stock = total
+	if _, err := s.ItemRepository.Update(tx, item); err != nil {
+		return fmt.Errorf("error updating stock for item %s: %w", item.Name, err)
+	}
+	return nil
+}
+
+// writeStockHistory mencatat perubahan stok; changeType kosong = create_stock/update_stock otomatis.
+func (s *ItemLotService) writeStockHistory(tx *gorm.DB, changeType string, itemID uuid.UUID, lotID, warehouseID *uuid.UUID, oldStock, newStock int, description string, userID uuid.UUID) error {
+	if changeType == "" {
+		changeType = "update_stock"
+		if _, err := s.ItemHistoryRepository.FindLastByItem(tx, itemID, "stock"); err != nil {
+			if !errors.Is(err, repositories.ErrItemHistoryNotFound) {
+				return fmt.Errorf("error querying last stock history: %w", err)
+			}
+			changeType = "create_stock"
+			oldStock = 0
+		}
+	}
+
+	hist := models.ItemHistory{
+		ID:           uuid.New(),
+		ItemID:       itemID,
+		LotID:        lotID,
+		WarehouseID:  warehouseID,
+		ChangeType:   changeType,
+		OldStock:     oldStock,
+		NewStock:     newStock,
+		CurrentStock: newStock,
+		Description:  description,
+		CreatedBy:    &userID,
+		UpdatedBy:    &userID,
+	}
+	if _, err := s.ItemHistoryRepository.Insert(tx, &hist); err != nil {
+		return fmt.Errorf("error creating item history: %w", err)
+	}
+	return nil
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
@@ -19,13 +20,15 @@ type ItemService struct {
 	UploadRepository      repositories.UploadRepository
 	ItemRepository        repositories.ItemRepository
 	ItemHistoryRepository repositories.ItemHistoryRepository
+	ItemLotService        *ItemLotService
 }
 
-func NewItemService(uploadRepo repositories.UploadRepository, itemRepo repositories.ItemRepository, itemHistoryRepo repositories.ItemHistoryRepository) *ItemService {
+func NewItemService(uploadRepo repositories.UploadRepository, itemRepo repositories.ItemRepository, itemHistoryRepo repositories.ItemHistoryRepository, itemLotRepo repositories.ItemLotRepository, itemStockRepo repositories.ItemStockRepository, warehouseRepo repositories.WarehouseRepository) *ItemService {
 	return &ItemService{
 		UploadRepository:      uploadRepo,
 		ItemRepository:        itemRepo,
 		ItemHistoryRepository: itemHistoryRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
 	}
 }
 
@@ -56,6 +59,8 @@ func (s *ItemService) GetAllItems() ([]models.ResponseGetItem, error) {
 			Batch: 					it.Batch,
 			ExpiredAt: 		it.ExpiredAt,
 			Stock:         it.Stock,
+			ReservedStock:  it.ReservedStock,
+			AvailableStock: it.Stock - it.ReservedStock,
 			LowStock:      it.LowStock,
 			ItemHistories: it.ItemHistories,
 			CreatedAt:     it.CreatedAt,
@@ -101,9 +106,12 @@ func (s *ItemService) GetAllItemsPaginated(req *models.PaginationRequest, userIn
 			Description:   it.Description,
 			Batch: 					it.Batch,
 			IsConsignment: it.IsConsignment,
+			TaxExempt:     it.TaxExempt,
 			DueDate:       it.DueDate,
 			ExpiredAt: 		it.ExpiredAt,
 			Stock:         it.Stock,
+			ReservedStock:  it.ReservedStock,
+			AvailableStock: it.Stock - it.ReservedStock,
 			LowStock:      it.LowStock,
 			ItemHistories: it.ItemHistories,
 			CreatedAt:     it.CreatedAt,
@@ -144,9 +152,12 @@ func (s *ItemService) GetItemByID(itemId string) (*models.ResponseGetItem, error
 			Description:   it.Description,
 			Batch: 					it.Batch,
 			IsConsignment: it.IsConsignment,
+			TaxExempt:     it.TaxExempt,
 			DueDate:       it.DueDate,
 			ExpiredAt: 		it.ExpiredAt,
 			Stock:         it.Stock,
+			ReservedStock:  it.ReservedStock,
+			AvailableStock: it.Stock - it.ReservedStock,
 			LowStock:      it.LowStock,
 			ItemHistories: it.ItemHistories,
 	}, nil
@@ -193,6 +204,7 @@ func (s *ItemService) CreateItem(req *models.ItemCreateRequest, ctx *fiber.Ctx,
 		Description: req.Description,
 		Batch:       req.Batch,
 		IsConsignment: req.IsConsignment,
+		TaxExempt:     req.TaxExempt,
 		DueDate:     req.DueDate,
 		ExpiredAt:   req.ExpiredAt,
 	}
@@ -223,6 +235,51 @@ func (s *ItemService) CreateItem(req *models.ItemCreateRequest, ctx *fiber.Ctx,
 		return nil, err
 	}
 
+	// stok awal masuk sebagai lot pertama (batch & expired dari request) di gudang terpilih
+	var initialLotID, initialWarehouseID *uuid.UUID
+	if created.Stock > 0 {
+		warehouse, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID)
+		if err != nil {
+			tx.Rollback()
+			if newImageUUIDStr != "" {
+				helpers.DeleteLocalFileImmediate(newImageUUIDStr)
+			}
+			return nil, err
+		}
+
+		var expiredAt *time.Time
+		if !req.ExpiredAt.IsZero() {
+			exp := req.ExpiredAt
+			expiredAt = &exp
+		}
+		lot := &models.ItemLot{
+			ID:          uuid.New(),
+			ItemID:      created.ID,
+			WarehouseID: warehouse.ID,
+			LotNumber:   strconv.Itoa(req.Batch),
+			ExpiredAt:   expiredAt,
+			Quantity:    created.Stock,
+			ReceivedAt:  time.Now(),
+			Notes:       "Initial stock",
+		}
+		if _, err := s.ItemLotService.ItemLotRepository.Insert(tx, lot); err != nil {
+			tx.Rollback()
+			if newImageUUIDStr != "" {
+				helpers.DeleteLocalFileImmediate(newImageUUIDStr)
+			}
+			return nil, fmt.Errorf("failed to create initial lot: %w", err)
+		}
+		if err := s.ItemLotService.ItemStockRepository.Upsert(tx, created.ID, warehouse.ID, created.Stock); err != nil {
+			tx.Rollback()
+			if newImageUUIDStr != "" {
+				helpers.DeleteLocalFileImmediate(newImageUUIDStr)
+			}
+			return nil, fmt.Errorf("failed to create warehouse stock: %w", err)
+		}
+		initialLotID = &lot.ID
+		initialWarehouseID = &warehouse.ID
+	}
+
 	// histories (atomic bareng create)
 	if _, err := s.ItemHistoryRepository.Insert(tx, &models.ItemHistory{
 		ID:           uuid.New(),
@@ -244,6 +301,8 @@ func (s *ItemService) CreateItem(req *models.ItemCreateRequest, ctx *fiber.Ctx,
 	if _, err := s.ItemHistoryRepository.Insert(tx, &models.ItemHistory{
 		ID:           uuid.New(),
 		ItemID:       created.ID,
+		LotID:        initialLotID,
+		WarehouseID:  initialWarehouseID,
 		ChangeType:   "create_stock",
 		OldStock:     0,
 		NewStock:     created.Stock,
@@ -326,6 +385,9 @@ func (s *ItemService) UpdateItem(req *models.ItemUpdateRequest, itemID string, c
 	if req.IsConsignment != false {
 		item.IsConsignment = req.IsConsignment
 	}
+	if req.TaxExempt != nil {
+		item.TaxExempt = *req.TaxExempt
+	}
 	if !req.DueDate.IsZero() {
 		item.DueDate = req.DueDate
 	}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultSequence adalah konfigurasi awal per jenis dokumen. Table/Column menunjuk kolom nomor
+// dokumen lama, dipakai untuk melanjutkan nomor yang sudah terbit sebelum counter ada.
+type defaultSequence struct {
+	DocumentType string
+	Name         string
+	Prefix       string
+	Table        string
+	Column       string
+}
+
+var defaultSequences = []defaultSequence{
+	{models.SequencePurchaseOrder, "Purchase Order", "PO", "purchase_orders", "po_number"},
+	{models.SequenceSalesOrder, "Sales Order", "SO", "sales_orders", "so_number"},
+	{models.SequenceSalesQuotation, "Sales Quotation", "QT", "sales_quotations", "quotation_number"},
+	{models.SequenceShipment, "Delivery Order", "DO", "shipments", "shipment_number"},
+	{models.SequenceStockTransfer, "Stock Transfer", "TRF", "stock_transfers", "transfer_number"},
+	{models.SequenceStockOpname, "Stock Opname", "SOP", "stock_opnames", "opname_number"},
+	{models.SequenceGoodsReceipt, "Goods Receipt Note", "GRN", "goods_receipts", "gr_number"},
+	{models.SequenceSalesReturn, "Sales Return", "SR", "sales_returns", "return_number"},
+	{models.SequenceCreditNote, "Credit Note", "CN", "sales_returns", "credit_note_number"},
+	{models.SequencePurchaseReturn, "Purchase Return", "PRT", "purchase_returns", "return_number"},
+	{models.SequenceDebitNote, "Debit Note", "DN", "purchase_returns", "debit_note_number"},
+	{models.SequenceInvoice, "Invoice", "INV", "sales_orders", "invoice_number"},
+	{models.SequencePaymentRun, "Payment Run", "PAY", "payment_runs", "run_number"},
+	{models.SequencePaymentReceipt, "Payment Receipt", "RCP", "payment_receipts", "receipt_number"},
+}
+
+const defaultSequenceFormat = "{PREFIX}-{YYYY}-{SEQ}"
+
+type NumberSequenceService struct {
+	NumberSequenceRepository repositories.NumberSequenceRepository
+}
+
+func NewNumberSequenceService(numberSequenceRepo repositories.NumberSequenceRepository) *NumberSequenceService {
+	return &NumberSequenceService{NumberSequenceRepository: numberSequenceRepo}
+}
+
+// Next memesan nomor berikutnya untuk jenis dokumen. Wajib dipanggil di dalam transaksi
+// pembuat dokumen: counter dikunci sampai commit, dan rollback ikut membatalkan nomornya.
+func (s *NumberSequenceService) Next(tx *gorm.DB, documentType string) (string, error) {
+	if tx == nil {
+		return "", fmt.Errorf("number sequence requires a transaction")
+	}
+
+	seq, err := s.findOrCreateSequence(tx, documentType)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	period := sequencePeriod(seq.ResetPeriod, now)
+
+	counter, created, err := s.NumberSequenceRepository.LockCounter(tx, seq.ID, period)
+	if err != nil {
+		return "", err
+	}
+
+	if created {
+		// counter baru: lanjutkan dari nomor lama yang formatnya sama (data sebelum sequence dipakai)
+		if last, err := s.lastIssuedValue(tx, seq, now); err != nil {
+			return "", err
+		} else if last > counter.LastValue {
+			counter.LastValue = last
+		}
+	}
+
+	counter.LastValue++
+	if err := s.NumberSequenceRepository.UpdateCounter(tx, counter); err != nil {
+		return "", err
+	}
+
+	return renderSequence(seq, now, counter.LastValue), nil
+}
+
+func (s *NumberSequenceService) GetAllNumberSequences() ([]models.ResponseGetNumberSequence, error) {
+	for _, def := range defaultSequences {
+		if _, err := s.findOrCreateSequence(nil, def.DocumentType); err != nil {
+			return nil, err
+		}
+	}
+
+	sequences, err := s.NumberSequenceRepository.FindAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ResponseGetNumberSequence, 0, len(sequences))
+	for i := range sequences {
+		res, err := s.mapNumberSequenceToResponse(&sequences[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *res)
+	}
+	return result, nil
+}
+
+func (s *NumberSequenceService) UpdateNumberSequence(sequenceId string, req *models.NumberSequenceUpdateRequest, userInfo *models.User) (*models.ResponseGetNumberSequence, error) {
+	req.Prefix = strings.TrimSpace(req.Prefix)
+	req.Format = strings.TrimSpace(req.Format)
+	if err := validateSequenceFormat(req.Format, req.ResetPeriod); err != nil {
+		return nil, err
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	seq, err := s.NumberSequenceRepository.FindById(tx, sequenceId)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrNumberSequenceNotFound) {
+			return nil, errors.New("number sequence not found")
+		}
+		return nil, fmt.Errorf("error finding number sequence: %w", err)
+	}
+
+	// counter lama tetap tersimpan per periode; format baru yang prefix-nya sama melanjutkan nomor
+	seq.Prefix = req.Prefix
+	seq.Format = req.Format
+	seq.Padding = req.Padding
+	seq.ResetPeriod = req.ResetPeriod
+	seq.UpdatedBy = &userInfo.ID
+
+	if _, err := s.NumberSequenceRepository.Update(tx, seq); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating number sequence: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return s.mapNumberSequenceToResponse(seq)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *NumberSequenceService) findOrCreateSequence(tx *gorm.DB, documentType string) (*models.NumberSequence, error) {
+	seq, err := s.NumberSequenceRepository.FindByDocumentType(tx, documentType)
+	if err == nil {
+		return seq, nil
+	}
+	if !errors.Is(err, repositories.ErrNumberSequenceNotFound) {
+		return nil, fmt.Errorf("error finding number sequence: %w", err)
+	}
+
+	def, ok := findDefaultSequence(documentType)
+	if !ok {
+		return nil, fmt.Errorf("unknown document type for numbering: %s", documentType)
+	}
+
+	if err := s.NumberSequenceRepository.InsertIfMissing(tx, &models.NumberSequence{
+		ID:           uuid.New(),
+		DocumentType: def.DocumentType,
+		Name:         def.Name,
+		Prefix:       def.Prefix,
+		Format:       defaultSequenceFormat,
+		Padding:      4,
+		ResetPeriod:  "Yearly",
+	}); err != nil {
+		return nil, fmt.Errorf("error creating number sequence: %w", err)
+	}
+
+	// baca ulang: bisa jadi baris dibuat oleh request lain secara bersamaan
+	return s.NumberSequenceRepository.FindByDocumentType(tx, documentType)
+}
+
+// lastIssuedValue mengembalikan angka SEQ terbesar yang sudah terbit di tabel dokumen untuk
+// format & periode saat ini. Hanya berlaku bila {SEQ} berada di akhir format.
+func (s *NumberSequenceService) lastIssuedValue(tx *gorm.DB, seq *models.NumberSequence, now time.Time) (int, error) {
+	def, ok := findDefaultSequence(seq.DocumentType)
+	if !ok || def.Table == "" {
+		return 0, nil
+	}
+
+	rendered := renderSequenceTokens(seq, now)
+	if !strings.HasSuffix(rendered, "{SEQ}") {
+		return 0, nil
+	}
+	prefix := strings.TrimSuffix(rendered, "{SEQ}")
+
+	last, err := s.NumberSequenceRepository.FindLastNumber(tx, def.Table, def.Column, prefix)
+	if err != nil || last == "" {
+		return 0, err
+	}
+
+	value, convErr := strconv.Atoi(strings.TrimPrefix(last, prefix))
+	if convErr != nil {
+		return 0, nil
+	}
+	return value, nil
+}
+
+func (s *NumberSequenceService) mapNumberSequenceToResponse(seq *models.NumberSequence) (*models.ResponseGetNumberSequence, error) {
+	now := time.Now()
+	current := 0
+	counter, err := s.NumberSequenceRepository.FindCounter(nil, seq.ID, sequencePeriod(seq.ResetPeriod, now))
+	if err != nil {
+		return nil, err
+	}
+	if counter != nil {
+		current = counter.LastValue
+	}
+
+	return &models.ResponseGetNumberSequence{
+		ID:           seq.ID,
+		DocumentType: seq.DocumentType,
+		Name:         seq.Name,
+		Prefix:       seq.Prefix,
+		Format:       seq.Format,
+		Padding:      seq.Padding,
+		ResetPeriod:  seq.ResetPeriod,
+		CurrentValue: current,
+		NextNumber:   renderSequence(seq, now, current+1),
+		UpdatedBy:    seq.UpdatedBy,
+		CreatedAt:    seq.CreatedAt,
+		UpdatedAt:    seq.UpdatedAt,
+	}, nil
+}
+
+func findDefaultSequence(documentType string) (defaultSequence, bool) {
+	for _, def := range defaultSequences {
+		if def.DocumentType == documentType {
+			return def, true
+		}
+	}
+	return defaultSequence{}, false
+}
+
+func sequencePeriod(resetPeriod string, now time.Time) string {
+	switch resetPeriod {
+	case "Monthly":
+		return now.Format("2006-01")
+	case "Yearly":
+		return now.Format("2006")
+	default:
+		return ""
+	}
+}
+
+// renderSequenceTokens mengisi semua token kecuali {SEQ}.
+func renderSequenceTokens(seq *models.NumberSequence, now time.Time) string {
+	return strings.NewReplacer(
+		"{PREFIX}", seq.Prefix,
+		"{YYYY}", now.Format("2006"),
+		"{YY}", now.Format("06"),
+		"{MM}", now.Format("01"),
+	).Replace(seq.Format)
+}
+
+func renderSequence(seq *models.NumberSequence, now time.Time, value int) string {
+	return strings.ReplaceAll(renderSequenceTokens(seq, now), "{SEQ}", fmt.Sprintf("%0*d", seq.Padding, value))
+}
+
+func validateSequenceFormat(format, resetPeriod string) error {
+	if strings.Count(format, "{SEQ}") != 1 {
+		return errors.New("format must contain {SEQ} exactly once")
+	}
+	hasYear := strings.Contains(format, "{YYYY}") || strings.Contains(format, "{YY}")
+	switch resetPeriod {
+	case "Yearly":
+		if !hasYear {
+			return errors.New("yearly reset requires {YYYY} or {YY} in the format")
+		}
+	case "Monthly":
+		if !hasYear || !strings.Contains(format, "{MM}") {
+			return errors.New("monthly reset requires a year token and {MM} in the format")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import "github.com/SalmanDMA/inventory-app/backend/src/models"
+
+// normalizePagination mengisi default page (1) dan limit (10), serta membatasi limit maksimal 100.
+func normalizePagination(req *models.PaginationRequest) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+}
+
+// paginationResponse menyusun metadata halaman dari request yang sudah dinormalisasi dan total record.
+func paginationResponse(req *models.PaginationRequest, total int64) models.PaginationResponse {
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+	return models.PaginationResponse{
+		CurrentPage:  req.Page,
+		PerPage:      req.Limit,
+		TotalPages:   totalPages,
+		TotalRecords: total,
+		HasNext:      req.Page < totalPages,
+		HasPrev:      req.Page > 1,
+	}
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentReceiptService struct {
+	PaymentReceiptRepository repositories.PaymentReceiptRepository
+	PaymentService           *PaymentService
+	NumberSequenceService    *NumberSequenceService
+}
+
+func NewPaymentReceiptService(
+	paymentReceiptRepo repositories.PaymentReceiptRepository,
+	paymentRepo repositories.PaymentRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	soRepo repositories.SalesOrderRepository,
+	uploadRepo repositories.UploadRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *PaymentReceiptService {
+	return &PaymentReceiptService{
+		PaymentReceiptRepository: paymentReceiptRepo,
+		PaymentService:           NewPaymentService(paymentRepo, poRepo, soRepo, uploadRepo),
+		NumberSequenceService:    NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *PaymentReceiptService) GetAllPaymentReceiptsPaginated(req *models.PaginationRequest) (*models.PaymentReceiptPaginatedResponse, error) {
+	normalizePagination(req)
+
+	rows, total, err := s.PaymentReceiptRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentReceiptPaginatedResponse{
+		Data:       rows,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *PaymentReceiptService) GetPaymentReceiptByID(receiptId string) (*models.PaymentReceipt, error) {
+	return s.PaymentReceiptRepository.FindById(nil, receiptId, false)
+}
+
+// GetDeposits merangkum saldo deposit (receipt yang belum habis dialokasikan) per customer / supplier.
+func (s *PaymentReceiptService) GetDeposits(req *models.PaymentDepositRequest) ([]models.PaymentDepositBalance, error) {
+	receipts, err := s.PaymentReceiptRepository.FindWithBalance(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	byParty := make(map[string]*models.PaymentDepositBalance)
+	order := make([]string, 0)
+	for _, rc := range receipts {
+		var (
+			partyID   uuid.UUID
+			partyName string
+		)
+		switch {
+		case rc.OrderType == "SO" && rc.CustomerID != nil:
+			partyID = *rc.CustomerID
+			if rc.Customer != nil {
+				partyName = rc.Customer.Name
+			}
+		case rc.OrderType == "PO" && rc.SupplierID != nil:
+			partyID = *rc.SupplierID
+			if rc.Supplier != nil {
+				partyName = rc.Supplier.Name
+			}
+		default:
+			continue
+		}
+
+		key := rc.OrderType + ":" + partyID.String()
+		bal, ok := byParty[key]
+		if !ok {
+			bal = &models.PaymentDepositBalance{
+				OrderType: rc.OrderType,
+				PartyID:   partyID,
+				PartyName: partyName,
+				Receipts:  make([]models.PaymentReceipt, 0),
+			}
+			byParty[key] = bal
+			order = append(order, key)
+		}
+		bal.Balance += rc.UnallocatedAmount
+		bal.Receipts = append(bal.Receipts, rc)
+	}
+
+	result := make([]models.PaymentDepositBalance, 0, len(order))
+	for _, key := range order {
+		result = append(result, *byParty[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].PartyName < result[j].PartyName })
+	return result, nil
+}
+
+// CreatePaymentReceipt mencatat satu transfer lalu langsung mengalokasikannya ke order yang diminta.
+// Sisa yang tidak dialokasikan disimpan sebagai deposit customer / supplier.
+func (s *PaymentReceiptService) CreatePaymentReceipt(req *models.PaymentReceiptCreateRequest, userInfo *models.User) (*models.PaymentReceipt, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	receipt, err := s.CreateReceipt(tx, req, userInfo)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.PaymentReceiptRepository.FindById(nil, receipt.ID.String(), false)
+}
+
+// CreateReceipt adalah inti CreatePaymentReceipt di dalam transaksi pemanggil, dipakai juga
+// oleh alur lain yang membuat pembayaran dari satu transfer (mis. rekonsiliasi bank).
+func (s *PaymentReceiptService) CreateReceipt(tx *gorm.DB, req *models.PaymentReceiptCreateRequest, userInfo *models.User) (*models.PaymentReceipt, error) {
+	receipt := &models.PaymentReceipt{
+		ID:                uuid.New(),
+		OrderType:         req.OrderType,
+		ReceiptDate:       req.ReceiptDate,
+		Amount:            req.Amount,
+		UnallocatedAmount: req.Amount,
+		PaymentMethod:     strings.TrimSpace(req.PaymentMethod),
+		ReferenceNumber:   strings.TrimSpace(req.ReferenceNumber),
+		Notes:             req.Notes,
+	}
+	if userInfo != nil {
+		receipt.CreatedBy = &userInfo.ID
+	}
+
+	switch req.OrderType {
+	case "SO":
+		if req.CustomerID == nil || *req.CustomerID == uuid.Nil {
+			return nil, errors.New("customer_id is required for SO receipts")
+		}
+		var customer models.Customer
+		if err := tx.First(&customer, "id = ?", *req.CustomerID).Error; err != nil {
+			return nil, repositories.HandleDatabaseError(err, "customer")
+		}
+		receipt.CustomerID = &customer.ID
+	case "PO":
+		if req.SupplierID == nil || *req.SupplierID == uuid.Nil {
+			return nil, errors.New("supplier_id is required for PO receipts")
+		}
+		var supplier models.Supplier
+		if err := tx.First(&supplier, "id = ?", *req.SupplierID).Error; err != nil {
+			return nil, repositories.HandleDatabaseError(err, "supplier")
+		}
+		receipt.SupplierID = &supplier.ID
+	default:
+		return nil, errors.New("order_type must be either PO or SO")
+	}
+
+	receiptNumber, err := s.NumberSequenceService.Next(tx, models.SequencePaymentReceipt)
+	if err != nil {
+		return nil, fmt.Errorf("error generating receipt number: %w", err)
+	}
+	receipt.ReceiptNumber = receiptNumber
+	if receipt.ReferenceNumber == "" {
+		receipt.ReferenceNumber = receiptNumber
+	}
+
+	if _, err := s.PaymentReceiptRepository.Insert(tx, receipt); err != nil {
+		return nil, fmt.Errorf("error creating payment receipt: %w", err)
+	}
+
+	if len(req.Allocations) > 0 {
+		if err := s.allocate(tx, receipt, req.Allocations, receipt.ReceiptDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return receipt, nil
+}
+
+// AllocatePaymentReceipt memakai saldo deposit receipt untuk melunasi order (biasanya order baru).
+func (s *PaymentReceiptService) AllocatePaymentReceipt(receiptId string, req *models.PaymentReceiptAllocateRequest, userInfo *models.User) (*models.PaymentReceipt, error) {
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	receipt, err := s.PaymentReceiptRepository.FindById(tx, receiptId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if receipt.UnallocatedAmount <= 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("payment receipt %s has no unallocated balance", receipt.ReceiptNumber)
+	}
+
+	paymentDate := time.Now()
+	if req.PaymentDate != nil {
+		paymentDate = *req.PaymentDate
+	}
+
+	if err := s.allocate(tx, receipt, req.Allocations, paymentDate); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.PaymentReceiptRepository.FindById(nil, receipt.ID.String(), false)
+}
+
+// allocate membuat satu Payment per order lewat PaymentService.RecordPayment lalu mengurangi saldo
+// receipt. Order harus milik customer / supplier yang sama dengan receipt dan sudah bisa ditagih.
+func (s *PaymentReceiptService) allocate(tx *gorm.DB, receipt *models.PaymentReceipt, allocations []models.PaymentAllocationRequest, paymentDate time.Time) error {
+	seen := make(map[uuid.UUID]bool, len(allocations))
+
+	for _, al := range allocations {
+		if seen[al.OrderID] {
+			return fmt.Errorf("order %s is allocated more than once", al.OrderID)
+		}
+		seen[al.OrderID] = true
+
+		var (
+			orderNumber string
+			outstanding int
+		)
+		payment := &models.Payment{
+			ID:              uuid.New(),
+			OrderType:       receipt.OrderType,
+			PaymentDate:     paymentDate,
+			PaymentMethod:   receipt.PaymentMethod,
+			ReferenceNumber: receipt.ReferenceNumber,
+			Notes:           fmt.Sprintf("Receipt %s", receipt.ReceiptNumber),
+			ReceiptID:       &receipt.ID,
+		}
+
+		switch receipt.OrderType {
+		case "SO":
+			var so models.SalesOrder
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				First(&so, "id = ?", al.OrderID).Error; err != nil {
+				return repositories.HandleDatabaseError(err, "sales_order")
+			}
+			if receipt.CustomerID == nil || so.CustomerID != *receipt.CustomerID {
+				return fmt.Errorf("sales order %s belongs to another customer", so.SONumber)
+			}
+			switch so.SOStatus {
+			case "Draft", "Cancelled":
+				return fmt.Errorf("sales order %s with status %s cannot be paid", so.SONumber, so.SOStatus)
+			}
+			orderNumber, outstanding = so.SONumber, so.TotalAmount-so.PaidAmount
+			payment.SalesOrderID = &so.ID
+
+		case "PO":
+			var po models.PurchaseOrder
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				First(&po, "id = ?", al.OrderID).Error; err != nil {
+				return repositories.HandleDatabaseError(err, "purchase_order")
+			}
+			if receipt.SupplierID == nil || po.SupplierID != *receipt.SupplierID {
+				return fmt.Errorf("purchase order %s belongs to another supplier", po.PONumber)
+			}
+			switch po.POStatus {
+			case "Draft", "PendingApproval", "Approved", "Rejected":
+				return fmt.Errorf("purchase order %s with status %s cannot be paid yet", po.PONumber, po.POStatus)
+			}
+			orderNumber, outstanding = po.PONumber, po.TotalAmount-po.PaidAmount
+			payment.PurchaseOrderID = &po.ID
+		}
+
+		if outstanding <= 0 {
+			return fmt.Errorf("%s is already fully paid", orderNumber)
+		}
+
+		amount := al.Amount
+		if amount == 0 {
+			amount = min(outstanding, receipt.UnallocatedAmount)
+		}
+		if amount > receipt.UnallocatedAmount {
+			return fmt.Errorf("allocation for %s (%d) exceeds receipt balance (%d)", orderNumber, amount, receipt.UnallocatedAmount)
+		}
+		if amount <= 0 {
+			return fmt.Errorf("payment receipt %s has no balance left for %s", receipt.ReceiptNumber, orderNumber)
+		}
+
+		payment.Amount = amount
+		payment.PaymentType = "Installment"
+		if amount >= outstanding {
+			payment.PaymentType = "Full"
+		}
+
+		if _, err := s.PaymentService.RecordPayment(tx, payment); err != nil {
+			return fmt.Errorf("%s: %w", orderNumber, err)
+		}
+
+		receipt.AllocatedAmount += amount
+		receipt.UnallocatedAmount -= amount
+	}
+
+	if _, err := s.PaymentReceiptRepository.Update(tx, receipt); err != nil {
+		return fmt.Errorf("error updating payment receipt: %w", err)
+	}
+	return nil
+}
+
+// recordOverpaymentDeposit memindahkan kelebihan bayar order (paid > total, mis. retur atas order
+// yang sudah lunas) menjadi deposit customer / supplier: entri Refund negatif di ledger order dan
+// PaymentReceipt tanpa alokasi sebesar kelebihan tersebut. Saldo order diperbarui oleh pemanggil.
+func recordOverpaymentDeposit(
+	tx *gorm.DB,
+	paymentRepo repositories.PaymentRepository,
+	numberSequenceService *NumberSequenceService,
+	orderType string,
+	orderID uuid.UUID,
+	partyID uuid.UUID,
+	amount int,
+	reference string,
+	notes string,
+	userID uuid.UUID,
+) (*models.PaymentReceipt, error) {
+	receiptNumber, err := numberSequenceService.Next(tx, models.SequencePaymentReceipt)
+	if err != nil {
+		return nil, fmt.Errorf("error generating receipt number: %w", err)
+	}
+
+	now := time.Now()
+	receipt := &models.PaymentReceipt{
+		ID:                uuid.New(),
+		ReceiptNumber:     receiptNumber,
+		OrderType:         orderType,
+		ReceiptDate:       now,
+		Amount:            amount,
+		UnallocatedAmount: amount,
+		PaymentMethod:     "Deposit",
+		ReferenceNumber:   reference,
+		Notes:             notes,
+		CreatedBy:         &userID,
+	}
+	payment := &models.Payment{
+		ID:              uuid.New(),
+		OrderType:       orderType,
+		PaymentType:     "Refund",
+		Amount:          -amount,
+		PaymentDate:     now,
+		PaymentMethod:   "Deposit",
+		ReferenceNumber: receiptNumber,
+		Notes:           notes,
+		CreatedBy:       &userID,
+	}
+	if orderType == "PO" {
+		receipt.SupplierID = &partyID
+		payment.PurchaseOrderID = &orderID
+	} else {
+		receipt.CustomerID = &partyID
+		payment.SalesOrderID = &orderID
+	}
+
+	if err := tx.Omit(clause.Associations).Create(receipt).Error; err != nil {
+		return nil, repositories.HandleDatabaseError(err, "payment_receipt")
+	}
+	if _, err := paymentRepo.Insert(tx, payment); err != nil {
+		return nil, fmt.Errorf("error moving overpayment to deposit: %w", err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRunService struct {
+	PaymentRunRepository  repositories.PaymentRunRepository
+	PaymentService        *PaymentService
+	NumberSequenceService *NumberSequenceService
+}
+
+func NewPaymentRunService(
+	paymentRunRepo repositories.PaymentRunRepository,
+	paymentRepo repositories.PaymentRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	soRepo repositories.SalesOrderRepository,
+	uploadRepo repositories.UploadRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *PaymentRunService {
+	return &PaymentRunService{
+		PaymentRunRepository:  paymentRunRepo,
+		PaymentService:        NewPaymentService(paymentRepo, poRepo, soRepo, uploadRepo),
+		NumberSequenceService: NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *PaymentRunService) GetAllPaymentRunsPaginated(req *models.PaginationRequest) (*models.PaymentRunPaginatedResponse, error) {
+	normalizePagination(req)
+
+	rows, total, err := s.PaymentRunRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentRunPaginatedResponse{
+		Data:       rows,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *PaymentRunService) GetPaymentRunByID(runId string) (*models.PaymentRun, error) {
+	return s.PaymentRunRepository.FindById(nil, runId, false)
+}
+
+// CreatePaymentRun menyimpan usulan pembayaran beberapa PO. Nominal per PO default seluruh sisa
+// tagihan; satu PO hanya boleh ada di satu payment run Proposed.
+func (s *PaymentRunService) CreatePaymentRun(req *models.PaymentRunCreateRequest, userInfo *models.User) (*models.PaymentRun, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	run := &models.PaymentRun{
+		ID:            uuid.New(),
+		ScheduledDate: req.ScheduledDate,
+		PaymentMethod: strings.TrimSpace(req.PaymentMethod),
+		Status:        "Proposed",
+		Notes:         req.Notes,
+		CreatedBy:     &userInfo.ID,
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Items))
+	poIDs := make([]uuid.UUID, 0, len(req.Items))
+	for _, it := range req.Items {
+		if seen[it.PurchaseOrderID] {
+			tx.Rollback()
+			return nil, fmt.Errorf("purchase order %s is listed more than once", it.PurchaseOrderID)
+		}
+		seen[it.PurchaseOrderID] = true
+
+		// kunci PO supaya sisa tagihan tidak berubah selama usulan disusun
+		var po models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&po, "id = ?", it.PurchaseOrderID).Error; err != nil {
+			tx.Rollback()
+			return nil, repositories.HandleDatabaseError(err, "purchase_order")
+		}
+
+		switch po.POStatus {
+		case "Draft", "PendingApproval", "Approved", "Rejected":
+			tx.Rollback()
+			return nil, fmt.Errorf("purchase order %s with status %s cannot be paid yet", po.PONumber, po.POStatus)
+		}
+
+		outstanding := po.TotalAmount - po.PaidAmount
+		if outstanding <= 0 {
+			tx.Rollback()
+			return nil, fmt.Errorf("purchase order %s is already fully paid", po.PONumber)
+		}
+
+		amount := it.Amount
+		if amount == 0 {
+			amount = outstanding
+		}
+		if amount > outstanding {
+			tx.Rollback()
+			return nil, fmt.Errorf("amount for %s (%d) exceeds remaining amount (%d)", po.PONumber, amount, outstanding)
+		}
+
+		run.Items = append(run.Items, models.PaymentRunItem{
+			ID:              uuid.New(),
+			PaymentRunID:    run.ID,
+			PurchaseOrderID: po.ID,
+			SupplierID:      po.SupplierID,
+			DueDate:         po.DueDate,
+			Amount:          amount,
+		})
+		run.TotalAmount += amount
+		poIDs = append(poIDs, po.ID)
+	}
+
+	scheduled, err := s.PaymentRunRepository.FindOpenItemsByPurchaseOrders(tx, poIDs, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(scheduled) > 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("purchase order %s is already scheduled in another payment run", scheduled[0].PurchaseOrder.PONumber)
+	}
+
+	runNumber, err := s.NumberSequenceService.Next(tx, models.SequencePaymentRun)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating payment run number: %w", err)
+	}
+	run.RunNumber = runNumber
+
+	if _, err := s.PaymentRunRepository.Insert(tx, run); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating payment run: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.PaymentRunRepository.FindById(nil, run.ID.String(), false)
+}
+
+// ExecutePaymentRun membuat satu Payment per PO dalam satu transaksi; bila salah satu PO gagal
+// (mis. sudah dibayar sebagian lewat jalur lain) seluruh run dibatalkan.
+func (s *PaymentRunService) ExecutePaymentRun(runId string, req *models.PaymentRunExecuteRequest, userInfo *models.User) (*models.PaymentRun, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	run, err := s.PaymentRunRepository.FindById(tx, runId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if run.Status != "Proposed" {
+		tx.Rollback()
+		return nil, fmt.Errorf("payment run with status %s cannot be executed", run.Status)
+	}
+
+	paymentDate := run.ScheduledDate
+	if req.PaymentDate != nil {
+		paymentDate = *req.PaymentDate
+	}
+	reference := strings.TrimSpace(req.ReferenceNumber)
+	if reference == "" {
+		reference = run.RunNumber
+	}
+
+	for i := range run.Items {
+		item := &run.Items[i]
+
+		// sisa hutang dibaca dari PO yang sudah dikunci, bukan dari preload run
+		var po models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&po, "id = ?", item.PurchaseOrderID).Error; err != nil {
+			tx.Rollback()
+			return nil, repositories.HandleDatabaseError(err, "purchase_order")
+		}
+		paymentType := "Installment"
+		if item.Amount >= po.TotalAmount-po.PaidAmount {
+			paymentType = "Full"
+		}
+
+		poID := item.PurchaseOrderID
+		payment, err := s.PaymentService.RecordPayment(tx, &models.Payment{
+			ID:              uuid.New(),
+			OrderType:       "PO",
+			PurchaseOrderID: &poID,
+			PaymentType:     paymentType,
+			Amount:          item.Amount,
+			PaymentDate:     paymentDate,
+			PaymentMethod:   run.PaymentMethod,
+			ReferenceNumber: reference,
+			Notes:           fmt.Sprintf("Payment run %s", run.RunNumber),
+			CreatedBy:       &userInfo.ID,
+		})
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: %w", item.PurchaseOrder.PONumber, err)
+		}
+
+		item.PaymentID = &payment.ID
+		if err := s.PaymentRunRepository.UpdateItem(tx, item); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error updating payment run item: %w", err)
+		}
+	}
+
+	now := time.Now()
+	run.Status = "Executed"
+	run.ExecutedBy = &userInfo.ID
+	run.ExecutedAt = &now
+	if _, err := s.PaymentRunRepository.Update(tx, run); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating payment run: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.PaymentRunRepository.FindById(nil, run.ID.String(), false)
+}
+
+func (s *PaymentRunService) CancelPaymentRun(runId string, userInfo *models.User) (*models.PaymentRun, error) {
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	run, err := s.PaymentRunRepository.FindById(tx, runId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if run.Status != "Proposed" {
+		tx.Rollback()
+		return nil, errors.New("only proposed payment runs can be cancelled")
+	}
+
+	now := time.Now()
+	run.Status = "Cancelled"
+	run.CancelledAt = &now
+	if _, err := s.PaymentRunRepository.Update(tx, run); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating payment run: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.PaymentRunRepository.FindById(nil, run.ID.String(), false)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
@@ -11,6 +12,8 @@ import (
 	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentService struct {
@@ -39,8 +42,6 @@ func (service *PaymentService) CreatePayment(
 	ctx *fiber.Ctx,
 	userInfo *models.User,
 ) (*models.Payment, error) {
-	_ = userInfo
-
 	if paymentRequest.OrderType != "PO" && paymentRequest.OrderType != "SO" {
 		return nil, errors.New("order_type must be either PO or SO")
 	}
@@ -54,37 +55,6 @@ func (service *PaymentService) CreatePayment(
 		return nil, errors.New("payment amount must be greater than 0")
 	}
 
-	var (
-		totalAmount   int
-		paidAmount    int
-		targetOrderID uuid.UUID
-	)
-
-	switch paymentRequest.OrderType {
-	case "PO":
-		po, err := service.PurchaseOrderRepository.FindById(nil, paymentRequest.PurchaseOrderID.String(), true)
-		if err != nil {
-			return nil, errors.New("purchase order not found")
-		}
-		totalAmount = po.TotalAmount
-		paidAmount = po.PaidAmount
-		targetOrderID = po.ID
-
-	case "SO":
-		so, err := service.SalesOrderRepository.FindById(nil, paymentRequest.SalesOrderID.String(), true)
-		if err != nil {
-			return nil, errors.New("sales order not found")
-		}
-		totalAmount = so.TotalAmount
-		paidAmount = so.PaidAmount
-		targetOrderID = so.ID
-	}
-
-	remaining := totalAmount - paidAmount
-	if paymentRequest.Amount > remaining {
-		return nil, fmt.Errorf("payment amount (%d) exceeds remaining amount (%d)", paymentRequest.Amount, remaining)
-	}
-
 	// ---- Siapkan entity Payment
 	newPayment := &models.Payment{
 		ID:              uuid.New(),
@@ -95,6 +65,7 @@ func (service *PaymentService) CreatePayment(
 		PaymentMethod:   paymentRequest.PaymentMethod,
 		ReferenceNumber: paymentRequest.ReferenceNumber,
 		Notes:           paymentRequest.Notes,
+		CreatedBy:       &userInfo.ID,
 	}
 	if paymentRequest.OrderType == "PO" {
 		newPayment.PurchaseOrderID = &paymentRequest.PurchaseOrderID
@@ -140,64 +111,295 @@ func (service *PaymentService) CreatePayment(
 		newPayment.InvoiceID = &invoiceID
 	}
 
-	createdPayment, err := service.PaymentRepository.Insert(tx, newPayment)
+	createdPayment, err := service.RecordPayment(tx, newPayment)
 	if err != nil {
 		_ = tx.Rollback()
 		if invoiceUUIDStr != "" {
 			helpers.DeleteLocalFileImmediate(invoiceUUIDStr)
 		}
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		if invoiceUUIDStr != "" {
+			helpers.DeleteLocalFileImmediate(invoiceUUIDStr)
+		}
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	fresh, err := service.PaymentRepository.FindById(nil, createdPayment.ID.String(), true)
+	if err != nil {
+		log.Printf("Warning: Payment created but failed to fetch created data: %v", err)
+		return createdPayment, nil
+	}
+	return fresh, nil
+}
+
+// RecordPayment menyimpan pembayaran di dalam transaksi pemanggil: order dikunci, nominal dicek
+// terhadap sisa tagihan, lalu PaidAmount / PaymentStatus order diperbarui.
+func (service *PaymentService) RecordPayment(tx *gorm.DB, payment *models.Payment) (*models.Payment, error) {
+	if tx == nil {
+		return nil, errors.New("record payment requires a transaction")
+	}
+	if payment.Amount <= 0 {
+		return nil, errors.New("payment amount must be greater than 0")
+	}
+
+	var (
+		orderModel  interface{}
+		orderID     uuid.UUID
+		totalAmount int
+		paidAmount  int
+	)
+
+	switch payment.OrderType {
+	case "PO":
+		if payment.PurchaseOrderID == nil || *payment.PurchaseOrderID == uuid.Nil {
+			return nil, errors.New("purchase_order_id is required for PO payments")
+		}
+		var po models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&po, "id = ?", *payment.PurchaseOrderID).Error; err != nil {
+			return nil, errors.New("purchase order not found")
+		}
+		orderModel, orderID = &models.PurchaseOrder{}, po.ID
+		totalAmount, paidAmount = po.TotalAmount, po.PaidAmount
+
+	case "SO":
+		if payment.SalesOrderID == nil || *payment.SalesOrderID == uuid.Nil {
+			return nil, errors.New("sales_order_id is required for SO payments")
+		}
+		var so models.SalesOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&so, "id = ?", *payment.SalesOrderID).Error; err != nil {
+			return nil, errors.New("sales order not found")
+		}
+		orderModel, orderID = &models.SalesOrder{}, so.ID
+		totalAmount, paidAmount = so.TotalAmount, so.PaidAmount
+
+	default:
+		return nil, errors.New("order_type must be either PO or SO")
+	}
+
+	remaining := totalAmount - paidAmount
+	if payment.Amount > remaining {
+		return nil, fmt.Errorf("payment amount (%d) exceeds remaining amount (%d)", payment.Amount, remaining)
+	}
+
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	createdPayment, err := service.PaymentRepository.Insert(tx, payment)
+	if err != nil {
 		return nil, fmt.Errorf("error creating payment: %w", err)
 	}
 
-	newPaid := paidAmount + paymentRequest.Amount
+	newPaid := paidAmount + payment.Amount
 	newPayStat := "Partial"
 	if newPaid >= totalAmount {
 		newPayStat = "Paid"
 	}
 
-	switch paymentRequest.OrderType {
-	case "PO":
-		if err := tx.Model(&models.PurchaseOrder{}).
-			Where("id = ?", targetOrderID).
-			Updates(map[string]interface{}{
-				"paid_amount":    newPaid,
-				"payment_status": newPayStat,
-			}).Error; err != nil {
-			_ = tx.Rollback()
-			if invoiceUUIDStr != "" {
-				helpers.DeleteLocalFileImmediate(invoiceUUIDStr)
-			}
+	if err := tx.Model(orderModel).
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"paid_amount":    newPaid,
+			"payment_status": newPayStat,
+		}).Error; err != nil {
+		if payment.OrderType == "PO" {
 			return nil, fmt.Errorf("error updating purchase order: %w", err)
 		}
+		return nil, fmt.Errorf("error updating sales order: %w", err)
+	}
 
-	case "SO":
-		if err := tx.Model(&models.SalesOrder{}).
-			Where("id = ?", targetOrderID).
+	return createdPayment, nil
+}
+
+// VoidPayment membatalkan payment tanpa menghapusnya: payment asli ditandai void, entri Reversal
+// (amount negatif) dicatat dengan alasan & pelaku, lalu saldo order dihitung ulang dari ledger.
+func (service *PaymentService) VoidPayment(
+	paymentId string,
+	voidRequest *models.PaymentVoidRequest,
+	userInfo *models.User,
+) (*models.Payment, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var original models.Payment
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&original, "id = ?", paymentId).Error; err != nil {
+		_ = tx.Rollback()
+		return nil, repositories.HandleDatabaseError(err, "payment")
+	}
+
+	switch {
+	case original.VoidedAt != nil:
+		_ = tx.Rollback()
+		return nil, errors.New("payment is already voided")
+	case original.PaymentType == "Reversal":
+		_ = tx.Rollback()
+		return nil, errors.New("reversal entries cannot be voided")
+	case original.PaymentType == "Refund":
+		_ = tx.Rollback()
+		return nil, errors.New("refund payments follow their return document and cannot be voided here")
+	}
+
+	now := time.Now()
+	reversal := &models.Payment{
+		ID:              uuid.New(),
+		OrderType:       original.OrderType,
+		PurchaseOrderID: original.PurchaseOrderID,
+		SalesOrderID:    original.SalesOrderID,
+		PaymentType:     "Reversal",
+		Amount:          -original.Amount,
+		PaymentDate:     now,
+		PaymentMethod:   original.PaymentMethod,
+		ReferenceNumber: original.ReferenceNumber,
+		Notes:           voidRequest.Reason,
+		ReceiptID:       original.ReceiptID,
+		CreatedBy:       &userInfo.ID,
+		ReversalOfID:    &original.ID,
+	}
+	if _, err := service.PaymentRepository.Insert(tx, reversal); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("error creating reversal entry: %w", err)
+	}
+
+	if err := tx.Model(&models.Payment{}).
+		Where("id = ?", original.ID).
+		Updates(map[string]interface{}{
+			"voided_at":   now,
+			"voided_by":   userInfo.ID,
+			"void_reason": voidRequest.Reason,
+		}).Error; err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("error voiding payment: %w", err)
+	}
+
+	// bukti invoice diarsipkan (soft delete) supaya tidak tampil lagi namun tetap bisa diaudit
+	if original.InvoiceID != nil {
+		if err := service.UploadRepository.Delete(tx, original.InvoiceID.String(), false); err != nil && !errors.Is(err, repositories.ErrUploadNotFound) {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("error archiving invoice: %w", err)
+		}
+	}
+
+	orderID := original.SalesOrderID
+	if original.OrderType == "PO" {
+		orderID = original.PurchaseOrderID
+	}
+	if orderID != nil {
+		if err := service.RecalculateOrderBalance(tx, original.OrderType, *orderID); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// alokasi dari payment receipt kembali menjadi saldo deposit
+	if original.ReceiptID != nil {
+		if err := service.recalculateReceiptBalance(tx, *original.ReceiptID); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+		// mutasi bank yang dikonfirmasi menjadi receipt ini kembali ke antrian rekonsiliasi;
+		// ReceiptID dipertahankan agar konfirmasi ulang memakai saldo receipt yang sama
+		if err := tx.Model(&models.BankStatementLine{}).
+			Where("receipt_id = ? AND status = ?", *original.ReceiptID, models.BankLineConfirmed).
 			Updates(map[string]interface{}{
-				"paid_amount":    newPaid,
-				"payment_status": newPayStat,
+				"status":       models.BankLineUnmatched,
+				"confirmed_by": nil,
+				"confirmed_at": nil,
 			}).Error; err != nil {
 			_ = tx.Rollback()
-			if invoiceUUIDStr != "" {
-				helpers.DeleteLocalFileImmediate(invoiceUUIDStr)
-			}
-			return nil, fmt.Errorf("error updating sales order: %w", err)
+			return nil, fmt.Errorf("error reopening bank statement line: %w", err)
 		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		if invoiceUUIDStr != "" {
-			helpers.DeleteLocalFileImmediate(invoiceUUIDStr)
-		}
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	fresh, err := service.PaymentRepository.FindById(nil, createdPayment.ID.String(), true)
+	return service.PaymentRepository.FindById(nil, reversal.ID.String(), true)
+}
+
+// RecalculateOrderBalance menghitung ulang PaidAmount / PaymentStatus order dari jumlah seluruh
+// entri payment-nya, bukan dari selisih, sehingga koreksi apa pun selalu konsisten dengan ledger.
+func (service *PaymentService) RecalculateOrderBalance(tx *gorm.DB, orderType string, orderID uuid.UUID) error {
+	var (
+		orderModel  interface{}
+		totalAmount int
+	)
+
+	switch orderType {
+	case "PO":
+		var po models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&po, "id = ?", orderID).Error; err != nil {
+			return repositories.HandleDatabaseError(err, "purchase_order")
+		}
+		orderModel, totalAmount = &models.PurchaseOrder{}, po.TotalAmount
+	case "SO":
+		var so models.SalesOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&so, "id = ?", orderID).Error; err != nil {
+			return repositories.HandleDatabaseError(err, "sales_order")
+		}
+		orderModel, totalAmount = &models.SalesOrder{}, so.TotalAmount
+	default:
+		return errors.New("order_type must be either PO or SO")
+	}
+
+	paid, err := service.PaymentRepository.SumAmountByOrder(tx, orderType, orderID)
 	if err != nil {
-		log.Printf("Warning: Payment created but failed to fetch created data: %v", err)
-		return createdPayment, nil
+		return err
 	}
-	return fresh, nil
+	if paid < 0 {
+		paid = 0
+	}
+
+	if err := tx.Model(orderModel).
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"paid_amount":    paid,
+			"payment_status": paymentStatusFor(totalAmount, paid),
+		}).Error; err != nil {
+		if orderType == "PO" {
+			return fmt.Errorf("error updating purchase order: %w", err)
+		}
+		return fmt.Errorf("error updating sales order: %w", err)
+	}
+	return nil
+}
+
+func (service *PaymentService) recalculateReceiptBalance(tx *gorm.DB, receiptID uuid.UUID) error {
+	var receipt models.PaymentReceipt
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&receipt, "id = ?", receiptID).Error; err != nil {
+		return repositories.HandleDatabaseError(err, "payment_receipt")
+	}
+
+	allocated, err := service.PaymentRepository.SumAmountByReceipt(tx, receiptID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.PaymentReceipt{}).
+		Where("id = ?", receiptID).
+		Updates(map[string]interface{}{
+			"allocated_amount":   allocated,
+			"unallocated_amount": receipt.Amount - allocated,
+		}).Error; err != nil {
+		return fmt.Errorf("error updating payment receipt: %w", err)
+	}
+	return nil
 }
 
 func (service *PaymentService) GetPaymentsByPurchaseOrder(poId string) ([]models.ResponseGetPayment, error) {
@@ -219,11 +421,18 @@ func (service *PaymentService) GetPaymentsByPurchaseOrder(poId string) ([]models
 			PaymentMethod:   p.PaymentMethod,
 			ReferenceNumber: p.ReferenceNumber,
 			InvoiceID:       p.InvoiceID,
+			ReceiptID:       p.ReceiptID,
+			CreatedBy:       p.CreatedBy,
+			ReversalOfID:    p.ReversalOfID,
+			VoidedAt:        p.VoidedAt,
+			VoidedBy:        p.VoidedBy,
+			VoidReason:      p.VoidReason,
 			Notes:           p.Notes,
 			CreatedAt:       p.CreatedAt,
 			UpdatedAt:       p.UpdatedAt,
 			DeletedAt:       p.DeletedAt,
 			Invoice:         p.Invoice,
+			Receipt:         p.Receipt,
 			PurchaseOrder:   p.PurchaseOrder,
 			SalesOrder:      p.SalesOrder,
 		})
@@ -250,11 +459,18 @@ func (service *PaymentService) GetPaymentsBySalesOrder(soId string) ([]models.Re
 			PaymentMethod:   p.PaymentMethod,
 			ReferenceNumber: p.ReferenceNumber,
 			InvoiceID:       p.InvoiceID,
+			ReceiptID:       p.ReceiptID,
+			CreatedBy:       p.CreatedBy,
+			ReversalOfID:    p.ReversalOfID,
+			VoidedAt:        p.VoidedAt,
+			VoidedBy:        p.VoidedBy,
+			VoidReason:      p.VoidReason,
 			Notes:           p.Notes,
 			CreatedAt:       p.CreatedAt,
 			UpdatedAt:       p.UpdatedAt,
 			DeletedAt:       p.DeletedAt,
 			Invoice:         p.Invoice,
+			Receipt:         p.Receipt,
 			PurchaseOrder:   p.PurchaseOrder,
 			SalesOrder:      p.SalesOrder,
 		})
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderApprovalService struct {
+	PurchaseOrderApprovalRepository repositories.PurchaseOrderApprovalRepository
+	RoleRepository                  repositories.RoleRepository
+	CategoryRepository              repositories.CategoryRepository
+}
+
+func NewPurchaseOrderApprovalService(
+	approvalRepo repositories.PurchaseOrderApprovalRepository,
+	roleRepo repositories.RoleRepository,
+	categoryRepo repositories.CategoryRepository,
+) *PurchaseOrderApprovalService {
+	return &PurchaseOrderApprovalService{
+		PurchaseOrderApprovalRepository: approvalRepo,
+		RoleRepository:                  roleRepo,
+		CategoryRepository:              categoryRepo,
+	}
+}
+
+// ==============================
+// Approval rules
+// ==============================
+
+func (s *PurchaseOrderApprovalService) GetAllApprovalRules() ([]models.PurchaseOrderApprovalRule, error) {
+	return s.PurchaseOrderApprovalRepository.FindAllRules(nil)
+}
+
+func (s *PurchaseOrderApprovalService) CreateApprovalRule(req *models.PurchaseOrderApprovalRuleRequest) (*models.PurchaseOrderApprovalRule, error) {
+	if err := s.validateRuleRequest(req); err != nil {
+		return nil, err
+	}
+
+	rule := &models.PurchaseOrderApprovalRule{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(req.Name),
+		MinAmount:   req.MinAmount,
+		CategoryID:  req.CategoryID,
+		RoleID:      req.RoleID,
+		Level:       req.Level,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		Description: req.Description,
+	}
+
+	if _, err := s.PurchaseOrderApprovalRepository.InsertRule(nil, rule); err != nil {
+		return nil, fmt.Errorf("error creating approval rule: %w", err)
+	}
+
+	return s.PurchaseOrderApprovalRepository.FindRuleById(nil, rule.ID.String())
+}
+
+func (s *PurchaseOrderApprovalService) UpdateApprovalRule(ruleId string, req *models.PurchaseOrderApprovalRuleRequest) (*models.PurchaseOrderApprovalRule, error) {
+	rule, err := s.PurchaseOrderApprovalRepository.FindRuleById(nil, ruleId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPurchaseOrderApprovalRuleNotFound) {
+			return nil, errors.New("approval rule not found")
+		}
+		return nil, fmt.Errorf("error finding approval rule: %w", err)
+	}
+
+	if err := s.validateRuleRequest(req); err != nil {
+		return nil, err
+	}
+
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.MinAmount = req.MinAmount
+	rule.CategoryID = req.CategoryID
+	rule.RoleID = req.RoleID
+	rule.Level = req.Level
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	rule.Description = req.Description
+	rule.Category = nil
+	rule.Role = nil
+
+	if _, err := s.PurchaseOrderApprovalRepository.UpdateRule(nil, rule); err != nil {
+		return nil, fmt.Errorf("error updating approval rule: %w", err)
+	}
+
+	return s.PurchaseOrderApprovalRepository.FindRuleById(nil, rule.ID.String())
+}
+
+// DeleteApprovalRule hanya memengaruhi pengajuan berikutnya; rantai yang sudah berjalan tetap.
+func (s *PurchaseOrderApprovalService) DeleteApprovalRule(ruleId string) error {
+	if err := s.PurchaseOrderApprovalRepository.DeleteRule(nil, ruleId); err != nil {
+		if errors.Is(err, repositories.ErrPurchaseOrderApprovalRuleNotFound) {
+			return errors.New("approval rule not found")
+		}
+		return fmt.Errorf("error deleting approval rule: %w", err)
+	}
+	return nil
+}
+
+// ==============================
+// Approval chain (dipakai PurchaseOrderService di dalam transaksi)
+// ==============================
+
+// BuildChain menyusun langkah persetujuan dari rule aktif yang cocok dengan nilai & kategori PO.
+// Role yang muncul di beberapa level cukup menyetujui sekali (level terendah).
+func (s *PurchaseOrderApprovalService) BuildChain(tx *gorm.DB, totalAmount int, categoryIDs map[uuid.UUID]bool) ([]models.PurchaseOrderApprovalRule, error) {
+	rules, err := s.PurchaseOrderApprovalRepository.FindActiveRules(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := map[uuid.UUID]models.PurchaseOrderApprovalRule{}
+	for _, rule := range rules {
+		if rule.MinAmount > 0 && totalAmount < rule.MinAmount {
+			continue
+		}
+		if rule.CategoryID != nil && !categoryIDs[*rule.CategoryID] {
+			continue
+		}
+		if existing, ok := byRole[rule.RoleID]; ok && existing.Level <= rule.Level {
+			continue
+		}
+		byRole[rule.RoleID] = rule
+	}
+
+	chain := make([]models.PurchaseOrderApprovalRule, 0, len(byRole))
+	for _, rule := range byRole {
+		chain = append(chain, rule)
+	}
+	sort.SliceStable(chain, func(i, j int) bool {
+		if chain[i].Level != chain[j].Level {
+			return chain[i].Level < chain[j].Level
+		}
+		return chain[i].RoleID.String() < chain[j].RoleID.String()
+	})
+	return chain, nil
+}
+
+// Submit membuat round persetujuan baru untuk PO. Mengembalikan langkah yang dibuat
+// (kosong bila tidak ada rule yang berlaku).
+func (s *PurchaseOrderApprovalService) Submit(tx *gorm.DB, po *models.PurchaseOrder, categoryIDs map[uuid.UUID]bool, userInfo *models.User) ([]models.PurchaseOrderApproval, error) {
+	chain, err := s.BuildChain(tx, po.TotalAmount, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	lastRound, err := s.PurchaseOrderApprovalRepository.FindLastRound(tx, po.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]models.PurchaseOrderApproval, 0, len(chain))
+	for i, rule := range chain {
+		ruleID := rule.ID
+		steps = append(steps, models.PurchaseOrderApproval{
+			ID:              uuid.New(),
+			PurchaseOrderID: po.ID,
+			Round:           lastRound + 1,
+			StepOrder:       i + 1,
+			RoleID:          rule.RoleID,
+			RuleID:          &ruleID,
+			Status:          "Pending",
+			RequestedBy:     &userInfo.ID,
+		})
+	}
+
+	if err := s.PurchaseOrderApprovalRepository.InsertMany(tx, steps); err != nil {
+		return nil, fmt.Errorf("error creating approval steps: %w", err)
+	}
+	return steps, nil
+}
+
+// CurrentApprovalStep mengembalikan langkah Pending paling awal pada round terakhir.
+func CurrentApprovalStep(approvals []models.PurchaseOrderApproval) *models.PurchaseOrderApproval {
+	var current *models.PurchaseOrderApproval
+	for i := range approvals {
+		a := &approvals[i]
+		if a.Status != "Pending" {
+			continue
+		}
+		if current == nil || a.Round > current.Round || (a.Round == current.Round && a.StepOrder < current.StepOrder) {
+			current = a
+		}
+	}
+	return current
+}
+
+// NotifyApprover memberi tahu semua user dengan role langkah berikutnya.
+func (s *PurchaseOrderApprovalService) NotifyApprover(po *models.PurchaseOrder, step models.PurchaseOrderApproval) {
+	role, err := s.RoleRepository.FindById(nil, step.RoleID.String(), false)
+	if err != nil {
+		log.Printf("Warning: failed to resolve approver role for PO %s: %v", po.PONumber, err)
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"purchase_order_id": po.ID.String(),
+		"po_number":         po.PONumber,
+		"total_amount":      po.TotalAmount,
+		"step_order":        step.StepOrder,
+		"role":              role.Name,
+	}
+	title := fmt.Sprintf("PO Approval Needed: %s", po.PONumber)
+	message := fmt.Sprintf("Purchase order %s (total %d) is waiting for your approval (step %d).", po.PONumber, po.TotalAmount, step.StepOrder)
+	if err := helpers.SendNotificationToRoles([]string{role.Name}, "po_approval_request", title, message, metadata); err != nil {
+		log.Printf("failed to send PO approval notification: %v", err)
+	}
+}
+
+// NotifyDecision mengabarkan hasil akhir ke pengaju dan ke role pemantau (po_approval_result).
+func (s *PurchaseOrderApprovalService) NotifyDecision(po *models.PurchaseOrder, step models.PurchaseOrderApproval, status string) {
+	metadata := map[string]interface{}{
+		"purchase_order_id": po.ID.String(),
+		"po_number":         po.PONumber,
+		"status":            status,
+		"comment":           step.Comment,
+	}
+	title := fmt.Sprintf("PO %s: %s", status, po.PONumber)
+	message := fmt.Sprintf("Purchase order %s has been %s.", po.PONumber, strings.ToLower(status))
+	if step.Comment != "" {
+		message += " Comment: " + step.Comment
+	}
+
+	if step.RequestedBy != nil {
+		if err := helpers.SendNotificationToUser(*step.RequestedBy, "po_approval_result", title, message, metadata); err != nil {
+			log.Printf("failed to send PO approval result notification: %v", err)
+		}
+	}
+	if err := helpers.SendNotificationAuto("po_approval_result", title, message, metadata); err != nil {
+		log.Printf("failed to send PO approval result notification: %v", err)
+	}
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *PurchaseOrderApprovalService) validateRuleRequest(req *models.PurchaseOrderApprovalRuleRequest) error {
+	if _, err := s.RoleRepository.FindById(nil, req.RoleID.String(), false); err != nil {
+		return errors.New("role not found")
+	}
+	if req.CategoryID != nil {
+		if _, err := s.CategoryRepository.FindById(nil, req.CategoryID.String(), false); err != nil {
+			return errors.New("category not found")
+		}
+	}
+	return nil
+}
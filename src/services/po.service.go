@@ -4,14 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers"
 	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
 	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PurchaseOrderService struct {
@@ -20,6 +23,9 @@ type PurchaseOrderService struct {
 	ItemRepository          repositories.ItemRepository
 	PaymentRepository       repositories.PaymentRepository
 	ItemHistoryRepository   repositories.ItemHistoryRepository
+	ItemLotService          *ItemLotService
+	NumberSequenceService   *NumberSequenceService
+	ApprovalService         *PurchaseOrderApprovalService
 }
 
 func NewPurchaseOrderService(
@@ -28,6 +34,13 @@ func NewPurchaseOrderService(
 	itemRepo repositories.ItemRepository,
 	paymentRepo repositories.PaymentRepository,
 	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+	approvalRepo repositories.PurchaseOrderApprovalRepository,
+	roleRepo repositories.RoleRepository,
+	categoryRepo repositories.CategoryRepository,
 ) *PurchaseOrderService {
 	return &PurchaseOrderService{
 		PurchaseOrderRepository: poRepo,
@@ -35,6 +48,9 @@ func NewPurchaseOrderService(
 		ItemRepository:          itemRepo,
 		PaymentRepository:       paymentRepo,
 		ItemHistoryRepository:   itemHistoryRepo,
+		ItemLotService:          NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService:   NewNumberSequenceService(numberSequenceRepo),
+		ApprovalService:         NewPurchaseOrderApprovalService(approvalRepo, roleRepo, categoryRepo),
 	}
 }
 
@@ -140,9 +156,9 @@ func (service *PurchaseOrderService) CreatePurchaseOrder(
 		return nil, errors.New("supplier not found")
 	}
 
-	// build items + hitung total dalam tx (ambil UoM dari item repo)
-	var totalAmount int
+	// build items dalam tx (ambil UoM dari item repo); total dihitung applyPurchaseOrderPricing
 	poItems := make([]models.PurchaseOrderItem, 0, len(poRequest.Items))
+	categoryIDs := map[uuid.UUID]bool{}
 
 	for _, itemReq := range poRequest.Items {
 		itemData, err := service.ItemRepository.FindById(tx, itemReq.ItemID.String(), false)
@@ -151,21 +167,22 @@ func (service *PurchaseOrderService) CreatePurchaseOrder(
 			return nil, fmt.Errorf("item %s not found", itemReq.ItemID.String())
 		}
 
-		totalPrice := itemReq.Quantity * itemReq.UnitPrice
-		totalAmount += totalPrice
+		categoryIDs[itemData.CategoryID] = true
 
 		poItems = append(poItems, models.PurchaseOrderItem{
-			ID:         uuid.New(),
-			ItemID:     itemReq.ItemID,
-			Quantity:   itemReq.Quantity,
-			UoMID:      itemData.UoMID,
-			UnitPrice:  itemReq.UnitPrice,
-			TotalPrice: totalPrice,
-			Status:     "Ordered",
+			ID:            uuid.New(),
+			ItemID:        itemReq.ItemID,
+			Quantity:      itemReq.Quantity,
+			UoMID:         itemData.UoMID,
+			UnitPrice:     itemReq.UnitPrice,
+			DiscountType:  itemReq.DiscountType,
+			DiscountValue: itemReq.DiscountValue,
+			TaxExempt:     itemData.TaxExempt,
+			Status:        "Ordered",
 		})
 	}
 
-	poNumber, err := service.PurchaseOrderRepository.GenerateNextPONumber(tx)
+	poNumber, err := service.NumberSequenceService.Next(tx, models.SequencePurchaseOrder)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("error generating PO number: %w", err)
@@ -185,13 +202,22 @@ func (service *PurchaseOrderService) CreatePurchaseOrder(
 		TermOfPayment:      poRequest.TermOfPayment,
 		POStatus:           poRequest.POStatus,
 		PaymentStatus:      paymentStatus,
-		TotalAmount:        totalAmount,
+		DiscountType:       poRequest.DiscountType,
+		DiscountValue:      poRequest.DiscountValue,
+		TaxInclusive:       poRequest.TaxInclusive,
 		DPAmount:           poRequest.DPAmount,
 		DueDate:            poRequest.DueDate,
 		Notes:              poRequest.Notes,
-		Tax:                poRequest.Tax,
-		PurchaseOrderItems: poItems,
+		Tax:                float32(helpers.PPNRate()),
 	}
+	if poRequest.Tax != nil {
+		newPO.Tax = *poRequest.Tax
+	}
+	if err := applyPurchaseOrderPricing(newPO, poItems); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	newPO.PurchaseOrderItems = poItems
 
 	if _, err := service.PurchaseOrderRepository.Insert(tx, newPO); err != nil {
 		tx.Rollback()
@@ -221,10 +247,35 @@ func (service *PurchaseOrderService) CreatePurchaseOrder(
 		}
 	}
 
+	// PO yang langsung diajukan/dipesan masuk rantai approval bila ada rule yang berlaku
+	var approvalSteps []models.PurchaseOrderApproval
+	if newPO.POStatus == "PendingApproval" || newPO.POStatus == "Ordered" {
+		approvalSteps, err = service.ApprovalService.Submit(tx, newPO, categoryIDs, userInfo)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if len(approvalSteps) == 0 && newPO.POStatus == "PendingApproval" {
+			tx.Rollback()
+			return nil, errors.New("no approval rule applies to this purchase order; create it as Draft or Ordered")
+		}
+		if len(approvalSteps) > 0 && newPO.POStatus != "PendingApproval" {
+			newPO.POStatus = "PendingApproval"
+			if err := service.PurchaseOrderRepository.UpdateStatus(tx, newPO.ID.String(), newPO.POStatus, ""); err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error updating PO status: %w", err)
+			}
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	if len(approvalSteps) > 0 {
+		go service.ApprovalService.NotifyApprover(newPO, approvalSteps[0])
+	}
+
 	createdPO, err := service.PurchaseOrderRepository.FindById(nil, newPO.ID.String(), false)
 	if err != nil {
 		log.Printf("Warning: PO created but failed to fetch created data: %v", err)
@@ -287,8 +338,25 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 	if poRequest.Notes != "" {
 		updates["notes"] = poRequest.Notes
 	}
-	updates["tax"] = poRequest.Tax
+	repricing := len(poRequest.Items) > 0
+	if poRequest.Tax != nil {
+		po.Tax = *poRequest.Tax
+		repricing = true
+	}
+	if poRequest.TaxInclusive != nil {
+		po.TaxInclusive = *poRequest.TaxInclusive
+		repricing = true
+	}
+	if poRequest.DiscountType != nil {
+		po.DiscountType = *poRequest.DiscountType
+		repricing = true
+	}
+	if poRequest.DiscountValue != nil {
+		po.DiscountValue = *poRequest.DiscountValue
+		repricing = true
+	}
 
+	var finalItems []models.PurchaseOrderItem
 	if len(poRequest.Items) > 0 {
 		// ambil existing items dulu
 		var existingItems []models.PurchaseOrderItem
@@ -302,7 +370,7 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 			existingByItemID[existingItems[i].ItemID] = &existingItems[i]
 		}
 
-		finalItems := make([]models.PurchaseOrderItem, 0, len(poRequest.Items))
+		finalItems = make([]models.PurchaseOrderItem, 0, len(poRequest.Items))
 		seen := make(map[uuid.UUID]bool)
 
 		for _, req := range poRequest.Items {
@@ -321,12 +389,18 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 				changed := (ex.Quantity != newQty) ||
 					(ex.UnitPrice != newPrice) ||
 					(ex.UoMID != newUoMID) ||
-					(ex.TotalPrice != newTotal)
+					(ex.TotalPrice != newTotal) ||
+					(ex.DiscountType != req.DiscountType) ||
+					(ex.DiscountValue != req.DiscountValue) ||
+					(ex.TaxExempt != itemData.TaxExempt)
 
 				ex.Quantity = newQty
 				ex.UnitPrice = newPrice
 				ex.UoMID = newUoMID
 				ex.TotalPrice = newTotal
+				ex.DiscountType = req.DiscountType
+				ex.DiscountValue = req.DiscountValue
+				ex.TaxExempt = itemData.TaxExempt
 
 				if changed {
 					if err := tx.Model(&models.PurchaseOrderItem{}).
@@ -336,6 +410,9 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 							"unit_price":  ex.UnitPrice,
 							"uom_id":      ex.UoMID,
 							"total_price": ex.TotalPrice,
+							"discount_type":  ex.DiscountType,
+							"discount_value": ex.DiscountValue,
+							"tax_exempt":     ex.TaxExempt,
 						}).Error; err != nil {
 						tx.Rollback()
 						return nil, fmt.Errorf("error updating item %s: %w", ex.ID, err)
@@ -353,6 +430,9 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 					UoMID:           itemData.UoMID,
 					UnitPrice:       req.UnitPrice,
 					TotalPrice:      req.Quantity * req.UnitPrice,
+					DiscountType:    req.DiscountType,
+					DiscountValue:   req.DiscountValue,
+					TaxExempt:       itemData.TaxExempt,
 					Status:          "Ordered",
 				}
 				if err := tx.Create(&newRow).Error; err != nil {
@@ -373,12 +453,42 @@ func (service *PurchaseOrderService) UpdatePurchaseOrder(
 				}
 			}
 		}
+	}
 
-		total := 0
+	// diskon / PPN / grand total dihitung ulang bila baris atau pengaturan pajak-diskon berubah
+	if repricing {
+		if finalItems == nil {
+			if err := tx.Where("purchase_order_id = ?", po.ID).Find(&finalItems).Error; err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error fetching existing items: %w", err)
+			}
+		}
+		if err := applyPurchaseOrderPricing(po, finalItems); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 		for _, it := range finalItems {
-			total += it.TotalPrice
+			if err := tx.Model(&models.PurchaseOrderItem{}).
+				Where("id = ?", it.ID).
+				Updates(map[string]interface{}{
+					"total_price":     it.TotalPrice,
+					"discount_amount": it.DiscountAmount,
+					"net_amount":      it.NetAmount,
+				}).Error; err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error updating item %s: %w", it.ID, err)
+			}
 		}
-		updates["total_amount"] = total
+		updates["subtotal"] = po.Subtotal
+		updates["discount_type"] = po.DiscountType
+		updates["discount_value"] = po.DiscountValue
+		updates["discount_amount"] = po.DiscountAmount
+		updates["tax"] = po.Tax
+		updates["tax_inclusive"] = po.TaxInclusive
+		updates["tax_base"] = po.TaxBase
+		updates["tax_amount"] = po.TaxAmount
+		updates["grand_total"] = po.GrandTotal
+		updates["total_amount"] = po.TotalAmount
 	}
 
 	// lakukan update ke PO kalau ada perubahan
@@ -414,13 +524,65 @@ func (service *PurchaseOrderService) UpdatePurchaseOrderStatus(poId string, stat
 		return err
 	}
 
+	if po.POStatus == "Draft" {
+		chain, err := service.ApprovalService.BuildChain(nil, po.TotalAmount, purchaseOrderCategoryIDs(po))
+		if err != nil {
+			return err
+		}
+		switch {
+		case statusRequest.POStatus == "Ordered" && len(chain) > 0:
+			return fmt.Errorf("purchase order requires approval (%d step(s)); submit it for approval first", len(chain))
+		case statusRequest.POStatus == "PendingApproval" && len(chain) == 0:
+			return errors.New("no approval rule applies to this purchase order; it can be ordered directly")
+		case statusRequest.POStatus == "PendingApproval":
+			return service.submitForApproval(po, statusRequest.PaymentStatus, userInfo)
+		}
+	}
+
 	return service.PurchaseOrderRepository.UpdateStatus(nil, poId, statusRequest.POStatus, statusRequest.PaymentStatus)
 }
 
-func (service *PurchaseOrderService) ReceiveItems(poId string, receiveRequest *models.ReceiveItemsRequest, userInfo *models.User) error {
+func (service *PurchaseOrderService) GetPurchaseOrderApprovals(poId string) ([]models.PurchaseOrderApproval, error) {
+	po, err := service.PurchaseOrderRepository.FindById(nil, poId, false)
+	if err != nil {
+		return nil, err
+	}
+	return service.ApprovalService.PurchaseOrderApprovalRepository.FindByPurchaseOrder(nil, po.ID)
+}
+
+// GetPendingApprovals mengembalikan PO yang langkah approval berjalannya menunggu role user.
+func (service *PurchaseOrderService) GetPendingApprovals(userInfo *models.User) ([]models.ResponseGetPurchaseOrder, error) {
+	result := []models.ResponseGetPurchaseOrder{}
+	if userInfo.RoleID == nil {
+		return result, nil
+	}
+
+	ids, err := service.ApprovalService.PurchaseOrderApprovalRepository.FindPendingPurchaseOrderIDsByRole(nil, *userInfo.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		po, err := service.PurchaseOrderRepository.FindById(nil, id.String(), false)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, service.mapPOToResponse(*po))
+	}
+	return result, nil
+}
+
+// DecidePurchaseOrderApproval mencatat keputusan approver untuk langkah yang sedang berjalan.
+// Langkah terakhir yang disetujui membuat PO Approved; satu penolakan membuat PO Rejected.
+func (service *PurchaseOrderService) DecidePurchaseOrderApproval(poId string, req *models.PurchaseOrderApprovalDecisionRequest, userInfo *models.User) (*models.PurchaseOrder, error) {
+	req.Comment = strings.TrimSpace(req.Comment)
+	if req.Action == "Reject" && req.Comment == "" {
+		return nil, errors.New("a comment is required when rejecting a purchase order")
+	}
+
 	tx := configs.DB.Begin()
 	if tx.Error != nil {
-		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
 	defer func() {
 		if r := recover(); r != nil {
@@ -428,156 +590,124 @@ func (service *PurchaseOrderService) ReceiveItems(poId string, receiveRequest *m
 		}
 	}()
 
-	po, err := service.PurchaseOrderRepository.FindById(tx, poId, false)
-	if err != nil {
+	var po models.PurchaseOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, "id = ?", poId).Error; err != nil {
 		tx.Rollback()
-		return err
+		return nil, repositories.HandleDatabaseError(err, "purchase_order")
 	}
-	if po.POStatus != "Ordered" {
+	if po.POStatus != "PendingApproval" {
 		tx.Rollback()
-		return errors.New("can only receive items for purchase orders in 'Ordered' status")
+		return nil, fmt.Errorf("purchase order is not waiting for approval (current status: %s)", po.POStatus)
 	}
 
-	allReceived := true
-	allReturned := true
-	allOrdered := true
-
-	for _, req := range receiveRequest.Items {
-		var poItem models.PurchaseOrderItem
-		if err := tx.First(&poItem, "id = ? AND purchase_order_id = ?", req.PurchaseOrderItemID, poId).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("purchase order item not found: %w", err)
-		}
-
-		oldRecv := poItem.ReceivedQuantity
-		oldRet := poItem.ReturnedQuantity
-		qty := poItem.Quantity
-
-		deltaRecv := req.ReceivedQuantity
-		deltaRet := req.ReturnedQuantity
-		if deltaRecv < 0 || deltaRet < 0 {
-			tx.Rollback()
-			return errors.New("quantities must be non-negative")
-		}
-
-		newRecv := oldRecv + deltaRecv
-		newRet := oldRet + deltaRet
-		totalProcessed := newRecv + newRet
+	approvals, err := service.ApprovalService.PurchaseOrderApprovalRepository.FindByPurchaseOrder(tx, po.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	current := CurrentApprovalStep(approvals)
+	if current == nil {
+		tx.Rollback()
+		return nil, errors.New("no pending approval step found for this purchase order")
+	}
 
-		if totalProcessed > qty {
-			tx.Rollback()
-			return errors.New("received + returned quantity cannot exceed ordered quantity")
+	if userInfo.RoleID == nil || *userInfo.RoleID != current.RoleID {
+		tx.Rollback()
+		roleName := current.RoleID.String()
+		if current.Role != nil {
+			roleName = current.Role.Name
 		}
+		return nil, fmt.Errorf("this approval step is waiting for role %s", roleName)
+	}
+	if current.RequestedBy != nil && *current.RequestedBy == userInfo.ID {
+		tx.Rollback()
+		return nil, errors.New("the requester cannot approve their own purchase order")
+	}
 
-		oldStatus := "Partial"
-		switch {
-		case oldRecv+oldRet == 0:
-			oldStatus = "Ordered"
-		case oldRet == qty:
-			oldStatus = "Returned"
-		case oldRecv == qty && oldRet == 0:
-			oldStatus = "Received"
-		}
+	now := time.Now()
+	current.ApproverID = &userInfo.ID
+	current.Comment = req.Comment
+	current.DecidedAt = &now
 
-		newStatus := "Partial"
-		switch {
-		case totalProcessed == 0:
-			newStatus = "Ordered"
-		case newRet == qty:
-			newStatus = "Returned"
-		case newRecv == qty && newRet == 0:
-			newStatus = "Received"
-		default:
-			newStatus = "Partial"
+	newStatus := ""
+	var nextStep *models.PurchaseOrderApproval
+	if req.Action == "Reject" {
+		current.Status = "Rejected"
+		newStatus = "Rejected"
+	} else {
+		current.Status = "Approved"
+		if nextStep = CurrentApprovalStep(approvals); nextStep == nil {
+			newStatus = "Approved"
 		}
+	}
 
-		poItem.ReceivedQuantity = newRecv
-		poItem.ReturnedQuantity = newRet
-		poItem.Status = newStatus
+	if err := service.ApprovalService.PurchaseOrderApprovalRepository.Update(tx, current); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
-		if newStatus != "Received" {
-			allReceived = false
-		}
-		if newStatus != "Returned" {
-			allReturned = false
-		}
-		if newStatus != "Ordered" {
-			allOrdered = false
+	if newStatus != "" {
+		if newStatus == "Rejected" {
+			if err := service.ApprovalService.PurchaseOrderApprovalRepository.SkipPending(tx, po.ID); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
 		}
-
-		if err := tx.Save(&poItem).Error; err != nil {
+		if err := service.PurchaseOrderRepository.UpdateStatus(tx, po.ID.String(), newStatus, ""); err != nil {
 			tx.Rollback()
-			return fmt.Errorf("error updating purchase order item: %w", err)
+			return nil, fmt.Errorf("error updating PO status: %w", err)
 		}
+		po.POStatus = newStatus
+	}
 
-		// Bila item BARU menjadi "Received", baru tambahkan stock + catat history
-		if oldStatus != "Received" && newStatus == "Received" {
-			item, err := service.ItemRepository.FindById(tx, poItem.ItemID.String(), false)
-			if err != nil {
-				tx.Rollback()
-				return fmt.Errorf("item not found: %w", err)
-			}
-
-			toAdd := newRecv
-			item.Stock += toAdd
-			if _, err := service.ItemRepository.Update(tx, item); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error updating item stock: %w", err)
-			}
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
-			// cek last stock history via repo (tx-aware)
-			lastHist, err := service.ItemHistoryRepository.FindLastByItem(tx, poItem.ItemID, "stock")
-			if err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error querying last stock history: %w", err)
-			}
+	decided := *current
+	if nextStep != nil {
+		go service.ApprovalService.NotifyApprover(&po, *nextStep)
+	} else {
+		go service.ApprovalService.NotifyDecision(&po, decided, newStatus)
+	}
 
-			changeType := "update_stock"
-			oldStock := 0
-			if lastHist != nil {
-				oldStock = lastHist.CurrentStock
-			} else {
-				changeType = "create_stock"
-			}
+	updatedPO, err := service.PurchaseOrderRepository.FindById(nil, po.ID.String(), false)
+	if err != nil {
+		log.Printf("Warning: PO approval saved but failed to fetch updated data: %v", err)
+		return &po, nil
+	}
+	return updatedPO, nil
+}
 
-			newHist := models.ItemHistory{
-				ID:           uuid.New(),
-				ItemID:       poItem.ItemID,
-				ChangeType:   changeType,
-				OldStock:     oldStock,
-				NewStock:     item.Stock,
-				CurrentStock: item.Stock,
-				Description:  fmt.Sprintf("PO fully received: +%d units (%s)", toAdd, po.PONumber),
-				CreatedBy:    &userInfo.ID,
-				UpdatedBy:    &userInfo.ID,
-			}
-			if _, err := service.ItemHistoryRepository.Insert(tx, &newHist); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error creating item history: %w", err)
-			}
-		}
+func (service *PurchaseOrderService) submitForApproval(po *models.PurchaseOrder, paymentStatus string, userInfo *models.User) error {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
 
-	newPOStatus := "Partial"
-	switch {
-	case allReceived:
-		newPOStatus = "Received"
-	case allReturned:
-		newPOStatus = "Returned"
-	case allOrdered:
-		newPOStatus = "Ordered"
-	default:
-		newPOStatus = "Partial"
+	steps, err := service.ApprovalService.Submit(tx, po, purchaseOrderCategoryIDs(po), userInfo)
+	if err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	if err := service.PurchaseOrderRepository.UpdateStatus(tx, poId, newPOStatus, ""); err != nil {
+	if err := service.PurchaseOrderRepository.UpdateStatus(tx, po.ID.String(), "PendingApproval", paymentStatus); err != nil {
 		tx.Rollback()
-		return fmt.Errorf("error updating purchase order status: %w", err)
+		return err
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+
+	if len(steps) > 0 {
+		go service.ApprovalService.NotifyApprover(po, steps[0])
+	}
 	return nil
 }
 
@@ -697,6 +827,14 @@ func (service *PurchaseOrderService) mapPOToResponse(po models.PurchaseOrder) mo
 		TermOfPayment:      po.TermOfPayment,
 		POStatus:           po.POStatus,
 		PaymentStatus:      po.PaymentStatus,
+		Subtotal:           po.Subtotal,
+		DiscountType:       po.DiscountType,
+		DiscountValue:      po.DiscountValue,
+		DiscountAmount:     po.DiscountAmount,
+		TaxInclusive:       po.TaxInclusive,
+		TaxBase:            po.TaxBase,
+		TaxAmount:          po.TaxAmount,
+		GrandTotal:         po.GrandTotal,
 		TotalAmount:        po.TotalAmount,
 		PaidAmount:         po.PaidAmount,
 		DPAmount:           po.DPAmount,
@@ -709,12 +847,16 @@ func (service *PurchaseOrderService) mapPOToResponse(po models.PurchaseOrder) mo
 		Supplier:           po.Supplier,
 		PurchaseOrderItems: po.PurchaseOrderItems,
 		Payments:           po.Payments,
+		Approvals:          po.Approvals,
 	}
 }
 
 func (service *PurchaseOrderService) validateStatusTransition(currentStatus, newStatus string) error {
 	validTransitions := map[string][]string{
-		"Draft":    {"Ordered"},
+		"Draft":           {"Ordered", "PendingApproval"},
+		"PendingApproval": {"Approved", "Rejected"},
+		"Approved":        {"Ordered"},
+		"Rejected":        {"Draft"},
 		"Ordered":  {"Received", "Returned", "Closed"},
 		"Received": {"Closed", "Returned"},
 		"Partial":  {"Received", "Returned"},
@@ -735,3 +877,52 @@ func (service *PurchaseOrderService) validateStatusTransition(currentStatus, new
 
 	return fmt.Errorf("invalid status transition from %s to %s", currentStatus, newStatus)
 }
+
+// applyPurchaseOrderPricing menghitung ulang diskon, DPP, PPN & grand total PO dari baris-barisnya
+// (tarif PPN = po.Tax). Hasil ditulis ke po & lines (belum disimpan); TotalAmount = GrandTotal.
+func applyPurchaseOrderPricing(po *models.PurchaseOrder, lines []models.PurchaseOrderItem) error {
+	input := helpers.PricingInput{
+		Lines:         make([]helpers.PricingLine, len(lines)),
+		DiscountType:  po.DiscountType,
+		DiscountValue: po.DiscountValue,
+		TaxRate:       float64(po.Tax),
+		TaxInclusive:  po.TaxInclusive,
+	}
+	for i, l := range lines {
+		input.Lines[i] = helpers.PricingLine{
+			Quantity:      l.Quantity,
+			UnitPrice:     l.UnitPrice,
+			DiscountType:  l.DiscountType,
+			DiscountValue: l.DiscountValue,
+			TaxExempt:     l.TaxExempt,
+		}
+	}
+
+	result, err := helpers.CalculatePricing(input)
+	if err != nil {
+		return err
+	}
+
+	for i := range lines {
+		lines[i].DiscountAmount = result.Lines[i].Discount
+		lines[i].TotalPrice = result.Lines[i].Total
+		lines[i].NetAmount = result.Lines[i].NetAmount
+	}
+	po.Subtotal = result.Subtotal
+	po.DiscountAmount = result.DiscountAmount
+	po.TaxBase = result.TaxBase
+	po.TaxAmount = result.TaxAmount
+	po.GrandTotal = result.GrandTotal
+	po.TotalAmount = result.GrandTotal
+	return nil
+}
+
+func purchaseOrderCategoryIDs(po *models.PurchaseOrder) map[uuid.UUID]bool {
+	categoryIDs := map[uuid.UUID]bool{}
+	for _, it := range po.PurchaseOrderItems {
+		if it.Item.CategoryID != uuid.Nil {
+			categoryIDs[it.Item.CategoryID] = true
+		}
+	}
+	return categoryIDs
+}
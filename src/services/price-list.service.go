@@ -0,0 +1,446 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PriceListService struct {
+	PriceListRepository    repositories.PriceListRepository
+	CustomerRepository     repositories.CustomerRepository
+	CustomerTypeRepository repositories.CustomerTypeRepository
+	ItemRepository         repositories.ItemRepository
+	ItemHistoryRepository  repositories.ItemHistoryRepository
+}
+
+func NewPriceListService(
+	priceListRepo repositories.PriceListRepository,
+	customerRepo repositories.CustomerRepository,
+	customerTypeRepo repositories.CustomerTypeRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+) *PriceListService {
+	return &PriceListService{
+		PriceListRepository:    priceListRepo,
+		CustomerRepository:     customerRepo,
+		CustomerTypeRepository: customerTypeRepo,
+		ItemRepository:         itemRepo,
+		ItemHistoryRepository:  itemHistoryRepo,
+	}
+}
+
+func (s *PriceListService) GetAllPriceListsPaginated(req *models.PaginationRequest) (*models.PriceListPaginatedResponse, error) {
+	normalizePagination(req)
+
+	priceLists, totalCount, err := s.PriceListRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PriceListPaginatedResponse{
+		Data:       priceLists,
+		Pagination: paginationResponse(req, totalCount),
+	}, nil
+}
+
+func (s *PriceListService) GetPriceListByID(priceListId string) (*models.PriceList, error) {
+	return s.PriceListRepository.FindById(nil, priceListId, true)
+}
+
+// ResolvePrice menampilkan harga yang akan dipakai SO untuk customer, item dan qty tertentu.
+func (s *PriceListService) ResolvePrice(req *models.PriceResolveRequest) (*models.ResolvedPrice, error) {
+	customer, err := s.CustomerRepository.FindById(nil, req.CustomerID, false)
+	if err != nil {
+		return nil, errors.New("customer not found")
+	}
+	item, err := s.ItemRepository.FindById(nil, req.ItemID, false)
+	if err != nil {
+		return nil, errors.New("item not found")
+	}
+
+	at := time.Now()
+	if strings.TrimSpace(req.Date) != "" {
+		if at, err = time.ParseInLocation("2006-01-02", req.Date, time.Local); err != nil {
+			return nil, errors.New("invalid date, expected YYYY-MM-DD")
+		}
+	}
+	return resolveItemPrice(nil, s.PriceListRepository, customer, item, priceBreakQuantity(req.Quantity), at)
+}
+
+func (s *PriceListService) CreatePriceList(req *models.PriceListCreateRequest, userInfo *models.User) (*models.PriceList, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := s.validatePriceListRequest(tx, req); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	priceList := &models.PriceList{
+		ID:             uuid.New(),
+		Name:           strings.TrimSpace(req.Name),
+		CustomerTypeID: req.CustomerTypeID,
+		CustomerID:     req.CustomerID,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		IsActive:       req.IsActive == nil || *req.IsActive,
+		EnforceMinimum: req.EnforceMinimum,
+		Description:    req.Description,
+		CreatedBy:      &userInfo.ID,
+	}
+	if _, err := s.PriceListRepository.Insert(tx, priceList); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating price list: %w", err)
+	}
+
+	items := buildPriceListItems(priceList.ID, req.Items)
+	if err := s.PriceListRepository.ReplaceItems(tx, priceList.ID, items); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error saving price list items: %w", err)
+	}
+
+	if err := s.recordPriceHistory(tx, priceList, nil, items, userInfo); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.refetch(priceList)
+}
+
+// UpdatePriceList mengganti header dan seluruh baris harga; setiap perubahan harga dicatat di ItemHistory.
+func (s *PriceListService) UpdatePriceList(priceListId string, req *models.PriceListCreateRequest, userInfo *models.User) (*models.PriceList, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	priceList, err := s.PriceListRepository.FindById(tx, priceListId, false)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrPriceListNotFound) {
+			return nil, errors.New("price list not found")
+		}
+		return nil, fmt.Errorf("error finding price list: %w", err)
+	}
+
+	if err := s.validatePriceListRequest(tx, req); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	oldItems := priceList.PriceListItems
+
+	priceList.Name = strings.TrimSpace(req.Name)
+	priceList.CustomerTypeID = req.CustomerTypeID
+	priceList.CustomerID = req.CustomerID
+	priceList.ValidFrom = req.ValidFrom
+	priceList.ValidUntil = req.ValidUntil
+	if req.IsActive != nil {
+		priceList.IsActive = *req.IsActive
+	}
+	priceList.EnforceMinimum = req.EnforceMinimum
+	priceList.Description = req.Description
+
+	if _, err := s.PriceListRepository.Update(tx, priceList); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating price list: %w", err)
+	}
+
+	items := buildPriceListItems(priceList.ID, req.Items)
+	if err := s.PriceListRepository.ReplaceItems(tx, priceList.ID, items); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error saving price list items: %w", err)
+	}
+
+	if err := s.recordPriceHistory(tx, priceList, oldItems, items, userInfo); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.refetch(priceList)
+}
+
+func (s *PriceListService) DeletePriceLists(req *models.PriceListIsHardDeleteRequest, userInfo *models.User) error {
+	_ = userInfo
+
+	for _, id := range req.IDs {
+		tx := configs.DB.Begin()
+		if tx.Error != nil {
+			log.Printf("Failed to begin transaction for price list %v: %v\n", id, tx.Error)
+			return errors.New("error beginning transaction")
+		}
+
+		if err := s.PriceListRepository.Delete(tx, id.String(), req.IsHardDelete == "hardDelete"); err != nil {
+			tx.Rollback()
+			if errors.Is(err, repositories.ErrPriceListNotFound) {
+				log.Printf("Price list not found: %v\n", id)
+				continue
+			}
+			log.Printf("Error deleting price list %v: %v\n", id, err)
+			return errors.New("error deleting price list")
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("Error committing price list deletion %v: %v\n", id, err)
+			return errors.New("error committing price list deletion")
+		}
+	}
+	return nil
+}
+
+func (s *PriceListService) RestorePriceLists(req *models.PriceListRestoreRequest, userInfo *models.User) ([]models.PriceList, error) {
+	_ = userInfo
+
+	var restored []models.PriceList
+	for _, id := range req.IDs {
+		tx := configs.DB.Begin()
+		if tx.Error != nil {
+			log.Printf("Failed to begin transaction for price list restore %v: %v\n", id, tx.Error)
+			return nil, errors.New("error beginning transaction")
+		}
+
+		row, err := s.PriceListRepository.Restore(tx, id.String())
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, repositories.ErrPriceListNotFound) {
+				log.Printf("Price list not found for restore: %v\n", id)
+				continue
+			}
+			log.Printf("Error restoring price list %v: %v\n", id, err)
+			return nil, errors.New("error restoring price list")
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("Error committing price list restore %v: %v\n", id, err)
+			return nil, errors.New("error committing price list restore")
+		}
+		restored = append(restored, *row)
+	}
+	return restored, nil
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *PriceListService) validatePriceListRequest(tx *gorm.DB, req *models.PriceListCreateRequest) error {
+	if (req.CustomerTypeID == nil) == (req.CustomerID == nil) {
+		return errors.New("price list must target exactly one of customer_type_id or customer_id")
+	}
+	if req.CustomerTypeID != nil {
+		if _, err := s.CustomerTypeRepository.FindById(tx, req.CustomerTypeID.String(), false); err != nil {
+			return errors.New("customer type not found")
+		}
+	}
+	if req.CustomerID != nil {
+		if _, err := s.CustomerRepository.FindById(tx, req.CustomerID.String(), false); err != nil {
+			return errors.New("customer not found")
+		}
+	}
+	if req.ValidUntil != nil && req.ValidUntil.Before(req.ValidFrom) {
+		return errors.New("valid_until must be after valid_from")
+	}
+
+	seen := make(map[string]bool, len(req.Items))
+	for _, it := range req.Items {
+		if _, err := s.ItemRepository.FindById(tx, it.ItemID.String(), false); err != nil {
+			return fmt.Errorf("item %s not found", it.ItemID.String())
+		}
+		key := fmt.Sprintf("%s:%d", it.ItemID, priceBreakQuantity(it.MinQuantity))
+		if seen[key] {
+			return fmt.Errorf("duplicate price break for item %s at min quantity %d", it.ItemID.String(), priceBreakQuantity(it.MinQuantity))
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// recordPriceHistory mencatat baris harga yang baru, berubah, atau dihapus ke ItemHistory (change_type price_list).
+func (s *PriceListService) recordPriceHistory(
+	tx *gorm.DB,
+	priceList *models.PriceList,
+	oldItems []models.PriceListItem,
+	newItems []models.PriceListItem,
+	userInfo *models.User,
+) error {
+	type breakKey struct {
+		ItemID      uuid.UUID
+		MinQuantity int
+	}
+
+	oldPrices := make(map[breakKey]int, len(oldItems))
+	for _, it := range oldItems {
+		oldPrices[breakKey{it.ItemID, it.MinQuantity}] = it.UnitPrice
+	}
+	newPrices := make(map[breakKey]int, len(newItems))
+	for _, it := range newItems {
+		newPrices[breakKey{it.ItemID, it.MinQuantity}] = it.UnitPrice
+	}
+
+	write := func(key breakKey, oldPrice, newPrice int, action string) error {
+		item, err := s.ItemRepository.FindById(tx, key.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found", key.ItemID.String())
+		}
+		if _, err := s.ItemHistoryRepository.Insert(tx, &models.ItemHistory{
+			ID:           uuid.New(),
+			ItemID:       key.ItemID,
+			ChangeType:   "price_list",
+			OldPrice:     oldPrice,
+			NewPrice:     newPrice,
+			CurrentPrice: item.Price,
+			CreatedBy:    &userInfo.ID,
+			UpdatedBy:    &userInfo.ID,
+			Description:  fmt.Sprintf("Price list %s (min qty %d) %s: %d -> %d", priceList.Name, key.MinQuantity, action, oldPrice, newPrice),
+		}); err != nil {
+			return fmt.Errorf("error recording price history: %w", err)
+		}
+		return nil
+	}
+
+	for _, it := range newItems {
+		key := breakKey{it.ItemID, it.MinQuantity}
+		oldPrice, existed := oldPrices[key]
+		switch {
+		case !existed:
+			if err := write(key, 0, it.UnitPrice, "added"); err != nil {
+				return err
+			}
+		case oldPrice != it.UnitPrice:
+			if err := write(key, oldPrice, it.UnitPrice, "changed"); err != nil {
+				return err
+			}
+		}
+	}
+	for _, it := range oldItems {
+		key := breakKey{it.ItemID, it.MinQuantity}
+		if _, kept := newPrices[key]; !kept {
+			if err := write(key, it.UnitPrice, 0, "removed"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PriceListService) refetch(priceList *models.PriceList) (*models.PriceList, error) {
+	saved, err := s.PriceListRepository.FindById(nil, priceList.ID.String(), false)
+	if err != nil {
+		log.Printf("Warning: price list saved but failed to fetch data: %v", err)
+		return priceList, nil
+	}
+	return saved, nil
+}
+
+func buildPriceListItems(priceListID uuid.UUID, reqItems []models.PriceListItemRequest) []models.PriceListItem {
+	items := make([]models.PriceListItem, 0, len(reqItems))
+	for _, it := range reqItems {
+		items = append(items, models.PriceListItem{
+			ID:          uuid.New(),
+			PriceListID: priceListID,
+			ItemID:      it.ItemID,
+			MinQuantity: priceBreakQuantity(it.MinQuantity),
+			UnitPrice:   it.UnitPrice,
+		})
+	}
+	return items
+}
+
+func priceBreakQuantity(minQuantity int) int {
+	if minQuantity < 1 {
+		return 1
+	}
+	return minQuantity
+}
+
+// resolveItemPrice memilih harga item untuk customer: price list customer didahulukan dari
+// price list tipe customer, lalu list dengan ValidFrom terbaru; di dalam list dipakai quantity
+// break terbesar yang <= qty. Bila tidak ada yang cocok, dipakai Item.Price.
+func resolveItemPrice(
+	tx *gorm.DB,
+	priceListRepo repositories.PriceListRepository,
+	customer *models.Customer,
+	item *models.Item,
+	quantity int,
+	at time.Time,
+) (*models.ResolvedPrice, error) {
+	resolved := &models.ResolvedPrice{
+		ItemID:    item.ID,
+		Quantity:  quantity,
+		UnitPrice: item.Price,
+		Source:    "item_price",
+	}
+
+	rows, err := priceListRepo.FindApplicableItems(tx, customer.ID, customer.CustomerTypeID, item.ID, at)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving price list: %w", err)
+	}
+
+	candidates := rows[:0]
+	for _, row := range rows {
+		if row.PriceList != nil && row.MinQuantity <= quantity {
+			candidates = append(candidates, row)
+		}
+	}
+	if len(candidates) == 0 {
+		return resolved, nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		aCustomer, bCustomer := a.PriceList.CustomerID != nil, b.PriceList.CustomerID != nil
+		if aCustomer != bCustomer {
+			return aCustomer
+		}
+		if !a.PriceList.ValidFrom.Equal(b.PriceList.ValidFrom) {
+			return a.PriceList.ValidFrom.After(b.PriceList.ValidFrom)
+		}
+		if a.PriceListID != b.PriceListID {
+			return a.PriceList.CreatedAt.After(b.PriceList.CreatedAt)
+		}
+		return a.MinQuantity > b.MinQuantity
+	})
+
+	best := candidates[0]
+	resolved.UnitPrice = best.UnitPrice
+	resolved.PriceListID = &best.PriceListID
+	resolved.PriceListName = best.PriceList.Name
+	resolved.MinQuantity = best.MinQuantity
+	resolved.EnforceMinimum = best.PriceList.EnforceMinimum
+	if best.PriceList.CustomerID != nil {
+		resolved.Source = "customer_price_list"
+	} else {
+		resolved.Source = "customer_type_price_list"
+	}
+	return resolved, nil
+}
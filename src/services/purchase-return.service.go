@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseReturnService struct {
+	PurchaseReturnRepository repositories.PurchaseReturnRepository
+	PurchaseOrderRepository  repositories.PurchaseOrderRepository
+	ItemRepository           repositories.ItemRepository
+	PaymentRepository        repositories.PaymentRepository
+	ItemLotService           *ItemLotService
+	NumberSequenceService    *NumberSequenceService
+}
+
+func NewPurchaseReturnService(
+	purchaseReturnRepo repositories.PurchaseReturnRepository,
+	poRepo repositories.PurchaseOrderRepository,
+	itemRepo repositories.ItemRepository,
+	paymentRepo repositories.PaymentRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *PurchaseReturnService {
+	return &PurchaseReturnService{
+		PurchaseReturnRepository: purchaseReturnRepo,
+		PurchaseOrderRepository:  poRepo,
+		ItemRepository:           itemRepo,
+		PaymentRepository:        paymentRepo,
+		ItemLotService:           NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService:    NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *PurchaseReturnService) GetAllPurchaseReturnsPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.PurchaseReturnPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.PurchaseReturnRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetPurchaseReturn, 0, len(rows))
+	for _, pr := range rows {
+		data = append(data, s.mapPurchaseReturnToResponse(pr))
+	}
+
+	return &models.PurchaseReturnPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *PurchaseReturnService) GetPurchaseReturnByID(returnId string) (*models.ResponseGetPurchaseReturn, error) {
+	pr, err := s.PurchaseReturnRepository.FindById(nil, returnId, false)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapPurchaseReturnToResponse(*pr)
+	return &out, nil
+}
+
+func (s *PurchaseReturnService) GenerateDebitNote(returnId string) (string, []byte, error) {
+	pr, err := s.PurchaseReturnRepository.FindById(nil, returnId, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if pr.Status != "Completed" || pr.DebitNoteNumber == nil {
+		return "", nil, errors.New("debit note is only available for completed purchase returns")
+	}
+
+	filename, data, err := documents.GenerateDebitNotePDF(pr)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate debit note PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+// CreatePurchaseReturn mencatat retur ke supplier (Draft) atas baris PO yang sudah diterima.
+// Stok & hutang belum berubah sampai retur di-Complete.
+func (s *PurchaseReturnService) CreatePurchaseReturn(req *models.PurchaseReturnCreateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.PurchaseReturn, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	po, err := s.PurchaseOrderRepository.FindById(tx, req.PurchaseOrderID.String(), false)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	switch po.POStatus {
+	case "Draft", "PendingApproval", "Approved", "Rejected", "Ordered":
+		tx.Rollback()
+		return nil, fmt.Errorf("returns can only be created for purchase orders with received goods (current status: %s)", po.POStatus)
+	}
+
+	if req.WarehouseID != nil {
+		if _, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	returnID := uuid.New()
+	items, debitAmount, err := s.buildReturnItems(tx, po, returnID, req.Items)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	settlement := req.SettlementType
+	if settlement == "" {
+		settlement = "DebitNote"
+	}
+	refundAmount := 0
+	if settlement == "Refund" {
+		refundAmount = req.RefundAmount
+		if refundAmount == 0 {
+			refundAmount = debitAmount
+		}
+		if refundAmount > debitAmount {
+			tx.Rollback()
+			return nil, fmt.Errorf("refund amount (%d) cannot exceed return value (%d)", refundAmount, debitAmount)
+		}
+	}
+
+	returnNumber, err := s.NumberSequenceService.Next(tx, models.SequencePurchaseReturn)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating return number: %w", err)
+	}
+
+	purchaseReturn := &models.PurchaseReturn{
+		ID:                  returnID,
+		ReturnNumber:        returnNumber,
+		PurchaseOrderID:     po.ID,
+		SupplierID:          po.SupplierID,
+		WarehouseID:         req.WarehouseID,
+		ReturnDate:          req.ReturnDate,
+		Status:              "Draft",
+		SettlementType:      settlement,
+		DebitAmount:         debitAmount,
+		RefundAmount:        refundAmount,
+		RefundMethod:        strings.TrimSpace(req.RefundMethod),
+		Notes:               strings.TrimSpace(req.Notes),
+		CreatedBy:           &userInfo.ID,
+		PurchaseReturnItems: items,
+	}
+
+	if _, err := s.PurchaseReturnRepository.Insert(tx, purchaseReturn); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating purchase return: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.PurchaseReturnRepository.FindById(nil, returnID.String(), false)
+}
+
+// UpdatePurchaseReturnStatus menjalankan Draft -> Completed (stok keluar + debit note/refund) atau Draft -> Cancelled.
+func (s *PurchaseReturnService) UpdatePurchaseReturnStatus(returnId string, req *models.PurchaseReturnStatusUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.PurchaseReturn, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	purchaseReturn, err := s.PurchaseReturnRepository.FindById(tx, returnId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if purchaseReturn.Status != "Draft" {
+		tx.Rollback()
+		return nil, fmt.Errorf("invalid status transition from %s to %s", purchaseReturn.Status, req.Status)
+	}
+
+	if req.Status == "Completed" {
+		if err := s.completeReturn(tx, purchaseReturn, userInfo.ID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	purchaseReturn.Status = req.Status
+	if strings.TrimSpace(req.Notes) != "" {
+		purchaseReturn.Notes = strings.TrimSpace(req.Notes)
+	}
+
+	if _, err := s.PurchaseReturnRepository.Update(tx, purchaseReturn); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating purchase return: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.PurchaseReturnRepository.FindById(nil, returnId, false)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *PurchaseReturnService) completeReturn(tx *gorm.DB, purchaseReturn *models.PurchaseReturn, userID uuid.UUID) error {
+	var po models.PurchaseOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, "id = ?", purchaseReturn.PurchaseOrderID).Error; err != nil {
+		return repositories.HandleDatabaseError(err, "purchase_order")
+	}
+
+	// qty bisa berubah sejak Draft dibuat (retur lain sudah Completed), cek ulang
+	poItemIDs := make([]uuid.UUID, 0, len(purchaseReturn.PurchaseReturnItems))
+	for _, it := range purchaseReturn.PurchaseReturnItems {
+		poItemIDs = append(poItemIDs, it.PurchaseOrderItemID)
+	}
+	returned, err := s.PurchaseReturnRepository.SumReturnedQuantity(tx, poItemIDs, &purchaseReturn.ID)
+	if err != nil {
+		return err
+	}
+	var poItems []models.PurchaseOrderItem
+	if err := tx.Where("id IN ?", poItemIDs).Find(&poItems).Error; err != nil {
+		return fmt.Errorf("error loading purchase order items: %w", err)
+	}
+	receivedQty := make(map[uuid.UUID]int, len(poItems))
+	for _, pi := range poItems {
+		receivedQty[pi.ID] = pi.ReceivedQuantity
+	}
+
+	warehouse, err := s.ItemLotService.ResolveWarehouse(tx, purchaseReturn.WarehouseID)
+	if err != nil {
+		return err
+	}
+
+	desc := fmt.Sprintf("Purchase return %s (PO %s) from %s", purchaseReturn.ReturnNumber, po.PONumber, warehouse.Name)
+	for i := range purchaseReturn.PurchaseReturnItems {
+		line := &purchaseReturn.PurchaseReturnItems[i]
+		if returned[line.PurchaseOrderItemID]+line.Quantity > receivedQty[line.PurchaseOrderItemID] {
+			return fmt.Errorf("return quantity for %s exceeds received quantity", line.Item.Name)
+		}
+		returned[line.PurchaseOrderItemID] += line.Quantity
+
+		item, err := s.ItemRepository.FindById(tx, line.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found: %w", line.ItemID, err)
+		}
+		lot, err := s.ItemLotService.TakeFromLot(tx, item, warehouse.ID, line.LotNumber, line.Quantity,
+			fmt.Sprintf("%s: -%d units, %s", desc, line.Quantity, line.Reason), userID)
+		if err != nil {
+			return err
+		}
+		line.LotID = &lot.ID
+		if err := s.PurchaseReturnRepository.UpdateItem(tx, line); err != nil {
+			return fmt.Errorf("error updating purchase return item: %w", err)
+		}
+	}
+
+	debitNoteNumber, err := s.NumberSequenceService.Next(tx, models.SequenceDebitNote)
+	if err != nil {
+		return fmt.Errorf("error generating debit note number: %w", err)
+	}
+
+	// debit note mengurangi hutang PO; refund = supplier mengembalikan uang yang sudah dibayar
+	newTotal := po.TotalAmount - purchaseReturn.DebitAmount
+	if newTotal < 0 {
+		newTotal = 0
+	}
+	newPaid := po.PaidAmount
+
+	if purchaseReturn.SettlementType == "Refund" && purchaseReturn.RefundAmount > 0 {
+		if purchaseReturn.RefundAmount > po.PaidAmount {
+			return fmt.Errorf("refund amount (%d) exceeds amount paid on PO %s (%d)", purchaseReturn.RefundAmount, po.PONumber, po.PaidAmount)
+		}
+		refund := &models.Payment{
+			ID:              uuid.New(),
+			OrderType:       "PO",
+			PurchaseOrderID: &po.ID,
+			PaymentType:     "Refund",
+			Amount:          -purchaseReturn.RefundAmount,
+			PaymentDate:     time.Now(),
+			PaymentMethod:   purchaseReturn.RefundMethod,
+			ReferenceNumber: debitNoteNumber,
+			Notes:           fmt.Sprintf("Supplier refund for purchase return %s", purchaseReturn.ReturnNumber),
+		}
+		if _, err := s.PaymentRepository.Insert(tx, refund); err != nil {
+			return fmt.Errorf("error creating refund payment: %w", err)
+		}
+		purchaseReturn.RefundPaymentID = &refund.ID
+		newPaid -= purchaseReturn.RefundAmount
+	}
+
+	// PO sudah dibayar melebihi nilai barunya: kelebihan menjadi deposit supplier
+	if newPaid > newTotal {
+		excess := newPaid - newTotal
+		deposit, err := recordOverpaymentDeposit(tx, s.PaymentRepository, s.NumberSequenceService, "PO", po.ID, po.SupplierID, excess,
+			debitNoteNumber, fmt.Sprintf("Supplier deposit from purchase return %s (PO %s)", purchaseReturn.ReturnNumber, po.PONumber), userID)
+		if err != nil {
+			return err
+		}
+		newPaid = newTotal
+		log.Printf("Purchase return %s: overpayment %d moved to supplier deposit %s", purchaseReturn.ReturnNumber, excess, deposit.ReceiptNumber)
+	}
+
+	if err := tx.Model(&models.PurchaseOrder{}).
+		Where("id = ?", po.ID).
+		Updates(map[string]interface{}{
+			"total_amount":   newTotal,
+			"paid_amount":    newPaid,
+			"payment_status": paymentStatusFor(newTotal, newPaid),
+		}).Error; err != nil {
+		return fmt.Errorf("error updating purchase order: %w", err)
+	}
+
+	now := time.Now()
+	purchaseReturn.DebitNoteNumber = &debitNoteNumber
+	purchaseReturn.CompletedAt = &now
+	purchaseReturn.CompletedBy = &userID
+
+	log.Printf("Purchase return %s completed: debit %d, refund %d (PO %s)", purchaseReturn.ReturnNumber, purchaseReturn.DebitAmount, purchaseReturn.RefundAmount, po.PONumber)
+	return nil
+}
+
+func (s *PurchaseReturnService) buildReturnItems(tx *gorm.DB, po *models.PurchaseOrder, returnID uuid.UUID, reqItems []models.PurchaseReturnItemRequest) ([]models.PurchaseReturnItem, int, error) {
+	poItems := make(map[uuid.UUID]models.PurchaseOrderItem, len(po.PurchaseOrderItems))
+	poItemIDs := make([]uuid.UUID, 0, len(po.PurchaseOrderItems))
+	for _, pi := range po.PurchaseOrderItems {
+		poItems[pi.ID] = pi
+		poItemIDs = append(poItemIDs, pi.ID)
+	}
+
+	returned, err := s.PurchaseReturnRepository.SumReturnedQuantity(tx, poItemIDs, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]models.PurchaseReturnItem, 0, len(reqItems))
+	total := 0
+	for _, ri := range reqItems {
+		pi, ok := poItems[ri.PurchaseOrderItemID]
+		if !ok {
+			return nil, 0, fmt.Errorf("purchase order item %s does not belong to PO %s", ri.PurchaseOrderItemID, po.PONumber)
+		}
+
+		returned[pi.ID] += ri.Quantity
+		if returned[pi.ID] > pi.ReceivedQuantity {
+			return nil, 0, fmt.Errorf("return quantity for %s exceeds received quantity (received %d, returned %d)",
+				pi.Item.Name, pi.ReceivedQuantity, returned[pi.ID])
+		}
+
+		// sama dengan default penerimaan (GRN): lot tanpa nomor dicatat dengan nomor PO
+		lotNumber := strings.TrimSpace(ri.LotNumber)
+		if lotNumber == "" {
+			lotNumber = po.PONumber
+		}
+
+		lineTotal := returnLineAmount(ri.Quantity, pi.Quantity, pi.UnitPrice, pi.NetAmount, po.Subtotal > 0)
+		total += lineTotal
+		items = append(items, models.PurchaseReturnItem{
+			ID:                  uuid.New(),
+			PurchaseReturnID:    returnID,
+			PurchaseOrderItemID: pi.ID,
+			ItemID:              pi.ItemID,
+			Quantity:            ri.Quantity,
+			UnitPrice:           pi.UnitPrice,
+			TotalPrice:          lineTotal,
+			Reason:              strings.TrimSpace(ri.Reason),
+			LotNumber:           lotNumber,
+		})
+	}
+	return items, total, nil
+}
+
+func (s *PurchaseReturnService) mapPurchaseReturnToResponse(pr models.PurchaseReturn) models.ResponseGetPurchaseReturn {
+	return models.ResponseGetPurchaseReturn{
+		ID:                  pr.ID,
+		ReturnNumber:        pr.ReturnNumber,
+		DebitNoteNumber:     pr.DebitNoteNumber,
+		PurchaseOrderID:     pr.PurchaseOrderID,
+		SupplierID:          pr.SupplierID,
+		WarehouseID:         pr.WarehouseID,
+		ReturnDate:          pr.ReturnDate,
+		Status:              pr.Status,
+		SettlementType:      pr.SettlementType,
+		DebitAmount:         pr.DebitAmount,
+		RefundAmount:        pr.RefundAmount,
+		RefundMethod:        pr.RefundMethod,
+		RefundPaymentID:     pr.RefundPaymentID,
+		Notes:               pr.Notes,
+		CreatedBy:           pr.CreatedBy,
+		CompletedBy:         pr.CompletedBy,
+		CompletedAt:         pr.CompletedAt,
+		CreatedAt:           pr.CreatedAt,
+		UpdatedAt:           pr.UpdatedAt,
+		DeletedAt:           pr.DeletedAt,
+		PurchaseOrder:       pr.PurchaseOrder,
+		Supplier:            pr.Supplier,
+		Warehouse:           pr.Warehouse,
+		RefundPayment:       pr.RefundPayment,
+		PurchaseReturnItems: pr.PurchaseReturnItems,
+	}
+}
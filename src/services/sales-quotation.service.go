@@ -0,0 +1,566 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SalesQuotationService struct {
+	SalesQuotationRepository repositories.SalesQuotationRepository
+	CustomerRepository       repositories.CustomerRepository
+	SalesPersonRepository    repositories.SalesPersonRepository
+	ItemRepository           repositories.ItemRepository
+	NumberSequenceService    *NumberSequenceService
+	SalesOrderService        *SalesOrderService
+}
+
+func NewSalesQuotationService(
+	quotationRepo repositories.SalesQuotationRepository,
+	soRepo repositories.SalesOrderRepository,
+	spRepo repositories.SalesPersonRepository,
+	customerRepo repositories.CustomerRepository,
+	itemRepo repositories.ItemRepository,
+	paymentRepo repositories.PaymentRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+	shipmentRepo repositories.ShipmentRepository,
+	creditOverrideRepo repositories.CreditOverrideRepository,
+	priceListRepo repositories.PriceListRepository,
+) *SalesQuotationService {
+	return &SalesQuotationService{
+		SalesQuotationRepository: quotationRepo,
+		CustomerRepository:       customerRepo,
+		SalesPersonRepository:    spRepo,
+		ItemRepository:           itemRepo,
+		NumberSequenceService:    NewNumberSequenceService(numberSequenceRepo),
+		SalesOrderService:        NewSalesOrderService(soRepo, spRepo, customerRepo, itemRepo, paymentRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo, shipmentRepo, creditOverrideRepo, priceListRepo),
+	}
+}
+
+func (s *SalesQuotationService) GetAllSalesQuotationsPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.SalesQuotationPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.SalesQuotationRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetSalesQuotation, 0, len(rows))
+	for _, q := range rows {
+		data = append(data, s.mapSalesQuotationToResponse(q))
+	}
+
+	return &models.SalesQuotationPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *SalesQuotationService) GetSalesQuotationByID(quotationId string) (*models.ResponseGetSalesQuotation, error) {
+	q, err := s.SalesQuotationRepository.FindById(nil, quotationId, false)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapSalesQuotationToResponse(*q)
+	return &out, nil
+}
+
+// GetSalesQuotationRevisions mengembalikan semua versi dari nomor quotation yang sama.
+func (s *SalesQuotationService) GetSalesQuotationRevisions(quotationId string) ([]models.ResponseGetSalesQuotation, error) {
+	q, err := s.SalesQuotationRepository.FindById(nil, quotationId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.SalesQuotationRepository.FindRevisions(nil, q.QuotationNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetSalesQuotation, 0, len(rows))
+	for _, row := range rows {
+		data = append(data, s.mapSalesQuotationToResponse(row))
+	}
+	return data, nil
+}
+
+func (s *SalesQuotationService) GenerateQuotationDocument(quotationId string) (string, []byte, error) {
+	q, err := s.SalesQuotationRepository.FindById(nil, quotationId, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filename, data, err := documents.GenerateQuotationPDF(q)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate quotation PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+func (s *SalesQuotationService) CreateSalesQuotation(req *models.SalesQuotationCreateRequest, userInfo *models.User) (*models.SalesQuotation, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	quotation := &models.SalesQuotation{ID: uuid.New(), Status: "Draft", CreatedBy: &userInfo.ID}
+	if err := s.applyQuotationRequest(tx, quotation, req); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	number, err := s.NumberSequenceService.Next(tx, models.SequenceSalesQuotation)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating quotation number: %w", err)
+	}
+	quotation.QuotationNumber = number
+
+	if _, err := s.SalesQuotationRepository.Insert(tx, quotation); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating sales quotation: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.refetch(quotation)
+}
+
+// UpdateSalesQuotation mengubah quotation Draft di tempat. Quotation yang sudah dikirim
+// harus direvisi (ReviseSalesQuotation) agar versi yang diterima customer tetap tersimpan.
+func (s *SalesQuotationService) UpdateSalesQuotation(quotationId string, req *models.SalesQuotationCreateRequest, userInfo *models.User) (*models.SalesQuotation, error) {
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	quotation, err := s.SalesQuotationRepository.FindById(tx, quotationId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if quotation.Status != "Draft" {
+		tx.Rollback()
+		return nil, fmt.Errorf("only Draft quotations can be edited; create a revision instead (current status: %s)", quotation.Status)
+	}
+
+	if err := s.applyQuotationRequest(tx, quotation, req); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := s.SalesQuotationRepository.ReplaceItems(tx, quotation.ID, quotation.SalesQuotationItems); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating quotation items: %w", err)
+	}
+	if _, err := s.SalesQuotationRepository.Update(tx, quotation); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating sales quotation: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.refetch(quotation)
+}
+
+// ReviseSalesQuotation membuat versi baru (Draft, Revision+1) dari quotation yang sudah dikirim,
+// ditolak, atau kedaluwarsa. Versi lama menjadi Superseded.
+func (s *SalesQuotationService) ReviseSalesQuotation(quotationId string, req *models.SalesQuotationCreateRequest, userInfo *models.User) (*models.SalesQuotation, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	previous, err := s.SalesQuotationRepository.FindById(tx, quotationId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	switch previous.Status {
+	case "Sent", "Rejected", "Expired":
+	default:
+		tx.Rollback()
+		return nil, fmt.Errorf("quotations in %s status cannot be revised", previous.Status)
+	}
+
+	revision := &models.SalesQuotation{
+		ID:              uuid.New(),
+		QuotationNumber: previous.QuotationNumber,
+		Revision:        previous.Revision + 1,
+		PreviousID:      &previous.ID,
+		Status:          "Draft",
+		CreatedBy:       &userInfo.ID,
+	}
+	if err := s.applyQuotationRequest(tx, revision, req); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	previous.Status = "Superseded"
+	if _, err := s.SalesQuotationRepository.Update(tx, previous); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error superseding previous revision: %w", err)
+	}
+	if _, err := s.SalesQuotationRepository.Insert(tx, revision); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating quotation revision: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.refetch(revision)
+}
+
+// UpdateSalesQuotationStatus: Draft -> Sent, Sent -> Accepted/Rejected. Quotation yang masa
+// berlakunya sudah lewat otomatis menjadi Expired dan tidak bisa diterima.
+func (s *SalesQuotationService) UpdateSalesQuotationStatus(quotationId string, req *models.SalesQuotationStatusUpdateRequest, userInfo *models.User) (*models.SalesQuotation, error) {
+	_ = userInfo
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Status == "Rejected" && req.Reason == "" {
+		return nil, errors.New("reason is required when rejecting a quotation")
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	quotation, err := s.SalesQuotationRepository.FindById(tx, quotationId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	if expired, err := s.expireIfPastValidity(tx, quotation, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	} else if expired {
+		if err := tx.Commit().Error; err != nil {
+			return nil, fmt.Errorf("commit: %w", err)
+		}
+		return nil, fmt.Errorf("quotation expired on %s", quotation.ValidUntil.Format("02 January 2006"))
+	}
+
+	if err := validateQuotationTransition(quotation.Status, req.Status); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	quotation.Status = req.Status
+	switch req.Status {
+	case "Sent":
+		quotation.SentAt = &now
+	case "Accepted":
+		quotation.DecidedAt = &now
+	case "Rejected":
+		quotation.DecidedAt = &now
+		quotation.StatusReason = req.Reason
+	}
+
+	if _, err := s.SalesQuotationRepository.Update(tx, quotation); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating quotation status: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return s.refetch(quotation)
+}
+
+// ConvertToSalesOrder membuat SO dari quotation Accepted dengan harga yang ditawarkan. SO dibuat
+// di tx yang sama dengan perubahan status quotation (baris quotation dikunci) agar tidak terbit dua SO.
+// Diskon order & PPN dihitung ulang (lihat convertedSalesOrderRequest); harga yang ditawarkan di bawah
+// price list EnforceMinimum ditolak dengan ErrPriceBelowMinimum.
+func (s *SalesQuotationService) ConvertToSalesOrder(quotationId string, req *models.SalesQuotationConvertRequest, userInfo *models.User) (*models.SalesOrder, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	quotation, err := s.SalesQuotationRepository.FindById(tx, quotationId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if quotation.Status != "Accepted" {
+		tx.Rollback()
+		return nil, fmt.Errorf("only Accepted quotations can be converted (current status: %s)", quotation.Status)
+	}
+
+	so, override, err := s.SalesOrderService.createSalesOrderTx(tx, convertedSalesOrderRequest(quotation, req), userInfo)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, ErrPriceBelowMinimum) {
+			return nil, fmt.Errorf("quotation %s rev. %d has a quoted price below the enforced price list minimum, revise the quotation before converting: %w", quotation.QuotationNumber, quotation.Revision, err)
+		}
+		return nil, fmt.Errorf("error creating sales order from quotation: %w", err)
+	}
+
+	now := time.Now()
+	quotation.Status = "Converted"
+	quotation.SalesOrderID = &so.ID
+	quotation.ConvertedAt = &now
+	if _, err := s.SalesQuotationRepository.Update(tx, quotation); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error marking quotation as converted: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	notifyBelowListPrice(so.SONumber, so.ID, so.SalesOrderItems, userInfo)
+	NotifyCreditOverride(override, so.SONumber, userInfo)
+
+	createdSO, err := s.SalesOrderService.SalesOrderRepository.FindById(nil, so.ID.String(), false)
+	if err != nil {
+		log.Printf("Warning: SO created from quotation but failed to fetch created data: %v", err)
+		return so, nil
+	}
+	return createdSO, nil
+}
+
+// convertedSalesOrderRequest menyusun request SO dari quotation. Harga satuan yang ditawarkan dipakai
+// apa adanya, tetapi diskon order & PPN diambil dari request konversi lalu dihitung ulang oleh
+// applySalesOrderPricing, jadi grand total SO bisa berbeda dari TotalAmount quotation (yang tanpa PPN).
+func convertedSalesOrderRequest(quotation *models.SalesQuotation, req *models.SalesQuotationConvertRequest) *models.SalesOrderCreateRequest {
+	soDate := time.Now()
+	if req.SODate != nil {
+		soDate = *req.SODate
+	}
+	soStatus := req.SOStatus
+	if soStatus == "" {
+		soStatus = "Draft"
+	}
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		notes = quotation.Notes
+	}
+	ref := fmt.Sprintf("Quotation %s rev. %d", quotation.QuotationNumber, quotation.Revision)
+	if notes == "" {
+		notes = ref
+	} else {
+		notes = ref + " - " + notes
+	}
+
+	items := make([]models.SalesOrderItemRequest, 0, len(quotation.SalesQuotationItems))
+	for _, it := range quotation.SalesQuotationItems {
+		items = append(items, models.SalesOrderItemRequest{
+			ItemID:    it.ItemID,
+			Quantity:  it.Quantity,
+			UnitPrice: it.UnitPrice,
+		})
+	}
+
+	return &models.SalesOrderCreateRequest{
+		SalesPersonID:    quotation.SalesPersonID,
+		CustomerID:       quotation.CustomerID,
+		SODate:           soDate,
+		SOStatus:         soStatus,
+		EstimatedArrival: req.EstimatedArrival,
+		TermOfPayment:    quotation.TermOfPayment,
+		DiscountType:     req.DiscountType,
+		DiscountValue:    req.DiscountValue,
+		TaxRate:          req.TaxRate,
+		TaxInclusive:     req.TaxInclusive,
+		DPAmount:         req.DPAmount,
+		DueDate:          req.DueDate,
+		Notes:            notes,
+		Items:            items,
+
+		CreditOverrideReason: req.CreditOverrideReason,
+	}
+}
+
+// ExpireSalesQuotations menandai quotation Draft/Sent yang masa berlakunya sudah lewat.
+func (s *SalesQuotationService) ExpireSalesQuotations(now time.Time) ([]models.SalesQuotation, error) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	rows, err := s.SalesQuotationRepository.FindExpired(nil, startOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, q := range rows {
+		ids = append(ids, q.ID)
+	}
+	if err := s.SalesQuotationRepository.MarkExpired(nil, ids); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *SalesQuotationService) applyQuotationRequest(tx *gorm.DB, quotation *models.SalesQuotation, req *models.SalesQuotationCreateRequest) error {
+	if req.ValidUntil.Before(req.QuotationDate) {
+		return errors.New("valid_until must not be before quotation_date")
+	}
+	if _, err := s.CustomerRepository.FindById(tx, req.CustomerID.String(), false); err != nil {
+		return errors.New("customer not found")
+	}
+	if _, err := s.SalesPersonRepository.FindById(tx, req.SalesPersonID.String(), false); err != nil {
+		return errors.New("sales person not found")
+	}
+
+	items := make([]models.SalesQuotationItem, 0, len(req.Items))
+	total := 0
+	for _, itemReq := range req.Items {
+		itemData, err := s.ItemRepository.FindById(tx, itemReq.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found", itemReq.ItemID.String())
+		}
+
+		unitPrice := itemReq.UnitPrice
+		if unitPrice == 0 {
+			unitPrice = itemData.Price
+		}
+		totalPrice := itemReq.Quantity * unitPrice
+		total += totalPrice
+
+		items = append(items, models.SalesQuotationItem{
+			ID:               uuid.New(),
+			SalesQuotationID: quotation.ID,
+			ItemID:           itemReq.ItemID,
+			UoMID:            itemData.UoMID,
+			Quantity:         itemReq.Quantity,
+			UnitPrice:        unitPrice,
+			TotalPrice:       totalPrice,
+			Notes:            itemReq.Notes,
+		})
+	}
+
+	quotation.CustomerID = req.CustomerID
+	quotation.SalesPersonID = req.SalesPersonID
+	quotation.QuotationDate = req.QuotationDate
+	quotation.ValidUntil = req.ValidUntil
+	quotation.TermOfPayment = req.TermOfPayment
+	quotation.Notes = req.Notes
+	quotation.TotalAmount = total
+	quotation.SalesQuotationItems = items
+	return nil
+}
+
+func (s *SalesQuotationService) expireIfPastValidity(tx *gorm.DB, quotation *models.SalesQuotation, now time.Time) (bool, error) {
+	if quotation.Status != "Draft" && quotation.Status != "Sent" {
+		return false, nil
+	}
+	v := quotation.ValidUntil.In(now.Location())
+	endOfDay := time.Date(v.Year(), v.Month(), v.Day(), 23, 59, 59, 0, now.Location())
+	if !now.After(endOfDay) {
+		return false, nil
+	}
+
+	quotation.Status = "Expired"
+	if _, err := s.SalesQuotationRepository.Update(tx, quotation); err != nil {
+		return false, fmt.Errorf("error expiring quotation: %w", err)
+	}
+	return true, nil
+}
+
+func (s *SalesQuotationService) refetch(quotation *models.SalesQuotation) (*models.SalesQuotation, error) {
+	out, err := s.SalesQuotationRepository.FindById(nil, quotation.ID.String(), false)
+	if err != nil {
+		log.Printf("Warning: quotation saved but failed to fetch data: %v", err)
+		return quotation, nil
+	}
+	return out, nil
+}
+
+func (s *SalesQuotationService) mapSalesQuotationToResponse(q models.SalesQuotation) models.ResponseGetSalesQuotation {
+	return models.ResponseGetSalesQuotation{
+		ID:                  q.ID,
+		QuotationNumber:     q.QuotationNumber,
+		Revision:            q.Revision,
+		PreviousID:          q.PreviousID,
+		CustomerID:          q.CustomerID,
+		SalesPersonID:       q.SalesPersonID,
+		QuotationDate:       q.QuotationDate,
+		ValidUntil:          q.ValidUntil,
+		TermOfPayment:       q.TermOfPayment,
+		Status:              q.Status,
+		TotalAmount:         q.TotalAmount,
+		Notes:               q.Notes,
+		StatusReason:        q.StatusReason,
+		SalesOrderID:        q.SalesOrderID,
+		CreatedBy:           q.CreatedBy,
+		SentAt:              q.SentAt,
+		DecidedAt:           q.DecidedAt,
+		ConvertedAt:         q.ConvertedAt,
+		CreatedAt:           q.CreatedAt,
+		UpdatedAt:           q.UpdatedAt,
+		DeletedAt:           q.DeletedAt,
+		Customer:            q.Customer,
+		SalesPerson:         q.SalesPerson,
+		SalesOrder:          q.SalesOrder,
+		SalesQuotationItems: q.SalesQuotationItems,
+	}
+}
+
+func validateQuotationTransition(current, next string) error {
+	valid := map[string][]string{
+		"Draft": {"Sent"},
+		"Sent":  {"Accepted", "Rejected"},
+	}
+	for _, allowed := range valid[current] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status transition from %s to %s", current, next)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// stubPriceListRepository hanya mengimplementasikan lookup yang dipakai resolveItemPrice.
+type stubPriceListRepository struct {
+	repositories.PriceListRepository
+	rows []models.PriceListItem
+}
+
+func (r *stubPriceListRepository) FindApplicableItems(tx *gorm.DB, customerID uuid.UUID, customerTypeID uuid.UUID, itemID uuid.UUID, at time.Time) ([]models.PriceListItem, error) {
+	return r.rows, nil
+}
+
+func newAcceptedQuotation() *models.SalesQuotation {
+	return &models.SalesQuotation{
+		ID:              uuid.New(),
+		QuotationNumber: "QT-0001",
+		Revision:        1,
+		CustomerID:      uuid.New(),
+		SalesPersonID:   uuid.New(),
+		TermOfPayment:   "Full",
+		Status:          "Accepted",
+		TotalAmount:     1000,
+		SalesQuotationItems: []models.SalesQuotationItem{
+			{ItemID: uuid.New(), Quantity: 2, UnitPrice: 300, TotalPrice: 600},
+			{ItemID: uuid.New(), Quantity: 4, UnitPrice: 100, TotalPrice: 400},
+		},
+	}
+}
+
+func TestConvertedSalesOrderRequestKeepsQuotedPrices(t *testing.T) {
+	quotation := newAcceptedQuotation()
+	rate := 11.0
+
+	req := convertedSalesOrderRequest(quotation, &models.SalesQuotationConvertRequest{TaxRate: &rate})
+
+	if len(req.Items) != len(quotation.SalesQuotationItems) {
+		t.Fatalf("expected %d items, got %d", len(quotation.SalesQuotationItems), len(req.Items))
+	}
+	for i, it := range quotation.SalesQuotationItems {
+		if req.Items[i].UnitPrice != it.UnitPrice || req.Items[i].Quantity != it.Quantity {
+			t.Fatalf("item %d: expected %d x %d, got %d x %d", i, it.Quantity, it.UnitPrice, req.Items[i].Quantity, req.Items[i].UnitPrice)
+		}
+	}
+	if req.SOStatus != "Draft" {
+		t.Fatalf("expected default status Draft, got %s", req.SOStatus)
+	}
+	if req.TaxRate == nil || *req.TaxRate != rate {
+		t.Fatalf("expected tax rate %v to be passed to the sales order", rate)
+	}
+}
+
+func TestConvertedSalesOrderIsRepricedWithPPN(t *testing.T) {
+	quotation := newAcceptedQuotation()
+	rate := 11.0
+
+	cases := []struct {
+		name         string
+		taxInclusive bool
+		grandTotal   int
+	}{
+		{"ppn added on top of quoted prices", false, 1110},
+		{"quoted prices already include ppn", true, 1000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := convertedSalesOrderRequest(quotation, &models.SalesQuotationConvertRequest{TaxRate: &rate, TaxInclusive: tc.taxInclusive})
+
+			so := &models.SalesOrder{TaxRate: *req.TaxRate, TaxInclusive: req.TaxInclusive}
+			lines := make([]models.SalesOrderItem, 0, len(req.Items))
+			for _, it := range req.Items {
+				lines = append(lines, models.SalesOrderItem{ItemID: it.ItemID, Quantity: it.Quantity, UnitPrice: it.UnitPrice})
+			}
+			if err := applySalesOrderPricing(so, lines); err != nil {
+				t.Fatalf("unexpected pricing error: %v", err)
+			}
+
+			if so.Subtotal != quotation.TotalAmount {
+				t.Fatalf("expected subtotal %d to match the quotation total, got %d", quotation.TotalAmount, so.Subtotal)
+			}
+			if so.GrandTotal != tc.grandTotal {
+				t.Fatalf("expected grand total %d, got %d", tc.grandTotal, so.GrandTotal)
+			}
+		})
+	}
+}
+
+func TestApplyListPriceRejectsQuotedPriceBelowEnforcedMinimum(t *testing.T) {
+	item := &models.Item{ID: uuid.New(), Name: "Barang A", Price: 500}
+	service := &SalesOrderService{
+		PriceListRepository: &stubPriceListRepository{rows: []models.PriceListItem{{
+			PriceListID: uuid.New(),
+			ItemID:      item.ID,
+			UnitPrice:   400,
+			PriceList:   &models.PriceList{Name: "Grosir", EnforceMinimum: true},
+		}}},
+	}
+
+	line := &models.SalesOrderItem{ItemID: item.ID, Quantity: 1}
+	err := service.applyListPrice(nil, &models.Customer{ID: uuid.New()}, item, line, 350, time.Now())
+
+	if !errors.Is(err, ErrPriceBelowMinimum) {
+		t.Fatalf("expected ErrPriceBelowMinimum, got %v", err)
+	}
+}
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SalesReturnService struct {
+	SalesReturnRepository repositories.SalesReturnRepository
+	SalesOrderRepository  repositories.SalesOrderRepository
+	ItemRepository        repositories.ItemRepository
+	PaymentRepository     repositories.PaymentRepository
+	ItemLotService        *ItemLotService
+	NumberSequenceService *NumberSequenceService
+}
+
+func NewSalesReturnService(
+	salesReturnRepo repositories.SalesReturnRepository,
+	soRepo repositories.SalesOrderRepository,
+	itemRepo repositories.ItemRepository,
+	paymentRepo repositories.PaymentRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *SalesReturnService {
+	return &SalesReturnService{
+		SalesReturnRepository: salesReturnRepo,
+		SalesOrderRepository:  soRepo,
+		ItemRepository:        itemRepo,
+		PaymentRepository:     paymentRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService: NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *SalesReturnService) GetAllSalesReturnsPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.SalesReturnPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.SalesReturnRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetSalesReturn, 0, len(rows))
+	for _, sr := range rows {
+		data = append(data, s.mapSalesReturnToResponse(sr))
+	}
+
+	return &models.SalesReturnPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *SalesReturnService) GetSalesReturnByID(returnId string) (*models.ResponseGetSalesReturn, error) {
+	sr, err := s.SalesReturnRepository.FindById(nil, returnId, false)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapSalesReturnToResponse(*sr)
+	return &out, nil
+}
+
+func (s *SalesReturnService) GenerateCreditNote(returnId string) (string, []byte, error) {
+	sr, err := s.SalesReturnRepository.FindById(nil, returnId, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if sr.Status != "Completed" || sr.CreditNoteNumber == nil {
+		return "", nil, errors.New("credit note is only available for completed sales returns")
+	}
+
+	filename, data, err := documents.GenerateCreditNotePDF(sr)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate credit note PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+// CreateSalesReturn mencatat retur (Draft) atas SO yang sudah (sebagian) dikirim. Stok & tagihan
+// belum berubah sampai retur di-Complete.
+func (s *SalesReturnService) CreateSalesReturn(req *models.SalesReturnCreateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.SalesReturn, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	so, err := s.SalesOrderRepository.FindById(tx, req.SalesOrderID.String(), false)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	switch so.SOStatus {
+	case "PartiallyShipped", "Shipped", "Delivered", "Closed":
+	default:
+		tx.Rollback()
+		return nil, fmt.Errorf("returns can only be created for shipped or delivered sales orders (current status: %s)", so.SOStatus)
+	}
+
+	if req.WarehouseID != nil {
+		if _, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	returnID := uuid.New()
+	items, creditAmount, err := s.buildReturnItems(tx, so, returnID, req.Items)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	settlement := req.SettlementType
+	if settlement == "" {
+		settlement = "CreditNote"
+	}
+	refundAmount := 0
+	if settlement == "Refund" {
+		refundAmount = req.RefundAmount
+		if refundAmount == 0 {
+			refundAmount = creditAmount
+		}
+		if refundAmount > creditAmount {
+			tx.Rollback()
+			return nil, fmt.Errorf("refund amount (%d) cannot exceed return value (%d)", refundAmount, creditAmount)
+		}
+	}
+
+	returnNumber, err := s.NumberSequenceService.Next(tx, models.SequenceSalesReturn)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating return number: %w", err)
+	}
+
+	salesReturn := &models.SalesReturn{
+		ID:               returnID,
+		ReturnNumber:     returnNumber,
+		SalesOrderID:     so.ID,
+		CustomerID:       so.CustomerID,
+		WarehouseID:      req.WarehouseID,
+		ReturnDate:       req.ReturnDate,
+		Status:           "Draft",
+		SettlementType:   settlement,
+		CreditAmount:     creditAmount,
+		RefundAmount:     refundAmount,
+		RefundMethod:     strings.TrimSpace(req.RefundMethod),
+		Notes:            strings.TrimSpace(req.Notes),
+		CreatedBy:        &userInfo.ID,
+		SalesReturnItems: items,
+	}
+
+	if _, err := s.SalesReturnRepository.Insert(tx, salesReturn); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating sales return: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.SalesReturnRepository.FindById(nil, returnID.String(), false)
+}
+
+// UpdateSalesReturnStatus menjalankan Draft -> Completed (restock + credit note/refund) atau Draft -> Cancelled.
+func (s *SalesReturnService) UpdateSalesReturnStatus(returnId string, req *models.SalesReturnStatusUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.SalesReturn, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	salesReturn, err := s.SalesReturnRepository.FindById(tx, returnId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if salesReturn.Status != "Draft" {
+		tx.Rollback()
+		return nil, fmt.Errorf("invalid status transition from %s to %s", salesReturn.Status, req.Status)
+	}
+
+	if req.Status == "Completed" {
+		if err := s.completeReturn(tx, salesReturn, userInfo.ID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	salesReturn.Status = req.Status
+	if strings.TrimSpace(req.Notes) != "" {
+		salesReturn.Notes = strings.TrimSpace(req.Notes)
+	}
+
+	if _, err := s.SalesReturnRepository.Update(tx, salesReturn); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating sales return: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.SalesReturnRepository.FindById(nil, returnId, false)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *SalesReturnService) completeReturn(tx *gorm.DB, salesReturn *models.SalesReturn, userID uuid.UUID) error {
+	var so models.SalesOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&so, "id = ?", salesReturn.SalesOrderID).Error; err != nil {
+		return repositories.HandleDatabaseError(err, "sales_order")
+	}
+
+	// qty bisa berubah sejak Draft dibuat (retur lain sudah Completed), cek ulang
+	soItemIDs := make([]uuid.UUID, 0, len(salesReturn.SalesReturnItems))
+	for _, it := range salesReturn.SalesReturnItems {
+		soItemIDs = append(soItemIDs, it.SalesOrderItemID)
+	}
+	returned, err := s.SalesReturnRepository.SumReturnedQuantity(tx, soItemIDs, &salesReturn.ID)
+	if err != nil {
+		return err
+	}
+	var soItems []models.SalesOrderItem
+	if err := tx.Where("id IN ?", soItemIDs).Find(&soItems).Error; err != nil {
+		return fmt.Errorf("error loading sales order items: %w", err)
+	}
+	var shipmentCount int64
+	if err := tx.Model(&models.Shipment{}).Where("sales_order_id = ?", so.ID).Count(&shipmentCount).Error; err != nil {
+		return fmt.Errorf("error loading shipments: %w", err)
+	}
+	soldQty := make(map[uuid.UUID]int, len(soItems))
+	for _, si := range soItems {
+		soldQty[si.ID] = returnableQuantity(so.SOStatus, shipmentCount > 0, si)
+	}
+
+	warehouse, err := s.ItemLotService.ResolveWarehouse(tx, salesReturn.WarehouseID)
+	if err != nil {
+		return err
+	}
+
+	desc := fmt.Sprintf("Sales return %s (SO %s) into %s", salesReturn.ReturnNumber, so.SONumber, warehouse.Name)
+	for i := range salesReturn.SalesReturnItems {
+		line := &salesReturn.SalesReturnItems[i]
+		if returned[line.SalesOrderItemID]+line.Quantity > soldQty[line.SalesOrderItemID] {
+			return fmt.Errorf("return quantity for %s exceeds delivered quantity", line.Item.Name)
+		}
+		returned[line.SalesOrderItemID] += line.Quantity
+
+		// barang karantina tidak kembali ke stok jual
+		if line.Condition != "Resellable" {
+			continue
+		}
+
+		item, err := s.ItemRepository.FindById(tx, line.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found: %w", line.ItemID, err)
+		}
+		receipt := models.LotReceipt{
+			WarehouseID: warehouse.ID,
+			LotNumber:   *line.LotNumber,
+			ExpiredAt:   line.ExpiredAt,
+			Quantity:    line.Quantity,
+			Notes:       salesReturn.ReturnNumber,
+		}
+		lot, err := s.ItemLotService.ReceiveLot(tx, item, receipt, fmt.Sprintf("%s: %s", desc, line.Reason), userID)
+		if err != nil {
+			return err
+		}
+		line.LotID = &lot.ID
+		if err := s.SalesReturnRepository.UpdateItem(tx, line); err != nil {
+			return fmt.Errorf("error updating sales return item: %w", err)
+		}
+	}
+
+	creditNoteNumber, err := s.NumberSequenceService.Next(tx, models.SequenceCreditNote)
+	if err != nil {
+		return fmt.Errorf("error generating credit note number: %w", err)
+	}
+
+	// credit note mengurangi nilai tagihan SO; refund mengembalikan uang yang sudah dibayar
+	newTotal := so.TotalAmount - salesReturn.CreditAmount
+	if newTotal < 0 {
+		newTotal = 0
+	}
+	newPaid := so.PaidAmount
+
+	if salesReturn.SettlementType == "Refund" && salesReturn.RefundAmount > 0 {
+		if salesReturn.RefundAmount > so.PaidAmount {
+			return fmt.Errorf("refund amount (%d) exceeds amount paid on SO %s (%d)", salesReturn.RefundAmount, so.SONumber, so.PaidAmount)
+		}
+		refund := &models.Payment{
+			ID:              uuid.New(),
+			OrderType:       "SO",
+			SalesOrderID:    &so.ID,
+			PaymentType:     "Refund",
+			Amount:          -salesReturn.RefundAmount,
+			PaymentDate:     time.Now(),
+			PaymentMethod:   salesReturn.RefundMethod,
+			ReferenceNumber: creditNoteNumber,
+			Notes:           fmt.Sprintf("Refund for sales return %s", salesReturn.ReturnNumber),
+		}
+		if _, err := s.PaymentRepository.Insert(tx, refund); err != nil {
+			return fmt.Errorf("error creating refund payment: %w", err)
+		}
+		salesReturn.RefundPaymentID = &refund.ID
+		newPaid -= salesReturn.RefundAmount
+	}
+
+	// SO sudah dibayar melebihi nilai barunya: kelebihan menjadi deposit customer
+	if newPaid > newTotal {
+		excess := newPaid - newTotal
+		deposit, err := recordOverpaymentDeposit(tx, s.PaymentRepository, s.NumberSequenceService, "SO", so.ID, so.CustomerID, excess,
+			creditNoteNumber, fmt.Sprintf("Customer deposit from sales return %s (SO %s)", salesReturn.ReturnNumber, so.SONumber), userID)
+		if err != nil {
+			return err
+		}
+		newPaid = newTotal
+		log.Printf("Sales return %s: overpayment %d moved to customer deposit %s", salesReturn.ReturnNumber, excess, deposit.ReceiptNumber)
+	}
+
+	if err := tx.Model(&models.SalesOrder{}).
+		Where("id = ?", so.ID).
+		Updates(map[string]interface{}{
+			"total_amount":   newTotal,
+			"paid_amount":    newPaid,
+			"payment_status": paymentStatusFor(newTotal, newPaid),
+		}).Error; err != nil {
+		return fmt.Errorf("error updating sales order: %w", err)
+	}
+
+	now := time.Now()
+	salesReturn.CreditNoteNumber = &creditNoteNumber
+	salesReturn.CompletedAt = &now
+	salesReturn.CompletedBy = &userID
+
+	log.Printf("Sales return %s completed: credit %d, refund %d (SO %s)", salesReturn.ReturnNumber, salesReturn.CreditAmount, salesReturn.RefundAmount, so.SONumber)
+	return nil
+}
+
+func (s *SalesReturnService) buildReturnItems(tx *gorm.DB, so *models.SalesOrder, returnID uuid.UUID, reqItems []models.SalesReturnItemRequest) ([]models.SalesReturnItem, int, error) {
+	soItems := make(map[uuid.UUID]models.SalesOrderItem, len(so.SalesOrderItems))
+	soItemIDs := make([]uuid.UUID, 0, len(so.SalesOrderItems))
+	for _, si := range so.SalesOrderItems {
+		soItems[si.ID] = si
+		soItemIDs = append(soItemIDs, si.ID)
+	}
+
+	returned, err := s.SalesReturnRepository.SumReturnedQuantity(tx, soItemIDs, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]models.SalesReturnItem, 0, len(reqItems))
+	total := 0
+	for _, ri := range reqItems {
+		si, ok := soItems[ri.SalesOrderItemID]
+		if !ok {
+			return nil, 0, fmt.Errorf("sales order item %s does not belong to SO %s", ri.SalesOrderItemID, so.SONumber)
+		}
+
+		delivered := returnableQuantity(so.SOStatus, len(so.Shipments) > 0, si)
+		returned[si.ID] += ri.Quantity
+		if returned[si.ID] > delivered {
+			return nil, 0, fmt.Errorf("return quantity for %s exceeds delivered quantity (delivered %d, returned %d)",
+				si.Item.Name, delivered, returned[si.ID])
+		}
+
+		var lotNumber *string
+		if ri.LotNumber != nil && strings.TrimSpace(*ri.LotNumber) != "" {
+			ln := strings.TrimSpace(*ri.LotNumber)
+			lotNumber = &ln
+		}
+		if ri.Condition == "Resellable" && lotNumber == nil {
+			return nil, 0, fmt.Errorf("lot number is required to restock %s", si.Item.Name)
+		}
+
+		lineTotal := returnLineAmount(ri.Quantity, si.Quantity, si.UnitPrice, si.NetAmount, so.Subtotal > 0)
+		total += lineTotal
+		items = append(items, models.SalesReturnItem{
+			ID:               uuid.New(),
+			SalesReturnID:    returnID,
+			SalesOrderItemID: si.ID,
+			ItemID:           si.ItemID,
+			Quantity:         ri.Quantity,
+			UnitPrice:        si.UnitPrice,
+			TotalPrice:       lineTotal,
+			Reason:           strings.TrimSpace(ri.Reason),
+			Condition:        ri.Condition,
+			LotNumber:        lotNumber,
+			ExpiredAt:        ri.ExpiredAt,
+		})
+	}
+	return items, total, nil
+}
+
+// returnableQuantity adalah qty baris SO yang sudah dikirim ke customer. SO lama yang
+// Delivered/Closed tanpa catatan shipment dianggap terkirim penuh.
+func returnableQuantity(soStatus string, hasShipments bool, si models.SalesOrderItem) int {
+	if hasShipments {
+		return si.ShippedQuantity
+	}
+	if soStatus == "Delivered" || soStatus == "Closed" {
+		return si.Quantity
+	}
+	return 0
+}
+
+func (s *SalesReturnService) mapSalesReturnToResponse(sr models.SalesReturn) models.ResponseGetSalesReturn {
+	return models.ResponseGetSalesReturn{
+		ID:               sr.ID,
+		ReturnNumber:     sr.ReturnNumber,
+		CreditNoteNumber: sr.CreditNoteNumber,
+		SalesOrderID:     sr.SalesOrderID,
+		CustomerID:       sr.CustomerID,
+		WarehouseID:      sr.WarehouseID,
+		ReturnDate:       sr.ReturnDate,
+		Status:           sr.Status,
+		SettlementType:   sr.SettlementType,
+		CreditAmount:     sr.CreditAmount,
+		RefundAmount:     sr.RefundAmount,
+		RefundMethod:     sr.RefundMethod,
+		RefundPaymentID:  sr.RefundPaymentID,
+		Notes:            sr.Notes,
+		CreatedBy:        sr.CreatedBy,
+		CompletedBy:      sr.CompletedBy,
+		CompletedAt:      sr.CompletedAt,
+		CreatedAt:        sr.CreatedAt,
+		UpdatedAt:        sr.UpdatedAt,
+		DeletedAt:        sr.DeletedAt,
+		SalesOrder:       sr.SalesOrder,
+		Customer:         sr.Customer,
+		Warehouse:        sr.Warehouse,
+		RefundPayment:    sr.RefundPayment,
+		SalesReturnItems: sr.SalesReturnItems,
+	}
+}
+
+// returnLineAmount menghitung nilai retur satu baris: porsi NetAmount (setelah diskon & PPN) sebanding qty retur.
+// Order lama yang belum punya rincian pajak (priced=false) memakai qty × harga satuan.
+func returnLineAmount(returnQty, orderQty, unitPrice, netAmount int, priced bool) int {
+	if !priced || orderQty <= 0 {
+		return returnQty * unitPrice
+	}
+	if returnQty >= orderQty {
+		return netAmount
+	}
+	return int(math.Round(float64(netAmount) * float64(returnQty) / float64(orderQty)))
+}
+
+// paymentStatusFor menurunkan status pembayaran dari total tagihan & total terbayar.
+func paymentStatusFor(total, paid int) string {
+	switch {
+	case paid <= 0 && total > 0:
+		return "Unpaid"
+	case paid >= total:
+		return "Paid"
+	default:
+		return "Partial"
+	}
+}
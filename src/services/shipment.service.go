@@ -0,0 +1,449 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShipmentService struct {
+	ShipmentRepository    repositories.ShipmentRepository
+	SalesOrderRepository  repositories.SalesOrderRepository
+	ItemRepository        repositories.ItemRepository
+	ItemLotService        *ItemLotService
+	NumberSequenceService *NumberSequenceService
+}
+
+func NewShipmentService(
+	shipmentRepo repositories.ShipmentRepository,
+	soRepo repositories.SalesOrderRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *ShipmentService {
+	return &ShipmentService{
+		ShipmentRepository:    shipmentRepo,
+		SalesOrderRepository:  soRepo,
+		ItemRepository:        itemRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService: NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *ShipmentService) GetAllShipmentsPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.ShipmentPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.ShipmentRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetShipment, 0, len(rows))
+	for _, sh := range rows {
+		data = append(data, s.mapShipmentToResponse(sh))
+	}
+
+	return &models.ShipmentPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *ShipmentService) GetShipmentByID(shipmentId string) (*models.ResponseGetShipment, error) {
+	sh, err := s.ShipmentRepository.FindById(nil, shipmentId)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapShipmentToResponse(*sh)
+	return &out, nil
+}
+
+// GetShipmentsBySalesOrder mengembalikan riwayat pengiriman untuk satu SO.
+func (s *ShipmentService) GetShipmentsBySalesOrder(soId string) ([]models.ResponseGetShipment, error) {
+	if _, err := s.SalesOrderRepository.FindById(nil, soId, false); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.ShipmentRepository.FindBySalesOrder(nil, soId)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.ResponseGetShipment, 0, len(rows))
+	for _, sh := range rows {
+		out = append(out, s.mapShipmentToResponse(sh))
+	}
+	return out, nil
+}
+
+func (s *ShipmentService) GenerateShipmentDeliveryOrder(shipmentId string) (string, []byte, error) {
+	sh, err := s.ShipmentRepository.FindById(nil, shipmentId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filename, data, err := documents.GenerateShipmentDeliveryOrderPDF(sh)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate delivery order PDF: %w", err)
+	}
+	return filename, data, nil
+}
+
+// CreateShipment mencatat satu pengiriman atas SO. Stok dikurangi FEFO sebanyak qty yang dikirim,
+// reservasi baris dilepas sebesar qty tersebut, dan status SO diturunkan dari seluruh barisnya.
+func (s *ShipmentService) CreateShipment(soId string, req *models.ShipmentCreateRequest, userInfo *models.User) (*models.Shipment, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// kunci header SO supaya dua shipment bersamaan tidak melebihi qty order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&models.SalesOrder{}, "id = ?", soId).Error; err != nil {
+		tx.Rollback()
+		return nil, repositories.HandleDatabaseError(err, "sales_order")
+	}
+
+	so, err := s.SalesOrderRepository.FindById(tx, soId, false)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	shipment, err := s.ship(tx, so, req, userInfo)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := s.syncSalesOrderStatus(tx, so); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.ShipmentRepository.FindById(nil, shipment.ID.String())
+}
+
+// MarkShipmentDelivered mengonfirmasi shipment sudah diterima customer. SO menjadi Delivered
+// setelah seluruh qty terkirim dan semua shipment-nya diterima.
+func (s *ShipmentService) MarkShipmentDelivered(shipmentId string, req *models.ShipmentDeliveredRequest, userInfo *models.User) (*models.Shipment, error) {
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	shipment, err := s.ShipmentRepository.FindById(tx, shipmentId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&models.SalesOrder{}, "id = ?", shipment.SalesOrderID).Error; err != nil {
+		tx.Rollback()
+		return nil, repositories.HandleDatabaseError(err, "sales_order")
+	}
+
+	if shipment.Status != "Shipped" {
+		tx.Rollback()
+		return nil, fmt.Errorf("shipment %s is already %s", shipment.ShipmentNumber, shipment.Status)
+	}
+
+	deliveredAt := time.Now()
+	if req.DeliveredAt != nil && !req.DeliveredAt.IsZero() {
+		deliveredAt = *req.DeliveredAt
+	}
+	shipment.Status = "Delivered"
+	shipment.DeliveredAt = &deliveredAt
+	shipment.ReceiverName = strings.TrimSpace(req.ReceiverName)
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		if shipment.Notes != "" {
+			shipment.Notes += "\n"
+		}
+		shipment.Notes += notes
+	}
+	if _, err := s.ShipmentRepository.Update(tx, shipment); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating shipment: %w", err)
+	}
+
+	so, err := s.SalesOrderRepository.FindById(tx, shipment.SalesOrderID.String(), false)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := s.syncSalesOrderStatus(tx, so); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.ShipmentRepository.FindById(nil, shipment.ID.String())
+}
+
+// ShipRemainder mengirim seluruh sisa qty (backorder) SO dalam satu shipment. Dipakai saat SO
+// langsung di-set Delivered tanpa mencatat shipment satu per satu. Mengembalikan nil bila tidak ada sisa.
+func (s *ShipmentService) ShipRemainder(tx *gorm.DB, so *models.SalesOrder, warehouseID *uuid.UUID, userInfo *models.User) (*models.Shipment, error) {
+	req := &models.ShipmentCreateRequest{
+		WarehouseID: warehouseID,
+		Notes:       fmt.Sprintf("Remaining quantity of %s", so.SONumber),
+	}
+	for _, soItem := range so.SalesOrderItems {
+		if remaining := soItem.Quantity - soItem.ShippedQuantity; remaining > 0 {
+			req.Items = append(req.Items, models.ShipmentItemRequest{SalesOrderItemID: soItem.ID, Quantity: remaining})
+		}
+	}
+	if len(req.Items) == 0 {
+		return nil, nil
+	}
+	return s.ship(tx, so, req, userInfo)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *ShipmentService) ship(tx *gorm.DB, so *models.SalesOrder, req *models.ShipmentCreateRequest, userInfo *models.User) (*models.Shipment, error) {
+	switch so.SOStatus {
+	case "Confirmed", "PartiallyShipped", "Shipped":
+	default:
+		return nil, fmt.Errorf("cannot ship sales order in %s status", so.SOStatus)
+	}
+
+	warehouse, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentNumber, err := s.NumberSequenceService.Next(tx, models.SequenceShipment)
+	if err != nil {
+		return nil, fmt.Errorf("error generating shipment number: %w", err)
+	}
+
+	shipmentDate := time.Now()
+	if req.ShipmentDate != nil && !req.ShipmentDate.IsZero() {
+		shipmentDate = *req.ShipmentDate
+	}
+
+	shipment := &models.Shipment{
+		ID:             uuid.New(),
+		ShipmentNumber: shipmentNumber,
+		SalesOrderID:   so.ID,
+		CustomerID:     so.CustomerID,
+		WarehouseID:    warehouse.ID,
+		ShipmentDate:   shipmentDate,
+		Courier:        strings.TrimSpace(req.Courier),
+		TrackingNumber: strings.TrimSpace(req.TrackingNumber),
+		Status:         "Shipped",
+		ShippedBy:      &userInfo.ID,
+		Notes:          strings.TrimSpace(req.Notes),
+	}
+
+	soItems := make(map[uuid.UUID]*models.SalesOrderItem, len(so.SalesOrderItems))
+	for i := range so.SalesOrderItems {
+		soItems[so.SalesOrderItems[i].ID] = &so.SalesOrderItems[i]
+	}
+
+	for _, line := range req.Items {
+		soItem, ok := soItems[line.SalesOrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("sales order item %s does not belong to SO %s", line.SalesOrderItemID, so.SONumber)
+		}
+		if line.Quantity < 0 {
+			return nil, errors.New("quantities must be non-negative")
+		}
+		if line.Quantity == 0 {
+			continue
+		}
+
+		remaining := soItem.Quantity - soItem.ShippedQuantity
+		if line.Quantity > remaining {
+			return nil, fmt.Errorf("shipped quantity for %s exceeds remaining quantity (ordered %d, shipped %d, requested %d)",
+				soItem.Item.Name, soItem.Quantity, soItem.ShippedQuantity, line.Quantity)
+		}
+
+		item, err := s.ItemRepository.FindById(tx, soItem.ItemID.String(), false)
+		if err != nil {
+			return nil, fmt.Errorf("item not found: %w", err)
+		}
+		if item.Stock < line.Quantity {
+			return nil, fmt.Errorf("insufficient stock for item %s: available %d, required %d",
+				item.Name, item.Stock, line.Quantity)
+		}
+
+		// ambil stok per lot FEFO (history dicatat per lot)
+		desc := fmt.Sprintf("Shipped %d units (SO %s, %s) from %s", line.Quantity, so.SONumber, shipmentNumber, warehouse.Name)
+		consumed, err := s.ItemLotService.ConsumeFEFO(tx, item, warehouse.ID, line.Quantity, desc, userInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+		lotNumbers := make([]string, 0, len(consumed))
+		for _, c := range consumed {
+			lotNumbers = append(lotNumbers, c.LotNumber)
+		}
+
+		// reservasi sebesar qty yang dikirim berubah jadi pengurangan stok riil
+		release := line.Quantity
+		if release > soItem.ReservedQuantity {
+			release = soItem.ReservedQuantity
+		}
+		if release > 0 {
+			if err := s.ItemRepository.AdjustReservedStock(tx, soItem.ItemID, -release); err != nil {
+				return nil, fmt.Errorf("error releasing reserved stock for item %s: %w", soItem.ItemID, err)
+			}
+		}
+
+		soItem.ShippedQuantity += line.Quantity
+		soItem.ReservedQuantity -= release
+		if err := tx.Model(&models.SalesOrderItem{}).
+			Where("id = ?", soItem.ID).
+			Updates(map[string]interface{}{
+				"shipped_quantity":  soItem.ShippedQuantity,
+				"reserved_quantity": soItem.ReservedQuantity,
+			}).Error; err != nil {
+			return nil, fmt.Errorf("error updating sales order item: %w", err)
+		}
+
+		if item.Stock <= item.LowStock {
+			go notifyLowStock(*item)
+		}
+
+		shipment.TotalQuantity += line.Quantity
+		shipment.ShipmentItems = append(shipment.ShipmentItems, models.ShipmentItem{
+			ID:                uuid.New(),
+			ShipmentID:        shipment.ID,
+			SalesOrderItemID:  soItem.ID,
+			ItemID:            soItem.ItemID,
+			OrderedQuantity:   soItem.Quantity,
+			Quantity:          line.Quantity,
+			BackorderQuantity: soItem.Quantity - soItem.ShippedQuantity,
+			LotNumbers:        strings.Join(lotNumbers, ", "),
+			Notes:             strings.TrimSpace(line.Notes),
+		})
+	}
+
+	if len(shipment.ShipmentItems) == 0 {
+		return nil, errors.New("nothing to ship: all quantities are zero")
+	}
+
+	if _, err := s.ShipmentRepository.Insert(tx, shipment); err != nil {
+		return nil, fmt.Errorf("error creating shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+// syncSalesOrderStatus menurunkan status SO dari qty terkirim & status shipment-nya. SO yang sudah
+// Closed/Cancelled (mis. backorder dibatalkan) tidak diubah.
+func (s *ShipmentService) syncSalesOrderStatus(tx *gorm.DB, so *models.SalesOrder) error {
+	switch so.SOStatus {
+	case "Confirmed", "PartiallyShipped", "Shipped":
+	default:
+		return nil
+	}
+
+	shipments, err := s.ShipmentRepository.FindBySalesOrder(tx, so.ID.String())
+	if err != nil {
+		return err
+	}
+
+	status := salesOrderShipmentStatus(so.SalesOrderItems, shipments)
+	if status == "" || status == so.SOStatus {
+		return nil
+	}
+	if err := s.SalesOrderRepository.UpdateStatus(tx, so.ID.String(), status, ""); err != nil {
+		return fmt.Errorf("error updating sales order status: %w", err)
+	}
+	so.SOStatus = status
+	return nil
+}
+
+func (s *ShipmentService) mapShipmentToResponse(sh models.Shipment) models.ResponseGetShipment {
+	return models.ResponseGetShipment{
+		ID:             sh.ID,
+		ShipmentNumber: sh.ShipmentNumber,
+		SalesOrderID:   sh.SalesOrderID,
+		CustomerID:     sh.CustomerID,
+		WarehouseID:    sh.WarehouseID,
+		ShipmentDate:   sh.ShipmentDate,
+		Courier:        sh.Courier,
+		TrackingNumber: sh.TrackingNumber,
+		Status:         sh.Status,
+		TotalQuantity:  sh.TotalQuantity,
+		ShippedBy:      sh.ShippedBy,
+		DeliveredAt:    sh.DeliveredAt,
+		ReceiverName:   sh.ReceiverName,
+		Notes:          sh.Notes,
+		CreatedAt:      sh.CreatedAt,
+		UpdatedAt:      sh.UpdatedAt,
+		DeletedAt:      sh.DeletedAt,
+		SalesOrder:     sh.SalesOrder,
+		Customer:       sh.Customer,
+		Warehouse:      sh.Warehouse,
+		ShippedByUser:  sh.ShippedByUser,
+		ShipmentItems:  sh.ShipmentItems,
+	}
+}
+
+// salesOrderShipmentStatus: PartiallyShipped selama masih ada backorder, Shipped bila semua qty
+// sudah dikirim, Delivered bila semua qty dikirim dan seluruh shipment sudah diterima.
+// Mengembalikan "" bila belum ada yang dikirim.
+func salesOrderShipmentStatus(items []models.SalesOrderItem, shipments []models.Shipment) string {
+	anyShipped, allShipped := false, true
+	for _, it := range items {
+		if it.ShippedQuantity > 0 {
+			anyShipped = true
+		}
+		if it.ShippedQuantity < it.Quantity {
+			allShipped = false
+		}
+	}
+
+	switch {
+	case !anyShipped:
+		return ""
+	case !allShipped:
+		return "PartiallyShipped"
+	}
+
+	for _, sh := range shipments {
+		if sh.Status != "Delivered" {
+			return "Shipped"
+		}
+	}
+	return "Delivered"
+}
@@ -23,6 +23,11 @@ type SalesOrderService struct {
 	ItemRepository          repositories.ItemRepository
 	PaymentRepository       repositories.PaymentRepository
 	ItemHistoryRepository   repositories.ItemHistoryRepository
+	ItemLotService          *ItemLotService
+	NumberSequenceService   *NumberSequenceService
+	ShipmentService         *ShipmentService
+	CustomerCreditService   *CustomerCreditService
+	PriceListRepository     repositories.PriceListRepository
 }
 
 func NewSalesOrderService(
@@ -32,6 +37,13 @@ func NewSalesOrderService(
 	itemRepo repositories.ItemRepository,
 	paymentRepo repositories.PaymentRepository,
 	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+	shipmentRepo repositories.ShipmentRepository,
+	creditOverrideRepo repositories.CreditOverrideRepository,
+	priceListRepo repositories.PriceListRepository,
 ) *SalesOrderService {
 	return &SalesOrderService{
 		SalesOrderRepository:  soRepo,
@@ -40,6 +52,11 @@ func NewSalesOrderService(
 		ItemRepository:        itemRepo,
 		PaymentRepository:     paymentRepo,
 		ItemHistoryRepository: itemHistoryRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService: NewNumberSequenceService(numberSequenceRepo),
+		ShipmentService:       NewShipmentService(shipmentRepo, soRepo, itemRepo, itemHistoryRepo, itemLotRepo, itemStockRepo, warehouseRepo, numberSequenceRepo),
+		CustomerCreditService: NewCustomerCreditService(customerRepo, soRepo, creditOverrideRepo),
+		PriceListRepository:   priceListRepo,
 	}
 }
 
@@ -108,6 +125,8 @@ func (service *SalesOrderService) GetSalesOrderByID(soId string) (*models.Respon
 	return &resp, nil
 }
 
+// GenerateDocumentDeliveryOrder hanya mencetak DO (read-only, aman untuk link publik); status SO
+// berubah lewat shipment (ShipmentService).
 func (service *SalesOrderService) GenerateDocumentDeliveryOrder(soId string) (string, []byte, error) {
 	so, err := service.SalesOrderRepository.FindById(nil, soId, true)
 	if err != nil {
@@ -119,20 +138,40 @@ func (service *SalesOrderService) GenerateDocumentDeliveryOrder(soId string) (st
 		return "", nil, fmt.Errorf("failed to generate delivery order PDF: %w", err)
 	}
 
-	if so.SOStatus == "Confirmed" {
-		if err := service.SalesOrderRepository.UpdateStatus(nil, soId, "Shipped", ""); err != nil {
-			log.Printf("Failed to update Sales order status: %v", err)
+	return filename, data, nil
+}
+
+// ErrInvoiceNotIssued dikembalikan RenderInvoice bila SO belum pernah diterbitkan invoice-nya.
+var ErrInvoiceNotIssued = errors.New("invoice has not been issued for this sales order")
+
+// ErrPriceBelowMinimum: harga manual di bawah price list yang EnforceMinimum.
+var ErrPriceBelowMinimum = errors.New("price list enforces a minimum price")
+
+// GenerateInvoice menerbitkan nomor invoice bila belum ada lalu mencetak invoice-nya.
+func (service *SalesOrderService) GenerateInvoice(soId string) (string, []byte, error) {
+	so, err := service.SalesOrderRepository.FindById(nil, soId, true)
+	if err != nil {
+		return "", nil, fmt.Errorf("Sales order not found: %w", err)
+	}
+
+	if so.InvoiceNumber == nil {
+		if _, err := issueInvoiceNumber(service.NumberSequenceService, so.ID); err != nil {
+			return "", nil, fmt.Errorf("failed to issue invoice number: %w", err)
 		}
 	}
 
-	return filename, data, nil
+	return service.RenderInvoice(soId)
 }
 
-func (service *SalesOrderService) GenerateInvoice(soId string) (string, []byte, error) {
+// RenderInvoice mencetak invoice yang sudah diterbitkan tanpa mengubah data (dipakai link publik).
+func (service *SalesOrderService) RenderInvoice(soId string) (string, []byte, error) {
 	so, err := service.SalesOrderRepository.FindById(nil, soId, true)
 	if err != nil {
 		return "", nil, fmt.Errorf("Sales order not found: %w", err)
 	}
+	if so.InvoiceNumber == nil {
+		return "", nil, ErrInvoiceNotIssued
+	}
 
 	filename, data, err := documents.GenerateInvoicePDF(so)
 	if err != nil {
@@ -142,6 +181,50 @@ func (service *SalesOrderService) GenerateInvoice(soId string) (string, []byte,
 	return filename, data, nil
 }
 
+// issueInvoiceNumber memberi nomor invoice (sequence "invoice") saat invoice SO pertama kali
+// diterbitkan. Nomor disimpan di SO sehingga cetak ulang & lampiran email memakai nomor yang sama.
+func issueInvoiceNumber(numberSequenceService *NumberSequenceService, soID uuid.UUID) (string, error) {
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var so models.SalesOrder
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "invoice_number").
+		First(&so, "id = ?", soID).Error; err != nil {
+		tx.Rollback()
+		return "", repositories.HandleDatabaseError(err, "sales_order")
+	}
+	if so.InvoiceNumber != nil {
+		tx.Rollback()
+		return *so.InvoiceNumber, nil
+	}
+
+	number, err := numberSequenceService.Next(tx, models.SequenceInvoice)
+	if err != nil {
+		tx.Rollback()
+		return "", fmt.Errorf("error generating invoice number: %w", err)
+	}
+	if err := tx.Unscoped().Model(&models.SalesOrder{}).
+		Where("id = ?", soID).
+		Update("invoice_number", number).Error; err != nil {
+		tx.Rollback()
+		return "", repositories.HandleDatabaseError(err, "sales_order")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return number, nil
+}
+
+// GenerateReceipt mencetak kwitansi SO (read-only, aman untuk link publik).
 func (service *SalesOrderService) GenerateReceipt(soId string) (string, []byte, error) {
 	so, err := service.SalesOrderRepository.FindById(nil, soId, true)
 	if err != nil {
@@ -160,8 +243,6 @@ func (service *SalesOrderService) CreateSalesOrder(
 	soRequest *models.SalesOrderCreateRequest,
 	userInfo *models.User,
 ) (*models.SalesOrder, error) {
-	_ = userInfo
-
 	tx := configs.DB.Begin()
 	if tx.Error != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
@@ -172,49 +253,76 @@ func (service *SalesOrderService) CreateSalesOrder(
 		}
 	}()
 
+	newSO, override, err := service.createSalesOrderTx(tx, soRequest, userInfo)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	notifyBelowListPrice(newSO.SONumber, newSO.ID, newSO.SalesOrderItems, userInfo)
+	NotifyCreditOverride(override, newSO.SONumber, userInfo)
+
+	createdSO, err := service.SalesOrderRepository.FindById(nil, newSO.ID.String(), false)
+	if err != nil {
+		log.Printf("Warning: SO created but failed to fetch created data: %v", err)
+		return newSO, nil
+	}
+	return createdSO, nil
+}
+
+// createSalesOrderTx membuat SO di dalam tx milik pemanggil (mis. konversi quotation) agar
+// SO dan perubahan dokumen asalnya commit / rollback bersama. Rollback menjadi tugas pemanggil,
+// begitu juga notifikasi credit override (bila ada) setelah commit.
+func (service *SalesOrderService) createSalesOrderTx(
+	tx *gorm.DB,
+	soRequest *models.SalesOrderCreateRequest,
+	userInfo *models.User,
+) (*models.SalesOrder, *models.CreditOverride, error) {
 	// validate master data di dalam tx
 	if _, err := service.SalesPersonRepository.FindById(tx, soRequest.SalesPersonID.String(), false); err != nil {
-		tx.Rollback()
-		return nil, errors.New("sales person not found")
+		return nil, nil, errors.New("sales person not found")
 	}
-	if _, err := service.CustomerRepository.FindById(tx, soRequest.CustomerID.String(), false); err != nil {
-		tx.Rollback()
-		return nil, errors.New("customer not found")
+	customer, err := service.CustomerRepository.FindById(tx, soRequest.CustomerID.String(), false)
+	if err != nil {
+		return nil, nil, errors.New("customer not found")
 	}
 
 	// lock & cek stok
 	if err := service.validateAndLockStock(tx, soRequest.Items); err != nil {
-		tx.Rollback()
-		return nil, err
+		return nil, nil, err
 	}
 
-	var totalAmount int
 	soItems := make([]models.SalesOrderItem, 0, len(soRequest.Items))
 
 	for _, itemReq := range soRequest.Items {
 		itemData, err := service.ItemRepository.FindById(tx, itemReq.ItemID.String(), false)
 		if err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("item %s not found", itemReq.ItemID.String())
+				return nil, nil, fmt.Errorf("item %s not found", itemReq.ItemID.String())
 		}
 
-		totalPrice := itemReq.Quantity * itemReq.UnitPrice
-		totalAmount += totalPrice
+		line := models.SalesOrderItem{
+			ID:            uuid.New(),
+			ItemID:        itemReq.ItemID,
+			Quantity:      itemReq.Quantity,
+			UoMID:         itemData.UoMID,
+			DiscountType:  itemReq.DiscountType,
+			DiscountValue: itemReq.DiscountValue,
+			TaxExempt:     itemData.TaxExempt,
+		}
+		if err := service.applyListPrice(tx, customer, itemData, &line, itemReq.UnitPrice, soRequest.SODate); err != nil {
+				return nil, nil, err
+		}
 
-		soItems = append(soItems, models.SalesOrderItem{
-			ID:         uuid.New(),
-			ItemID:     itemReq.ItemID,
-			Quantity:   itemReq.Quantity,
-			UoMID:      itemData.UoMID,
-			UnitPrice:  itemReq.UnitPrice,
-			TotalPrice: totalPrice,
-		})
+		soItems = append(soItems, line)
 	}
 
-	soNumber, err := service.SalesOrderRepository.GenerateNextSONumber(tx)
+	soNumber, err := service.NumberSequenceService.Next(tx, models.SequenceSalesOrder)
 	if err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("error generating SO number: %w", err)
+		return nil, nil, fmt.Errorf("error generating SO number: %w", err)
 	}
 
 	paymentStatus := "Unpaid"
@@ -232,16 +340,30 @@ func (service *SalesOrderService) CreateSalesOrder(
 		TermOfPayment:    soRequest.TermOfPayment,
 		SOStatus:         soRequest.SOStatus,
 		PaymentStatus:    paymentStatus,
-		TotalAmount:      totalAmount,
+		DiscountType:     soRequest.DiscountType,
+		DiscountValue:    soRequest.DiscountValue,
+		TaxRate:          helpers.PPNRate(),
+		TaxInclusive:     soRequest.TaxInclusive,
 		DPAmount:         soRequest.DPAmount,
 		DueDate:          soRequest.DueDate,
 		Notes:            soRequest.Notes,
-		SalesOrderItems:  soItems,
 	}
+	if soRequest.TaxRate != nil {
+		newSO.TaxRate = *soRequest.TaxRate
+	}
+	if err := applySalesOrderPricing(newSO, soItems); err != nil {
+		return nil, nil, err
+	}
+	newSO.SalesOrderItems = soItems
 
 	if _, err := service.SalesOrderRepository.Insert(tx, newSO); err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("error creating sales order: %w", err)
+		return nil, nil, fmt.Errorf("error creating sales order: %w", err)
+	}
+
+	if newSO.SOStatus == "Confirmed" {
+		if err := service.reserveStock(tx, newSO); err != nil {
+				return nil, nil, err
+		}
 	}
 
 	if soRequest.TermOfPayment == "DP" && soRequest.DPAmount > 0 {
@@ -255,27 +377,22 @@ func (service *SalesOrderService) CreateSalesOrder(
 			Notes:        "Initial DP payment",
 		}
 		if _, err := service.PaymentRepository.Insert(tx, dpPayment); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("error creating DP payment: %w", err)
+				return nil, nil, fmt.Errorf("error creating DP payment: %w", err)
 		}
 
 		newSO.PaidAmount = soRequest.DPAmount
 		if _, err := service.SalesOrderRepository.Update(tx, newSO); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("error updating SO paid amount: %w", err)
+				return nil, nil, fmt.Errorf("error updating SO paid amount: %w", err)
 		}
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	createdSO, err := service.SalesOrderRepository.FindById(nil, newSO.ID.String(), false)
+	// credit hold: piutang terbuka + SO ini tidak boleh melewati batas kredit customer
+	override, err := service.CustomerCreditService.EnforceCreditLimit(tx, newSO, "Create", soRequest.CreditOverrideReason, userInfo)
 	if err != nil {
-		log.Printf("Warning: SO created but failed to fetch created data: %v", err)
-		return newSO, nil
+		return nil, nil, err
 	}
-	return createdSO, nil
+
+	return newSO, override, nil
 }
 
 func (service *SalesOrderService) UpdateSalesOrder(
@@ -311,6 +428,9 @@ func (service *SalesOrderService) UpdateSalesOrder(
 	}
 
 	updates := map[string]interface{}{}
+	customer := &so.Customer
+	priceDate := so.SODate
+	repricing := len(soRequest.Items) > 0
 
 	if soRequest.SalesPersonID != uuid.Nil && soRequest.SalesPersonID != so.SalesPersonID {
 		if _, err := service.SalesPersonRepository.FindById(tx, soRequest.SalesPersonID.String(), false); err != nil {
@@ -321,15 +441,18 @@ func (service *SalesOrderService) UpdateSalesOrder(
 	}
 
 	if soRequest.CustomerID != uuid.Nil && soRequest.CustomerID != so.CustomerID {
-		if _, err := service.CustomerRepository.FindById(tx, soRequest.CustomerID.String(), false); err != nil {
+		newCustomer, err := service.CustomerRepository.FindById(tx, soRequest.CustomerID.String(), false)
+		if err != nil {
 			tx.Rollback()
 			return nil, errors.New("customer not found")
 		}
 		updates["customer_id"] = soRequest.CustomerID
+		customer = newCustomer
 	}
 
 	if !soRequest.SODate.IsZero() {
 		updates["so_date"] = soRequest.SODate
+		priceDate = soRequest.SODate
 	}
 	if soRequest.EstimatedArrival != nil {
 		updates["estimated_arrival"] = soRequest.EstimatedArrival
@@ -346,8 +469,25 @@ func (service *SalesOrderService) UpdateSalesOrder(
 	if soRequest.Notes != "" {
 		updates["notes"] = soRequest.Notes
 	}
+	if soRequest.DiscountType != nil {
+		so.DiscountType = *soRequest.DiscountType
+		repricing = true
+	}
+	if soRequest.DiscountValue != nil {
+		so.DiscountValue = *soRequest.DiscountValue
+		repricing = true
+	}
+	if soRequest.TaxRate != nil {
+		so.TaxRate = *soRequest.TaxRate
+		repricing = true
+	}
+	if soRequest.TaxInclusive != nil {
+		so.TaxInclusive = *soRequest.TaxInclusive
+		repricing = true
+	}
 
 	// items
+	var finalItems []models.SalesOrderItem
 	if len(soRequest.Items) > 0 {
 		var existingItems []models.SalesOrderItem
 		if err := tx.Where("sales_order_id = ?", so.ID).Find(&existingItems).Error; err != nil {
@@ -360,7 +500,7 @@ func (service *SalesOrderService) UpdateSalesOrder(
 			existingByItemID[existingItems[i].ItemID] = &existingItems[i]
 		}
 
-		finalItems := make([]models.SalesOrderItem, 0, len(soRequest.Items))
+		finalItems = make([]models.SalesOrderItem, 0, len(soRequest.Items))
 		seen := make(map[uuid.UUID]bool)
 
 		for _, req := range soRequest.Items {
@@ -370,30 +510,53 @@ func (service *SalesOrderService) UpdateSalesOrder(
 				return nil, fmt.Errorf("item %s not found", req.ItemID.String())
 			}
 
+			priced := models.SalesOrderItem{Quantity: req.Quantity}
+			if err := service.applyListPrice(tx, customer, itemData, &priced, req.UnitPrice, priceDate); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+
 			if ex, ok := existingByItemID[req.ItemID]; ok {
 				newQty := req.Quantity
-				newPrice := req.UnitPrice
+				newPrice := priced.UnitPrice
 				newUoMID := itemData.UoMID
-				newTotal := newQty * newPrice
+				newTotal := priced.TotalPrice
 
 				changed := (ex.Quantity != newQty) ||
 					(ex.UnitPrice != newPrice) ||
 					(ex.UoMID != newUoMID) ||
-					(ex.TotalPrice != newTotal)
+					(ex.TotalPrice != newTotal) ||
+					(ex.ListPrice != priced.ListPrice) ||
+					(ex.BelowListPrice != priced.BelowListPrice) ||
+					(ex.DiscountType != req.DiscountType) ||
+					(ex.DiscountValue != req.DiscountValue) ||
+					(ex.TaxExempt != itemData.TaxExempt)
 
 				ex.Quantity = newQty
 				ex.UnitPrice = newPrice
 				ex.UoMID = newUoMID
 				ex.TotalPrice = newTotal
+				ex.ListPrice = priced.ListPrice
+				ex.PriceListID = priced.PriceListID
+				ex.BelowListPrice = priced.BelowListPrice
+				ex.DiscountType = req.DiscountType
+				ex.DiscountValue = req.DiscountValue
+				ex.TaxExempt = itemData.TaxExempt
 
 				if changed {
 					if err := tx.Model(&models.SalesOrderItem{}).
 						Where("id = ?", ex.ID).
 						Updates(map[string]interface{}{
-							"quantity":    ex.Quantity,
-							"unit_price":  ex.UnitPrice,
-							"uom_id":      ex.UoMID,
-							"total_price": ex.TotalPrice,
+							"quantity":         ex.Quantity,
+							"unit_price":       ex.UnitPrice,
+							"uom_id":           ex.UoMID,
+							"total_price":      ex.TotalPrice,
+							"list_price":       ex.ListPrice,
+							"price_list_id":    ex.PriceListID,
+							"below_list_price": ex.BelowListPrice,
+							"discount_type":    ex.DiscountType,
+							"discount_value":   ex.DiscountValue,
+							"tax_exempt":       ex.TaxExempt,
 						}).Error; err != nil {
 						tx.Rollback()
 						return nil, fmt.Errorf("error updating item %s: %w", ex.ID, err)
@@ -409,8 +572,14 @@ func (service *SalesOrderService) UpdateSalesOrder(
 					ItemID:       req.ItemID,
 					Quantity:     req.Quantity,
 					UoMID:        itemData.UoMID,
-					UnitPrice:    req.UnitPrice,
-					TotalPrice:   req.Quantity * req.UnitPrice,
+					UnitPrice:    priced.UnitPrice,
+					TotalPrice:   priced.TotalPrice,
+					ListPrice:      priced.ListPrice,
+					PriceListID:    priced.PriceListID,
+					BelowListPrice: priced.BelowListPrice,
+					DiscountType:   req.DiscountType,
+					DiscountValue:  req.DiscountValue,
+					TaxExempt:      itemData.TaxExempt,
 				}
 				if err := tx.Create(&newRow).Error; err != nil {
 					tx.Rollback()
@@ -429,12 +598,42 @@ func (service *SalesOrderService) UpdateSalesOrder(
 				}
 			}
 		}
+	}
 
-		total := 0
+	// diskon / PPN / grand total dihitung ulang bila baris atau pengaturan pajak-diskon berubah
+	if repricing {
+		if finalItems == nil {
+			if err := tx.Where("sales_order_id = ?", so.ID).Find(&finalItems).Error; err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error fetching existing items: %w", err)
+			}
+		}
+		if err := applySalesOrderPricing(so, finalItems); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 		for _, it := range finalItems {
-			total += it.TotalPrice
+			if err := tx.Model(&models.SalesOrderItem{}).
+				Where("id = ?", it.ID).
+				Updates(map[string]interface{}{
+					"total_price":     it.TotalPrice,
+					"discount_amount": it.DiscountAmount,
+					"net_amount":      it.NetAmount,
+				}).Error; err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error updating item %s: %w", it.ID, err)
+			}
 		}
-		updates["total_amount"] = total
+		updates["subtotal"] = so.Subtotal
+		updates["discount_type"] = so.DiscountType
+		updates["discount_value"] = so.DiscountValue
+		updates["discount_amount"] = so.DiscountAmount
+		updates["tax_rate"] = so.TaxRate
+		updates["tax_inclusive"] = so.TaxInclusive
+		updates["tax_base"] = so.TaxBase
+		updates["tax_amount"] = so.TaxAmount
+		updates["grand_total"] = so.GrandTotal
+		updates["total_amount"] = so.TotalAmount
 	}
 
 	// apply updates ke SO
@@ -451,6 +650,8 @@ func (service *SalesOrderService) UpdateSalesOrder(
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	notifyBelowListPrice(so.SONumber, so.ID, finalItems, userInfo)
+
 	updatedSO, err := service.SalesOrderRepository.FindById(nil, so.ID.String(), false)
 	if err != nil {
 		log.Printf("Warning: SO updated but failed to fetch updated data: %v", err)
@@ -474,6 +675,14 @@ func (service *SalesOrderService) UpdateSalesOrderStatus(
 		}
 	}()
 
+	// kunci header SO supaya transisi status (dan reservasi stok) tidak dobel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&models.SalesOrder{}, "id = ?", soId).Error; err != nil {
+		tx.Rollback()
+		return repositories.HandleDatabaseError(err, "sales_order")
+	}
+
 	so, err := service.SalesOrderRepository.FindById(tx, soId, false)
 	if err != nil {
 		tx.Rollback()
@@ -485,71 +694,46 @@ func (service *SalesOrderService) UpdateSalesOrderStatus(
 		return err
 	}
 
-	if statusRequest.SOStatus == "Delivered" {
-		for _, soItem := range so.SalesOrderItems {
-			item, err := service.ItemRepository.FindById(tx, soItem.ItemID.String(), false)
-			if err != nil {
-				tx.Rollback()
-				return fmt.Errorf("item not found: %w", err)
-			}
-			if item.Stock < soItem.Quantity {
-				tx.Rollback()
-				return fmt.Errorf("insufficient stock for item %s: available %d, required %d",
-					item.ID.String(), item.Stock, soItem.Quantity)
-			}
-
-			oldStock := item.Stock
-			item.Stock -= soItem.Quantity
-
-			if _, err := service.ItemRepository.Update(tx, item); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error updating stock for item %s: %w", item.ID, err)
-			}
-
-			if item.Stock <= item.LowStock {
-				go func(it models.Item) {
-					metadata := map[string]interface{}{
-						"item_id":   it.ID.String(),
-						"item_name": it.Name,
-						"stock":     it.Stock,
-						"low_stock": it.LowStock,
-					}
-					title := fmt.Sprintf("Low Stock Alert: %s", it.Name)
-					message := fmt.Sprintf("Stock for item %s is low. Current: %d, Threshold: %d", it.Name, it.Stock, it.LowStock)
-					if err := helpers.SendNotificationAuto("low_stock", title, message, metadata); err != nil {
-						fmt.Printf("failed to send low stock notification: %v\n", err)
-					}
-				}(*item)
-			}
-
-			// history stok via repo tx-aware
-			lastHist, err := service.ItemHistoryRepository.FindLastByItem(tx, item.ID, "stock")
-			if err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error querying last stock history: %w", err)
-			}
+	var override *models.CreditOverride
+	switch statusRequest.SOStatus {
+	case "Confirmed":
+		override, err = service.CustomerCreditService.EnforceCreditLimit(tx, so, "Confirm", statusRequest.CreditOverrideReason, userInfo)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := service.reserveStock(tx, so); err != nil {
+			tx.Rollback()
+			return err
+		}
+	case "Closed", "Cancelled":
+		if err := service.releaseReservation(tx, so); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 
-			changeType := "update_stock"
-			if lastHist == nil {
-				changeType = "create_stock"
-				oldStock = 0
-			}
+	if statusRequest.SOStatus == "Shipped" && so.SOStatus == "PartiallyShipped" {
+		// Shipped berarti seluruh qty sudah dikirim, jadi backorder dikirim sekaligus
+		if _, err := service.ShipmentService.ShipRemainder(tx, so, statusRequest.WarehouseID, userInfo); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 
-			newHist := models.ItemHistory{
-				ID:           uuid.New(),
-				ItemID:       item.ID,
-				ChangeType:   changeType,
-				OldStock:     oldStock,
-				NewStock:     item.Stock,
-				CurrentStock: item.Stock,
-				Description:  fmt.Sprintf("Delivered %d units (SO %s)", soItem.Quantity, so.SONumber),
-				CreatedBy:    &userInfo.ID,
-				UpdatedBy:    &userInfo.ID,
-			}
-			if _, err := service.ItemHistoryRepository.Insert(tx, &newHist); err != nil {
-				tx.Rollback()
-				return fmt.Errorf("error creating stock history for item %s: %w", item.ID, err)
-			}
+	if statusRequest.SOStatus == "Delivered" {
+		// sisa qty yang belum punya shipment dikirim sekaligus, lalu semua shipment dianggap diterima
+		if _, err := service.ShipmentService.ShipRemainder(tx, so, statusRequest.WarehouseID, userInfo); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := service.ShipmentService.ShipmentRepository.MarkDeliveredBySalesOrder(tx, so.ID, time.Now()); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("error confirming shipments: %w", err)
+		}
+		if err := service.releaseReservation(tx, so); err != nil {
+			tx.Rollback()
+			return err
 		}
 	}
 
@@ -561,6 +745,8 @@ func (service *SalesOrderService) UpdateSalesOrderStatus(
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+
+	NotifyCreditOverride(override, so.SONumber, userInfo)
 	return nil
 }
 
@@ -586,6 +772,16 @@ func (service *SalesOrderService) DeleteSalesOrders(req *models.SalesOrderIsHard
 		}
 
 		if isHardDelete {
+			so, err := service.SalesOrderRepository.FindById(tx, id.String(), true)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("sales order %s not found: %w", id.String(), err)
+			}
+			if err := service.releaseReservation(tx, so); err != nil {
+				tx.Rollback()
+				return err
+			}
+
 			// hard-delete anak langsung via tx
 			if err := tx.Unscoped().Delete(&models.SalesOrderItem{}, "sales_order_id = ?", id).Error; err != nil {
 				tx.Rollback()
@@ -595,6 +791,10 @@ func (service *SalesOrderService) DeleteSalesOrders(req *models.SalesOrderIsHard
 				tx.Rollback()
 				return fmt.Errorf("error hard deleting payments for %s: %w", id.String(), err)
 			}
+			if err := tx.Unscoped().Delete(&models.Shipment{}, "sales_order_id = ?", id).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("error hard deleting shipments for %s: %w", id.String(), err)
+			}
 			if err := service.SalesOrderRepository.Delete(tx, id.String(), true); err != nil {
 				tx.Rollback()
 				return fmt.Errorf("error hard deleting sales order %s: %w", id.String(), err)
@@ -642,9 +842,18 @@ type stockViolation struct {
 }
 
 func (service *SalesOrderService) mapSOToResponse(so models.SalesOrder) models.ResponseGetSalesOrder {
+	shipped, backorder := 0, 0
+	for _, it := range so.SalesOrderItems {
+		shipped += it.ShippedQuantity
+		if rest := it.Quantity - it.ShippedQuantity; rest > 0 {
+			backorder += rest
+		}
+	}
+
 	return models.ResponseGetSalesOrder{
 		ID:               so.ID,
 		SONumber:         so.SONumber,
+		InvoiceNumber:    so.InvoiceNumber,
 		SalesPersonID:    so.SalesPersonID,
 		CustomerID:       so.CustomerID,
 		SODate:           so.SODate,
@@ -652,6 +861,15 @@ func (service *SalesOrderService) mapSOToResponse(so models.SalesOrder) models.R
 		TermOfPayment:    so.TermOfPayment,
 		SOStatus:         so.SOStatus,
 		PaymentStatus:    so.PaymentStatus,
+		Subtotal:         so.Subtotal,
+		DiscountType:     so.DiscountType,
+		DiscountValue:    so.DiscountValue,
+		DiscountAmount:   so.DiscountAmount,
+		TaxRate:          so.TaxRate,
+		TaxInclusive:     so.TaxInclusive,
+		TaxBase:          so.TaxBase,
+		TaxAmount:        so.TaxAmount,
+		GrandTotal:       so.GrandTotal,
 		TotalAmount:      so.TotalAmount,
 		PaidAmount:       so.PaidAmount,
 		DPAmount:         so.DPAmount,
@@ -664,16 +882,22 @@ func (service *SalesOrderService) mapSOToResponse(so models.SalesOrder) models.R
 		Customer:         so.Customer,
 		Payments:         so.Payments,
 		SalesOrderItems:  so.SalesOrderItems,
+		Shipments:        so.Shipments,
+
+		ShippedQuantity:   shipped,
+		BackorderQuantity: backorder,
 	}
 }
 
 func (service *SalesOrderService) validateStatusTransition(currentStatus, newStatus string) error {
 	validTransitions := map[string][]string{
-		"Draft":     {"Confirmed"},
-		"Confirmed": {"Shipped", "Closed"},
-		"Shipped":   {"Delivered", "Closed"},
-		"Delivered": {"Closed"},
-		"Closed":    {},
+		"Draft":            {"Confirmed", "Cancelled"},
+		"Confirmed":        {"Shipped", "Closed", "Cancelled"},
+		"PartiallyShipped": {"Shipped", "Delivered", "Closed", "Cancelled"}, // Cancelled = backorder dibatalkan
+		"Shipped":          {"Delivered", "Closed"},
+		"Delivered":        {"Closed"},
+		"Closed":           {},
+		"Cancelled":        {},
 	}
 
 	allowedStatuses, exists := validTransitions[currentStatus]
@@ -693,19 +917,31 @@ func (service *SalesOrderService) validateStatusTransition(currentStatus, newSta
 func (service *SalesOrderService) validateAndLockStock(tx *gorm.DB, items []models.SalesOrderItemRequest) error {
 	violations := make([]stockViolation, 0)
 
+	// item yang muncul di beberapa baris dijumlahkan dulu, stok tidak dicek per baris
+	requested := make(map[uuid.UUID]int, len(items))
+	itemIDs := make([]uuid.UUID, 0, len(items))
 	for _, it := range items {
+		if _, ok := requested[it.ItemID]; !ok {
+			itemIDs = append(itemIDs, it.ItemID)
+		}
+		requested[it.ItemID] += it.Quantity
+	}
+
+	for _, itemID := range itemIDs {
 		var item models.Item
 		if err := tx.
 			Clauses(clause.Locking{Strength: "UPDATE"}).
-			First(&item, "id = ?", it.ItemID).Error; err != nil {
-			return fmt.Errorf("item %s not found", it.ItemID.String())
+			First(&item, "id = ?", itemID).Error; err != nil {
+			return fmt.Errorf("item %s not found", itemID.String())
 		}
-		if it.Quantity > item.Stock {
+		// stok yang sudah di-reserve SO lain tidak boleh dijanjikan lagi
+		available := item.Stock - item.ReservedStock
+		if requested[itemID] > available {
 			violations = append(violations, stockViolation{
 				ItemID:    item.ID,
 				ItemName:  item.Name,
-				Requested: it.Quantity,
-				Available: item.Stock,
+				Requested: requested[itemID],
+				Available: available,
 			})
 		}
 	}
@@ -713,6 +949,181 @@ func (service *SalesOrderService) validateAndLockStock(tx *gorm.DB, items []mode
 	return formatStockError(violations)
 }
 
+// reserveStock mengunci item & menambah Item.ReservedStock sebanyak qty tiap baris SO.
+// Gagal bila available (stock - reserved) tidak cukup.
+func (service *SalesOrderService) reserveStock(tx *gorm.DB, so *models.SalesOrder) error {
+	requests := make([]models.SalesOrderItemRequest, 0, len(so.SalesOrderItems))
+	for _, soItem := range so.SalesOrderItems {
+		if soItem.ReservedQuantity > 0 {
+			continue
+		}
+		requests = append(requests, models.SalesOrderItemRequest{ItemID: soItem.ItemID, Quantity: soItem.Quantity})
+	}
+	if err := service.validateAndLockStock(tx, requests); err != nil {
+		return err
+	}
+
+	for _, soItem := range so.SalesOrderItems {
+		if soItem.ReservedQuantity > 0 {
+			continue
+		}
+		if err := service.ItemRepository.AdjustReservedStock(tx, soItem.ItemID, soItem.Quantity); err != nil {
+			return fmt.Errorf("error reserving stock for item %s: %w", soItem.ItemID, err)
+		}
+		if err := tx.Model(&models.SalesOrderItem{}).
+			Where("id = ?", soItem.ID).
+			Update("reserved_quantity", soItem.Quantity).Error; err != nil {
+			return fmt.Errorf("error updating reserved quantity: %w", err)
+		}
+	}
+	return nil
+}
+
+// releaseReservation melepas seluruh reservasi SO (Closed/Cancelled/hard delete).
+func (service *SalesOrderService) releaseReservation(tx *gorm.DB, so *models.SalesOrder) error {
+	for _, soItem := range so.SalesOrderItems {
+		if err := service.releaseItemReservation(tx, soItem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *SalesOrderService) releaseItemReservation(tx *gorm.DB, soItem models.SalesOrderItem) error {
+	if soItem.ReservedQuantity <= 0 {
+		return nil
+	}
+	if err := service.ItemRepository.AdjustReservedStock(tx, soItem.ItemID, -soItem.ReservedQuantity); err != nil {
+		return fmt.Errorf("error releasing reserved stock for item %s: %w", soItem.ItemID, err)
+	}
+	if err := tx.Model(&models.SalesOrderItem{}).
+		Where("id = ?", soItem.ID).
+		Update("reserved_quantity", 0).Error; err != nil {
+		return fmt.Errorf("error updating reserved quantity: %w", err)
+	}
+	return nil
+}
+
+// applyListPrice menentukan harga baris SO dari price list. requestedPrice 0 berarti pakai harga
+// list; harga manual di bawah list ditolak bila list-nya EnforceMinimum, selain itu ditandai BelowListPrice.
+func (service *SalesOrderService) applyListPrice(
+	tx *gorm.DB,
+	customer *models.Customer,
+	item *models.Item,
+	line *models.SalesOrderItem,
+	requestedPrice int,
+	at time.Time,
+) error {
+	resolved, err := resolveItemPrice(tx, service.PriceListRepository, customer, item, line.Quantity, at)
+	if err != nil {
+		return err
+	}
+
+	line.ListPrice = resolved.UnitPrice
+	line.PriceListID = resolved.PriceListID
+	line.UnitPrice = resolved.UnitPrice
+	line.BelowListPrice = false
+
+	if requestedPrice > 0 {
+		if requestedPrice < resolved.UnitPrice {
+			if resolved.EnforceMinimum {
+				return fmt.Errorf("unit price %d for item %s is below list price %d (%s): %w", requestedPrice, item.Name, resolved.UnitPrice, resolved.PriceListName, ErrPriceBelowMinimum)
+			}
+			line.BelowListPrice = true
+		}
+		line.UnitPrice = requestedPrice
+	}
+	if line.UnitPrice <= 0 {
+		return fmt.Errorf("no price available for item %s, fill unit_price manually", item.Name)
+	}
+
+	line.TotalPrice = line.Quantity * line.UnitPrice
+	return nil
+}
+
+// applySalesOrderPricing menghitung ulang diskon, DPP, PPN & grand total SO dari baris-barisnya.
+// Hasil ditulis ke so & lines (belum disimpan); TotalAmount = GrandTotal.
+func applySalesOrderPricing(so *models.SalesOrder, lines []models.SalesOrderItem) error {
+	input := helpers.PricingInput{
+		Lines:         make([]helpers.PricingLine, len(lines)),
+		DiscountType:  so.DiscountType,
+		DiscountValue: so.DiscountValue,
+		TaxRate:       so.TaxRate,
+		TaxInclusive:  so.TaxInclusive,
+	}
+	for i, l := range lines {
+		input.Lines[i] = helpers.PricingLine{
+			Quantity:      l.Quantity,
+			UnitPrice:     l.UnitPrice,
+			DiscountType:  l.DiscountType,
+			DiscountValue: l.DiscountValue,
+			TaxExempt:     l.TaxExempt,
+		}
+	}
+
+	result, err := helpers.CalculatePricing(input)
+	if err != nil {
+		return err
+	}
+
+	for i := range lines {
+		lines[i].DiscountAmount = result.Lines[i].Discount
+		lines[i].TotalPrice = result.Lines[i].Total
+		lines[i].NetAmount = result.Lines[i].NetAmount
+	}
+	so.Subtotal = result.Subtotal
+	so.DiscountAmount = result.DiscountAmount
+	so.TaxBase = result.TaxBase
+	so.TaxAmount = result.TaxAmount
+	so.GrandTotal = result.GrandTotal
+	so.TotalAmount = result.GrandTotal
+	return nil
+}
+
+// notifyBelowListPrice memberi tahu admin bila ada baris SO yang dijual di bawah harga list.
+func notifyBelowListPrice(soNumber string, soID uuid.UUID, lines []models.SalesOrderItem, userInfo *models.User) {
+	flagged := make([]map[string]interface{}, 0)
+	for _, line := range lines {
+		if !line.BelowListPrice {
+			continue
+		}
+		flagged = append(flagged, map[string]interface{}{
+			"item_id":    line.ItemID.String(),
+			"quantity":   line.Quantity,
+			"unit_price": line.UnitPrice,
+			"list_price": line.ListPrice,
+		})
+	}
+	if len(flagged) == 0 {
+		return
+	}
+
+	title := "Price Below List"
+	message := fmt.Sprintf("SO %s memuat %d item dengan harga di bawah price list (oleh %s)", soNumber, len(flagged), userInfo.Name)
+	metadata := map[string]interface{}{
+		"sales_order_id": soID.String(),
+		"so_number":      soNumber,
+		"items":          flagged,
+	}
+	if err := helpers.SendNotificationAuto("price_below_list", title, message, metadata); err != nil {
+		log.Printf("Warning: failed to send below-list price notification for SO %s: %v", soNumber, err)
+	}
+}
+
+func notifyLowStock(it models.Item) {
+	metadata := map[string]interface{}{
+		"item_id":   it.ID.String(),
+		"item_name": it.Name,
+		"stock":     it.Stock,
+		"low_stock": it.LowStock,
+	}
+	title := fmt.Sprintf("Low Stock Alert: %s", it.Name)
+	message := fmt.Sprintf("Stock for item %s is low. Current: %d, Threshold: %d", it.Name, it.Stock, it.LowStock)
+	if err := helpers.SendNotificationAuto("low_stock", title, message, metadata); err != nil {
+		fmt.Printf("failed to send low stock notification: %v\n", err)
+	}
+}
+
 func formatStockError(violations []stockViolation) error {
 	if len(violations) == 0 {
 		return nil
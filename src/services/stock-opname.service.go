@@ -0,0 +1,550 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/helpers/documents"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+type StockOpnameService struct {
+	StockOpnameRepository repositories.StockOpnameRepository
+	CategoryRepository    repositories.CategoryRepository
+	ItemRepository        repositories.ItemRepository
+	ItemLotService        *ItemLotService
+	NumberSequenceService *NumberSequenceService
+}
+
+func NewStockOpnameService(
+	stockOpnameRepo repositories.StockOpnameRepository,
+	categoryRepo repositories.CategoryRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *StockOpnameService {
+	return &StockOpnameService{
+		StockOpnameRepository: stockOpnameRepo,
+		CategoryRepository:    categoryRepo,
+		ItemRepository:        itemRepo,
+		ItemLotService:        NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService: NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *StockOpnameService) GetAllStockOpnamesPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.StockOpnamePaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.StockOpnameRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetStockOpname, 0, len(rows))
+	for _, op := range rows {
+		resp := s.mapStockOpnameToResponse(op)
+		// list cukup ringkasan; detail baris lewat GetStockOpnameByID
+		resp.StockOpnameItems = nil
+		data = append(data, resp)
+	}
+
+	return &models.StockOpnamePaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *StockOpnameService) GetStockOpnameByID(opnameId string) (*models.ResponseGetStockOpname, error) {
+	op, err := s.StockOpnameRepository.FindById(nil, opnameId, false)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapStockOpnameToResponse(*op)
+	return &out, nil
+}
+
+func (s *StockOpnameService) GenerateCountSheet(opnameId string) (string, *excelize.File, error) {
+	op, err := s.StockOpnameRepository.FindById(nil, opnameId, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, filename, err := documents.GenerateStockOpnameCountSheet(op)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate count sheet: %w", err)
+	}
+	return filename, f, nil
+}
+
+// CreateStockOpname membuka sesi hitung dan men-snapshot qty sistem per lot di gudang
+// (semua item atau satu kategori).
+func (s *StockOpnameService) CreateStockOpname(req *models.StockOpnameCreateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockOpname, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	warehouse, err := s.ItemLotService.ResolveWarehouse(tx, req.WarehouseID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var categoryID *uuid.UUID
+	if req.CategoryID != nil && *req.CategoryID != uuid.Nil {
+		if _, err := s.CategoryRepository.FindById(tx, req.CategoryID.String(), false); err != nil {
+			tx.Rollback()
+			return nil, errors.New("category not found")
+		}
+		categoryID = req.CategoryID
+	}
+
+	lots, err := s.ItemLotService.ItemLotRepository.FindForSnapshot(tx, warehouse.ID, categoryID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error loading stock snapshot: %w", err)
+	}
+	if len(lots) == 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("no stock found in %s for the selected scope", warehouse.Name)
+	}
+
+	lotIDs := make([]uuid.UUID, 0, len(lots))
+	for _, l := range lots {
+		lotIDs = append(lotIDs, l.ID)
+	}
+	costs, err := s.ItemLotService.ItemLotRepository.FindUnitCosts(tx, lotIDs)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error loading lot costs: %w", err)
+	}
+
+	opnameID := uuid.New()
+	items := make([]models.StockOpnameItem, 0, len(lots))
+	for _, l := range lots {
+		unitCost := l.Item.Price
+		if c, ok := costs[l.ID]; ok && c.UnitCost != nil {
+			unitCost = *c.UnitCost
+		}
+		lotID := l.ID
+		items = append(items, models.StockOpnameItem{
+			ID:             uuid.New(),
+			StockOpnameID:  opnameID,
+			ItemID:         l.ItemID,
+			LotID:          &lotID,
+			LotNumber:      l.LotNumber,
+			ExpiredAt:      l.ExpiredAt,
+			SystemQuantity: l.Quantity,
+			UnitCost:       unitCost,
+		})
+	}
+
+	opnameNumber, err := s.NumberSequenceService.Next(tx, models.SequenceStockOpname)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating opname number: %w", err)
+	}
+
+	opname := &models.StockOpname{
+		ID:               opnameID,
+		OpnameNumber:     opnameNumber,
+		WarehouseID:      warehouse.ID,
+		CategoryID:       categoryID,
+		Status:           "Draft",
+		SnapshotAt:       time.Now(),
+		Notes:            strings.TrimSpace(req.Notes),
+		CreatedBy:        &userInfo.ID,
+		StockOpnameItems: items,
+	}
+
+	if _, err := s.StockOpnameRepository.Insert(tx, opname); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating stock opname: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockOpnameRepository.FindById(nil, opnameID.String(), false)
+}
+
+func (s *StockOpnameService) UpdateCounts(opnameId string, req *models.StockOpnameCountsUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockOpname, error) {
+	_ = ctx
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := s.applyCounts(tx, opnameId, req.Items); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockOpnameRepository.FindById(nil, opnameId, false)
+}
+
+// UploadCounts membaca lembar hitung Excel (field form "file") dan menerapkannya seperti UpdateCounts.
+func (s *StockOpnameService) UploadCounts(opnameId string, ctx *fiber.Ctx, userInfo *models.User) (*models.StockOpname, error) {
+	_ = userInfo
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil || fileHeader == nil {
+		return nil, errors.New("excel file is required (form field: file)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := documents.ParseStockOpnameCountSheet(file)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(rows))
+	for _, row := range rows {
+		codes = append(codes, row.ItemCode)
+	}
+	itemsByCode := make(map[string]uuid.UUID, len(codes))
+	found, err := s.ItemRepository.FindByCodes(nil, codes)
+	if err != nil {
+		return nil, fmt.Errorf("error loading items: %w", err)
+	}
+	for _, it := range found {
+		itemsByCode[it.Code] = it.ID
+	}
+
+	counts := make([]models.StockOpnameCountRequest, 0, len(rows))
+	for _, row := range rows {
+		itemID, ok := itemsByCode[row.ItemCode]
+		if !ok {
+			return nil, fmt.Errorf("row %d: item code %s not found", row.Row, row.ItemCode)
+		}
+		counts = append(counts, models.StockOpnameCountRequest{
+			ItemID:          &itemID,
+			LotNumber:       row.LotNumber,
+			ExpiredAt:       row.ExpiredAt,
+			CountedQuantity: row.CountedQuantity,
+			Notes:           row.Notes,
+		})
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := s.applyCounts(tx, opnameId, counts); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockOpnameRepository.FindById(nil, opnameId, false)
+}
+
+// UpdateStockOpnameStatus:
+//   - Draft/Rejected -> Submitted (semua baris wajib sudah dihitung)
+//   - Submitted -> Approved (posting selisih) / Rejected (kembali bisa dihitung ulang)
+//   - Draft/Rejected -> Cancelled
+func (s *StockOpnameService) UpdateStockOpnameStatus(opnameId string, req *models.StockOpnameStatusUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockOpname, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	opname, err := s.StockOpnameRepository.FindById(tx, opnameId, true)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := validateStockOpnameTransition(opname.Status, req.Status); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	notes := strings.TrimSpace(req.Notes)
+
+	switch req.Status {
+	case "Submitted":
+		uncounted := 0
+		for _, it := range opname.StockOpnameItems {
+			if it.CountedQuantity == nil {
+				uncounted++
+			}
+		}
+		if uncounted > 0 {
+			tx.Rollback()
+			return nil, fmt.Errorf("%d line(s) have not been counted yet", uncounted)
+		}
+		opname.SubmittedBy = &userInfo.ID
+		opname.SubmittedAt = &now
+		if notes != "" {
+			opname.Notes = notes
+		}
+	case "Approved", "Rejected":
+		// pemisahan tugas: yang menghitung tidak boleh meng-approve hasilnya sendiri
+		if opname.SubmittedBy != nil && *opname.SubmittedBy == userInfo.ID {
+			tx.Rollback()
+			return nil, errors.New("stock opname must be reviewed by a different user than the submitter")
+		}
+		if req.Status == "Approved" {
+			if err := s.postAdjustments(tx, opname, userInfo.ID); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		opname.ReviewedBy = &userInfo.ID
+		opname.ReviewedAt = &now
+		opname.ReviewNotes = notes
+	case "Cancelled":
+		if notes != "" {
+			opname.Notes = notes
+		}
+	}
+
+	opname.Status = req.Status
+	if _, err := s.StockOpnameRepository.Update(tx, opname); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating stock opname: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockOpnameRepository.FindById(nil, opnameId, false)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *StockOpnameService) applyCounts(tx *gorm.DB, opnameId string, counts []models.StockOpnameCountRequest) error {
+	opname, err := s.StockOpnameRepository.FindById(tx, opnameId, true)
+	if err != nil {
+		return err
+	}
+	if opname.Status != "Draft" && opname.Status != "Rejected" {
+		return fmt.Errorf("counts can only be changed while stock opname is Draft or Rejected (current status: %s)", opname.Status)
+	}
+
+	lines := make([]*models.StockOpnameItem, 0, len(opname.StockOpnameItems))
+	byID := make(map[uuid.UUID]*models.StockOpnameItem, len(opname.StockOpnameItems))
+	byLot := make(map[string]*models.StockOpnameItem, len(opname.StockOpnameItems))
+	for i := range opname.StockOpnameItems {
+		line := &opname.StockOpnameItems[i]
+		lines = append(lines, line)
+		byID[line.ID] = line
+		byLot[opnameLotKey(line.ItemID, line.LotNumber)] = line
+	}
+
+	for _, c := range counts {
+		var line *models.StockOpnameItem
+		switch {
+		case c.StockOpnameItemID != nil:
+			line = byID[*c.StockOpnameItemID]
+			if line == nil {
+				return fmt.Errorf("stock opname line %s not found", c.StockOpnameItemID)
+			}
+		case c.ItemID != nil && strings.TrimSpace(c.LotNumber) != "":
+			lotNumber := strings.TrimSpace(c.LotNumber)
+			line = byLot[opnameLotKey(*c.ItemID, lotNumber)]
+			if line == nil {
+				// lot fisik yang tidak tercatat di sistem: tambah baris dengan qty sistem 0
+				created, err := s.newCountLine(tx, opname, *c.ItemID, lotNumber, c.ExpiredAt)
+				if err != nil {
+					return err
+				}
+				line = created
+				lines = append(lines, line)
+				byLot[opnameLotKey(line.ItemID, line.LotNumber)] = line
+			}
+		default:
+			return errors.New("each count needs stock_opname_item_id, or item_id with lot_number")
+		}
+
+		counted := c.CountedQuantity
+		line.CountedQuantity = &counted
+		line.VarianceQuantity = counted - line.SystemQuantity
+		line.VarianceValue = line.VarianceQuantity * line.UnitCost
+		if strings.TrimSpace(c.Notes) != "" {
+			line.Notes = strings.TrimSpace(c.Notes)
+		}
+		if err := s.StockOpnameRepository.UpdateItem(tx, line); err != nil {
+			return fmt.Errorf("error updating stock opname line: %w", err)
+		}
+	}
+
+	opname.TotalVarianceQty, opname.TotalVarianceValue = 0, 0
+	for _, line := range lines {
+		if line.CountedQuantity == nil {
+			continue
+		}
+		opname.TotalVarianceQty += line.VarianceQuantity
+		opname.TotalVarianceValue += line.VarianceValue
+	}
+	if _, err := s.StockOpnameRepository.Update(tx, opname); err != nil {
+		return fmt.Errorf("error updating stock opname: %w", err)
+	}
+	return nil
+}
+
+func (s *StockOpnameService) newCountLine(tx *gorm.DB, opname *models.StockOpname, itemID uuid.UUID, lotNumber string, expiredAt *time.Time) (*models.StockOpnameItem, error) {
+	item, err := s.ItemRepository.FindById(tx, itemID.String(), false)
+	if err != nil {
+		return nil, fmt.Errorf("item %s not found", itemID)
+	}
+	if opname.CategoryID != nil && item.CategoryID != *opname.CategoryID {
+		return nil, fmt.Errorf("item %s is outside the category of this stock opname", item.Name)
+	}
+
+	line := &models.StockOpnameItem{
+		ID:            uuid.New(),
+		StockOpnameID: opname.ID,
+		ItemID:        item.ID,
+		LotNumber:     lotNumber,
+		ExpiredAt:     expiredAt,
+		UnitCost:      item.Price,
+		Item:          *item,
+	}
+	if err := s.StockOpnameRepository.InsertItem(tx, line); err != nil {
+		return nil, fmt.Errorf("error creating stock opname line: %w", err)
+	}
+	return line, nil
+}
+
+// postAdjustments memposting selisih (counted - snapshot) ke lot. Selisih diterapkan sebagai
+// delta terhadap qty lot saat ini, jadi mutasi yang terjadi setelah snapshot tetap terjaga.
+func (s *StockOpnameService) postAdjustments(tx *gorm.DB, opname *models.StockOpname, userID uuid.UUID) error {
+	posted := 0
+	for _, line := range opname.StockOpnameItems {
+		if line.CountedQuantity == nil || line.VarianceQuantity == 0 {
+			continue
+		}
+
+		item, err := s.ItemRepository.FindById(tx, line.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found: %w", line.ItemID, err)
+		}
+
+		desc := fmt.Sprintf("Stock opname %s: counted %d vs system %d (variance %+d)",
+			opname.OpnameNumber, *line.CountedQuantity, line.SystemQuantity, line.VarianceQuantity)
+		if _, err := s.ItemLotService.AdjustLot(tx, item, opname.WarehouseID, line.LotNumber, line.ExpiredAt,
+			line.VarianceQuantity, "stock_opname", desc, userID); err != nil {
+			return fmt.Errorf("failed to post %s lot %s: %w", item.Name, line.LotNumber, err)
+		}
+		posted++
+	}
+
+	log.Printf("Stock opname %s approved: %d adjustment(s), variance qty %d, value %d",
+		opname.OpnameNumber, posted, opname.TotalVarianceQty, opname.TotalVarianceValue)
+	return nil
+}
+
+func (s *StockOpnameService) mapStockOpnameToResponse(op models.StockOpname) models.ResponseGetStockOpname {
+	counted := 0
+	for _, it := range op.StockOpnameItems {
+		if it.CountedQuantity != nil {
+			counted++
+		}
+	}
+
+	return models.ResponseGetStockOpname{
+		ID:                 op.ID,
+		OpnameNumber:       op.OpnameNumber,
+		WarehouseID:        op.WarehouseID,
+		CategoryID:         op.CategoryID,
+		Status:             op.Status,
+		SnapshotAt:         op.SnapshotAt,
+		TotalLines:         len(op.StockOpnameItems),
+		CountedLines:       counted,
+		TotalVarianceQty:   op.TotalVarianceQty,
+		TotalVarianceValue: op.TotalVarianceValue,
+		Notes:              op.Notes,
+		ReviewNotes:        op.ReviewNotes,
+		CreatedBy:          op.CreatedBy,
+		SubmittedBy:        op.SubmittedBy,
+		SubmittedAt:        op.SubmittedAt,
+		ReviewedBy:         op.ReviewedBy,
+		ReviewedAt:         op.ReviewedAt,
+		CreatedAt:          op.CreatedAt,
+		UpdatedAt:          op.UpdatedAt,
+		DeletedAt:          op.DeletedAt,
+		Warehouse:          op.Warehouse,
+		Category:           op.Category,
+		StockOpnameItems:   op.StockOpnameItems,
+	}
+}
+
+func validateStockOpnameTransition(currentStatus, newStatus string) error {
+	validTransitions := map[string][]string{
+		"Draft":     {"Submitted", "Cancelled"},
+		"Submitted": {"Approved", "Rejected"},
+		"Rejected":  {"Submitted", "Cancelled"},
+		"Approved":  {},
+		"Cancelled": {},
+	}
+
+	for _, allowed := range validTransitions[currentStatus] {
+		if allowed == newStatus {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status transition from %s to %s", currentStatus, newStatus)
+}
+
+func opnameLotKey(itemID uuid.UUID, lotNumber string) string {
+	return itemID.String() + "|" + strings.ToUpper(strings.TrimSpace(lotNumber))
+}
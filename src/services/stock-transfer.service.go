@@ -0,0 +1,424 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockTransferService struct {
+	StockTransferRepository repositories.StockTransferRepository
+	WarehouseRepository     repositories.WarehouseRepository
+	ItemRepository          repositories.ItemRepository
+	ItemLotService          *ItemLotService
+	NumberSequenceService   *NumberSequenceService
+}
+
+func NewStockTransferService(
+	stockTransferRepo repositories.StockTransferRepository,
+	warehouseRepo repositories.WarehouseRepository,
+	itemRepo repositories.ItemRepository,
+	itemHistoryRepo repositories.ItemHistoryRepository,
+	itemLotRepo repositories.ItemLotRepository,
+	itemStockRepo repositories.ItemStockRepository,
+	numberSequenceRepo repositories.NumberSequenceRepository,
+) *StockTransferService {
+	return &StockTransferService{
+		StockTransferRepository: stockTransferRepo,
+		WarehouseRepository:     warehouseRepo,
+		ItemRepository:          itemRepo,
+		ItemLotService:          NewItemLotService(itemLotRepo, itemStockRepo, warehouseRepo, itemRepo, itemHistoryRepo),
+		NumberSequenceService:   NewNumberSequenceService(numberSequenceRepo),
+	}
+}
+
+func (s *StockTransferService) GetAllStockTransfersPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.StockTransferPaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+
+	rows, total, err := s.StockTransferRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetStockTransfer, 0, len(rows))
+	for _, t := range rows {
+		data = append(data, s.mapStockTransferToResponse(t))
+	}
+
+	return &models.StockTransferPaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *StockTransferService) GetStockTransferByID(transferId string) (*models.ResponseGetStockTransfer, error) {
+	t, err := s.StockTransferRepository.FindById(nil, transferId, false)
+	if err != nil {
+		return nil, err
+	}
+	out := s.mapStockTransferToResponse(*t)
+	return &out, nil
+}
+
+func (s *StockTransferService) CreateStockTransfer(req *models.StockTransferCreateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockTransfer, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := s.validateWarehouses(tx, req.SourceWarehouseID, req.DestinationWarehouseID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	transferNumber, err := s.NumberSequenceService.Next(tx, models.SequenceStockTransfer)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error generating transfer number: %w", err)
+	}
+
+	transferID := uuid.New()
+	items, err := s.buildTransferItems(tx, transferID, req.Items)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	transfer := &models.StockTransfer{
+		ID:                     transferID,
+		TransferNumber:         transferNumber,
+		SourceWarehouseID:      req.SourceWarehouseID,
+		DestinationWarehouseID: req.DestinationWarehouseID,
+		TransferDate:           req.TransferDate,
+		Status:                 "Draft",
+		Notes:                  strings.TrimSpace(req.Notes),
+		CreatedBy:              &userInfo.ID,
+		StockTransferItems:     items,
+	}
+
+	if _, err := s.StockTransferRepository.Insert(tx, transfer); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error creating stock transfer: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockTransferRepository.FindById(nil, transferID.String(), false)
+}
+
+func (s *StockTransferService) UpdateStockTransfer(transferId string, req *models.StockTransferUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockTransfer, error) {
+	_ = ctx
+	_ = userInfo
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	transfer, err := s.StockTransferRepository.FindById(tx, transferId, true)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrStockTransferNotFound) {
+			return nil, errors.New("stock transfer not found")
+		}
+		return nil, fmt.Errorf("error finding stock transfer: %w", err)
+	}
+	if transfer.Status != "Draft" {
+		tx.Rollback()
+		return nil, fmt.Errorf("only draft transfers can be edited (current status: %s)", transfer.Status)
+	}
+
+	if req.SourceWarehouseID != nil {
+		transfer.SourceWarehouseID = *req.SourceWarehouseID
+	}
+	if req.DestinationWarehouseID != nil {
+		transfer.DestinationWarehouseID = *req.DestinationWarehouseID
+	}
+	if err := s.validateWarehouses(tx, transfer.SourceWarehouseID, transfer.DestinationWarehouseID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if req.TransferDate != nil {
+		transfer.TransferDate = *req.TransferDate
+	}
+	if req.Notes != nil {
+		transfer.Notes = strings.TrimSpace(*req.Notes)
+	}
+
+	if len(req.Items) > 0 {
+		items, err := s.buildTransferItems(tx, transfer.ID, req.Items)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := s.StockTransferRepository.ReplaceItems(tx, transfer.ID, items); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error updating transfer items: %w", err)
+		}
+	}
+
+	if _, err := s.StockTransferRepository.Update(tx, transfer); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating stock transfer: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockTransferRepository.FindById(nil, transferId, false)
+}
+
+// UpdateStockTransferStatus menjalankan alur Draft -> InTransit -> Received (atau Draft -> Cancelled).
+// InTransit mengurangi stok di gudang asal, Received menambah stok di gudang tujuan dengan lot yang sama.
+func (s *StockTransferService) UpdateStockTransferStatus(transferId string, req *models.StockTransferStatusUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.StockTransfer, error) {
+	_ = ctx
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	transfer, err := s.StockTransferRepository.FindById(tx, transferId, true)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repositories.ErrStockTransferNotFound) {
+			return nil, errors.New("stock transfer not found")
+		}
+		return nil, fmt.Errorf("error finding stock transfer: %w", err)
+	}
+
+	allowed := map[string][]string{
+		"Draft":     {"InTransit", "Cancelled"},
+		"InTransit": {"Received"},
+	}
+	valid := false
+	for _, next := range allowed[transfer.Status] {
+		if next == req.Status {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		tx.Rollback()
+		return nil, fmt.Errorf("invalid status transition from %s to %s", transfer.Status, req.Status)
+	}
+
+	now := time.Now()
+	switch req.Status {
+	case "InTransit":
+		if err := s.shipTransfer(tx, transfer, userInfo.ID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		transfer.ShippedAt = &now
+		transfer.ShippedBy = &userInfo.ID
+	case "Received":
+		if err := s.receiveTransfer(tx, transfer, userInfo.ID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		transfer.ReceivedAt = &now
+		transfer.ReceivedBy = &userInfo.ID
+	}
+
+	transfer.Status = req.Status
+	if strings.TrimSpace(req.Notes) != "" {
+		transfer.Notes = strings.TrimSpace(req.Notes)
+	}
+
+	if _, err := s.StockTransferRepository.Update(tx, transfer); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error updating stock transfer: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return s.StockTransferRepository.FindById(nil, transferId, false)
+}
+
+// ==============================
+// Helpers
+// ==============================
+
+func (s *StockTransferService) shipTransfer(tx *gorm.DB, transfer *models.StockTransfer, userID uuid.UUID) error {
+	if len(transfer.StockTransferItems) == 0 {
+		return errors.New("stock transfer has no items")
+	}
+
+	desc := fmt.Sprintf("Transfer out %s to %s", transfer.TransferNumber, transfer.DestinationWarehouse.Name)
+	var lots []models.StockTransferLot
+
+	for _, ti := range transfer.StockTransferItems {
+		item, err := s.ItemRepository.FindById(tx, ti.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found: %w", ti.ItemID, err)
+		}
+
+		if ti.LotNumber != nil && strings.TrimSpace(*ti.LotNumber) != "" {
+			lot, err := s.ItemLotService.TakeFromLot(tx, item, transfer.SourceWarehouseID, *ti.LotNumber, ti.Quantity, desc, userID)
+			if err != nil {
+				return err
+			}
+			lots = append(lots, models.StockTransferLot{
+				ID:                  uuid.New(),
+				StockTransferItemID: ti.ID,
+				LotNumber:           lot.LotNumber,
+				ExpiredAt:           lot.ExpiredAt,
+				Quantity:            ti.Quantity,
+			})
+			continue
+		}
+
+		consumed, err := s.ItemLotService.ConsumeFEFO(tx, item, transfer.SourceWarehouseID, ti.Quantity, desc, userID)
+		if err != nil {
+			return err
+		}
+		for _, c := range consumed {
+			lots = append(lots, models.StockTransferLot{
+				ID:                  uuid.New(),
+				StockTransferItemID: ti.ID,
+				LotNumber:           c.LotNumber,
+				ExpiredAt:           c.ExpiredAt,
+				Quantity:            c.Quantity,
+			})
+		}
+	}
+
+	if err := s.StockTransferRepository.InsertLots(tx, lots); err != nil {
+		return fmt.Errorf("error recording transferred lots: %w", err)
+	}
+	return nil
+}
+
+func (s *StockTransferService) receiveTransfer(tx *gorm.DB, transfer *models.StockTransfer, userID uuid.UUID) error {
+	desc := fmt.Sprintf("Transfer in %s from %s", transfer.TransferNumber, transfer.SourceWarehouse.Name)
+
+	for _, ti := range transfer.StockTransferItems {
+		if len(ti.StockTransferLots) == 0 {
+			return fmt.Errorf("no shipped lots recorded for item %s", ti.Item.Name)
+		}
+
+		item, err := s.ItemRepository.FindById(tx, ti.ItemID.String(), false)
+		if err != nil {
+			return fmt.Errorf("item %s not found: %w", ti.ItemID, err)
+		}
+
+		for _, l := range ti.StockTransferLots {
+			receipt := models.LotReceipt{
+				WarehouseID: transfer.DestinationWarehouseID,
+				LotNumber:   l.LotNumber,
+				ExpiredAt:   l.ExpiredAt,
+				Quantity:    l.Quantity,
+				Notes:       transfer.TransferNumber,
+			}
+			if _, err := s.ItemLotService.ReceiveLot(tx, item, receipt, desc, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StockTransferService) validateWarehouses(tx *gorm.DB, sourceID, destinationID uuid.UUID) error {
+	if sourceID == destinationID {
+		return errors.New("source and destination warehouse must be different")
+	}
+	if _, err := s.WarehouseRepository.FindById(tx, sourceID.String(), false); err != nil {
+		if errors.Is(err, repositories.ErrWarehouseNotFound) {
+			return errors.New("source warehouse not found")
+		}
+		return fmt.Errorf("error loading source warehouse: %w", err)
+	}
+	if _, err := s.WarehouseRepository.FindById(tx, destinationID.String(), false); err != nil {
+		if errors.Is(err, repositories.ErrWarehouseNotFound) {
+			return errors.New("destination warehouse not found")
+		}
+		return fmt.Errorf("error loading destination warehouse: %w", err)
+	}
+	return nil
+}
+
+func (s *StockTransferService) buildTransferItems(tx *gorm.DB, transferID uuid.UUID, reqItems []models.StockTransferItemRequest) ([]models.StockTransferItem, error) {
+	items := make([]models.StockTransferItem, 0, len(reqItems))
+	for _, ri := range reqItems {
+		if _, err := s.ItemRepository.FindById(tx, ri.ItemID.String(), false); err != nil {
+			if errors.Is(err, repositories.ErrItemNotFound) {
+				return nil, fmt.Errorf("item %s not found", ri.ItemID)
+			}
+			return nil, fmt.Errorf("error loading item %s: %w", ri.ItemID, err)
+		}
+		if ri.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
+		}
+
+		var lotNumber *string
+		if ri.LotNumber != nil && strings.TrimSpace(*ri.LotNumber) != "" {
+			ln := strings.TrimSpace(*ri.LotNumber)
+			lotNumber = &ln
+		}
+
+		items = append(items, models.StockTransferItem{
+			ID:              uuid.New(),
+			StockTransferID: transferID,
+			ItemID:          ri.ItemID,
+			Quantity:        ri.Quantity,
+			LotNumber:       lotNumber,
+		})
+	}
+	return items, nil
+}
+
+func (s *StockTransferService) mapStockTransferToResponse(t models.StockTransfer) models.ResponseGetStockTransfer {
+	return models.ResponseGetStockTransfer{
+		ID:                     t.ID,
+		TransferNumber:         t.TransferNumber,
+		SourceWarehouseID:      t.SourceWarehouseID,
+		DestinationWarehouseID: t.DestinationWarehouseID,
+		TransferDate:           t.TransferDate,
+		Status:                 t.Status,
+		ShippedAt:              t.ShippedAt,
+		ReceivedAt:             t.ReceivedAt,
+		Notes:                  t.Notes,
+		CreatedBy:              t.CreatedBy,
+		ShippedBy:              t.ShippedBy,
+		ReceivedBy:             t.ReceivedBy,
+		CreatedAt:              t.CreatedAt,
+		UpdatedAt:              t.UpdatedAt,
+		DeletedAt:              t.DeletedAt,
+		SourceWarehouse:        t.SourceWarehouse,
+		DestinationWarehouse:   t.DestinationWarehouse,
+		StockTransferItems:     t.StockTransferItems,
+	}
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/SalmanDMA/inventory-app/backend/src/configs"
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
+	"github.com/SalmanDMA/inventory-app/backend/src/repositories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WarehouseService struct {
+	WarehouseRepository repositories.WarehouseRepository
+	ItemStockRepository repositories.ItemStockRepository
+}
+
+func NewWarehouseService(warehouseRepo repositories.WarehouseRepository, itemStockRepo repositories.ItemStockRepository) *WarehouseService {
+	return &WarehouseService{
+		WarehouseRepository: warehouseRepo,
+		ItemStockRepository: itemStockRepo,
+	}
+}
+
+func (s *WarehouseService) GetAllWarehouses() ([]models.ResponseGetWarehouse, error) {
+	warehouses, err := s.WarehouseRepository.FindAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.ResponseGetWarehouse, 0, len(warehouses))
+	for _, wh := range warehouses {
+		out = append(out, s.mapWarehouseToResponse(wh))
+	}
+	return out, nil
+}
+
+func (s *WarehouseService) GetAllWarehousesPaginated(req *models.PaginationRequest, userInfo *models.User) (*models.WarehousePaginatedResponse, error) {
+	_ = userInfo
+
+	normalizePagination(req)
+	if req.Status == "" {
+		req.Status = "active"
+	}
+
+	rows, total, err := s.WarehouseRepository.FindAllPaginated(nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]models.ResponseGetWarehouse, 0, len(rows))
+	for _, wh := range rows {
+		data = append(data, s.mapWarehouseToResponse(wh))
+	}
+
+	return &models.WarehousePaginatedResponse{
+		Data:       data,
+		Pagination: paginationResponse(req, total),
+	}, nil
+}
+
+func (s *WarehouseService) GetWarehouseByID(warehouseId string) (*models.ResponseGetWarehouse, error) {
+	wh, err := s.WarehouseRepository.FindById(nil, warehouseId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := s.mapWarehouseToResponse(*wh)
+	return &out, nil
+}
+
+func (s *WarehouseService) GetWarehouseStocks(warehouseId string) ([]models.ResponseGetItemStock, error) {
+	wh, err := s.WarehouseRepository.FindById(nil, warehouseId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := s.ItemStockRepository.FindAllByWarehouse(nil, wh.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.ResponseGetItemStock, 0, len(stocks))
+	for _, st := range stocks {
+		item := st.Item
+		out = append(out, models.ResponseGetItemStock{
+			ID:          st.ID,
+			ItemID:      st.ItemID,
+			WarehouseID: st.WarehouseID,
+			Stock:       st.Stock,
+			UpdatedAt:   st.UpdatedAt,
+			Item:        &item,
+		})
+	}
+	return out, nil
+}
+
+func (s *WarehouseService) CreateWarehouse(req *models.WarehouseCreateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.Warehouse, error) {
+	_ = ctx
+	_ = userInfo
+
+	name := strings.TrimSpace(req.Name)
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if name == "" || code == "" {
+		return nil, errors.New("name and code are required")
+	}
+	if len(name) > 150 || len(code) > 30 {
+		return nil, errors.New("name/code exceeds max length")
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err := s.WarehouseRepository.FindByCode(tx, code); err == nil {
+		tx.Rollback()
+		return nil, errors.New("warehouse with this code already exists")
+	} else if !errors.Is(err, repositories.ErrWarehouseNotFound) {
+		tx.Rollback()
+		return nil, fmt.Errorf("check warehouse code failed: %w", err)
+	}
+
+	// gudang pertama otomatis jadi default
+	isDefault := req.IsDefault
+	if !isDefault {
+		if _, err := s.WarehouseRepository.FindDefault(tx); errors.Is(err, repositories.ErrWarehouseNotFound) {
+			isDefault = true
+		}
+	}
+
+	entity := &models.Warehouse{
+		ID:          uuid.New(),
+		Name:        name,
+		Code:        code,
+		Address:     trimOptional(req.Address),
+		Description: trimOptional(req.Description),
+		IsDefault:   isDefault,
+	}
+
+	inserted, err := s.WarehouseRepository.Insert(tx, entity)
+	if err != nil {
+		tx.Rollback()
+		if repositories.IsUniqueViolation(err) {
+			return nil, errors.New("warehouse already exists")
+		}
+		return nil, fmt.Errorf("error creating warehouse: %w", err)
+	}
+
+	if inserted.IsDefault {
+		if err := s.WarehouseRepository.ClearDefault(tx, inserted.ID); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error updating default warehouse: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	out, err := s.WarehouseRepository.FindById(nil, inserted.ID.String(), false)
+	if err != nil {
+		return inserted, nil
+	}
+	return out, nil
+}
+
+func (s *WarehouseService) UpdateWarehouse(idStr string, upd *models.WarehouseUpdateRequest, ctx *fiber.Ctx, userInfo *models.User) (*models.Warehouse, error) {
+	_ = ctx
+	_ = userInfo
+
+	cur, err := s.WarehouseRepository.FindById(nil, idStr, false)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWarehouseNotFound) {
+			return nil, errors.New("warehouse not found")
+		}
+		return nil, fmt.Errorf("find warehouse failed: %w", err)
+	}
+
+	tx := configs.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("begin tx: %w", tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if strings.TrimSpace(upd.Code) != "" {
+		newCode := strings.ToLower(strings.TrimSpace(upd.Code))
+		if len(newCode) > 30 {
+			tx.Rollback()
+			return nil, errors.New("code exceeds max length")
+		}
+		if newCode != cur.Code {
+			if ex, err := s.WarehouseRepository.FindByCode(tx, newCode); err == nil && ex.ID != cur.ID {
+				tx.Rollback()
+				return nil, errors.New("warehouse code already exists")
+			} else if err != nil && !errors.Is(err, repositories.ErrWarehouseNotFound) {
+				tx.Rollback()
+				return nil, fmt.Errorf("check code failed: %w", err)
+			}
+			cur.Code = newCode
+		}
+	}
+
+	if strings.TrimSpace(upd.Name) != "" {
+		newName := strings.TrimSpace(upd.Name)
+		if len(newName) > 150 {
+			tx.Rollback()
+			return nil, errors.New("name exceeds max length")
+		}
+		cur.Name = newName
+	}
+	if upd.Address != nil {
+		cur.Address = trimOptional(upd.Address)
+	}
+	if upd.Description != nil {
+		cur.Description = trimOptional(upd.Description)
+	}
+	if upd.IsDefault != nil {
+		if !*upd.IsDefault && cur.IsDefault {
+			tx.Rollback()
+			return nil, errors.New("set another warehouse as default instead of unsetting the current default")
+		}
+		cur.IsDefault = *upd.IsDefault
+	}
+
+	updated, err := s.WarehouseRepository.Update(tx, cur)
+	if err != nil {
+		tx.Rollback()
+		if repositories.IsUniqueViolation(err) {
+			return nil, errors.New("warehouse already exists")
+		}
+		return nil, fmt.Errorf("error updating warehouse: %w", err)
+	}
+
+	if updated.IsDefault {
+		if err := s.WarehouseRepository.ClearDefault(tx, updated.ID); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error updating default warehouse: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	out, err := s.WarehouseRepository.FindById(nil, updated.ID.String(), false)
+	if err != nil {
+		return updated, nil
+	}
+	return out, nil
+}
+
+func (s *WarehouseService) DeleteWarehouses(req *models.WarehouseIsHardDeleteRequest, ctx *fiber.Ctx, userInfo *models.User) error {
+	_ = ctx
+	_ = userInfo
+
+	for _, id := range req.IDs {
+		tx := configs.DB.Begin()
+		if tx.Error != nil {
+			log.Printf("Failed to begin transaction for warehouse %v: %v\n", id, tx.Error)
+			return errors.New("error beginning transaction")
+		}
+
+		wh, err := s.WarehouseRepository.FindById(tx, id.String(), true)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, repositories.ErrWarehouseNotFound) {
+				log.Printf("Warehouse not found: %v\n", id)
+				continue
+			}
+			log.Printf("Error finding warehouse %v: %v\n", id, err)
+			return errors.New("error finding warehouse")
+		}
+		if wh.IsDefault {
+			tx.Rollback()
+			return fmt.Errorf("cannot delete default warehouse %s", wh.Name)
+		}
+
+		// gudang yang masih menyimpan stok tidak boleh dihapus
+		stocks, err := s.ItemStockRepository.FindAllByWarehouse(tx, wh.ID)
+		if err != nil {
+			tx.Rollback()
+			log.Printf("Error checking stock of warehouse %v: %v\n", id, err)
+			return errors.New("error checking warehouse stock")
+		}
+		for _, st := range stocks {
+			if st.Stock != 0 {
+				tx.Rollback()
+				return fmt.Errorf("warehouse %s still holds stock, transfer it out first", wh.Name)
+			}
+		}
+
+		if err := s.WarehouseRepository.Delete(tx, id.String(), req.IsHardDelete == "hardDelete"); err != nil {
+			tx.Rollback()
+			log.Printf("Error deleting warehouse %v: %v\n", id, err)
+			return errors.New("error deleting warehouse")
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("Error committing delete for warehouse %v: %v\n", id, err)
+			return errors.New("error committing delete")
+		}
+	}
+	return nil
+}
+
+func (s *WarehouseService) RestoreWarehouses(req *models.WarehouseRestoreRequest, ctx *fiber.Ctx, userInfo *models.User) ([]models.Warehouse, error) {
+	_ = ctx
+	_ = userInfo
+
+	var restored []models.Warehouse
+	for _, id := range req.IDs {
+		tx := configs.DB.Begin()
+		if tx.Error != nil {
+			log.Printf("Failed to begin transaction for warehouse restore %v: %v\n", id, tx.Error)
+			return nil, errors.New("error beginning transaction")
+		}
+
+		wh := &models.Warehouse{ID: id}
+		if _, err := s.WarehouseRepository.Restore(tx, id.String()); err != nil {
+			tx.Rollback()
+			if errors.Is(err, repositories.ErrWarehouseNotFound) {
+				log.Printf("Warehouse not found for restore: %v\n", id)
+				continue
+			}
+			log.Printf("Error restoring warehouse %v: %v\n", id, err)
+			return nil, errors.New("error restoring warehouse")
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("Error committing warehouse restore %v: %v\n", id, err)
+			return nil, errors.New("error committing warehouse restore")
+		}
+
+		row, err := s.WarehouseRepository.FindById(nil, id.String(), true)
+		if err != nil {
+			restored = append(restored, *wh)
+			continue
+		}
+		restored = append(restored, *row)
+	}
+	return restored, nil
+}
+
+// Helper functions
+func (s *WarehouseService) mapWarehouseToResponse(wh models.Warehouse) models.ResponseGetWarehouse {
+	return models.ResponseGetWarehouse{
+		ID:          wh.ID,
+		Name:        wh.Name,
+		Code:        wh.Code,
+		Address:     wh.Address,
+		Description: wh.Description,
+		IsDefault:   wh.IsDefault,
+		CreatedAt:   wh.CreatedAt,
+		UpdatedAt:   wh.UpdatedAt,
+		DeletedAt:   wh.DeletedAt,
+	}
+}
+
+func trimOptional(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*v)
+	if t == "" {
+		return nil
+	}
+	return &t
+}
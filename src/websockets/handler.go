@@ -3,30 +3,28 @@ package websockets
 import (
 	"log"
 
+	"github.com/SalmanDMA/inventory-app/backend/src/models"
 	"github.com/gofiber/fiber/v2"
 	ws "github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
-func Register(app *fiber.App) {
-	app.Use("/ws", ws.New(func(c *ws.Conn) {
-		userID := c.Query("userId")
-		if userID == "" {
-			log.Println("❌ userId is required")
+// Register memasang endpoint /ws. auth dijalankan sebelum upgrade dan wajib mengisi
+// Locals "userInfo" (lihat middlewares.WebSocketAuth); userId tidak lagi diambil dari query.
+func Register(app *fiber.App, auth fiber.Handler) {
+	app.Use("/ws", auth, ws.New(func(c *ws.Conn) {
+		user, ok := c.Locals("userInfo").(*models.User)
+		if !ok || user == nil {
+			log.Println("❌ unauthenticated websocket connection")
 			c.Close()
 			return
 		}
+		sessionID, _ := c.Locals("sessionID").(uuid.UUID)
 
-		AddClient(userID, c)
-		defer RemoveClient(userID)
+		client := AddClient(user.ID.String(), sessionID, c)
+		log.Printf("🟢 %s connected via WebSocket", client.UserID)
 
-		log.Printf("🟢 %s connected via WebSocket", userID)
-
-		for {
-			_, _, err := c.ReadMessage()
-			if err != nil {
-				log.Printf("🔌 Disconnected: %s", userID)
-				break
-			}
-		}
+		client.serve()
+		log.Printf("🔌 Disconnected: %s", client.UserID)
 	}))
 }
@@ -2,16 +2,33 @@ package websockets
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	ws "github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+	sendBufferSize = 64
+)
+
+// Client adalah satu koneksi WebSocket. Satu user bisa punya banyak Client (tab/device);
+// semua penulisan ke Conn hanya dilakukan oleh writePump milik Client itu sendiri.
 type Client struct {
-	UserID string
-	Conn   *ws.Conn
+	UserID    string
+	SessionID uuid.UUID
+	Conn      *ws.Conn
+
+	send       chan []byte
+	done       chan struct{}
+	writerDone chan struct{}
+	stopOnce   sync.Once
 }
 
 type Message struct {
@@ -21,65 +38,192 @@ type Message struct {
 }
 
 var (
-	clients   = make(map[string]*Client)
+	clients   = make(map[string]map[*Client]struct{})
 	clientsMu sync.RWMutex
 )
 
-func AddClient(userID string, conn *ws.Conn) {
+func AddClient(userID string, sessionID uuid.UUID, conn *ws.Conn) *Client {
+	client := &Client{
+		UserID:     userID,
+		SessionID:  sessionID,
+		Conn:       conn,
+		send:       make(chan []byte, sendBufferSize),
+		done:       make(chan struct{}),
+		writerDone: make(chan struct{}),
+	}
+
 	clientsMu.Lock()
 	defer clientsMu.Unlock()
 
-	if old, exists := clients[userID]; exists {
-		old.Conn.WriteMessage(ws.CloseMessage, []byte{})
-		old.Conn.Close()
+	if clients[userID] == nil {
+		clients[userID] = make(map[*Client]struct{})
 	}
-
-	clients[userID] = &Client{UserID: userID, Conn: conn}
-	log.Printf("🟢 %s connected", userID)
+	clients[userID][client] = struct{}{}
+	log.Printf("🟢 %s connected (%d connection(s))", userID, len(clients[userID]))
+	return client
 }
 
-func RemoveClient(userID string) {
+func RemoveClient(client *Client) {
+	client.stop()
+
 	clientsMu.Lock()
 	defer clientsMu.Unlock()
 
-	if client, exists := clients[userID]; exists {
-		client.Conn.WriteMessage(ws.CloseMessage, []byte{})
-		client.Conn.Close()
-		delete(clients, userID)
-		log.Printf("🔴 %s disconnected", userID)
+	if conns, exists := clients[client.UserID]; exists {
+		if _, ok := conns[client]; ok {
+			delete(conns, client)
+			if len(conns) == 0 {
+				delete(clients, client.UserID)
+			}
+			log.Printf("🔴 %s disconnected (%d connection(s) left)", client.UserID, len(conns))
+		}
+	}
+}
+
+// DisconnectSession menutup semua koneksi yang dibuka dengan session tertentu (mis. setelah logout).
+func DisconnectSession(sessionID uuid.UUID) {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+
+	for _, conns := range clients {
+		for client := range conns {
+			if client.SessionID == sessionID {
+				client.stop()
+			}
+		}
+	}
+}
+
+// DisconnectUser menutup semua koneksi milik user (mis. setelah logout dari semua device).
+func DisconnectUser(userID string) {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+
+	for client := range clients[userID] {
+		client.stop()
+	}
+}
+
+// DisconnectOtherSessions menutup koneksi user kecuali yang dibuka dengan session keepID
+// (mis. setelah ganti password, device yang sedang dipakai tetap tersambung).
+func DisconnectOtherSessions(userID string, keepID *uuid.UUID) {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+
+	for client := range clients[userID] {
+		if keepID != nil && client.SessionID == *keepID {
+			continue
+		}
+		client.stop()
 	}
 }
 
 func SendToUser(userID string, event string, data interface{}) {
+	b, ok := encode(Message{Event: event, To: userID, Data: data})
+	if !ok {
+		return
+	}
+
 	clientsMu.RLock()
 	defer clientsMu.RUnlock()
 
-	if client, ok := clients[userID]; ok {
-		msg := Message{Event: event, Data: data}
-		send(client.Conn, msg)
+	for client := range clients[userID] {
+		client.enqueue(b)
 	}
 }
 
 func Broadcast(event string, data interface{}) {
+	b, ok := encode(Message{Event: event, Data: data})
+	if !ok {
+		return
+	}
+
 	clientsMu.RLock()
 	defer clientsMu.RUnlock()
 
-	for _, client := range clients {
-		msg := Message{Event: event, Data: data}
-		send(client.Conn, msg)
+	for _, conns := range clients {
+		for client := range conns {
+			client.enqueue(b)
+		}
 	}
 }
 
-func send(conn *ws.Conn, msg Message) {
+func encode(msg Message) ([]byte, bool) {
 	b, err := json.Marshal(msg)
-	fmt.Println("send", msg)
 	if err != nil {
 		log.Println("marshal error:", err)
-		return
+		return nil, false
 	}
+	return b, true
+}
+
+// enqueue tidak pernah blocking: client yang buffer-nya penuh dianggap lambat dan diputus,
+// supaya Broadcast/SendToUser tidak tertahan selama memegang clientsMu.
+func (c *Client) enqueue(b []byte) {
+	select {
+	case <-c.done:
+	case c.send <- b:
+	default:
+		log.Printf("⚠️ %s send buffer full, dropping connection", c.UserID)
+		c.stop()
+	}
+}
+
+func (c *Client) stop() {
+	c.stopOnce.Do(func() { close(c.done) })
+}
+
+// serve menjalankan read loop di goroutine handler dan write loop di goroutine terpisah.
+// Handler baru boleh return setelah writePump selesai, karena Conn dilepas fiber setelahnya.
+func (c *Client) serve() {
+	go c.writePump()
+	c.readPump()
+	RemoveClient(c)
+	<-c.writerDone
+}
+
+func (c *Client) readPump() {
+	c.Conn.SetReadLimit(maxMessageSize)
+	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.Conn.SetPongHandler(func(string) error {
+		return c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.Conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
 
-	if err := conn.WriteMessage(ws.TextMessage, b); err != nil {
-		log.Println("write error:", err)
-		conn.Close()
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		// membuka blokir ReadMessage di readPump bila writer berhenti lebih dulu
+		c.Conn.Close()
+		close(c.writerDone)
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.Conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseNormalClosure, ""))
+			return
+		case b := <-c.send:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.Conn.WriteMessage(ws.TextMessage, b); err != nil {
+				log.Println("write error:", err)
+				c.stop()
+				return
+			}
+		case <-ticker.C:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.Conn.WriteMessage(ws.PingMessage, nil); err != nil {
+				c.stop()
+				return
+			}
+		}
 	}
 }